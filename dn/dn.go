@@ -0,0 +1,132 @@
+// Package dn provides helpers for building, parsing and comparing LDAP distinguished names as described in RFC 4514.
+package dn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	organizationalUnitAttr = "ou"
+
+	invalidDNErrMsg  = "Invalid distinguished name '%s': %v"
+	ouNotFoundErrMsg = "The distinguished name '%s' does not contain an organizational unit"
+)
+
+// ParseDN parses a distinguished name.
+// The method returns an error if the distinguished name is not valid.
+func ParseDN(dn string) (*ldap.DN, *errors.Error) {
+	parsedDN, err := ldap.ParseDN(dn)
+	if err != nil {
+		return nil, errors.BadRequestError(fmt.Sprintf(invalidDNErrMsg, dn, err))
+	}
+	return parsedDN, nil
+}
+
+// Normalize returns the normalized string representation of a distinguished name, which has lower-cased
+// attribute types, no insignificant spaces and consistently escaped attribute values.
+// The method returns an error if the distinguished name is not valid.
+func Normalize(dn string) (string, *errors.Error) {
+	parsedDN, cErr := ParseDN(dn)
+	if cErr != nil {
+		return "", cErr
+	}
+	return parsedDN.String(), nil
+}
+
+// IsDN reports whether the value is a distinguished name, e.g. "uid=C00001,ou=users,o=company", rather than a plain
+// attribute value like a uid. A value is a distinguished name if it contains at least one attribute type and value
+// pair and can be parsed.
+func IsDN(value string) bool {
+	if !strings.Contains(value, "=") {
+		return false
+	}
+	parsedDN, err := ldap.ParseDN(value)
+	return err == nil && len(parsedDN.RDNs) > 0
+}
+
+// EscapeRDNValue escapes the special characters of an attribute value so the value can be used in a
+// relative distinguished name.
+func EscapeRDNValue(value string) string {
+	return ldap.EscapeDN(value)
+}
+
+// RDN returns the relative distinguished name attr=value with an escaped value.
+func RDN(attr, value string) string {
+	return attr + "=" + EscapeRDNValue(value)
+}
+
+// Join joins relative distinguished names and distinguished names into a single distinguished name.
+// Empty parts are skipped.
+func Join(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ",")
+}
+
+// EqualFold reports whether two distinguished names are equal, ignoring the case of attribute types and values
+// and insignificant spaces. Distinguished names which can not be parsed are compared case-insensitively as strings.
+func EqualFold(a, b string) bool {
+	parsedA, errA := ldap.ParseDN(a)
+	parsedB, errB := ldap.ParseDN(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return parsedA.EqualFold(parsedB)
+}
+
+// ExtractOU returns the value of the first organizational unit in a distinguished name, which is the
+// organizational unit an entry is directly or most closely located in.
+// The method returns an error if the distinguished name is not valid or does not contain an organizational unit.
+func ExtractOU(dn string) (string, *errors.Error) {
+	parsedDN, cErr := ParseDN(dn)
+	if cErr != nil {
+		return "", cErr
+	}
+	for _, rdn := range parsedDN.RDNs {
+		for _, attr := range rdn.Attributes {
+			if strings.EqualFold(attr.Type, organizationalUnitAttr) {
+				return attr.Value, nil
+			}
+		}
+	}
+	return "", errors.BadRequestError(fmt.Sprintf(ouNotFoundErrMsg, dn))
+}
+
+// ExtractOUPath returns the values of the organizational units in a distinguished name which are located below
+// the base distinguished name, ordered from the top-level organizational unit to the organizational unit the entry
+// is located in. The base distinguished name itself has no organizational unit path.
+// If the distinguished name is not located below the base distinguished name, all the
+// organizational units of the distinguished name are returned.
+// The method returns an error if the distinguished name is not valid.
+func ExtractOUPath(dn, baseDN string) ([]string, *errors.Error) {
+	parsedDN, cErr := ParseDN(dn)
+	if cErr != nil {
+		return nil, cErr
+	}
+	rdns := parsedDN.RDNs
+	if parsedBaseDN, cErr := ParseDN(baseDN); cErr == nil {
+		if parsedBaseDN.EqualFold(parsedDN) {
+			return nil, nil
+		}
+		if parsedBaseDN.AncestorOfFold(parsedDN) {
+			rdns = rdns[:len(rdns)-len(parsedBaseDN.RDNs)]
+		}
+	}
+	var ouPath []string
+	for i := len(rdns) - 1; i >= 0; i-- {
+		for _, attr := range rdns[i].Attributes {
+			if strings.EqualFold(attr.Type, organizationalUnitAttr) {
+				ouPath = append(ouPath, attr.Value)
+			}
+		}
+	}
+	return ouPath, nil
+}
@@ -0,0 +1,112 @@
+package dn
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDN(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		parsedDN, cErr := ParseDN(`cn=group\,1,ou=projects,o=company`)
+		assert.Nil(t, cErr)
+		assert.Len(t, parsedDN.RDNs, 3)
+		assert.Equal(t, "group,1", parsedDN.RDNs[0].Attributes[0].Value)
+	})
+
+	t.Run("invalid dn", func(t *testing.T) {
+		parsedDN, cErr := ParseDN("cn")
+		assert.Nil(t, parsedDN)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	normalized, cErr := Normalize("CN=Group 1, OU=Projects ,o=company")
+	assert.Nil(t, cErr)
+	assert.Equal(t, "cn=Group 1,ou=Projects,o=company", normalized)
+
+	_, cErr = Normalize("cn")
+	assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+}
+
+func TestEscapeRDNValue(t *testing.T) {
+	assert.Equal(t, `Doe\, John`, EscapeRDNValue("Doe, John"))
+	assert.Equal(t, `\#1 \+ 2`, EscapeRDNValue("#1 + 2"))
+	assert.Equal(t, "plain", EscapeRDNValue("plain"))
+}
+
+func TestRDNAndJoin(t *testing.T) {
+	assert.Equal(t, `cn=Doe\, John`, RDN("cn", "Doe, John"))
+	assert.Equal(t, `cn=Doe\, John,ou=users,o=company`, Join(RDN("cn", "Doe, John"), "", "ou=users,o=company"))
+	assert.Equal(t, "", Join())
+}
+
+func TestIsDN(t *testing.T) {
+	assert.True(t, IsDN("uid=C00001,ou=users,o=company"))
+	assert.True(t, IsDN("cn=group-1"))
+	assert.False(t, IsDN("C00001"))
+	assert.False(t, IsDN("nxrm-ado-agent"))
+	assert.False(t, IsDN("=C00001"))
+	assert.False(t, IsDN(""))
+}
+
+func TestEqualFold(t *testing.T) {
+	assert.True(t, EqualFold("UID=C00001,OU=Users,O=Company", "uid=c00001, ou=users, o=company"))
+	assert.False(t, EqualFold("uid=C00001,ou=users,o=company", "uid=C00002,ou=users,o=company"))
+	assert.True(t, EqualFold("not a dn", "NOT A DN"))
+}
+
+func TestExtractOU(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ou, cErr := ExtractOU(`cn=group-1,ou=team\, a,ou=projects,o=company`)
+		assert.Nil(t, cErr)
+		assert.Equal(t, "team, a", ou)
+	})
+
+	t.Run("no organizational unit", func(t *testing.T) {
+		ou, cErr := ExtractOU("cn=group-1,o=company")
+		assert.Equal(t, "", ou)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("invalid dn", func(t *testing.T) {
+		_, cErr := ExtractOU("cn")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestExtractOUPath(t *testing.T) {
+	t.Run("below base dn", func(t *testing.T) {
+		ouPath, cErr := ExtractOUPath("cn=group-1,ou=team-a,ou=department-1,ou=projects,o=company",
+			"OU=Projects,O=Company")
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{"department-1", "team-a"}, ouPath)
+	})
+
+	t.Run("not below base dn", func(t *testing.T) {
+		ouPath, cErr := ExtractOUPath("cn=group-1,ou=team-a,ou=projects,o=company", "ou=users,o=company")
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{"projects", "team-a"}, ouPath)
+	})
+
+	t.Run("no organizational unit", func(t *testing.T) {
+		ouPath, cErr := ExtractOUPath("cn=group-1,ou=projects,o=company", "ou=projects,o=company")
+		assert.Nil(t, cErr)
+		assert.Nil(t, ouPath)
+	})
+
+	t.Run("base dn", func(t *testing.T) {
+		ouPath, cErr := ExtractOUPath("ou=projects,o=company", "ou=projects,o=company")
+		assert.Nil(t, cErr)
+		assert.Nil(t, ouPath)
+	})
+
+	t.Run("invalid dn", func(t *testing.T) {
+		_, cErr := ExtractOUPath("cn", "ou=projects,o=company")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
@@ -0,0 +1,88 @@
+package ldap
+
+import (
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const archiveBaseDNParam = "archiveBaseDN"
+
+// Archive moves an existing user entry from the user base dn into the ArchiveBaseDN set in the client Config.
+// params:
+//
+//	uid = user identifier
+//
+// The entry is renamed with a ModifyDN request, so all the attributes of the user, including the password, are
+// preserved and the user can be restored with Unarchive. The group memberships of the user are not changed.
+// The method returns an error:
+//   - if a validation fails
+//   - if the ArchiveBaseDN is not set in the client Config
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Archive(uid string) *Error {
+	archiveBaseDN, cErr := um.archiveBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := um.validateUid(uid); cErr != nil {
+		return cErr
+	}
+	user, cErr := um.get(uid)
+	if cErr != nil {
+		return cErr
+	}
+	archived := &usersManager{Client: um.Client, baseDN: archiveBaseDN}
+	if cErr := um.move(user.Uid, archived); cErr != nil {
+		return cErr
+	}
+	archived.emit(EventUserArchived, user.Uid)
+	return nil
+}
+
+// Unarchive moves an archived user entry from the ArchiveBaseDN set in the client Config back into the user base dn.
+// params:
+//
+//	uid = user identifier
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the ArchiveBaseDN is not set in the client Config
+//   - if the user is not found in the ArchiveBaseDN
+//   - if a user with the same uid already exists in the user base dn
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Unarchive(uid string) *Error {
+	archiveBaseDN, cErr := um.archiveBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := um.validateUid(uid); cErr != nil {
+		return cErr
+	}
+	archived := &usersManager{Client: um.Client, baseDN: archiveBaseDN}
+	user, cErr := archived.Get(uid)
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := archived.move(user.Uid, um); cErr != nil {
+		return cErr
+	}
+	um.emit(EventUserUnarchived, user.Uid)
+	return nil
+}
+
+// archiveBaseDN returns the ArchiveBaseDN set in the client Config or an error if it is not set.
+func (um *usersManager) archiveBaseDN() (string, *Error) {
+	archiveBaseDN := um.Client.getConfig().ArchiveBaseDN
+	if archiveBaseDN == "" {
+		return "", missingParametersError([]string{archiveBaseDNParam})
+	}
+	return archiveBaseDN, nil
+}
+
+// move moves the user entry into the base dn of the target UsersManager.
+func (um *usersManager) move(uid string, target *usersManager) *Error {
+	mdr := ldap.NewModifyDNRequest(um.getDN(uid), dn.RDN(userIdAttr, uid), true, target.userBaseDN())
+	return um.Client.doLDAPModifyDN(mdr)
+}
@@ -0,0 +1,134 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const testArchiveBaseDN = "ou=archive,o=company"
+
+func TestUsersManager_Archive(t *testing.T) {
+	config := testConfig
+	config.ArchiveBaseDN = testArchiveBaseDN
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		var events []Event
+		client.On(EventUserArchived, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameModifyDN, ldap.NewModifyDNRequest(um.getDN(testUser1.Uid), "uid="+testUser1.Uid, true,
+			testArchiveBaseDN)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserLifecycleManager).Archive(testUser1.Uid))
+		assert.Len(t, events, 1)
+		assert.Equal(t, "uid="+testUser1.Uid+","+testArchiveBaseDN, events[0].Dn)
+	})
+
+	t.Run("proxy authz", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		userDN := "uid=C00002,ou=users,o=company"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting(), WithProxyAuthz(userDN))
+		um := usersManager{Client: client}
+		sr := um.getUserSearchRequest(um.getDN(testUser1.Uid))
+		sr.Controls = []ldap.Control{NewControlProxyAuthz(userDN)}
+		mdr := ldap.NewModifyDNRequest(um.getDN(testUser1.Uid), "uid="+testUser1.Uid, true, testArchiveBaseDN)
+		mdr.Controls = []ldap.Control{NewControlProxyAuthz(userDN)}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameModifyDN, mdr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserLifecycleManager).Archive(testUser1.Uid))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserLifecycleManager).Archive(testUser1.Uid)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("archive base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UserLifecycleManager).Archive(testUser1.Uid)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(archiveBaseDNParam).Code)
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		client := NewClient(config)
+
+		cErr := client.Users.(UserLifecycleManager).Archive("")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestUsersManager_Unarchive(t *testing.T) {
+	config := testConfig
+	config.ArchiveBaseDN = testArchiveBaseDN
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		archived := usersManager{Client: client, baseDN: testArchiveBaseDN}
+		var events []Event
+		client.On(EventUserUnarchived, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, archived.getUserSearchRequest(archived.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameModifyDN, ldap.NewModifyDNRequest(archived.getDN(testUser1.Uid), "uid="+testUser1.Uid,
+			true, client.Config.UserBaseDN)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserLifecycleManager).Unarchive(testUser1.Uid))
+		assert.Len(t, events, 1)
+		assert.Equal(t, um.getDN(testUser1.Uid), events[0].Dn)
+	})
+
+	t.Run("uid already exists", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		archived := usersManager{Client: client, baseDN: testArchiveBaseDN}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, archived.getUserSearchRequest(archived.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameModifyDN, ldap.NewModifyDNRequest(archived.getDN(testUser1.Uid), "uid="+testUser1.Uid,
+			true, client.Config.UserBaseDN)).
+			Return(ldapEntryAlreadyExistsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserLifecycleManager).Unarchive(testUser1.Uid)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("archive base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UserLifecycleManager).Unarchive(testUser1.Uid)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(archiveBaseDNParam).Code)
+	})
+}
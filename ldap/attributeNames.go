@@ -0,0 +1,69 @@
+package ldap
+
+type (
+	// AttributeNames represents the names of the attributes and the filters used by the client, taking the client
+	// Config into account, e.g. to build custom search filters for Users.Search and Groups.Search which stay
+	// consistent with the filters built by the client.
+	AttributeNames struct {
+		Uid                string `json:"uid"`
+		AlternateUid       string `json:"alternateUid"`
+		CommonName         string `json:"commonName"`
+		FamilyName         string `json:"familyName"`
+		DisplayName        string `json:"displayName"`
+		EmployeeNumber     string `json:"employeeNumber"`
+		Mail               string `json:"mail"`
+		Status             string `json:"status"`
+		JpegPhoto          string `json:"jpegPhoto"`
+		UserCertificate    string `json:"userCertificate"`
+		OrganizationalUnit string `json:"organizationalUnit"`
+		UniqueMember       string `json:"uniqueMember"`
+		Owner              string `json:"owner"`
+		Description        string `json:"description"`
+		BusinessCategory   string `json:"businessCategory"`
+		ObjectClass        string `json:"objectClass"`
+		// LastLogin is the LastLoginAttr set in the client Config or its default.
+		LastLogin string `json:"lastLogin"`
+		// Revision is the RevisionAttr set in the client Config or its default.
+		Revision string `json:"revision"`
+		// GroupReviewDate is the GroupReviewDateAttr set in the client Config, empty if the review dates are not
+		// managed.
+		GroupReviewDate string `json:"groupReviewDate,omitempty"`
+		// UserFilter is the filter which matches the user entries, the UserSearchFilter set in the client Config or
+		// its default.
+		UserFilter string `json:"userFilter"`
+		// GroupFilter is the filter which matches the group entries, the GroupSearchFilter set in the client Config
+		// or its default.
+		GroupFilter string `json:"groupFilter"`
+	}
+)
+
+// AttributeNames returns the names of the attributes and the filters used by the client.
+//
+//	names := client.AttributeNames()
+//	filter := fmt.Sprintf("(&%s(%s=%s))", names.UserFilter, names.Mail, goldap.EscapeFilter(mail))
+func (c *Client) AttributeNames() AttributeNames {
+	config := c.getConfig()
+	return AttributeNames{
+		Uid:                userIdAttr,
+		AlternateUid:       alternateUserIdAttr,
+		CommonName:         CommonNameAttr,
+		FamilyName:         familyNameAttr,
+		DisplayName:        displayNameAttr,
+		EmployeeNumber:     employeeNumberAttr,
+		Mail:               mailAttr,
+		Status:             statusAttr,
+		JpegPhoto:          jpegPhotoAttr,
+		UserCertificate:    userCertificateAttr,
+		OrganizationalUnit: OrganizationalUnitAttr,
+		UniqueMember:       uniqueMemberAttr,
+		Owner:              ownerAttr,
+		Description:        descriptionAttr,
+		BusinessCategory:   businessCategoryAttr,
+		ObjectClass:        objectClassAttr,
+		LastLogin:          (&usersManager{Client: c}).lastLoginAttr(),
+		Revision:           c.revisionAttr(),
+		GroupReviewDate:    config.GroupReviewDateAttr,
+		UserFilter:         config.userObjectFilter(),
+		GroupFilter:        config.groupObjectFilter(),
+	}
+}
@@ -0,0 +1,38 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_AttributeNames(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		names := NewClient(testConfig).AttributeNames()
+		assert.Equal(t, "uid", names.Uid)
+		assert.Equal(t, "mail", names.Mail)
+		assert.Equal(t, "status", names.Status)
+		assert.Equal(t, "uniqueMember", names.UniqueMember)
+		assert.Equal(t, defaultLastLoginAttr, names.LastLogin)
+		assert.Equal(t, defaultRevisionAttr, names.Revision)
+		assert.Empty(t, names.GroupReviewDate)
+		assert.Equal(t, "(objectClass=inetOrgPerson)", names.UserFilter)
+		assert.Equal(t, "(objectClass=groupOfUniqueNames)", names.GroupFilter)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		config := testConfig
+		config.LastLoginAttr = "lastLoginTime"
+		config.RevisionAttr = "entryCSN"
+		config.GroupReviewDateAttr = "reviewDate"
+		config.UserSearchFilter = "(objectClass=posixAccount)"
+		config.GroupSearchFilter = "(objectClass=groupOfNames)"
+
+		names := NewClient(config).AttributeNames()
+		assert.Equal(t, "lastLoginTime", names.LastLogin)
+		assert.Equal(t, "entryCSN", names.Revision)
+		assert.Equal(t, "reviewDate", names.GroupReviewDate)
+		assert.Equal(t, "(objectClass=posixAccount)", names.UserFilter)
+		assert.Equal(t, "(objectClass=groupOfNames)", names.GroupFilter)
+	})
+}
@@ -0,0 +1,50 @@
+package ldap
+
+import (
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+type (
+	// Attributes represents the attributes of an LDAP entry keyed by the attribute name as returned by the server.
+	Attributes map[string][]string
+)
+
+// newAttributes returns the Attributes of an LDAP entry.
+func newAttributes(entry *ldap.Entry) Attributes {
+	attributes := make(Attributes, len(entry.Attributes))
+	for _, attr := range entry.Attributes {
+		attributes[attr.Name] = attr.Values
+	}
+	return attributes
+}
+
+// Get returns the first value of the attribute or an empty string if the attribute is not set.
+// Attribute names are case-insensitive.
+func (a Attributes) Get(name string) string {
+	if values := a.GetAll(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// GetAll returns all the values of the attribute or nil if the attribute is not set.
+// Attribute names are case-insensitive.
+func (a Attributes) GetAll(name string) []string {
+	if values, ok := a[name]; ok {
+		return values
+	}
+	for attrName, values := range a {
+		if strings.EqualFold(attrName, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Has reports whether the attribute is set.
+// Attribute names are case-insensitive.
+func (a Attributes) Has(name string) bool {
+	return a.GetAll(name) != nil
+}
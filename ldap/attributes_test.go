@@ -0,0 +1,34 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	entry := ldap.NewEntry("uid=C00001,ou=users,o=company", map[string][]string{
+		"uid":        {"C00001"},
+		"mobile":     {"+31600000001", "+31600000002"},
+		"costCenter": {"CC100"},
+	})
+	attributes := newAttributes(entry)
+
+	t.Run("get", func(t *testing.T) {
+		assert.Equal(t, "C00001", attributes.Get("uid"))
+		assert.Equal(t, "+31600000001", attributes.Get("mobile"))
+		assert.Equal(t, "CC100", attributes.Get("costcenter"))
+		assert.Equal(t, "", attributes.Get("mail"))
+	})
+
+	t.Run("get all", func(t *testing.T) {
+		assert.Equal(t, []string{"+31600000001", "+31600000002"}, attributes.GetAll("Mobile"))
+		assert.Nil(t, attributes.GetAll("mail"))
+	})
+
+	t.Run("has", func(t *testing.T) {
+		assert.True(t, attributes.Has("COSTCENTER"))
+		assert.False(t, attributes.Has("mail"))
+	})
+}
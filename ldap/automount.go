@@ -0,0 +1,357 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	automountMapNameAttr     = "automountMapName"
+	automountKeyAttr         = "automountKey"
+	automountInformationAttr = "automountInformation"
+
+	automountMapObjectClassFilter = "(objectClass=automountMap)"
+	automountObjectClassFilter    = "(objectClass=automount)"
+
+	automountBaseDNParam = "automountBaseDN"
+
+	automountMapNotFoundMsg      = "Automount map '%s' was not found"
+	automountMapAlreadyExistsMsg = "Automount map '%s' already exists"
+	automountNotFoundMsg         = "Automount entry with key = '%s' was not found in the map '%s'"
+	automountAlreadyExistsMsg    = "Automount entry with key = '%s' already exists in the map '%s'"
+)
+
+var (
+	defaultObjectClassesAutomountMap = []string{
+		"automountMap",
+		"top",
+	}
+
+	defaultObjectClassesAutomount = []string{
+		"automount",
+		"top",
+	}
+)
+
+type (
+	// AutomountsManager describes the interface which needs to be implemented for managing the automount maps and
+	// their entries within the AutomountBaseDN set in the client Config, which are consumed by autofs.
+	AutomountsManager interface {
+		GetMaps(opts ...RequestOption) ([]AutomountMap, *Error)
+		CreateMap(name string) *Error
+		DeleteMap(name string) *Error
+		GetEntries(mapName string, opts ...RequestOption) ([]Automount, *Error)
+		CreateEntry(mapName string, entry Automount) *Error
+		UpdateEntry(mapName string, entry Automount) *Error
+		DeleteEntry(mapName, key string) *Error
+	}
+
+	// automountsManager implements the AutomountsManager interface.
+	automountsManager struct {
+		Client *Client
+	}
+
+	// AutomountMap represents an automountMap entry in LDAP, e.g. auto.master or auto.home.
+	AutomountMap struct {
+		Dn   string `json:"dn"`
+		Name string `json:"name"`
+	}
+
+	// Automount represents an automount entry of an automount map in LDAP.
+	Automount struct {
+		Dn string `json:"dn"`
+		// Key is the mount point or the key of the map, e.g. "/home" in auto.master or "*" in auto.home.
+		Key string `json:"key"`
+		// Information is the map or the location and the mount options, e.g. "auto.home" in auto.master or
+		// "-fstype=nfs4 nfs.company.com:/home/&" in auto.home.
+		Information string `json:"information"`
+		Description string `json:"description,omitempty"`
+	}
+)
+
+// WithAutomountsManager overrides the default AutomountsManager.
+func WithAutomountsManager(am AutomountsManager) ClientOption {
+	return func(c *Client) {
+		c.Automounts = am
+	}
+}
+
+// GetMaps retrieves all the automount maps from LDAP.
+// The method returns an error:
+//   - if the AutomountBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (am *automountsManager) GetMaps(opts ...RequestOption) ([]AutomountMap, *Error) {
+	automountBaseDN, cErr := am.automountBaseDN()
+	if cErr != nil {
+		return nil, cErr
+	}
+	maps := []AutomountMap{}
+	sr := am.getSearchRequest(automountBaseDN, ldap.ScopeWholeSubtree, automountMapObjectClassFilter,
+		[]string{automountMapNameAttr})
+	cErr = am.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			maps = append(maps, AutomountMap{Dn: entry.DN, Name: entry.GetAttributeValue(automountMapNameAttr)})
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return maps, nil
+}
+
+// CreateMap creates a new automount map in LDAP.
+// params:
+//
+//	name = the name of the map, e.g. auto.home
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the AutomountBaseDN is not set in the client Config
+//   - if the map already exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (am *automountsManager) CreateMap(name string) *Error {
+	mapDN, cErr := am.getMapDN(name)
+	if cErr != nil {
+		return cErr
+	}
+	ar := ldap.NewAddRequest(mapDN, nil)
+	ar.Attribute(objectClassAttr, defaultObjectClassesAutomountMap)
+	ar.Attribute(automountMapNameAttr, []string{name})
+	if cErr := am.Client.doLDAPAdd(ar); cErr != nil {
+		if cErr.Status == http.StatusBadRequest {
+			return WrapError(errors.ConflictError(fmt.Sprintf(automountMapAlreadyExistsMsg, name)))
+		}
+		return cErr
+	}
+	am.Client.emit(Event{Type: EventAutomountMapCreated, Dn: mapDN})
+	return nil
+}
+
+// DeleteMap deletes an existing automount map from LDAP including all its entries.
+// params:
+//
+//	name = the name of the map, e.g. auto.home
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the AutomountBaseDN is not set in the client Config
+//   - if the map is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (am *automountsManager) DeleteMap(name string) *Error {
+	mapDN, cErr := am.getMapDN(name)
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := (&entriesManager{Client: am.Client}).DeleteSubtree(mapDN); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(automountMapNotFoundMsg, name)))
+		}
+		return cErr
+	}
+	am.Client.emit(Event{Type: EventAutomountMapDeleted, Dn: mapDN})
+	return nil
+}
+
+// GetEntries retrieves the entries of an automount map from LDAP.
+// params:
+//
+//	mapName = the name of the map, e.g. auto.home
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the AutomountBaseDN is not set in the client Config
+//   - if the map is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (am *automountsManager) GetEntries(mapName string, opts ...RequestOption) ([]Automount, *Error) {
+	mapDN, cErr := am.getMapDN(mapName)
+	if cErr != nil {
+		return nil, cErr
+	}
+	entries := []Automount{}
+	sr := am.getSearchRequest(mapDN, ldap.ScopeSingleLevel, automountObjectClassFilter,
+		[]string{automountKeyAttr, automountInformationAttr, descriptionAttr})
+	cErr = am.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			entries = append(entries, Automount{
+				Dn:          entry.DN,
+				Key:         entry.GetAttributeValue(automountKeyAttr),
+				Information: entry.GetAttributeValue(automountInformationAttr),
+				Description: entry.GetAttributeValue(descriptionAttr),
+			})
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(automountMapNotFoundMsg, mapName)))
+		}
+		return nil, cErr
+	}
+	return entries, nil
+}
+
+// CreateEntry creates a new entry in an existing automount map in LDAP.
+// params:
+//
+//	mapName = the name of the map, e.g. auto.home
+//	entry = the entry, the Key and the Information are mandatory
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the AutomountBaseDN is not set in the client Config
+//   - if the map is not found
+//   - if the entry already exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (am *automountsManager) CreateEntry(mapName string, entry Automount) *Error {
+	entryDN, cErr := am.getEntryDN(mapName, entry)
+	if cErr != nil {
+		return cErr
+	}
+	ar := ldap.NewAddRequest(entryDN, nil)
+	ar.Attribute(objectClassAttr, defaultObjectClassesAutomount)
+	ar.Attribute(automountKeyAttr, []string{entry.Key})
+	ar.Attribute(automountInformationAttr, []string{entry.Information})
+	if entry.Description != "" {
+		ar.Attribute(descriptionAttr, []string{entry.Description})
+	}
+	if cErr := am.Client.doLDAPAdd(ar); cErr != nil {
+		switch cErr.Status {
+		case http.StatusBadRequest:
+			return WrapError(errors.ConflictError(fmt.Sprintf(automountAlreadyExistsMsg, entry.Key, mapName)))
+		case http.StatusNotFound:
+			return WrapError(errors.NotFoundError(fmt.Sprintf(automountMapNotFoundMsg, mapName)))
+		}
+		return cErr
+	}
+	am.Client.emit(Event{Type: EventAutomountCreated, Dn: entryDN})
+	return nil
+}
+
+// UpdateEntry replaces the information and the description of an existing entry of an automount map in LDAP. The
+// description is removed if it is not set.
+// params:
+//
+//	mapName = the name of the map, e.g. auto.home
+//	entry = the entry, the Key and the Information are mandatory
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the AutomountBaseDN is not set in the client Config
+//   - if the entry is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (am *automountsManager) UpdateEntry(mapName string, entry Automount) *Error {
+	entryDN, cErr := am.getEntryDN(mapName, entry)
+	if cErr != nil {
+		return cErr
+	}
+	mr := ldap.NewModifyRequest(entryDN, nil)
+	mr.Replace(automountInformationAttr, []string{entry.Information})
+	mr.Replace(descriptionAttr, nonEmptyValues(entry.Description))
+	if cErr := am.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(automountNotFoundMsg, entry.Key, mapName)))
+		}
+		return cErr
+	}
+	am.Client.emit(Event{Type: EventAutomountUpdated, Dn: entryDN})
+	return nil
+}
+
+// DeleteEntry deletes an existing entry of an automount map from LDAP.
+// params:
+//
+//	mapName = the name of the map, e.g. auto.home
+//	key = the key of the entry
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the AutomountBaseDN is not set in the client Config
+//   - if the entry is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (am *automountsManager) DeleteEntry(mapName, key string) *Error {
+	mapDN, cErr := am.getMapDN(mapName)
+	if cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(key) == "" {
+		return missingParametersError([]string{automountKeyAttr})
+	}
+	entryDN := dn.Join(dn.RDN(automountKeyAttr, key), mapDN)
+	if cErr := am.Client.doLDAPDelete(ldap.NewDelRequest(entryDN, nil)); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(automountNotFoundMsg, key, mapName)))
+		}
+		return cErr
+	}
+	am.Client.emit(Event{Type: EventAutomountDeleted, Dn: entryDN})
+	return nil
+}
+
+// getSearchRequest returns a ldap search request for the automount maps or entries.
+func (am *automountsManager) getSearchRequest(baseDN string, scope int, searchFilter string,
+	attributes []string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		baseDN,
+		scope,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		searchFilter,
+		attributes,
+		nil,
+	)
+}
+
+// getMapDN validates the map name and returns the dn of the automount map.
+func (am *automountsManager) getMapDN(name string) (string, *Error) {
+	automountBaseDN, cErr := am.automountBaseDN()
+	if cErr != nil {
+		return "", cErr
+	}
+	if strings.TrimSpace(name) == "" {
+		return "", missingParametersError([]string{automountMapNameAttr})
+	}
+	return dn.Join(dn.RDN(automountMapNameAttr, name), automountBaseDN), nil
+}
+
+// getEntryDN validates the entry and returns the dn of the entry of the automount map.
+func (am *automountsManager) getEntryDN(mapName string, entry Automount) (string, *Error) {
+	mapDN, cErr := am.getMapDN(mapName)
+	if cErr != nil {
+		return "", cErr
+	}
+	var missingParams []string
+	if strings.TrimSpace(entry.Key) == "" {
+		missingParams = append(missingParams, automountKeyAttr)
+	}
+	if strings.TrimSpace(entry.Information) == "" {
+		missingParams = append(missingParams, automountInformationAttr)
+	}
+	if len(missingParams) > 0 {
+		return "", missingParametersError(missingParams)
+	}
+	return dn.Join(dn.RDN(automountKeyAttr, entry.Key), mapDN), nil
+}
+
+// automountBaseDN returns the AutomountBaseDN set in the client Config or an error if it is not set.
+func (am *automountsManager) automountBaseDN() (string, *Error) {
+	automountBaseDN := am.Client.getConfig().AutomountBaseDN
+	if automountBaseDN == "" {
+		return "", missingParametersError([]string{automountBaseDNParam})
+	}
+	return automountBaseDN, nil
+}
@@ -0,0 +1,267 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testAutomountConfig = func() Config {
+		config := testConfig
+		config.AutomountBaseDN = "ou=automount,o=company"
+		return config
+	}()
+
+	testAutomountMap = AutomountMap{
+		Dn:   "automountMapName=auto.home,ou=automount,o=company",
+		Name: "auto.home",
+	}
+
+	testAutomount = Automount{
+		Dn:          "automountKey=*,automountMapName=auto.home,ou=automount,o=company",
+		Key:         "*",
+		Information: "-fstype=nfs4 nfs.company.com:/home/&",
+		Description: "Home directories",
+	}
+)
+
+func TestAutomountsManager_GetMaps(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, testAutomountConfig.AutomountBaseDN, sr.BaseDN)
+				assert.Equal(t, automountMapObjectClassFilter, sr.Filter)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry(testAutomountMap.Dn, map[string][]string{automountMapNameAttr: {testAutomountMap.Name}}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		maps, cErr := client.Automounts.GetMaps()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []AutomountMap{testAutomountMap}, maps)
+	})
+
+	t.Run("automount base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		maps, cErr := client.Automounts.GetMaps()
+		assert.Nil(t, maps)
+		assert.Equal(t, missingParametersError([]string{automountBaseDNParam}), cErr)
+	})
+}
+
+func TestAutomountsManager_CreateMap(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest(testAutomountMap.Dn, nil)
+		ar.Attribute(objectClassAttr, defaultObjectClassesAutomountMap)
+		ar.Attribute(automountMapNameAttr, []string{testAutomountMap.Name})
+		var events []Event
+		client.On(EventAutomountMapCreated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Automounts.CreateMap(testAutomountMap.Name))
+		assert.Len(t, events, 1)
+		assert.Equal(t, testAutomountMap.Dn, events[0].Dn)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).Return(ldapEntryAlreadyExistsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Automounts.CreateMap(testAutomountMap.Name)
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testAutomountConfig, UnitTesting())
+
+		cErr := client.Automounts.CreateMap(" ")
+		assert.Equal(t, missingParametersError([]string{automountMapNameAttr}), cErr)
+	})
+}
+
+func TestAutomountsManager_DeleteMap(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var events []EventType
+		client.On(EventAutomountMapDeleted, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete,
+			ldap.NewDelRequest(testAutomountMap.Dn, []ldap.Control{ldap.NewControlSubtreeDelete()})).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Automounts.DeleteMap(testAutomountMap.Name))
+		assert.Equal(t, []EventType{EventAutomountMapDeleted}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, mock.AnythingOfType("*ldap.DelRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Automounts.DeleteMap(testAutomountMap.Name)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestAutomountsManager_GetEntries(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, testAutomountMap.Dn, sr.BaseDN)
+				assert.Equal(t, ldap.ScopeSingleLevel, sr.Scope)
+				assert.Equal(t, automountObjectClassFilter, sr.Filter)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry(testAutomount.Dn, map[string][]string{
+					automountKeyAttr:         {testAutomount.Key},
+					automountInformationAttr: {testAutomount.Information},
+					descriptionAttr:          {testAutomount.Description},
+				}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		entries, cErr := client.Automounts.GetEntries(testAutomountMap.Name)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []Automount{testAutomount}, entries)
+	})
+
+	t.Run("map not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		entries, cErr := client.Automounts.GetEntries(testAutomountMap.Name)
+		assert.Nil(t, entries)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestAutomountsManager_CreateEntry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest(testAutomount.Dn, nil)
+		ar.Attribute(objectClassAttr, defaultObjectClassesAutomount)
+		ar.Attribute(automountKeyAttr, []string{testAutomount.Key})
+		ar.Attribute(automountInformationAttr, []string{testAutomount.Information})
+		ar.Attribute(descriptionAttr, []string{testAutomount.Description})
+		var events []Event
+		client.On(EventAutomountCreated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Automounts.CreateEntry(testAutomountMap.Name, testAutomount))
+		assert.Len(t, events, 1)
+		assert.Equal(t, testAutomount.Dn, events[0].Dn)
+	})
+
+	t.Run("map not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Automounts.CreateEntry(testAutomountMap.Name, testAutomount)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testAutomountConfig, UnitTesting())
+
+		cErr := client.Automounts.CreateEntry(testAutomountMap.Name, Automount{})
+		assert.Equal(t, missingParametersError([]string{automountKeyAttr, automountInformationAttr}), cErr)
+	})
+}
+
+func TestAutomountsManager_UpdateEntry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest(testAutomount.Dn, nil)
+		mr.Replace(automountInformationAttr, []string{"nfs.company.com:/home/&"})
+		mr.Replace(descriptionAttr, []string{})
+		var events []EventType
+		client.On(EventAutomountUpdated, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Automounts.UpdateEntry(testAutomountMap.Name,
+			Automount{Key: testAutomount.Key, Information: "nfs.company.com:/home/&"}))
+		assert.Equal(t, []EventType{EventAutomountUpdated}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Automounts.UpdateEntry(testAutomountMap.Name, testAutomount)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestAutomountsManager_DeleteEntry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testAutomountConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var events []EventType
+		client.On(EventAutomountDeleted, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(testAutomount.Dn, nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Automounts.DeleteEntry(testAutomountMap.Name, testAutomount.Key))
+		assert.Equal(t, []EventType{EventAutomountDeleted}, events)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testAutomountConfig, UnitTesting())
+
+		cErr := client.Automounts.DeleteEntry(testAutomountMap.Name, "")
+		assert.Equal(t, missingParametersError([]string{automountKeyAttr}), cErr)
+	})
+}
@@ -0,0 +1,282 @@
+package ldap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	backupChecksumPrefix = "# sha256: "
+
+	defaultRestoreBatchSize = 100
+
+	backupWriteErrMsg          = "Writing the backup failed: %v"
+	backupReadErrMsg           = "Reading the backup failed: %v"
+	backupChecksumMissingMsg   = "The backup does not end with a checksum"
+	backupChecksumMismatchMsg  = "The checksum of the backup does not match, expected '%s' but was '%s'"
+	backupAllUserAttributes    = "*"
+	backupOrganizationalUnits  = "(objectClass=organizationalUnit)"
+	backupSearchFilterTemplate = "(|%s%s%s)"
+)
+
+type (
+	// BackupOptions configure a Backup.
+	BackupOptions struct {
+		// PageSize is the number of entries retrieved per page, defaults to 500.
+		PageSize uint32
+		// Progress is invoked after every page with the number of entries written so far.
+		Progress func(progress BackupProgress)
+	}
+
+	// RestoreOptions configure a Restore.
+	RestoreOptions struct {
+		// DryRun only verifies and parses the backup without changing LDAP.
+		DryRun bool
+		// BatchSize is the number of entries which are added using the same connection, defaults to 100.
+		BatchSize int
+		// Progress is invoked after every batch with the number of entries processed so far.
+		Progress func(progress BackupProgress)
+	}
+
+	// BackupProgress reports the progress of a Backup or a Restore.
+	BackupProgress struct {
+		// Entries is the number of entries processed so far.
+		Entries int
+		// Total is the total number of entries, 0 if it is not known yet.
+		Total int
+	}
+
+	// RestoreResult summarizes a Restore.
+	RestoreResult struct {
+		// Total is the number of entries in the backup.
+		Total int `json:"total"`
+		// Added is the number of entries which were added.
+		Added int `json:"added"`
+		// Skipped is the number of entries which were not added because they already exist.
+		Skipped int `json:"skipped"`
+	}
+
+	// checksumWriter writes to a writer while computing the checksum of the written bytes and remembers the first
+	// write error.
+	checksumWriter struct {
+		w    io.Writer
+		hash hash.Hash
+		err  error
+	}
+)
+
+// Backup writes the organizational units, groups and users managed by the client as LDIF to w.
+// All the user attributes of the entries within the UserBaseDN and the GroupBaseDN are written, including the
+// base entries themselves. The backup ends with a comment containing the SHA-256 checksum of the backup, which is
+// verified by Restore. The entries are retrieved page by page, so the memory usage does not grow with the number
+// of entries.
+// The method returns an error:
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+//   - if writing to w fails
+func (c *Client) Backup(w io.Writer, opts BackupOptions) *Error {
+	pageSize := opts.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	cw := &checksumWriter{w: w, hash: sha256.New()}
+	_, _ = io.WriteString(cw, ldifVersion)
+
+	progress := BackupProgress{}
+	for _, baseDN := range c.backupBaseDNs() {
+		cErr := c.doLDAPPagedSearch(c.getBackupSearchRequest(baseDN), pageSize,
+			func(result *ldap.SearchResult) *Error {
+				for _, entry := range result.Entries {
+					writeLDIFEntry(cw, entry)
+				}
+				if cw.err != nil {
+					return WrapError(errors.InternalServerErrorf(backupWriteErrMsg, cw.err))
+				}
+				progress.Entries += len(result.Entries)
+				if opts.Progress != nil {
+					opts.Progress(progress)
+				}
+				return nil
+			})
+		if cErr != nil {
+			return cErr
+		}
+	}
+
+	if _, err := io.WriteString(w, "\n"+backupChecksumPrefix+hex.EncodeToString(cw.hash.Sum(nil))+"\n"); err != nil {
+		return WrapError(errors.InternalServerErrorf(backupWriteErrMsg, err))
+	}
+	return nil
+}
+
+// Restore adds the entries of a backup written by Backup to LDAP.
+// The checksum of the backup is verified before any entry is added. The entries are added parent entries first,
+// entries which already exist are skipped and left unchanged.
+// The method returns the result of the restore, which is also returned together with an error if adding an entry
+// fails.
+// The method returns an error:
+//   - if reading the backup fails
+//   - if the checksum of the backup is missing or does not match
+//   - if the backup is not valid LDIF
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if adding an entry fails
+func (c *Client) Restore(r io.Reader, opts RestoreOptions) (*RestoreResult, *Error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, WrapError(errors.InternalServerErrorf(backupReadErrMsg, err))
+	}
+	content, cErr := verifyBackupChecksum(data)
+	if cErr != nil {
+		return nil, cErr
+	}
+	entries, cErr := parseLDIF(bytes.NewReader(content))
+	if cErr != nil {
+		return nil, cErr
+	}
+	sortEntriesParentsFirst(entries)
+
+	result := &RestoreResult{Total: len(entries)}
+	if opts.DryRun {
+		return result, nil
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultRestoreBatchSize
+	}
+	for start := 0; start < len(entries); start += batchSize {
+		end := start + batchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		requests := make([]any, 0, end-start)
+		for _, entry := range entries[start:end] {
+			requests = append(requests, getRestoreAddRequest(entry))
+		}
+		errs, cErr := c.doLDAPBatch(requests)
+		if cErr != nil {
+			return result, cErr
+		}
+		for _, cErr := range errs {
+			switch {
+			case cErr == nil:
+				result.Added++
+			case cErr.Status == http.StatusBadRequest &&
+				cErr.Message == ldap.LDAPResultCodeMap[ldap.LDAPResultEntryAlreadyExists]:
+				result.Skipped++
+			default:
+				return result, cErr
+			}
+		}
+		if opts.Progress != nil {
+			opts.Progress(BackupProgress{Entries: end, Total: len(entries)})
+		}
+	}
+	return result, nil
+}
+
+// backupBaseDNs returns the base dns of the subtrees to back up. A base dn within the subtree of another base dn
+// is not returned, so no entry is backed up twice.
+func (c *Client) backupBaseDNs() []string {
+	config := c.getConfig()
+	baseDNs := []string{config.UserBaseDN}
+	switch {
+	case dn.EqualFold(config.GroupBaseDN, config.UserBaseDN) || isDescendantDN(config.GroupBaseDN, config.UserBaseDN):
+	case isDescendantDN(config.UserBaseDN, config.GroupBaseDN):
+		baseDNs = []string{config.GroupBaseDN}
+	default:
+		baseDNs = append(baseDNs, config.GroupBaseDN)
+	}
+	return baseDNs
+}
+
+// getBackupSearchRequest returns a ldap search request to get the organizational unit, group and user entries
+// within the subtree of the base dn.
+func (c *Client) getBackupSearchRequest(baseDN string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf(backupSearchFilterTemplate, backupOrganizationalUnits, c.getConfig().groupsFilter(),
+			c.getConfig().usersFilter()),
+		[]string{backupAllUserAttributes},
+		nil,
+	)
+}
+
+// getRestoreAddRequest returns a ldap add request to add an entry of a backup.
+func getRestoreAddRequest(entry *ldap.Entry) *ldap.AddRequest {
+	ar := ldap.NewAddRequest(entry.DN, nil)
+	for _, attribute := range entry.Attributes {
+		ar.Attribute(attribute.Name, attribute.Values)
+	}
+	return ar
+}
+
+// verifyBackupChecksum verifies the checksum at the end of a backup and returns the content of the backup without
+// the checksum.
+func verifyBackupChecksum(data []byte) ([]byte, *Error) {
+	trimmed := bytes.TrimRight(data, "\r\n")
+	index := bytes.LastIndex(trimmed, []byte("\n"+backupChecksumPrefix))
+	if index < 0 {
+		return nil, WrapError(errors.BadRequestError(backupChecksumMissingMsg))
+	}
+	content := trimmed[:index]
+	expected := strings.TrimSpace(string(trimmed[index+1+len(backupChecksumPrefix):]))
+	sum := sha256.Sum256(content)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, WrapError(errors.BadRequestError(fmt.Sprintf(backupChecksumMismatchMsg, expected, actual)))
+	}
+	return content, nil
+}
+
+// sortEntriesParentsFirst sorts the entries by the depth of their dn, so parent entries are added before their
+// children. Entries of which the dn can not be parsed keep their position relative to each other.
+func sortEntriesParentsFirst(entries []*ldap.Entry) {
+	depth := func(entryDN string) int {
+		parsedDN, err := ldap.ParseDN(entryDN)
+		if err != nil {
+			return 0
+		}
+		return len(parsedDN.RDNs)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return depth(entries[i].DN) < depth(entries[j].DN)
+	})
+}
+
+// isDescendantDN reports whether the dn is within the subtree of the base dn, excluding the base dn itself.
+func isDescendantDN(entryDN, baseDN string) bool {
+	parsedDN, errDN := ldap.ParseDN(entryDN)
+	parsedBaseDN, errBaseDN := ldap.ParseDN(baseDN)
+	if errDN != nil || errBaseDN != nil {
+		return false
+	}
+	return parsedBaseDN.AncestorOfFold(parsedDN)
+}
+
+// Write writes p to the underlying writer and adds p to the checksum.
+func (cw *checksumWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.hash.Write(p[:n])
+	if err != nil {
+		cw.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,151 @@
+package ldap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testBackupUsersEntries = []*ldap.Entry{
+		ldap.NewEntry("uid=C00001,ou=users,o=company", map[string][]string{
+			objectClassAttr: {"inetOrgPerson"},
+			userIdAttr:      {"C00001"},
+			jpegPhotoAttr:   {"\xff\xd8\xff"},
+		}),
+		ldap.NewEntry("ou=users,o=company", map[string][]string{
+			objectClassAttr:        {"organizationalUnit"},
+			OrganizationalUnitAttr: {"users"},
+		}),
+	}
+	testBackupGroupsEntries = []*ldap.Entry{
+		ldap.NewEntry("cn=group1,ou=test-ou-1,ou=projects,o=company", map[string][]string{
+			objectClassAttr:  {"groupOfUniqueNames"},
+			CommonNameAttr:   {"group1"},
+			uniqueMemberAttr: {"uid=C00001,ou=users,o=company"},
+		}),
+	}
+)
+
+func backupTestEntries(t *testing.T) []byte {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, mock.MatchedBy(func(sr *ldap.SearchRequest) bool {
+		return sr.BaseDN == testConfig.UserBaseDN
+	})).Return(&ldap.SearchResult{Entries: testBackupUsersEntries}, nil)
+	ldapMock.On(methodNameSearch, mock.MatchedBy(func(sr *ldap.SearchRequest) bool {
+		return sr.BaseDN == testConfig.GroupBaseDN
+	})).Return(&ldap.SearchResult{Entries: testBackupGroupsEntries}, nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	var progress []BackupProgress
+	var buf bytes.Buffer
+	cErr := client.Backup(&buf, BackupOptions{Progress: func(p BackupProgress) {
+		progress = append(progress, p)
+	}})
+	assert.Nil(t, cErr)
+	assert.Equal(t, []BackupProgress{{Entries: 2}, {Entries: 3}}, progress)
+	return buf.Bytes()
+}
+
+func TestClient_Backup(t *testing.T) {
+	backup := string(backupTestEntries(t))
+
+	assert.True(t, strings.HasPrefix(backup, ldifVersion+"\ndn: uid=C00001,ou=users,o=company\n"))
+	assert.Contains(t, backup, "jpegPhoto:: /9j/\n")
+	assert.Contains(t, backup, "\ndn: cn=group1,ou=test-ou-1,ou=projects,o=company\n")
+	assert.Regexp(t, "\n# sha256: [0-9a-f]{64}\n$", backup)
+}
+
+func TestClient_Restore(t *testing.T) {
+	backup := backupTestEntries(t)
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var added []string
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.MatchedBy(func(ar *ldap.AddRequest) bool {
+			return ar.DN == "ou=users,o=company"
+		})).Return(ldapEntryAlreadyExistsErr)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).
+			Run(func(args mock.Arguments) {
+				added = append(added, args.Get(0).(*ldap.AddRequest).DN)
+			}).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var progress []BackupProgress
+		result, cErr := client.Restore(bytes.NewReader(backup), RestoreOptions{
+			BatchSize: 2,
+			Progress: func(p BackupProgress) {
+				progress = append(progress, p)
+			},
+		})
+		assert.Nil(t, cErr)
+		assert.Equal(t, &RestoreResult{Total: 3, Added: 2, Skipped: 1}, result)
+		assert.Equal(t, []string{"uid=C00001,ou=users,o=company", "cn=group1,ou=test-ou-1,ou=projects,o=company"},
+			added)
+		assert.Equal(t, []BackupProgress{{Entries: 2, Total: 3}, {Entries: 3, Total: 3}}, progress)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		result, cErr := client.Restore(bytes.NewReader(backup), RestoreOptions{DryRun: true})
+		assert.Nil(t, cErr)
+		assert.Equal(t, &RestoreResult{Total: 3}, result)
+	})
+
+	t.Run("add error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).Return(ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Restore(bytes.NewReader(backup), RestoreOptions{})
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+		assert.Equal(t, 0, result.Added)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		client := NewClient(testConfig)
+		tampered := bytes.Replace(backup, []byte("group1"), []byte("group2"), 1)
+
+		result, cErr := client.Restore(bytes.NewReader(tampered), RestoreOptions{})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Contains(t, cErr.Message, "checksum")
+	})
+
+	t.Run("checksum missing", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		_, cErr := client.Restore(strings.NewReader(ldifVersion), RestoreOptions{})
+		assert.Equal(t, backupChecksumMissingMsg, cErr.Message)
+	})
+}
+
+func TestClient_backupBaseDNs(t *testing.T) {
+	t.Run("separate subtrees", func(t *testing.T) {
+		client := NewClient(testConfig)
+		assert.Equal(t, []string{testConfig.UserBaseDN, testConfig.GroupBaseDN}, client.backupBaseDNs())
+	})
+
+	t.Run("nested subtrees", func(t *testing.T) {
+		config := testConfig
+		config.GroupBaseDN = "ou=groups," + config.UserBaseDN
+		client := NewClient(config)
+		assert.Equal(t, []string{testConfig.UserBaseDN}, client.backupBaseDNs())
+	})
+}
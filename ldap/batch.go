@@ -0,0 +1,65 @@
+package ldap
+
+import (
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+type (
+	// BatchRequest represents LDAP add, delete and modify requests which are executed one after the other using the
+	// same connection. The result of a BatchRequest is a []*Error with the error of every request, or nil if
+	// the request succeeded.
+	BatchRequest struct {
+		Requests []any
+	}
+)
+
+// doLDAPBatch executes the add, delete and modify requests using the same connection and returns the error of every
+// request. The returned error is set if the batch could not be executed at all.
+func (c *Client) doLDAPBatch(requests []any, opts ...RequestOption) ([]*Error, *Error) {
+	result, cErr := c.doLDAPOperation(OperationBatch, &BatchRequest{Requests: requests}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	results, _ := result.([]*Error)
+	return results, nil
+}
+
+// executeBatch executes the requests of a batch using the connection. The last network error of the requests, if
+// any, is returned for the circuit breaker.
+func (c *Client) executeBatch(conn ldap.Client, br *BatchRequest) ([]*Error, error) {
+	results := make([]*Error, len(br.Requests))
+	var networkErr error
+	for i, request := range br.Requests {
+		if cErr := c.checkProtected(request); cErr != nil {
+			results[i] = cErr
+			continue
+		}
+		if cErr := c.checkPolicy(request); cErr != nil {
+			results[i] = cErr
+			continue
+		}
+		var err error
+		switch r := request.(type) {
+		case *ldap.AddRequest:
+			c.traceRequest(OperationAdd, r)
+			err = conn.Add(r)
+		case *ldap.DelRequest:
+			c.traceRequest(OperationDelete, r)
+			err = c.executeDelete(conn, r)
+		case *ldap.ModifyRequest:
+			c.traceRequest(OperationModify, r)
+			err = conn.Modify(r)
+		default:
+			results[i] = WrapError(errors.InternalServerErrorf(unsupportedOperationErrMsg, OperationBatch, request))
+			continue
+		}
+		if ldap.IsErrorAnyOf(err, networkErrorResultCodes...) {
+			networkErr = err
+		}
+		if err != nil {
+			results[i] = c.handleLdapError(err, requestSecrets(request)...)
+		}
+	}
+	return results, networkErr
+}
@@ -0,0 +1,29 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_doLDAPBatch(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	dr := ldap.NewDelRequest("uid=C00001,ou=users,o=company", nil)
+	mr := ldap.NewModifyRequest("uid=C00002,ou=users,o=company", nil)
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Once()
+	ldapMock.On(methodNameDelete, dr).Return(nil)
+	ldapMock.On(methodNameModify, mr).Return(ldapNoSuchObjectErr)
+	ldapMock.On(methodNameClose).Return(nil).Once()
+
+	results, cErr := client.doLDAPBatch([]any{dr, mr, ldap.NewSearchRequest("", 0, 0, 0, 0, false, "", nil, nil)})
+	assert.Nil(t, cErr)
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0])
+	assert.Equal(t, errors.ErrCodeNotFound, results[1].Code)
+	assert.Equal(t, errors.ErrCodeInternalServerError, results[2].Code)
+}
@@ -0,0 +1,138 @@
+package ldap
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+
+	ErrCodeCircuitOpen = "LDAP_CIRCUIT_OPEN"
+
+	circuitOpenErrMsg     = "The LDAP server is unavailable, requests are rejected for %s after %d consecutive network errors"
+	circuitOpenedMsg      = "Circuit breaker opened after %d consecutive network errors"
+	circuitHalfOpenedMsg  = "Circuit breaker half-opened, probing the LDAP server"
+	circuitClosedMsg      = "Circuit breaker closed, the LDAP server is reachable again"
+	defaultCircuitTimeout = 30 * time.Second
+)
+
+var (
+	// networkErrorResultCodes are the result codes which indicate that the LDAP server could not be reached.
+	networkErrorResultCodes = []uint16{
+		ldap.ErrorNetwork,
+		ldap.LDAPResultServerDown,
+		ldap.LDAPResultConnectError,
+		ldap.LDAPResultTimeout,
+		ldap.LDAPResultUnavailable,
+	}
+)
+
+type (
+	// circuitBreaker rejects LDAP operations once a number of consecutive network errors occurred, so callers
+	// fail fast instead of waiting for a dial timeout while the LDAP server is down. After the cool-down period
+	// a single probe operation is let through; the circuit is closed again if the probe succeeds.
+	circuitBreaker struct {
+		mu        sync.Mutex
+		threshold int
+		cooldown  time.Duration
+		failures  int
+		state     string
+		openedAt  time.Time
+		now       func() time.Time
+	}
+)
+
+// WithCircuitBreaker enables a circuit breaker which opens after threshold consecutive network errors and
+// rejects all operations for the cooldown period. After the cooldown period a single probe operation is allowed,
+// which closes the circuit if it succeeds or opens the circuit again if it fails.
+// If cooldown is zero a default of 30 seconds is used.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		if cooldown <= 0 {
+			cooldown = defaultCircuitTimeout
+		}
+		c.breaker = &circuitBreaker{
+			threshold: threshold,
+			cooldown:  cooldown,
+			state:     CircuitClosed,
+			now:       time.Now,
+		}
+	}
+}
+
+// CircuitBreakerState returns the current state of the circuit breaker.
+// CircuitClosed is returned if no circuit breaker is configured.
+func (c *Client) CircuitBreakerState() string {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	return c.breaker.state
+}
+
+// allow checks if an operation is allowed to be executed.
+// The method returns an error if the circuit is open or if a probe operation is already in progress.
+func (cb *circuitBreaker) allow() *Error {
+	if cb == nil {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cooldown {
+			return cb.openError()
+		}
+		logger.Info(circuitHalfOpenedMsg)
+		cb.state = CircuitHalfOpen
+		return nil
+	case CircuitHalfOpen:
+		return cb.openError()
+	default:
+		return nil
+	}
+}
+
+// record updates the state of the circuit breaker based on the result of an operation.
+// Only network errors are counted as failures, any other error means that the LDAP server is reachable.
+func (cb *circuitBreaker) record(err error) {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if err == nil || !ldap.IsErrorAnyOf(err, networkErrorResultCodes...) {
+		if cb.state != CircuitClosed {
+			logger.Info(circuitClosedMsg)
+		}
+		cb.failures = 0
+		cb.state = CircuitClosed
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.threshold {
+		if cb.state != CircuitOpen {
+			logger.Warnf(circuitOpenedMsg, cb.failures)
+		}
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// openError returns the error returned while the circuit is open.
+func (cb *circuitBreaker) openError() *Error {
+	return WrapError(errors.Newf(ErrCodeCircuitOpen, http.StatusServiceUnavailable, circuitOpenErrMsg, cb.cooldown,
+		cb.threshold))
+}
@@ -0,0 +1,127 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	t.Run("custom cooldown", func(t *testing.T) {
+		client := NewClient(testConfig, WithCircuitBreaker(3, time.Minute))
+		assert.Equal(t, 3, client.breaker.threshold)
+		assert.Equal(t, time.Minute, client.breaker.cooldown)
+		assert.Equal(t, CircuitClosed, client.CircuitBreakerState())
+	})
+
+	t.Run("default cooldown", func(t *testing.T) {
+		client := NewClient(testConfig, WithCircuitBreaker(3, 0))
+		assert.Equal(t, defaultCircuitTimeout, client.breaker.cooldown)
+	})
+
+	t.Run("no circuit breaker", func(t *testing.T) {
+		client := NewClient(testConfig)
+		assert.Nil(t, client.breaker)
+		assert.Equal(t, CircuitClosed, client.CircuitBreakerState())
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	cb := &circuitBreaker{
+		threshold: 2,
+		cooldown:  time.Minute,
+		state:     CircuitClosed,
+		now:       func() time.Time { return now },
+	}
+
+	t.Run("non network errors do not open the circuit", func(t *testing.T) {
+		cb.record(ldapInsufficientRightsErr)
+		cb.record(ldapNoSuchObjectErr)
+		assert.Equal(t, CircuitClosed, cb.state)
+		assert.Nil(t, cb.allow())
+	})
+
+	t.Run("open after threshold", func(t *testing.T) {
+		cb.record(ldapNetworkErr)
+		assert.Equal(t, CircuitClosed, cb.state)
+		cb.record(ldapNetworkErr)
+		assert.Equal(t, CircuitOpen, cb.state)
+
+		cErr := cb.allow()
+		assert.Equal(t, ErrCodeCircuitOpen, cErr.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, cErr.Status)
+	})
+
+	t.Run("half-open after cooldown", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.Nil(t, cb.allow())
+		assert.Equal(t, CircuitHalfOpen, cb.state)
+
+		// only a single probe is allowed
+		cErr := cb.allow()
+		assert.Equal(t, ErrCodeCircuitOpen, cErr.Code)
+	})
+
+	t.Run("failed probe opens the circuit", func(t *testing.T) {
+		cb.record(ldapNetworkErr)
+		assert.Equal(t, CircuitOpen, cb.state)
+		assert.NotNil(t, cb.allow())
+	})
+
+	t.Run("successful probe closes the circuit", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.Nil(t, cb.allow())
+		cb.record(nil)
+		assert.Equal(t, CircuitClosed, cb.state)
+		assert.Equal(t, 0, cb.failures)
+	})
+
+	t.Run("nil circuit breaker", func(t *testing.T) {
+		var nilBreaker *circuitBreaker
+		assert.Nil(t, nilBreaker.allow())
+		nilBreaker.record(ldapNetworkErr)
+	})
+}
+
+func TestClient_circuitBreaker(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithCircuitBreaker(2, time.Minute))
+	um := usersManager{Client: client}
+	sr := um.getUsersSearchRequest(userSearchFilter)
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Times(2)
+	ldapMock.On(methodNameSearch, sr).Return(nil, ldapNetworkErr).Times(2)
+	ldapMock.On(methodNameClose).Return(nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, cErr := client.Users.GetAll()
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	}
+	assert.Equal(t, CircuitOpen, client.CircuitBreakerState())
+
+	_, cErr := client.Users.GetAll()
+	assert.Equal(t, ErrCodeCircuitOpen, cErr.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, cErr.Status)
+}
+
+func TestClient_circuitBreaker_emptyBatchProbe(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	now := time.Now()
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithCircuitBreaker(1, time.Minute))
+	client.breaker.now = func() time.Time { return now }
+	client.breaker.record(ldapNetworkErr)
+	assert.Equal(t, CircuitOpen, client.CircuitBreakerState())
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	now = now.Add(time.Minute)
+	results, cErr := client.doLDAPBatch(nil)
+	assert.Nil(t, cErr)
+	assert.Empty(t, results)
+	assert.Equal(t, CircuitClosed, client.CircuitBreakerState())
+}
@@ -0,0 +1,120 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultBulkConcurrency is the number of tasks of a bulk operation which are executed concurrently by default.
+const defaultBulkConcurrency = 1
+
+type (
+	// BulkRunner executes the tasks of a bulk operation using a bounded number of concurrent workers, so large jobs
+	// complete quickly without opening an unbounded number of connections with the LDAP server. Every task which
+	// executes an LDAP operation uses its own connection, so at most Concurrency connections are open at a time.
+	BulkRunner struct {
+		// Concurrency is the maximum number of tasks executed at the same time. Values below 1 execute the tasks
+		// one after the other.
+		Concurrency int
+		// Progress is invoked after every item. Optional.
+		Progress ProgressFunc
+	}
+
+	// BulkTask is a task of a bulk operation, i is the index of the item the task is executed for.
+	BulkTask func(i int) *Error
+)
+
+// NewBulkRunner returns a BulkRunner which executes at most concurrency tasks at the same time.
+func NewBulkRunner(concurrency int) *BulkRunner {
+	return &BulkRunner{Concurrency: concurrency}
+}
+
+// Run executes the task for the items 0 to n-1 and returns the error of every item, the error of an item which
+// succeeded is nil. A task which fails does not stop the other tasks. If the Progress func cancels the job, the
+// remaining items are skipped and reported with an ErrCodeCancelled error.
+func (r *BulkRunner) Run(n int, task BulkTask) []*Error {
+	tracker := newProgressTracker(r.Progress, n)
+	return r.run(n, func(i int) *Error {
+		cErr := task(i)
+		tracker.doneItem(cErr)
+		return cErr
+	}, tracker)
+}
+
+// run executes the task for the items 0 to n-1 until the job tracked by the tracker is cancelled.
+func (r *BulkRunner) run(n int, task BulkTask, tracker *progressTracker) []*Error {
+	errs := make([]*Error, n)
+	execute := func(i int) {
+		if tracker.isCancelled() {
+			errs[i] = cancelledError()
+			return
+		}
+		errs[i] = task(i)
+	}
+	workers := min(max(r.Concurrency, 1), n)
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			execute(i)
+		}
+		return errs
+	}
+
+	items := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				execute(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+	return errs
+}
+
+// bulkRunner returns the BulkRunner of the client which uses the BulkConcurrency set in the client Config.
+func (c *Client) bulkRunner() *BulkRunner {
+	concurrency := c.getConfig().BulkConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	return NewBulkRunner(concurrency)
+}
+
+// CreateBulk creates new user entries in LDAP. The users are created concurrently by at most BulkConcurrency
+// workers, see the client Config.
+// params:
+//
+//	users = the user entries, every user must contain all the attributes required by Create
+//
+// A user which cannot be created does not stop the other users, the error of every user is reported in the
+// UserChange of the user. The changes are returned in the order of the users.
+// The method returns an error:
+//   - if a user does not have a uid or the uid is not unique
+func (um *usersManager) CreateBulk(users []User) ([]UserChange, *Error) {
+	seen := make(map[string]bool, len(users))
+	for _, user := range users {
+		if strings.TrimSpace(user.Uid) == "" {
+			return nil, missingParametersError([]string{userIdAttr})
+		}
+		if seen[strings.ToLower(user.Uid)] {
+			return nil, invalidParameterError(userIdAttr, fmt.Sprintf(duplicateUidMsg, user.Uid))
+		}
+		seen[strings.ToLower(user.Uid)] = true
+	}
+	changes := make([]UserChange, len(users))
+	errs := um.Client.bulkRunner().Run(len(users), func(i int) *Error {
+		return um.Create(users[i])
+	})
+	for i, user := range users {
+		changes[i] = UserChange{Action: UserChangeCreate, Uid: user.Uid, Error: errs[i]}
+	}
+	return changes, nil
+}
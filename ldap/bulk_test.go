@@ -0,0 +1,114 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkRunner_Run(t *testing.T) {
+	t.Run("sequential", func(t *testing.T) {
+		var order []int
+		errs := NewBulkRunner(0).Run(3, func(i int) *Error {
+			order = append(order, i)
+			if i == 1 {
+				return WrapError(errors.BadRequestError("failed"))
+			}
+			return nil
+		})
+		assert.Equal(t, []int{0, 1, 2}, order)
+		assert.Nil(t, errs[0])
+		assert.Equal(t, "failed", errs[1].Message)
+		assert.Nil(t, errs[2])
+	})
+
+	t.Run("bounded concurrency", func(t *testing.T) {
+		var running, maxRunning atomic.Int32
+		var mu sync.Mutex
+		done := map[int]bool{}
+		errs := NewBulkRunner(3).Run(20, func(i int) *Error {
+			current := running.Add(1)
+			for {
+				highest := maxRunning.Load()
+				if current <= highest || maxRunning.CompareAndSwap(highest, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			mu.Lock()
+			done[i] = true
+			mu.Unlock()
+			if i%2 == 0 {
+				return WrapError(errors.BadRequestError(fmt.Sprint(i)))
+			}
+			return nil
+		})
+		assert.Len(t, done, 20)
+		assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+		for i, cErr := range errs {
+			if i%2 == 0 {
+				assert.Equal(t, fmt.Sprint(i), cErr.Message)
+			} else {
+				assert.Nil(t, cErr)
+			}
+		}
+	})
+
+	t.Run("no items", func(t *testing.T) {
+		errs := NewBulkRunner(4).Run(0, func(i int) *Error { return nil })
+		assert.Empty(t, errs)
+	})
+}
+
+func TestUsersManager_CreateBulk(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		config := testConfig
+		config.BulkConcurrency = 2
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		existingUser := testUser1
+		existingUser.Uid = "C00009"
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, um.getAddRequest(testUser1)).Return(nil)
+		ldapMock.On("PasswordModify", um.getPasswordModifyRequest(testUser1.Uid, testUser1.UserPassword,
+			testUser1.UserPassword)).Return(nil, nil)
+		ldapMock.On(methodNameAdd, um.getAddRequest(existingUser)).Return(ldapEntryAlreadyExistsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Users.(UserProvisioner).CreateBulk([]User{testUser1, existingUser})
+		assert.Nil(t, cErr)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, UserChange{Action: UserChangeCreate, Uid: testUser1.Uid}, changes[0])
+		assert.Equal(t, existingUser.Uid, changes[1].Uid)
+		assert.Equal(t, http.StatusConflict, changes[1].Error.Status)
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		changes, cErr := client.Users.(UserProvisioner).CreateBulk([]User{testUser1, {}})
+		assert.Nil(t, changes)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("duplicate uid", func(t *testing.T) {
+		client := NewClient(testConfig)
+		duplicate := testUser1
+		duplicate.Uid = "c00001"
+
+		changes, cErr := client.Users.(UserProvisioner).CreateBulk([]User{testUser1, duplicate})
+		assert.Nil(t, changes)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(duplicateUidMsg, duplicate.Uid), cErr.Message)
+	})
+}
@@ -0,0 +1,310 @@
+package ldap
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	usersCacheKeyPrefix  = "ldap:users:"
+	groupsCacheKeyPrefix = "ldap:groups:"
+)
+
+type (
+	// CacheStore describes the interface which needs to be implemented by a store used for caching the results of
+	// read operations. A CacheStore must be safe for concurrent use by multiple goroutines.
+	CacheStore interface {
+		// Get returns the value cached for the key and whether a value which has not expired was found.
+		Get(key string) (any, bool)
+		// Set caches the value for the key for the duration of the ttl.
+		Set(key string, value any, ttl time.Duration)
+		// Delete removes the value cached for the key.
+		Delete(key string)
+	}
+
+	// MemoryCacheStore is an in-memory CacheStore.
+	MemoryCacheStore struct {
+		mu      sync.Mutex
+		entries map[string]memoryCacheEntry
+		now     func() time.Time
+	}
+
+	// memoryCacheEntry is a value cached by the MemoryCacheStore.
+	memoryCacheEntry struct {
+		value     any
+		expiresAt time.Time
+	}
+
+	// readCache holds the user and group entries cached by the UsersManager and the GroupsManager of a client and
+	// invalidates them on the write operations of the client.
+	readCache struct {
+		client *Client
+		store  CacheStore
+		ttl    time.Duration
+	}
+)
+
+// WithCache caches the results of Users.Get, Users.GetAll, Groups.Get and Groups.GetAll in the store for the ttl.
+// The cached results are invalidated by every write operation the client sends to LDAP for a user or a group entry,
+// whichever manager or method sent it, e.g. Users.Delete, Entries.Modify or a workflow, and whether or not the
+// operation succeeded, as e.g. a timed out write may still have been applied. The user entries are identified by the
+// uid of their dn and the group entries by the cn of their dn below the GroupBaseDN. Changes made outside the client
+// become visible after the ttl.
+// The cached results are copied, so the users and groups returned can be modified by the caller without changing
+// the cache. The results retrieved with request options, e.g. with Users.(UsersReader).GetWithOptions, the results
+// of the managers returned by UsersIn and GroupsIn and the results of the managers set with WithUsersManager and
+// WithGroupsManager are not cached.
+func WithCache(store CacheStore, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.readCache = &readCache{client: c, store: store, ttl: ttl}
+		c.middleware = append(c.middleware, c.readCache.invalidation)
+	}
+}
+
+// NewMemoryCacheStore returns an empty in-memory CacheStore.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value cached for the key and whether a value which has not expired was found.
+func (s *MemoryCacheStore) Get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !s.now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set caches the value for the key for the duration of the ttl.
+func (s *MemoryCacheStore) Set(key string, value any, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryCacheEntry{value: value, expiresAt: s.now().Add(ttl)}
+}
+
+// Delete removes the value cached for the key.
+func (s *MemoryCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// users returns all the user entries from the cache or loads them from LDAP and caches them.
+func (rc *readCache) users(load func() ([]User, *Error)) ([]User, *Error) {
+	key := rc.usersKey("all")
+	if value, ok := rc.store.Get(key); ok {
+		if users, ok := value.([]User); ok {
+			return cloneUsers(users), nil
+		}
+	}
+	users, cErr := load()
+	if cErr != nil {
+		return nil, cErr
+	}
+	rc.store.Set(key, cloneUsers(users), rc.ttl)
+	return users, nil
+}
+
+// user returns a single user's entry from the cache or loads it from LDAP and caches it.
+func (rc *readCache) user(uid string, load func() (*User, *Error)) (*User, *Error) {
+	key := rc.usersKey("uid:" + uid)
+	if value, ok := rc.store.Get(key); ok {
+		if user, ok := value.(User); ok {
+			user = user.clone()
+			return &user, nil
+		}
+	}
+	user, cErr := load()
+	if cErr != nil {
+		return nil, cErr
+	}
+	rc.store.Set(key, user.clone(), rc.ttl)
+	return user, nil
+}
+
+// groups returns the group entries retrieved with cn and ou from the cache or loads them from LDAP and caches them.
+func (rc *readCache) groups(cn, ou string, load func() ([]Group, *Error)) ([]Group, *Error) {
+	key := rc.groupsKey(cn, ou)
+	if value, ok := rc.store.Get(key); ok {
+		if groups, ok := value.([]Group); ok {
+			return cloneGroups(groups), nil
+		}
+	}
+	groups, cErr := load()
+	if cErr != nil {
+		return nil, cErr
+	}
+	rc.store.Set(key, cloneGroups(groups), rc.ttl)
+	return groups, nil
+}
+
+// cache returns the readCache of the client if the user entries retrieved with the request options are cached,
+// i.e. the client has a readCache, the options are empty and the usersManager manages the UserBaseDN.
+func (um *usersManager) cache(opts []RequestOption) *readCache {
+	if len(opts) > 0 || um.baseDN != "" {
+		return nil
+	}
+	return um.Client.readCache
+}
+
+// cache returns the readCache of the client if the group entries retrieved with the request options are cached,
+// i.e. the client has a readCache, the options are empty and the groupsManager manages the GroupBaseDN.
+func (gm *groupsManager) cache(opts []RequestOption) *readCache {
+	if len(opts) > 0 || gm.baseDN != "" {
+		return nil
+	}
+	return gm.Client.readCache
+}
+
+// invalidation is the Middleware which invalidates the cached entries written by the write operations of the client.
+func (rc *readCache) invalidation(next Operation) Operation {
+	return func(req *OperationRequest) (any, *Error) {
+		result, cErr := next(req)
+		for _, entryDN := range writtenDNs(req.Request) {
+			rc.invalidate(entryDN)
+		}
+		return result, cErr
+	}
+}
+
+// invalidate removes the cached results which may contain the entry with the dn: the user entry and the list of all
+// user entries for a user, the results which contain the group for a group and the results of the organizational
+// unit for an organizational unit below the GroupBaseDN.
+func (rc *readCache) invalidate(entryDN string) {
+	parsedDN, err := ldap.ParseDN(entryDN)
+	if err != nil || len(parsedDN.RDNs) == 0 || len(parsedDN.RDNs[0].Attributes) == 0 {
+		return
+	}
+	rdn := parsedDN.RDNs[0].Attributes[0]
+	if strings.EqualFold(rdn.Type, userIdAttr) {
+		rc.store.Delete(rc.usersKey("uid:" + rdn.Value))
+		rc.store.Delete(rc.usersKey("all"))
+		return
+	}
+	groupBaseDN := rc.client.getConfig().GroupBaseDN
+	if parsedBaseDN, err := ldap.ParseDN(groupBaseDN); err != nil || !parsedBaseDN.AncestorOfFold(parsedDN) {
+		return
+	}
+	ouPath, _ := dn.ExtractOUPath(entryDN, groupBaseDN)
+	switch {
+	case strings.EqualFold(rdn.Type, CommonNameAttr):
+		rc.invalidateGroup(rdn.Value, strings.Join(ouPath, OuPathSeparator))
+	case strings.EqualFold(rdn.Type, OrganizationalUnitAttr):
+		rc.invalidateGroup("", strings.Join(ouPath, OuPathSeparator))
+	}
+}
+
+// invalidateGroup removes the cached results which contain the group, which are the results for all the groups and
+// for the organizational unit of the group and all its parent organizational units, with and without the cn of the
+// group.
+func (rc *readCache) invalidateGroup(cn, ou string) {
+	rc.store.Delete(rc.groupsKey(cn, ou))
+	names := splitOuPath(ou)
+	for _, name := range []string{"", cn} {
+		rc.store.Delete(rc.groupsKey(name, ""))
+		for i := range names {
+			rc.store.Delete(rc.groupsKey(name, strings.Join(names[:i+1], OuPathSeparator)))
+		}
+	}
+}
+
+// usersKey returns the cache key of the user entries retrieved with the id. The key is case-insensitive, like the
+// uids in LDAP.
+func (rc *readCache) usersKey(id string) string {
+	return usersCacheKeyPrefix + strings.ToLower(id)
+}
+
+// groupsKey returns the cache key of the groups retrieved with cn and ou. The key is case-insensitive, like the cns
+// and the organizational units in LDAP.
+func (rc *readCache) groupsKey(cn, ou string) string {
+	return groupsCacheKeyPrefix + strings.ToLower(cn+":"+strings.Join(splitOuPath(ou), OuPathSeparator))
+}
+
+// writtenDNs returns the dns of the entries written by a go-ldap request or a batch, the old and the new dn of the
+// entry for a modify dn request.
+func writtenDNs(request any) []string {
+	switch r := request.(type) {
+	case *ldap.AddRequest:
+		return []string{r.DN}
+	case *ldap.DelRequest:
+		return []string{r.DN}
+	case *ldap.ModifyRequest:
+		return []string{r.DN}
+	case *ldap.ModifyDNRequest:
+		return []string{r.DN, modifiedDN(r)}
+	case *ldap.PasswordModifyRequest:
+		return []string{r.UserIdentity}
+	case *BatchRequest:
+		var dns []string
+		for _, request := range r.Requests {
+			dns = append(dns, writtenDNs(request)...)
+		}
+		return dns
+	default:
+		return nil
+	}
+}
+
+// clone returns a deep copy of the user.
+func (u User) clone() User {
+	u.Photo = slices.Clone(u.Photo)
+	if u.Certificates != nil {
+		certificates := make([][]byte, len(u.Certificates))
+		for i, certificate := range u.Certificates {
+			certificates[i] = slices.Clone(certificate)
+		}
+		u.Certificates = certificates
+	}
+	return u
+}
+
+// cloneUsers returns a deep copy of the users.
+func cloneUsers(users []User) []User {
+	if users == nil {
+		return nil
+	}
+	clones := make([]User, len(users))
+	for i, user := range users {
+		clones[i] = user.clone()
+	}
+	return clones
+}
+
+// clone returns a deep copy of the group.
+func (g Group) clone() Group {
+	g.OuPath = slices.Clone(g.OuPath)
+	g.Members = slices.Clone(g.Members)
+	g.Owners = slices.Clone(g.Owners)
+	g.BusinessCategory = slices.Clone(g.BusinessCategory)
+	if g.ReviewDate != nil {
+		reviewDate := *g.ReviewDate
+		g.ReviewDate = &reviewDate
+	}
+	return g
+}
+
+// cloneGroups returns a deep copy of the groups.
+func cloneGroups(groups []Group) []Group {
+	if groups == nil {
+		return nil
+	}
+	clones := make([]Group, len(groups))
+	for i, group := range groups {
+		clones[i] = group.clone()
+	}
+	return clones
+}
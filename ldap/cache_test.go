@@ -0,0 +1,325 @@
+package ldap
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMemoryCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	_, ok := store.Get("key")
+	assert.False(t, ok)
+
+	store.Set("key", "value", time.Minute)
+	value, ok := store.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+
+	now = now.Add(time.Minute)
+	_, ok = store.Get("key")
+	assert.False(t, ok)
+
+	store.Set("key", "value", time.Minute)
+	store.Delete("key")
+	_, ok = store.Get("key")
+	assert.False(t, ok)
+}
+
+func TestWithCache_Users(t *testing.T) {
+	t.Run("cached user", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithCache(NewMemoryCacheStore(), time.Minute))
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		user, cErr := client.Users.Get(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testUser1.Uid, user.Uid)
+
+		// the cached user is a copy which can not be modified by the caller
+		user.Mail = "modified"
+		user, cErr = client.Users.Get(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testUser1.Mail, user.Mail)
+	})
+
+	t.Run("not cached with request options", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithCache(NewMemoryCacheStore(), time.Minute))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.Anything).Return(getUserSearchResult, nil).Twice()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		for i := 0; i < 2; i++ {
+			user, cErr := client.Users.(UsersReader).GetWithOptions(testUser1.Uid, WithAttributes(userIdAttr))
+			assert.Nil(t, cErr)
+			assert.Equal(t, testUser1.Uid, user.Uid)
+		}
+	})
+
+	t.Run("cached user is a deep copy", func(t *testing.T) {
+		store := NewMemoryCacheStore()
+		client := NewClient(testConfig, WithCache(store, time.Minute))
+		store.Set(client.readCache.usersKey("uid:"+testUser1.Uid), User{Uid: testUser1.Uid, Certificates: [][]byte{{1}}},
+			time.Minute)
+
+		user, cErr := client.Users.Get(testUser1.Uid)
+		assert.Nil(t, cErr)
+		user.Certificates[0][0] = 2
+		user, cErr = client.Users.Get(strings.ToLower(testUser1.Uid))
+		assert.Nil(t, cErr)
+		assert.Equal(t, [][]byte{{1}}, user.Certificates)
+	})
+
+	t.Run("invalidated by delete", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithCache(NewMemoryCacheStore(), time.Minute))
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil).Twice()
+		ldapMock.On(methodNameDelete, um.getDeleteRequest(testUser1.Uid)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.GetAll()
+		assert.Nil(t, cErr)
+		users[0].Uid = "modified"
+		users, cErr = client.Users.GetAll()
+		assert.Nil(t, cErr)
+		assert.Equal(t, testUser1.Uid, users[0].Uid)
+
+		assert.Nil(t, client.Users.Delete(testUser1.Uid))
+		_, cErr = client.Users.GetAll()
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("invalidated by import", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithCache(NewMemoryCacheStore(), time.Minute))
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(CommonNameAttr, []string{"Johnny"})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil).Times(3)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.GetAll()
+		assert.Nil(t, cErr)
+		result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader("uid,cn\nC00001,Johnny\n"), CSVImportOptions{})
+		assert.Nil(t, cErr)
+		assert.Equal(t, 1, result.Updated)
+		_, cErr = client.Users.GetAll()
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("invalidated by set manager", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithCache(NewMemoryCacheStore(), time.Minute))
+		um := usersManager{Client: client}
+		searches := 0
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil).Run(func(_ mock.Arguments) { searches++ })
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.Get(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Nil(t, client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, ""))
+		before := searches
+		_, cErr = client.Users.Get(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, before+1, searches)
+	})
+
+	t.Run("request options bypass the cache", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithCache(NewMemoryCacheStore(), time.Minute))
+		um := usersManager{Client: client}
+		expected := um.getUserSearchRequest(um.getDN(testUser1.Uid))
+		expected.Attributes = []string{mailAttr}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, expected).Return(getUserSearchResult, nil).Twice()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		for i := 0; i < 2; i++ {
+			_, cErr := client.Users.(UsersReader).GetWithOptions(testUser1.Uid, WithAttributes(mailAttr))
+			assert.Nil(t, cErr)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithCache(NewMemoryCacheStore(), time.Minute))
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(nil, ldapNoSuchObjectErr).Twice()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.Get(testUser1.Uid)
+		assert.NotNil(t, cErr)
+		_, cErr = client.Users.Get(testUser1.Uid)
+		assert.NotNil(t, cErr)
+	})
+}
+
+func TestWithCache_Groups(t *testing.T) {
+	t.Run("invalidated by delete", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation(), WithCache(NewMemoryCacheStore(), time.Minute))
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", groupSearchFilter)).
+			Return(getGroupsOuEmptySearchResult, nil).Twice()
+		ldapMock.On(methodNameDelete, gm.getDeleteRequest(testGroupCn1, testOrganizationUnit1)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		groups, cErr := client.Groups.GetAll()
+		assert.Nil(t, cErr)
+		assert.Len(t, groups, 4)
+		groups, cErr = client.Groups.GetAll()
+		assert.Nil(t, cErr)
+		assert.Len(t, groups, 4)
+
+		assert.Nil(t, client.Groups.Delete(testGroupCn1, testOrganizationUnit1))
+		_, cErr = client.Groups.GetAll()
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("request options bypass the cache", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		store := NewMemoryCacheStore()
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation(), WithCache(store, time.Minute))
+		gm := groupsManager{Client: client}
+		sr := gm.getSearchRequest("", "", groupSearchFilter)
+		sr.Scope = ldap.ScopeSingleLevel
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(getGroupsOuEmptySearchResult, nil).Twice()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		for i := 0; i < 2; i++ {
+			groups, cErr := client.Groups.(GroupsReader).GetWithOptions("", "", WithScope(ldap.ScopeSingleLevel))
+			assert.Nil(t, cErr)
+			assert.Len(t, groups, 4)
+		}
+		_, ok := store.Get((&readCache{}).groupsKey("", ""))
+		assert.False(t, ok)
+	})
+
+	t.Run("cached groups are copies", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation(), WithCache(NewMemoryCacheStore(), time.Minute))
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", groupSearchFilter)).
+			Return(getGroupsOuEmptySearchResult, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Groups.GetAll()
+		assert.Nil(t, cErr)
+		groups, cErr := client.Groups.GetAll()
+		assert.Nil(t, cErr)
+		groups[0].Members[0] = "modified"
+		groups, cErr = client.Groups.GetAll()
+		assert.Nil(t, cErr)
+		assert.NotEqual(t, "modified", groups[0].Members[0])
+	})
+
+	t.Run("invalidated by the writes of other managers", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation(), WithCache(NewMemoryCacheStore(), time.Minute))
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", groupSearchFilter)).
+			Return(getGroupsOuEmptySearchResult, nil).Twice()
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Groups.GetAll()
+		assert.Nil(t, cErr)
+		// a write of an entry which is not a group leaves the cached groups
+		assert.Nil(t, client.Entries.Modify("cn=printer,o=company", map[string][]string{descriptionAttr: {"a"}}))
+		_, cErr = client.Groups.GetAll()
+		assert.Nil(t, cErr)
+
+		assert.Nil(t, client.Entries.Modify(strings.ToUpper(gm.getDN(testGroupCn1, testOrganizationUnit1)),
+			map[string][]string{descriptionAttr: {"a"}}))
+		_, cErr = client.Groups.GetAll()
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("invalidate parent organizational units", func(t *testing.T) {
+		store := NewMemoryCacheStore()
+		rc := &readCache{store: store, ttl: time.Minute}
+		keys := []string{
+			rc.groupsKey("", ""),
+			rc.groupsKey("", "department-1"),
+			rc.groupsKey("", "department-1/team-a"),
+			rc.groupsKey(testCN, "department-1/team-a"),
+			rc.groupsKey(testCN, "department-1"),
+			rc.groupsKey(testCN, ""),
+			rc.groupsKey("", "department-2"),
+		}
+		for _, key := range keys {
+			store.Set(key, []Group{}, time.Minute)
+		}
+
+		rc.invalidateGroup(testCN, "department-1/team-a")
+		for _, key := range keys[:6] {
+			_, ok := store.Get(key)
+			assert.False(t, ok, key)
+		}
+		_, ok := store.Get(keys[6])
+		assert.True(t, ok)
+	})
+}
+
+func TestWrittenDNs(t *testing.T) {
+	mdr := ldap.NewModifyDNRequest("uid=C00001,ou=users,o=company", "uid=C00002", true, "")
+	batch := &BatchRequest{Requests: []any{
+		ldap.NewAddRequest("cn=a,o=company", nil),
+		ldap.NewDelRequest("cn=b,o=company", nil),
+	}}
+
+	assert.Equal(t, []string{"uid=C00001,ou=users,o=company", "uid=C00002,ou=users,o=company"}, writtenDNs(mdr))
+	assert.Equal(t, []string{"cn=a,o=company", "cn=b,o=company"}, writtenDNs(batch))
+	assert.Equal(t, []string{"uid=C00001"}, writtenDNs(ldap.NewPasswordModifyRequest("uid=C00001", "", "")))
+	assert.Nil(t, writtenDNs(&ldap.SearchRequest{}))
+}
@@ -0,0 +1,170 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ExtensionPasswordModify is the OID of the password modify extended operation defined in RFC 3062.
+	ExtensionPasswordModify = "1.3.6.1.4.1.4203.1.11.1"
+
+	// ErrCodeUnsupportedFeature is the code of the error returned if an operation requires a control or an extended
+	// operation which is not supported by the LDAP server.
+	ErrCodeUnsupportedFeature = "UNSUPPORTED_FEATURE"
+
+	unsupportedFeatureErrMsg = "The LDAP server does not support the %s"
+	passwordModifyFeature    = "password modify extended operation"
+	controlFeature           = "control '%s'"
+)
+
+type (
+	// Capabilities represents the optional features supported by the LDAP server, as published in the RootDSE entry.
+	Capabilities struct {
+		SupportedControls   []string `json:"supportedControls"`
+		SupportedExtensions []string `json:"supportedExtensions"`
+	}
+)
+
+// WithCapabilityProbing enables the probing of the capabilities of the LDAP server. The RootDSE entry is read once,
+// before the first LDAP operation of the client, and the optional features are enabled or disabled accordingly:
+//   - paged searches fall back to a single search if the simple paged results control is not supported
+//   - non-critical controls which are not supported, e.g. the password policy control, are not sent
+//   - operations with a critical control or the password modify extended operation which are not supported fail
+//     with an ErrCodeUnsupportedFeature error instead of a protocol error
+//
+// If the RootDSE entry cannot be read the operation fails and the capabilities are probed again by the next one.
+func WithCapabilityProbing() ClientOption {
+	return func(c *Client) {
+		c.probeCapabilities = true
+	}
+}
+
+// Capabilities returns the controls and the extended operations supported by the LDAP server. The RootDSE entry
+// is read by the first call only, later calls return the same capabilities.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (c *Client) Capabilities() (*Capabilities, *Error) {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	if c.capabilities != nil {
+		return c.capabilities, nil
+	}
+	entry, cErr := c.getRootDSE()
+	if cErr != nil {
+		return nil, cErr
+	}
+	c.capabilities = &Capabilities{
+		SupportedControls:   entry.GetAttributeValues(supportedControlAttr),
+		SupportedExtensions: entry.GetAttributeValues(supportedExtensionAttr),
+	}
+	return c.capabilities, nil
+}
+
+// SupportsControl checks if the LDAP server supports the control with the OID.
+func (cp *Capabilities) SupportsControl(oid string) bool {
+	return slice.EntryExists(cp.SupportedControls, oid)
+}
+
+// SupportsExtension checks if the LDAP server supports the extended operation with the OID.
+func (cp *Capabilities) SupportsExtension(oid string) bool {
+	return slice.EntryExists(cp.SupportedExtensions, oid)
+}
+
+// checkCapabilities checks if the LDAP server supports the controls and the extended operation of a request if the
+// capability probing is enabled. The non-critical controls which are not supported are removed from the request.
+// The read of the RootDSE entry, which probes the capabilities, is not checked.
+func (c *Client) checkCapabilities(req *OperationRequest) *Error {
+	if !c.probeCapabilities || isRootDSESearch(req.Request) {
+		return nil
+	}
+	caps, cErr := c.Capabilities()
+	if cErr != nil {
+		return cErr
+	}
+	switch r := req.Request.(type) {
+	case *ldap.PasswordModifyRequest:
+		if !caps.SupportsExtension(ExtensionPasswordModify) {
+			return unsupportedFeatureError(passwordModifyFeature)
+		}
+	case *BatchRequest:
+		for _, request := range r.Requests {
+			if cErr := caps.filterControls(requestControls(request)); cErr != nil {
+				return cErr
+			}
+		}
+	default:
+		return caps.filterControls(requestControls(req.Request))
+	}
+	return nil
+}
+
+// supportsControl checks if the LDAP server supports the control with the OID. The control is considered supported
+// if the capability probing is disabled or the capabilities were not probed yet.
+func (c *Client) supportsControl(oid string) bool {
+	if !c.probeCapabilities {
+		return true
+	}
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	return c.capabilities == nil || c.capabilities.SupportsControl(oid)
+}
+
+// filterControls removes the non-critical controls which are not supported by the LDAP server and returns an error
+// if a critical control is not supported.
+func (cp *Capabilities) filterControls(controls *[]ldap.Control) *Error {
+	if controls == nil {
+		return nil
+	}
+	supported := (*controls)[:0]
+	for _, control := range *controls {
+		if cp.SupportsControl(control.GetControlType()) {
+			supported = append(supported, control)
+			continue
+		}
+		if isCriticalControl(control) {
+			return unsupportedFeatureError(fmt.Sprintf(controlFeature, control.GetControlType()))
+		}
+	}
+	*controls = supported
+	return nil
+}
+
+// requestControls returns the controls of an LDAP request or nil if the request does not support controls.
+func requestControls(request any) *[]ldap.Control {
+	switch r := request.(type) {
+	case *ldap.SearchRequest:
+		return &r.Controls
+	case *ldap.AddRequest:
+		return &r.Controls
+	case *ldap.DelRequest:
+		return &r.Controls
+	case *ldap.ModifyRequest:
+		return &r.Controls
+	case *PagedSearchRequest:
+		return &r.SearchRequest.Controls
+	}
+	return nil
+}
+
+// isCriticalControl checks if the criticality of the encoded control is set.
+func isCriticalControl(control ldap.Control) bool {
+	packet := control.Encode()
+	if len(packet.Children) < 2 {
+		return false
+	}
+	critical, ok := packet.Children[1].Value.(bool)
+	return ok && critical
+}
+
+// unsupportedFeatureError returns the error returned if a feature is not supported by the LDAP server.
+func unsupportedFeatureError(feature string) *Error {
+	return WrapError(errors.New(ErrCodeUnsupportedFeature, http.StatusNotImplemented,
+		fmt.Sprintf(unsupportedFeatureErrMsg, feature)))
+}
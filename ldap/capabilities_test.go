@@ -0,0 +1,148 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// getCapabilitiesRootDSESearchResult returns a RootDSE search result with the supported controls and extensions.
+func getCapabilitiesRootDSESearchResult(controls, extensions []string) *ldap.SearchResult {
+	return &ldap.SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry("", map[string][]string{supportedControlAttr: controls, supportedExtensionAttr: extensions}),
+	}}
+}
+
+// mockRootDSE sets up the mock to return the RootDSE entry with the supported controls and extensions once.
+func mockRootDSE(ldapMock *mocks.Client, client *Client, controls, extensions []string) {
+	ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return().Once()
+	ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).
+		Return(getCapabilitiesRootDSESearchResult(controls, extensions), nil).Once()
+}
+
+func TestWithCapabilityProbing(t *testing.T) {
+	client := NewClient(testConfig, WithCapabilityProbing())
+	assert.True(t, client.probeCapabilities)
+}
+
+func TestClient_Capabilities(t *testing.T) {
+	t.Run("probed once", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		mockRootDSE(ldapMock, client, []string{ldap.ControlTypePaging}, []string{ExtensionPasswordModify})
+		ldapMock.On(methodNameClose).Return(nil)
+
+		caps, cErr := client.Capabilities()
+		assert.Nil(t, cErr)
+		assert.True(t, caps.SupportsControl(ldap.ControlTypePaging))
+		assert.False(t, caps.SupportsControl(ControlTypeAssertion))
+		assert.True(t, caps.SupportsExtension(ExtensionPasswordModify))
+
+		cached, cErr := client.Capabilities()
+		assert.Nil(t, cErr)
+		assert.Same(t, caps, cached)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(nil, ldapNetworkErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		caps, cErr := client.Capabilities()
+		assert.Nil(t, caps)
+		assert.NotNil(t, cErr)
+		assert.Nil(t, client.capabilities)
+	})
+}
+
+func TestClient_checkCapabilities(t *testing.T) {
+	t.Run("unsupported password modify", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithCapabilityProbing())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		mockRootDSE(ldapMock, client, nil, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.doLDAPPasswordModify(ldap.NewPasswordModifyRequest("uid=C00001", "", "secret"))
+		assert.Equal(t, ErrCodeUnsupportedFeature, cErr.Code)
+		assert.Equal(t, http.StatusNotImplemented, cErr.Status)
+		assert.Equal(t, "The LDAP server does not support the password modify extended operation", cErr.Message)
+	})
+
+	t.Run("unsupported critical control", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithCapabilityProbing())
+		assertion, _ := NewControlAssertion("(objectClass=*)")
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		mockRootDSE(ldapMock, client, nil, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.doLDAPDelete(ldap.NewDelRequest("uid=C00001", nil), WithControls(assertion))
+		assert.Equal(t, ErrCodeUnsupportedFeature, cErr.Code)
+		assert.Equal(t, "The LDAP server does not support the control '1.3.6.1.1.12'", cErr.Message)
+	})
+
+	t.Run("unsupported non-critical control is removed", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithCapabilityProbing())
+		manageDsaIT := ldap.NewControlManageDsaIT(true)
+		expected := ldap.NewModifyRequest("uid=C00001", []ldap.Control{manageDsaIT})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		mockRootDSE(ldapMock, client, []string{ldap.ControlTypeManageDsaIT}, nil)
+		ldapMock.On(methodNameModify, expected).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.doLDAPModify(ldap.NewModifyRequest("uid=C00001", nil),
+			WithControls(ldap.NewControlBeheraPasswordPolicy(), manageDsaIT))
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("paged search falls back to a single search", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithCapabilityProbing())
+		sr := ldap.NewSearchRequest(testConfig.UserBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0,
+			false, userSearchFilter, nil, nil)
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		mockRootDSE(ldapMock, client, nil, nil)
+		ldapMock.On(methodNameSearch, sr).Return(getPagedSearchResult("", "uid=1", "uid=2"), nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var dns []string
+		cErr := client.doLDAPPagedSearch(sr, 1, func(result *ldap.SearchResult) *Error {
+			for _, entry := range result.Entries {
+				dns = append(dns, entry.DN)
+			}
+			return nil
+		})
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{"uid=1", "uid=2"}, dns)
+		assert.Empty(t, sr.Controls)
+	})
+
+	t.Run("probing disabled", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		assert.Nil(t, client.checkCapabilities(&OperationRequest{Request: &ldap.PasswordModifyRequest{}}))
+		assert.True(t, client.supportsControl(ldap.ControlTypePaging))
+	})
+}
+
+func TestIsCriticalControl(t *testing.T) {
+	assert.True(t, isCriticalControl(ldap.NewControlManageDsaIT(true)))
+	assert.False(t, isCriticalControl(ldap.NewControlManageDsaIT(false)))
+	assert.False(t, isCriticalControl(ldap.NewControlPaging(10)))
+	assert.True(t, isCriticalControl(NewControlRelaxRules()))
+}
@@ -0,0 +1,271 @@
+package ldap
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ChangeTypeAdd is the type of a change which added an entry.
+	ChangeTypeAdd = "add"
+	// ChangeTypeModify is the type of a change which modified the attributes of an entry.
+	ChangeTypeModify = "modify"
+	// ChangeTypeDelete is the type of a change which deleted an entry.
+	ChangeTypeDelete = "delete"
+	// ChangeTypeModRDN is the type of a change which renamed or moved an entry.
+	ChangeTypeModRDN = "modrdn"
+
+	// ModificationAdd is the operation of a modification which added values to an attribute.
+	ModificationAdd = "add"
+	// ModificationDelete is the operation of a modification which deleted values or all the values of an attribute.
+	ModificationDelete = "delete"
+	// ModificationReplace is the operation of a modification which replaced the values of an attribute.
+	ModificationReplace = "replace"
+	// ModificationIncrement is the operation of a modification which incremented the value of an attribute.
+	ModificationIncrement = "increment"
+
+	reqTypeAttr          = "reqType"
+	reqModAttr           = "reqMod"
+	reqOldAttr           = "reqOld"
+	reqNewRDNAttr        = "reqNewRDN"
+	reqNewSuperiorAttr   = "reqNewSuperior"
+	authzIDPrefix        = "dn:"
+	auditWriteFilter     = "(objectClass=auditWriteObject)"
+	auditSuccessFilter   = "(reqResult=0)"
+	auditDNFilter        = "(reqDN=%s)"
+	auditSinceFilter     = "(reqStart>=%s)"
+	auditUntilFilter     = "(reqStart<=%s)"
+	auditChangedByFilter = "(reqAuthzID=dn:%s)"
+	auditTypeFilter      = "(reqType=%s)"
+
+	changeLogFeature = "reading of the change history, set the AccessLogBaseDN in the client Config"
+)
+
+var modificationOperations = map[string]string{
+	"+": ModificationAdd,
+	"-": ModificationDelete,
+	"=": ModificationReplace,
+	"#": ModificationIncrement,
+}
+
+type (
+	// ChangeLogManager describes the interface which needs to be implemented for reading the history of the changes
+	// made to the entries in LDAP, which is recorded by the OpenLDAP accesslog overlay in the AccessLogBaseDN set in the
+	// client Config.
+	ChangeLogManager interface {
+		GetChanges(query ChangeLogQuery, opts ...RequestOption) ([]ChangeRecord, *Error)
+	}
+
+	// changeLogManager implements the ChangeLogManager interface.
+	changeLogManager struct {
+		Client *Client
+	}
+
+	// ChangeLogQuery selects the changes retrieved from the accesslog. The zero value selects all the changes.
+	ChangeLogQuery struct {
+		// Dn is the dn of the entry the changes are retrieved for.
+		Dn string `json:"dn,omitempty"`
+		// Since and Until limit the changes to the ones made within the time range, both are inclusive.
+		Since time.Time `json:"since"`
+		Until time.Time `json:"until"`
+		// Types limits the changes to the given types, e.g. ChangeTypeModify.
+		Types []string `json:"types,omitempty"`
+		// Attribute limits the changes to the ones which modified the attribute, e.g. member.
+		Attribute string `json:"attribute,omitempty"`
+		// ChangedBy is the dn of the user who made the changes.
+		ChangedBy string `json:"changedBy,omitempty"`
+	}
+
+	// ChangeRecord represents a successful write operation recorded in the accesslog.
+	ChangeRecord struct {
+		Dn   string    `json:"dn"`
+		Type string    `json:"type"`
+		Time time.Time `json:"time"`
+		// ChangedBy is the dn of the user who made the change.
+		ChangedBy     string         `json:"changedBy,omitempty"`
+		Modifications []Modification `json:"modifications,omitempty"`
+		// OldAttributes are the values of the attributes before the change, only recorded if the logold option of the
+		// accesslog overlay matches the entry. The values of the password attributes are replaced by the
+		// RedactedValue.
+		OldAttributes map[string][]string `json:"oldAttributes,omitempty"`
+		// NewDn is the dn of the entry after a ChangeTypeModRDN change.
+		NewDn string `json:"newDn,omitempty"`
+	}
+
+	// Modification represents the modification of an attribute by a change.
+	Modification struct {
+		Attribute string `json:"attribute"`
+		// Operation is one of ModificationAdd, ModificationDelete, ModificationReplace or ModificationIncrement.
+		Operation string `json:"operation"`
+		// Values are the values of the modification, the values of the password attributes, e.g. userPassword, are
+		// replaced by the RedactedValue.
+		Values []string `json:"values,omitempty"`
+	}
+)
+
+// WithChangeLogManager overrides the default ChangeLogManager.
+func WithChangeLogManager(cm ChangeLogManager) ClientOption {
+	return func(c *Client) {
+		c.ChangeLog = cm
+	}
+}
+
+// GetChanges retrieves the successful write operations recorded in the accesslog which match the query, ordered by
+// the time they were made, e.g. to find out who removed a user from a group:
+//
+//	changes, cErr := client.ChangeLog.GetChanges(ldap.ChangeLogQuery{Dn: groupDn, Attribute: "member"})
+//
+// params:
+//
+//	query = the query selecting the changes
+//
+// How long the changes can be read depends on the purge interval of the accesslog.
+// The method returns an error:
+//   - if the AccessLogBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (cm *changeLogManager) GetChanges(query ChangeLogQuery, opts ...RequestOption) ([]ChangeRecord, *Error) {
+	accessLogBaseDN := cm.Client.getConfig().AccessLogBaseDN
+	if accessLogBaseDN == "" {
+		return nil, unsupportedFeatureError(changeLogFeature)
+	}
+	changes := []ChangeRecord{}
+	sr := ldap.NewSearchRequest(
+		accessLogBaseDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		query.filter(),
+		[]string{reqTypeAttr, reqDNAttr, reqStartAttr, reqAuthzIDAttr, reqModAttr, reqOldAttr, reqNewRDNAttr,
+			reqNewSuperiorAttr},
+		nil,
+	)
+	cErr := cm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			change := parseChangeRecord(entry)
+			if query.Attribute != "" && !change.modifies(query.Attribute) {
+				continue
+			}
+			changes = append(changes, change)
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Time.Before(changes[j].Time)
+	})
+	return changes, nil
+}
+
+// filter returns the LDAP search filter of the accesslog entries matching the query. The attribute is matched by
+// the client, since the reqMod attribute does not support substring matching.
+func (q ChangeLogQuery) filter() string {
+	filter := auditWriteFilter + auditSuccessFilter
+	if q.Dn != "" {
+		filter += Filterf(auditDNFilter, q.Dn)
+	}
+	if !q.Since.IsZero() {
+		filter += Filterf(auditSinceFilter, q.Since.UTC().Format(generalizedTimeLayout))
+	}
+	if !q.Until.IsZero() {
+		filter += Filterf(auditUntilFilter, q.Until.UTC().Format(generalizedTimeLayout))
+	}
+	if q.ChangedBy != "" {
+		filter += Filterf(auditChangedByFilter, q.ChangedBy)
+	}
+	if len(q.Types) > 0 {
+		var types string
+		for _, changeType := range q.Types {
+			types += Filterf(auditTypeFilter, changeType)
+		}
+		filter += "(|" + types + ")"
+	}
+	return "(&" + filter + ")"
+}
+
+// modifies reports whether the change modified the attribute, ignoring the case of the attribute name.
+func (cr ChangeRecord) modifies(attribute string) bool {
+	for _, modification := range cr.Modifications {
+		if strings.EqualFold(modification.Attribute, attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseChangeRecord parses an accesslog entry into a ChangeRecord.
+func parseChangeRecord(entry *ldap.Entry) ChangeRecord {
+	changeTime, _ := parseGeneralizedTime(entry.GetAttributeValue(reqStartAttr))
+	change := ChangeRecord{
+		Dn:            entry.GetAttributeValue(reqDNAttr),
+		Type:          entry.GetAttributeValue(reqTypeAttr),
+		Time:          changeTime,
+		ChangedBy:     authzDN(entry.GetAttributeValue(reqAuthzIDAttr)),
+		Modifications: parseModifications(entry.GetAttributeValues(reqModAttr)),
+	}
+	if old := entry.GetAttributeValues(reqOldAttr); len(old) > 0 {
+		change.OldAttributes = parseOldAttributes(old)
+	}
+	if newRDN := entry.GetAttributeValue(reqNewRDNAttr); newRDN != "" {
+		parent := entry.GetAttributeValue(reqNewSuperiorAttr)
+		if parent == "" {
+			if parsed, err := ldap.ParseDN(change.Dn); err == nil && len(parsed.RDNs) > 1 {
+				parsed.RDNs = parsed.RDNs[1:]
+				parent = parsed.String()
+			}
+		}
+		change.NewDn = newRDN + "," + parent
+	}
+	return change
+}
+
+// parseModifications parses the reqMod values of an accesslog entry, e.g. "member:+ uid=C00001,ou=users,o=company",
+// into modifications. The consecutive values of the same attribute and operation are merged into one modification.
+func parseModifications(values []string) []Modification {
+	var modifications []Modification
+	for _, value := range values {
+		attribute, rest, ok := strings.Cut(value, ":")
+		if !ok || rest == "" {
+			continue
+		}
+		operation, known := modificationOperations[rest[:1]]
+		if !known {
+			continue
+		}
+		last := len(modifications) - 1
+		if last < 0 || modifications[last].Attribute != attribute || modifications[last].Operation != operation {
+			modifications = append(modifications, Modification{Attribute: attribute, Operation: operation})
+			last++
+		}
+		if val, hasValue := strings.CutPrefix(rest[1:], " "); hasValue {
+			modifications[last].Values = append(modifications[last].Values, redactedValues(attribute, []string{val})...)
+		}
+	}
+	return modifications
+}
+
+// parseOldAttributes parses the reqOld values of an accesslog entry, e.g. "mail: john.doe@company.com", into the
+// values per attribute.
+func parseOldAttributes(values []string) map[string][]string {
+	attributes := make(map[string][]string)
+	for _, value := range values {
+		attribute, val, ok := strings.Cut(value, ": ")
+		if !ok {
+			continue
+		}
+		attributes[attribute] = append(attributes[attribute], redactedValues(attribute, []string{val})...)
+	}
+	return attributes
+}
+
+// authzDN returns the dn of a reqAuthzID value, which is prefixed with "dn:".
+func authzDN(authzID string) string {
+	return strings.TrimPrefix(authzID, authzIDPrefix)
+}
@@ -0,0 +1,150 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testChangeLogConfig = func() Config {
+		config := testConfig
+		config.AccessLogBaseDN = "cn=accesslog"
+		return config
+	}()
+
+	testGroupDn = "cn=admins,ou=projects,o=company"
+)
+
+func getAccessLogEntry(reqStart, reqType, reqDN string, attributes map[string][]string) *ldap.Entry {
+	values := map[string][]string{
+		reqStartAttr:   {reqStart},
+		reqTypeAttr:    {reqType},
+		reqDNAttr:      {reqDN},
+		reqAuthzIDAttr: {"dn:cn=root,o=company"},
+	}
+	for attr, vals := range attributes {
+		values[attr] = vals
+	}
+	return ldap.NewEntry("reqStart="+reqStart+",cn=accesslog", values)
+}
+
+func TestChangeLogManager_GetChanges(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testChangeLogConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, testChangeLogConfig.AccessLogBaseDN, sr.BaseDN)
+				assert.Equal(t, "(&(objectClass=auditWriteObject)(reqResult=0)(reqDN=cn=admins,ou=projects,o=company)"+
+					"(reqStart>=20240101000000Z)(|(reqType=modify)(reqType=modrdn)))", sr.Filter)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getAccessLogEntry("20240103080000.000000Z", ChangeTypeModRDN, testGroupDn, map[string][]string{
+					reqNewRDNAttr: {"cn=administrators"},
+				}),
+				getAccessLogEntry("20240102080000.000000Z", ChangeTypeModify, testGroupDn, map[string][]string{
+					reqModAttr: {
+						"member:- uid=C00001,ou=users,o=company",
+						"member:- uid=C00002,ou=users,o=company",
+						"description:-",
+						"modifiersName:= cn=root,o=company",
+					},
+					reqOldAttr: {"member: uid=C00001,ou=users,o=company", "member: uid=C00002,ou=users,o=company"},
+				}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.ChangeLog.GetChanges(ChangeLogQuery{
+			Dn:    testGroupDn,
+			Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Types: []string{ChangeTypeModify, ChangeTypeModRDN},
+		})
+		assert.Nil(t, cErr)
+		assert.Equal(t, []ChangeRecord{
+			{
+				Dn:        testGroupDn,
+				Type:      ChangeTypeModify,
+				Time:      time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC),
+				ChangedBy: "cn=root,o=company",
+				Modifications: []Modification{
+					{Attribute: "member", Operation: ModificationDelete,
+						Values: []string{"uid=C00001,ou=users,o=company", "uid=C00002,ou=users,o=company"}},
+					{Attribute: "description", Operation: ModificationDelete},
+					{Attribute: "modifiersName", Operation: ModificationReplace, Values: []string{"cn=root,o=company"}},
+				},
+				OldAttributes: map[string][]string{
+					"member": {"uid=C00001,ou=users,o=company", "uid=C00002,ou=users,o=company"},
+				},
+			},
+			{
+				Dn:        testGroupDn,
+				Type:      ChangeTypeModRDN,
+				Time:      time.Date(2024, 1, 3, 8, 0, 0, 0, time.UTC),
+				ChangedBy: "cn=root,o=company",
+				NewDn:     "cn=administrators,ou=projects,o=company",
+			},
+		}, changes)
+	})
+
+	t.Run("attribute", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testChangeLogConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getAccessLogEntry("20240102080000.000000Z", ChangeTypeModify, testGroupDn, map[string][]string{
+					reqModAttr: {"member:- uid=C00001,ou=users,o=company"},
+				}),
+				getAccessLogEntry("20240103080000.000000Z", ChangeTypeModify, testGroupDn, map[string][]string{
+					reqModAttr: {"description:= Administrators"},
+				}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.ChangeLog.GetChanges(ChangeLogQuery{Dn: testGroupDn, Attribute: "Member"})
+		assert.Nil(t, cErr)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, "member", changes[0].Modifications[0].Attribute)
+	})
+
+	t.Run("access log base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		changes, cErr := client.ChangeLog.GetChanges(ChangeLogQuery{})
+		assert.Nil(t, changes)
+		assert.Equal(t, ErrCodeUnsupportedFeature, cErr.Code)
+		assert.Equal(t, http.StatusNotImplemented, cErr.Status)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testChangeLogConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.ChangeLog.GetChanges(ChangeLogQuery{})
+		assert.Nil(t, changes)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestChangeLogQuery_filter(t *testing.T) {
+	query := ChangeLogQuery{
+		Until:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		ChangedBy: "cn=admin (ops),o=company",
+	}
+	assert.Equal(t, "(&(objectClass=auditWriteObject)(reqResult=0)(reqStart<=20240201000000Z)"+
+		"(reqAuthzID=dn:cn=admin \\28ops\\29,o=company))", query.filter())
+}
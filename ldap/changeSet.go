@@ -0,0 +1,71 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// AttributeChange describes the change of the values of an attribute of an entry.
+	AttributeChange struct {
+		Attribute string `json:"attribute"`
+		// Old are the values of the attribute before the change, empty if the attribute is added.
+		Old []string `json:"old,omitempty"`
+		// New are the values of the attribute after the change, empty if the attribute is removed.
+		New []string `json:"new,omitempty"`
+	}
+
+	// ChangeSet describes the changes of the attributes of an entry made by an update, e.g. to display or log the
+	// precise differences. The password of a user is never part of a ChangeSet.
+	ChangeSet []AttributeChange
+)
+
+// Attributes returns the names of the changed attributes.
+func (cs ChangeSet) Attributes() []string {
+	attributes := make([]string, 0, len(cs))
+	for _, change := range cs {
+		attributes = append(attributes, change.Attribute)
+	}
+	return attributes
+}
+
+// Get returns the change of the attribute or nil if the attribute is not changed.
+// The attribute name is matched case-insensitively.
+func (cs ChangeSet) Get(attribute string) *AttributeChange {
+	for i := range cs {
+		if strings.EqualFold(cs[i].Attribute, attribute) {
+			return &cs[i]
+		}
+	}
+	return nil
+}
+
+// String returns a human-readable description of the changes, e.g. "mail: [a@company.com] -> [b@company.com]".
+func (cs ChangeSet) String() string {
+	changes := make([]string, 0, len(cs))
+	for _, change := range cs {
+		changes = append(changes, change.String())
+	}
+	return strings.Join(changes, ", ")
+}
+
+// String returns a human-readable description of the change, e.g. "mail: [a@company.com] -> [b@company.com]".
+func (ac AttributeChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", ac.Attribute, ac.Old, ac.New)
+}
+
+// userChangeSet returns the changes of the attributes attrs from the existing user to the updated user.
+func userChangeSet(existing, updated User, attrs []string) ChangeSet {
+	changes := ChangeSet{}
+	for _, attr := range attrs {
+		if attr == userPasswordAttr {
+			continue
+		}
+		changes = append(changes, AttributeChange{
+			Attribute: attr,
+			Old:       nonEmptyValues(userAttributeValue(existing, attr)),
+			New:       nonEmptyValues(userAttributeValue(updated, attr)),
+		})
+	}
+	return changes
+}
@@ -0,0 +1,40 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSet(t *testing.T) {
+	changes := ChangeSet{
+		{Attribute: mailAttr, Old: []string{"john.doe@company.com"}, New: []string{"john.doe@example.com"}},
+		{Attribute: employeeNumberAttr, Old: []string{"12345"}},
+	}
+
+	t.Run("attributes", func(t *testing.T) {
+		assert.Equal(t, []string{mailAttr, employeeNumberAttr}, changes.Attributes())
+		assert.Empty(t, ChangeSet{}.Attributes())
+	})
+
+	t.Run("get", func(t *testing.T) {
+		assert.Equal(t, &changes[0], changes.Get("MAIL"))
+		assert.Nil(t, changes.Get(displayNameAttr))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, "mail: [john.doe@company.com] -> [john.doe@example.com], employeeNumber: [12345] -> []",
+			changes.String())
+	})
+}
+
+func TestUserChangeSet(t *testing.T) {
+	user := testUser1
+	user.DisplayName = "Johnny"
+	user.UserPassword = "newPassword"
+
+	changes := userChangeSet(testUser1, user, []string{displayNameAttr, userPasswordAttr})
+	assert.Equal(t, ChangeSet{
+		{Attribute: displayNameAttr, Old: []string{testUser1.DisplayName}, New: []string{"Johnny"}},
+	}, changes)
+}
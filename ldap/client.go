@@ -2,7 +2,11 @@ package ldap
 
 import (
 	"fmt"
+	"math"
+	"net"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/atselvan/go-utils/utils/config"
 	"github.com/atselvan/go-utils/utils/errors"
@@ -12,8 +16,6 @@ import (
 )
 
 const (
-	ldapUrlFormat = "%s://%s:%s"
-
 	userIdAttr             = "uid"
 	alternateUserIdAttr    = "altUid"
 	CommonNameAttr         = "cn"
@@ -23,29 +25,48 @@ const (
 	mailAttr               = "mail"
 	userPasswordAttr       = "userPassword"
 	statusAttr             = "status"
+	jpegPhotoAttr          = "jpegPhoto"
+	userCertificateAttr    = "userCertificate;binary"
 	OrganizationalUnitAttr = "ou"
 	uniqueMemberAttr       = "uniqueMember"
 	objectClassAttr        = "objectClass"
 
 	orgUnitSearchFilter = "(&(objectClass=organizationalUnit))"
-	groupSearchFilter   = "(&(objectClass=groupOfUniqueNames))"
-	userSearchFilter    = "(&(objectClass=inetOrgPerson))"
+	groupSearchFilter   = "(&" + groupObjectClassFilter + ")"
+	userSearchFilter    = "(&" + userObjectClassFilter + ")"
+
+	// userObjectClassFilter matches the user entries unless a UserSearchFilter is set in the client Config.
+	userObjectClassFilter = "(objectClass=inetOrgPerson)"
+	// groupObjectClassFilter matches the group entries unless a GroupSearchFilter is set in the client Config.
+	groupObjectClassFilter = "(objectClass=groupOfUniqueNames)"
+	// userFilterTemplate combines the filter matching the user entries with an attribute value assertion.
+	userFilterTemplate = "(&(%s=%s)%s)"
 
 	ProtocolLdap                      = "ldap"
 	ProtocolLdaps                     = "ldaps"
+	ProtocolLdapi                     = "ldapi"
 	UniqueMemberAttrValuePrefix       = userIdAttr + "="
 	OrganizationalUnitAttrValuePrefix = OrganizationalUnitAttr + "="
 	WildcardGroupsSearchFilter        = "(&(cn=%s*)(objectClass=groupOfUniqueNames))"
 	WildcardUserSearchFilter          = "(&(%s=%s)(objectClass=inetOrgPerson))"
 
+	invalidDerefAliasesErrMsg = "Invalid alias dereferencing policy '%d'. Valid policies are %v"
+	invalidSearchFilterErrMsg = "Invalid %s '%s': %v"
+	userSearchFilterParam     = "user search filter"
+	groupSearchFilterParam    = "group search filter"
+
 	connectionMsg        = "Connecting to the LDAP server %s..."
 	connectionSuccessMsg = "Connected to the LDAP server"
+
+	defaultDialTimeout    = 10 * time.Second
+	defaultRequestTimeout = 30 * time.Second
 )
 
 var (
 	validProtocols = []string{
 		ProtocolLdap,
 		ProtocolLdaps,
+		ProtocolLdapi,
 	}
 
 	defaultObjectClassesGroup = []string{
@@ -72,20 +93,217 @@ type (
 		BaseDN       string `json:"baseDN" yaml:"baseDN" mapstructure:"LDAP_BASE_DN" required:"true"`
 		UserBaseDN   string `json:"userBaseDN" yaml:"userBaseDN" mapstructure:"LDAP_USER_BASE_DN" required:"true"`
 		GroupBaseDN  string `json:"groupBaseDN" yaml:"groupBaseDN" mapstructure:"LDAP_GROUP_BASE_DN" required:"true"`
-		BindUser     string `json:"bindUser" required:"true"`
-		BindPassword string `json:"bindPassword" required:"true"`
+		BindUser     string `json:"bindUser" yaml:"bindUser" required:"true"`
+		BindPassword string `json:"bindPassword" yaml:"bindPassword" required:"true"`
+
+		// URL is the LDAP URL of the server, e.g. "ldaps://[fd00::1]:1636" or "ldapi://%2Fvar%2Frun%2Fslapd%2Fldapi".
+		// Optional, if set the Protocol, the Hostname and the Port are taken from the URL and the port defaults to the
+		// default port of the protocol.
+		URL string `json:"url" yaml:"url" mapstructure:"LDAP_URL"`
+
+		// SRVDomain is the DNS domain the LDAP servers are discovered in using the _ldap._tcp SRV records, e.g. the
+		// domain of an Active Directory forest. Optional, if set the Hostname is not used and the Port is only used
+		// to override the port of the SRV records. The servers are tried in the order of their priority and weight
+		// until a connection is established.
+		SRVDomain string `json:"srvDomain" yaml:"srvDomain" mapstructure:"LDAP_SRV_DOMAIN"`
+
+		// DialTimeout is the maximum amount of time a dial to the LDAP server is allowed to take.
+		// Defaults to 10 seconds.
+		DialTimeout time.Duration `json:"dialTimeout" yaml:"dialTimeout" mapstructure:"LDAP_DIAL_TIMEOUT"`
+		// RequestTimeout is the maximum amount of time to wait for the response of an LDAP request. Defaults to
+		// 30 seconds. If set, the timeout is also sent to the server as the time limit of search requests.
+		RequestTimeout time.Duration `json:"requestTimeout" yaml:"requestTimeout" mapstructure:"LDAP_REQUEST_TIMEOUT"`
+		// DerefAliases is the alias dereferencing policy used for search requests, one of ldap.NeverDerefAliases,
+		// ldap.DerefInSearching, ldap.DerefFindingBaseObj or ldap.DerefAlways. Defaults to ldap.NeverDerefAliases.
+		DerefAliases int `json:"derefAliases" yaml:"derefAliases" mapstructure:"LDAP_DEREF_ALIASES"`
+		// MaxResults is the maximum number of entries a search is allowed to return, e.g. Users.GetAll or
+		// Users.Filter. A search which matches more entries is stopped and fails with an ErrCodeTooManyResults error
+		// instead of loading all the entries into memory. Optional, the number of entries is not limited if not set.
+		// The limit can be overridden for a single search using WithMaxResults.
+		MaxResults int `json:"maxResults" yaml:"maxResults" mapstructure:"LDAP_MAX_RESULTS"`
+		// PasswordPolicyDN is the dn of the default password policy entry, which applies to the users without a
+		// pwdPolicySubentry. Optional, without a default password policy passwords of those users never expire.
+		PasswordPolicyDN string `json:"passwordPolicyDN" yaml:"passwordPolicyDN" mapstructure:"LDAP_PASSWORD_POLICY_DN"`
+		// LastLoginAttr is the operational attribute which holds the time of the last successful authentication of
+		// a user, e.g. authTimestamp of the OpenLDAP lastbind overlay or lastLoginTime. Defaults to authTimestamp.
+		LastLoginAttr string `json:"lastLoginAttr" yaml:"lastLoginAttr" mapstructure:"LDAP_LAST_LOGIN_ATTR"`
+		// ValidateSyntax enables the validation of the syntax of the mail, the uid and the display name of a user
+		// before the user is created. Disabled by default, only the presence of the mandatory attributes is validated.
+		ValidateSyntax bool `json:"validateSyntax" yaml:"validateSyntax" mapstructure:"LDAP_VALIDATE_SYNTAX"`
+		// EnforceUniqueMail enables a check before a user is created which rejects the user if another user entry
+		// already has the same mail. Disabled by default.
+		EnforceUniqueMail bool `json:"enforceUniqueMail" yaml:"enforceUniqueMail" mapstructure:"LDAP_ENFORCE_UNIQUE_MAIL"`
+		// EnforceUniqueEmployeeNumber enables a check before a user is created which rejects the user if another
+		// user entry already has the same employee number. Disabled by default.
+		EnforceUniqueEmployeeNumber bool `json:"enforceUniqueEmployeeNumber" yaml:"enforceUniqueEmployeeNumber" mapstructure:"LDAP_ENFORCE_UNIQUE_EMPLOYEE_NUMBER"`
+		// ArchiveBaseDN is the dn of the container the user entries are moved to by Users.Archive, e.g. to keep the
+		// deleted users restorable during a retention period. Optional, Archive and Unarchive fail if it is not set.
+		ArchiveBaseDN string `json:"archiveBaseDN" yaml:"archiveBaseDN" mapstructure:"LDAP_ARCHIVE_BASE_DN"`
+		// SoftDelete makes Users.Delete follow the soft-delete convention instead of removing the user entry: the
+		// status of the user is set to Deleted, the password is scrambled and the entry is moved to the ArchiveBaseDN,
+		// which must be set. Users.HardDelete removes the entry. Disabled by default.
+		SoftDelete bool `json:"softDelete" yaml:"softDelete" mapstructure:"LDAP_SOFT_DELETE"`
+		// DeleteJournalSize is the number of entries deleted by the client which are kept in the delete journal, so
+		// accidental deletes can be undone with UndoLastDelete or RestoreFromJournal. The entries are read before they
+		// are deleted and kept in memory as LDIF. Optional, the deletes are not journaled if it is not set.
+		DeleteJournalSize int `json:"deleteJournalSize" yaml:"deleteJournalSize" mapstructure:"LDAP_DELETE_JOURNAL_SIZE"`
+		// GroupReviewDateAttr is the attribute of the group entries which holds the date the group is due for an
+		// access review as generalized time. Optional, the review dates are not managed if it is not set. The
+		// attribute must be allowed on the group entries by the schema of the LDAP server.
+		GroupReviewDateAttr string `json:"groupReviewDateAttr" yaml:"groupReviewDateAttr" mapstructure:"LDAP_GROUP_REVIEW_DATE_ATTR"`
+		// RoleMappings map the logical roles used by the applications to the LDAP groups which grant them, see
+		// RolesManager.
+		RoleMappings []Role `json:"roleMappings" yaml:"roleMappings"`
+		// GroupNamePolicies are the naming conventions of the groups, which are enforced when a group is created.
+		// Optional, any group name is allowed if no policy applies to the organizational unit of the group.
+		GroupNamePolicies []GroupNamePolicy `json:"groupNamePolicies" yaml:"groupNamePolicies"`
+		// PreserveMemberCase keeps the case of the member ids when the unique member dns of the groups are built,
+		// e.g. for directories with lower-case uids. Disabled by default, the member ids are upper-cased.
+		PreserveMemberCase bool `json:"preserveMemberCase" yaml:"preserveMemberCase" mapstructure:"LDAP_PRESERVE_MEMBER_CASE"`
+		// BulkConcurrency is the maximum number of LDAP operations executed concurrently by the bulk operations,
+		// e.g. Users.CreateBulk and Users.Reconcile, and therefore the maximum number of connections they open with
+		// the LDAP server at a time. Defaults to 1, the operations are executed one after the other.
+		BulkConcurrency int `json:"bulkConcurrency" yaml:"bulkConcurrency" mapstructure:"LDAP_BULK_CONCURRENCY"`
+		// UidPolicy is the naming convention of the uids, which is enforced when a user is created. Optional.
+		UidPolicy UidPolicy `json:"uidPolicy" yaml:"uidPolicy"`
+		// RevisionAttr is the operational attribute which identifies the revision of an entry for conditional
+		// updates, e.g. modifyTimestamp or entryCSN. Defaults to modifyTimestamp.
+		RevisionAttr string `json:"revisionAttr" yaml:"revisionAttr" mapstructure:"LDAP_REVISION_ATTR"`
+		// UserSearchFilter is the filter which matches the user entries when the users are listed and searched, e.g.
+		// "(objectClass=posixAccount)" for directories which do not use inetOrgPerson. The filters of the searches
+		// are combined with it. Defaults to "(objectClass=inetOrgPerson)".
+		UserSearchFilter string `json:"userSearchFilter" yaml:"userSearchFilter" mapstructure:"LDAP_USER_SEARCH_FILTER"`
+		// GroupSearchFilter is the filter which matches the group entries when the groups are listed and searched,
+		// e.g. "(&(objectClass=groupOfUniqueNames)(businessCategory=managed))" to narrow the group lookups of very
+		// large trees. The filters of the searches are combined with it. Defaults to
+		// "(objectClass=groupOfUniqueNames)".
+		GroupSearchFilter string `json:"groupSearchFilter" yaml:"groupSearchFilter" mapstructure:"LDAP_GROUP_SEARCH_FILTER"`
+		// HostBaseDN is the dn of the container of the machine entries managed by the HostsManager. Optional, the
+		// methods of the HostsManager fail if it is not set.
+		HostBaseDN string `json:"hostBaseDN" yaml:"hostBaseDN" mapstructure:"LDAP_HOST_BASE_DN"`
+		// SudoersBaseDN is the dn of the container of the sudoRole entries managed by the SudoersManager, e.g.
+		// "ou=SUDOers,o=company". Optional, the methods of the SudoersManager fail if it is not set.
+		SudoersBaseDN string `json:"sudoersBaseDN" yaml:"sudoersBaseDN" mapstructure:"LDAP_SUDOERS_BASE_DN"`
+		// NetgroupBaseDN is the dn of the container of the nisNetgroup entries managed by the NetgroupsManager, e.g.
+		// "ou=netgroup,o=company". Optional, the methods of the NetgroupsManager fail if it is not set.
+		NetgroupBaseDN string `json:"netgroupBaseDN" yaml:"netgroupBaseDN" mapstructure:"LDAP_NETGROUP_BASE_DN"`
+		// AutomountBaseDN is the dn of the container of the automount maps managed by the AutomountsManager, e.g.
+		// "ou=automount,o=company". Optional, the methods of the AutomountsManager fail if it is not set.
+		AutomountBaseDN string `json:"automountBaseDN" yaml:"automountBaseDN" mapstructure:"LDAP_AUTOMOUNT_BASE_DN"`
+		// DeletedObjectsDN is the dn of the Active Directory Deleted Objects container the TombstonesManager reads
+		// the deleted entries from, e.g. "CN=Deleted Objects,DC=company,DC=com". Optional.
+		DeletedObjectsDN string `json:"deletedObjectsDN" yaml:"deletedObjectsDN" mapstructure:"LDAP_DELETED_OBJECTS_DN"`
+		// AccessLogBaseDN is the dn of the OpenLDAP accesslog database the ChangeLogManager reads the changes and the
+		// TombstonesManager reads the deleted entries from, e.g. "cn=accesslog". Optional, the methods of the
+		// ChangeLogManager fail if it is not set and the methods of the TombstonesManager fail if neither the
+		// DeletedObjectsDN nor the AccessLogBaseDN is set.
+		AccessLogBaseDN string `json:"accessLogBaseDN" yaml:"accessLogBaseDN" mapstructure:"LDAP_ACCESS_LOG_BASE_DN"`
+		// ServiceAccountOwnerAttr is the attribute of the service accounts which holds the dn of their owner, see
+		// ServiceAccountsManager. Defaults to manager.
+		ServiceAccountOwnerAttr string `json:"serviceAccountOwnerAttr" yaml:"serviceAccountOwnerAttr" mapstructure:"LDAP_SERVICE_ACCOUNT_OWNER_ATTR"`
+		// ServiceAccountRotationAttr is the attribute of the service accounts which holds the time their password was
+		// last rotated as generalized time. Defaults to pwdChangedTime, which is maintained by the password policy
+		// overlay of the LDAP server. A custom attribute is set by ServiceAccounts.Create and RotatePassword.
+		ServiceAccountRotationAttr string `json:"serviceAccountRotationAttr" yaml:"serviceAccountRotationAttr" mapstructure:"LDAP_SERVICE_ACCOUNT_ROTATION_ATTR"`
+		// MailAliasAttr is the attribute of the users which holds their mail aliases, see Users.AddMailAlias.
+		// Defaults to mailAlternateAddress, proxyAddresses is supported for Active Directory.
+		MailAliasAttr string `json:"mailAliasAttr" yaml:"mailAliasAttr" mapstructure:"LDAP_MAIL_ALIAS_ATTR"`
+		// UserContactAttributes are the optional contact attributes of the users which are retrieved, created and
+		// updated, any of telephoneNumber, title, o, l and departmentNumber. None by default.
+		UserContactAttributes []string `json:"userContactAttributes" yaml:"userContactAttributes" mapstructure:"LDAP_USER_CONTACT_ATTRIBUTES"`
+		// DebugRequests logs every LDAP request built by the client at debug level before it is sent, with its base
+		// dn, scope, filter, attributes and controls, see also WithRequestTracer. Disabled by default.
+		DebugRequests bool `json:"debugRequests" yaml:"debugRequests" mapstructure:"LDAP_DEBUG_REQUESTS"`
+		// HealthProbeDN is the dn of the entry HealthReport writes to, to check if the LDAP server accepts write
+		// operations from the bind user, e.g. a dedicated "cn=health-probe,o=company" entry. The HealthProbeAttr of
+		// the entry is replaced with the current time as generalized time on every HealthReport. Optional, the write
+		// probe is not sent and the writability is not reported if it is not set.
+		HealthProbeDN string `json:"healthProbeDN" yaml:"healthProbeDN" mapstructure:"LDAP_HEALTH_PROBE_DN"`
+		// HealthProbeAttr is the attribute of the HealthProbeDN entry written by the write probe, which must accept a
+		// generalized time string. Defaults to description.
+		HealthProbeAttr string `json:"healthProbeAttr" yaml:"healthProbeAttr" mapstructure:"LDAP_HEALTH_PROBE_ATTR"`
+		// TLSMinVersion is the minimum TLS version of the connections using the ldaps protocol, one of "1.0", "1.1",
+		// "1.2" and "1.3". Defaults to the default of crypto/tls, TLS 1.2.
+		TLSMinVersion string `json:"tlsMinVersion" yaml:"tlsMinVersion" mapstructure:"LDAP_TLS_MIN_VERSION"`
+		// TLSCipherSuites are the names of the cipher suites allowed for the connections using the ldaps protocol up
+		// to TLS 1.2, e.g. "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", see tls.CipherSuites. The TLS 1.3 cipher suites
+		// are not configurable. Defaults to the default cipher suites of crypto/tls.
+		TLSCipherSuites []string `json:"tlsCipherSuites" yaml:"tlsCipherSuites" mapstructure:"LDAP_TLS_CIPHER_SUITES"`
+		// FIPSMode restricts the client to the algorithms approved for regulated environments: the TLS version must
+		// be TLS 1.2 or later, the cipher suites default to and are restricted to the AES-GCM cipher suites with an
+		// ECDHE key exchange over the NIST curves, and passwords hashed with a scheme based on MD5, SHA-1 or crypt,
+		// e.g. {SSHA}, are rejected before they are sent to the LDAP server. Disabled by default.
+		FIPSMode bool `json:"fipsMode" yaml:"fipsMode" mapstructure:"LDAP_FIPS_MODE"`
+		// TLSCAFile is the path of a PEM file with the CA certificates which verify the certificate of the LDAP server
+		// for the ldaps protocol. Optional, the system roots are used if not set.
+		TLSCAFile string `json:"tlsCAFile" yaml:"tlsCAFile" mapstructure:"LDAP_TLS_CA_FILE"`
+		// TLSCertFile and TLSKeyFile are the paths of the PEM files of the client certificate and its private key
+		// presented to the LDAP server for the ldaps protocol. Optional, both must be set together.
+		TLSCertFile string `json:"tlsCertFile" yaml:"tlsCertFile" mapstructure:"LDAP_TLS_CERT_FILE"`
+		TLSKeyFile  string `json:"tlsKeyFile" yaml:"tlsKeyFile" mapstructure:"LDAP_TLS_KEY_FILE"`
+		// TLSReloadInterval is the interval the TLS files are checked for changes when a connection is opened. The
+		// changed files are reloaded, so rotated certificates are used without restarting the client. Defaults to
+		// 1 minute.
+		TLSReloadInterval time.Duration `json:"tlsReloadInterval" yaml:"tlsReloadInterval" mapstructure:"LDAP_TLS_RELOAD_INTERVAL"`
+		// ProtectedDNs are the dns of the entries the client refuses to delete or modify, e.g. the break-glass admin
+		// accounts and the root organizational units, as a safety net for bugs in automation. A dn is an exact dn or a
+		// pattern in which * matches any characters, including commas, e.g. "uid=admin-*,ou=users,o=company". The
+		// case and insignificant spaces are ignored. The delete, modify, modify dn and password modify of a protected
+		// entry fail with a forbidden error. Optional.
+		ProtectedDNs []string `json:"protectedDNs" yaml:"protectedDNs" mapstructure:"LDAP_PROTECTED_DNS"`
 	}
 
 	// Client represents the development ldap client.
+	// A Client is safe for concurrent use by multiple goroutines, every LDAP operation is executed on its own
+	// connection, which is taken from the pool set with WithConnectionPool, if any. The Config must only be changed using the setter methods once the Client is in use.
 	Client struct {
 		Config
 		ldapClient  ldap.Client
 		unitTesting bool
+		mu          *sync.RWMutex
+
+		credentialsProvider CredentialsProvider
+		credentialsLoaded   bool
+		middleware          []Middleware
+		breaker             *circuitBreaker
+		dialer              Dialer
+		lookupSRV           LookupSRVFunc
+		searchFlight        *searchFlight
+		requestOptions      []RequestOption
+		probeCapabilities   bool
+		capabilities        *Capabilities
+		capabilitiesMu      *sync.Mutex
+		lifecycle           *lifecycle
+
+		orgUnitsCache         *orgUnitsCache
+		membershipCache       *membershipCache
+		memberIdNormalizer    func(memberId string) string
+		skipOrgUnitValidation bool
+		events                *eventHandlers
+		userTemplates         *userTemplates
+		idGenerators          idGenerators
+		requestTracer         RequestTracer
+		tlsFiles              *tlsFiles
+		policy                PolicyFunc
+		journal               *deleteJournal
+		operationObserver     OperationObserver
+		pool                  *connectionPool
+		readCache             *readCache
 
 		// supported interfaces
 		OrganizationalUnits OrganizationalUnitsManager
 		Groups              GroupsManager
 		Users               UsersManager
+		Entries             EntriesManager
+		Roles               RolesManager
+		Monitor             MonitorManager
+		Hosts               HostsManager
+		ServiceAccounts     ServiceAccountsManager
+		Sudoers             SudoersManager
+		Netgroups           NetgroupsManager
+		Automounts          AutomountsManager
+		Tombstones          TombstonesManager
+		ChangeLog           ChangeLogManager
 	}
 
 	// ClientOption to configure API client
@@ -96,8 +314,15 @@ type (
 // You can override some default configuration using ClientOption.
 func NewClient(config Config, opts ...ClientOption) *Client {
 	c := &Client{
-		ldapClient: &ldap.Conn{},
-		Config:     config,
+		ldapClient:     &ldap.Conn{},
+		Config:         config,
+		mu:             &sync.RWMutex{},
+		capabilitiesMu: &sync.Mutex{},
+		lifecycle:      &lifecycle{},
+		events:         &eventHandlers{},
+		userTemplates:  &userTemplates{},
+		tlsFiles:       &tlsFiles{},
+		journal:        &deleteJournal{},
 	}
 
 	// setting default protocol
@@ -107,6 +332,16 @@ func NewClient(config Config, opts ...ClientOption) *Client {
 	c.OrganizationalUnits = &organizationalUnitsManager{Client: c}
 	c.Groups = &groupsManager{Client: c}
 	c.Users = &usersManager{Client: c}
+	c.Entries = &entriesManager{Client: c}
+	c.Roles = &rolesManager{Client: c}
+	c.Monitor = &monitorManager{Client: c}
+	c.Hosts = &hostsManager{Client: c}
+	c.ServiceAccounts = &serviceAccountsManager{Client: c}
+	c.Sudoers = &sudoersManager{Client: c}
+	c.Netgroups = &netgroupsManager{Client: c}
+	c.Automounts = &automountsManager{Client: c}
+	c.Tombstones = &tombstonesManager{Client: c}
+	c.ChangeLog = &changeLogManager{Client: c}
 
 	for _, opt := range opts {
 		opt(c)
@@ -114,8 +349,48 @@ func NewClient(config Config, opts ...ClientOption) *Client {
 	return c
 }
 
+// NewClientE returns a default ldap client like NewClient, but validates the client eagerly instead of when the first
+// LDAP operation is executed. The bind credentials are loaded from the CredentialsProvider, if set, the Config is
+// validated, the regular expressions of the UidPolicy and the GroupNamePolicies are compiled and the TLS files are
+// loaded.
+// The function returns an error describing the first problem found:
+//   - if the CredentialsProvider fails
+//   - if a mandatory field of the Config is missing or a field is invalid
+//   - if a pattern of the UidPolicy or the GroupNamePolicies does not compile
+//   - if the TLS files cannot be loaded
+func NewClientE(config Config, opts ...ClientOption) (*Client, *Error) {
+	c := NewClient(config, opts...)
+	if cErr := c.loadCredentials(); cErr != nil {
+		return nil, cErr
+	}
+	config, cErr := c.getConfig().withURL()
+	if cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.validate(config); cErr != nil {
+		return nil, cErr
+	}
+	if cErr := config.UidPolicy.validate(); cErr != nil {
+		return nil, cErr
+	}
+	if cErr := config.validateContactAttributes(); cErr != nil {
+		return nil, cErr
+	}
+	for _, policy := range config.GroupNamePolicies {
+		if cErr := policy.validate(); cErr != nil {
+			return nil, cErr
+		}
+	}
+	if _, err := c.tlsConfig(config); err != nil {
+		return nil, WrapError(errors.BadRequestError(err.Error()))
+	}
+	return c, nil
+}
+
 // SetProtocol sets the protocol in the Client Config.
 func (c *Client) SetProtocol(protocol string) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if slice.EntryExists(validProtocols, protocol) {
 		c.Config.Protocol = protocol
 	} else {
@@ -126,18 +401,33 @@ func (c *Client) SetProtocol(protocol string) *Client {
 
 // SetHostname sets the hostname in the Client Config.
 func (c *Client) SetHostname(hostname string) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.Config.Hostname = hostname
 	return c
 }
 
+// SetURL sets the LDAP URL of the server in the Client Config, which overrides the protocol, the hostname and the
+// port.
+func (c *Client) SetURL(url string) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Config.URL = url
+	return c
+}
+
 // SetPort sets the LDAP server port in the Config.
 func (c *Client) SetPort(port string) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.Config.Port = port
 	return c
 }
 
 // SetBindCredentials sets the LDAP basic authentication/bind credentials for LDAP in the config.
 func (c *Client) SetBindCredentials(bindUser, bindPassword string) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.Config.BindUser = bindUser
 	c.Config.BindPassword = bindPassword
 	return c
@@ -174,6 +464,40 @@ func WithUsersManager(um UsersManager) ClientOption {
 	}
 }
 
+// WithEntriesManager overrides the default EntriesManager.
+func WithEntriesManager(em EntriesManager) ClientOption {
+	return func(c *Client) {
+		c.Entries = em
+	}
+}
+
+// WithMemberIdNormalizer sets the function which converts the member ids, e.g. the uids passed to
+// Groups.AddMembers, before they are used in the unique member dns of the groups. The normalizer takes precedence
+// over PreserveMemberCase set in the client Config.
+func WithMemberIdNormalizer(normalizer func(memberId string) string) ClientOption {
+	return func(c *Client) {
+		c.memberIdNormalizer = normalizer
+	}
+}
+
+// UsersIn returns a UsersManager which manages the user entries within baseDN instead of the UserBaseDN set in
+// the client Config, e.g. for managing service accounts which are stored in a separate container.
+func (c *Client) UsersIn(baseDN string) UsersManager {
+	return &usersManager{Client: c, baseDN: baseDN}
+}
+
+// GroupsIn returns a GroupsManager which manages the group entries within baseDN instead of the GroupBaseDN set in
+// the client Config. The members of the groups are still resolved within the UserBaseDN.
+func (c *Client) GroupsIn(baseDN string) GroupsManager {
+	return &groupsManager{Client: c, baseDN: baseDN}
+}
+
+// OrganizationalUnitsIn returns an OrganizationalUnitsManager which manages the organizational unit entries within
+// baseDN instead of the GroupBaseDN set in the client Config.
+func (c *Client) OrganizationalUnitsIn(baseDN string) OrganizationalUnitsManager {
+	return &organizationalUnitsManager{Client: c, baseDN: baseDN}
+}
+
 // UnitTesting is a client option that will skip LDAP Dial and DialTls during unit testing.
 // This function is added because it is currently not possible to mock Dial and DialTls.
 func UnitTesting() ClientOption {
@@ -183,147 +507,447 @@ func UnitTesting() ClientOption {
 }
 
 // doLDAPSearch searches for entries in LDAP.
-func (c *Client) doLDAPSearch(sr *ldap.SearchRequest) (*ldap.SearchResult, *errors.Error) {
-	cErr := c.connect()
+func (c *Client) doLDAPSearch(sr *ldap.SearchRequest, opts ...RequestOption) (*ldap.SearchResult, *Error) {
+	result, cErr := c.doLDAPOperation(OperationSearch, sr, opts...)
 	if cErr != nil {
 		return nil, cErr
 	}
-	defer c.ldapClient.Close()
-	result, err := c.ldapClient.Search(sr)
-	if err != nil {
-		return nil, c.handleLdapError(err)
-	}
-	return result, nil
+	searchResult, _ := result.(*ldap.SearchResult)
+	return searchResult, nil
 }
 
 // doLDAPAdd adds a new entry in LDAP.
-func (c *Client) doLDAPAdd(ar *ldap.AddRequest) *errors.Error {
-	cErr := c.connect()
-	if cErr != nil {
-		return cErr
-	}
-	defer c.ldapClient.Close()
-	if err := c.ldapClient.Add(ar); err != nil {
-		return c.handleLdapError(err)
-	}
-	return nil
+func (c *Client) doLDAPAdd(ar *ldap.AddRequest, opts ...RequestOption) *Error {
+	_, cErr := c.doLDAPOperation(OperationAdd, ar, opts...)
+	return cErr
 }
 
 // doLDAPDelete removes an existing entry in LDAP.
-func (c *Client) doLDAPDelete(dr *ldap.DelRequest) *errors.Error {
-	cErr := c.connect()
-	if cErr != nil {
-		return cErr
-	}
-	defer c.ldapClient.Close()
-	if err := c.ldapClient.Del(dr); err != nil {
-		return c.handleLdapError(err)
-	}
-	return nil
+func (c *Client) doLDAPDelete(dr *ldap.DelRequest, opts ...RequestOption) *Error {
+	_, cErr := c.doLDAPOperation(OperationDelete, dr, opts...)
+	return cErr
 }
 
 // doLDAPModify update an existing entry in LDAP.
-func (c *Client) doLDAPModify(mr *ldap.ModifyRequest) *errors.Error {
-	cErr := c.connect()
+func (c *Client) doLDAPModify(mr *ldap.ModifyRequest, opts ...RequestOption) *Error {
+	_, cErr := c.doLDAPOperation(OperationModify, mr, opts...)
+	return cErr
+}
+
+// doLDAPModifyDN renames or moves an existing entry in LDAP.
+func (c *Client) doLDAPModifyDN(mdr *ldap.ModifyDNRequest, opts ...RequestOption) *Error {
+	_, cErr := c.doLDAPOperation(OperationModifyDN, mdr, opts...)
+	return cErr
+}
+
+// doLDAPPasswordModify updates the password of an existing entry in LDAP.
+func (c *Client) doLDAPPasswordModify(pmr *ldap.PasswordModifyRequest, opts ...RequestOption) (*ldap.PasswordModifyResult,
+	*Error) {
+	result, cErr := c.doLDAPOperation(OperationPasswordModify, pmr, opts...)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
 	}
-	defer c.ldapClient.Close()
-	if err := c.ldapClient.Modify(mr); err != nil {
-		return c.handleLdapError(err)
+	passwordModifyResult, _ := result.(*ldap.PasswordModifyResult)
+	return passwordModifyResult, nil
+}
+
+// doLDAPOperation applies the request options set on the client followed by the request options passed to the
+// method and executes an LDAP operation through the middleware chain registered on the client.
+func (c *Client) doLDAPOperation(name string, request any, opts ...RequestOption) (any, *Error) {
+	if !c.lifecycle.begin() {
+		return nil, clientClosedError()
 	}
-	return nil
+	defer c.lifecycle.end()
+	req := &OperationRequest{Name: name, Request: request}
+	for _, opt := range c.requestOptions {
+		opt(req)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	if sr, ok := req.Request.(*ldap.SearchRequest); ok && c.searchFlight != nil {
+		return c.searchFlight.do(c.searchKey(req, sr), func() (any, *Error) {
+			return c.chain(c.execute)(req)
+		})
+	}
+	return c.chain(c.execute)(req)
 }
 
-// doLDAPModify update an existing entry in LDAP.
-func (c *Client) doLDAPPasswordModify(pmr *ldap.PasswordModifyRequest) (*ldap.PasswordModifyResult, *errors.Error) {
-	cErr := c.connect()
+// execute opens a new connection with LDAP, executes the LDAP operation and closes the connection, unless the
+// connection is taken from and returned to the pool set with WithConnectionPool.
+// Every operation uses its own connection, so operations executed concurrently do not share any connection state.
+// The metadata of the operation is passed to the OperationObserver set on the client, if any.
+func (c *Client) execute(req *OperationRequest) (any, *Error) {
+	start := time.Now()
+	stats := &connectionStats{}
+	result, cErr := c.executeOperation(req, stats)
+	c.observeOperation(req.Name, start, stats, cErr)
+	return result, cErr
+}
+
+// executeOperation executes the LDAP operation and collects how the connection was established in the stats.
+func (c *Client) executeOperation(req *OperationRequest, stats *connectionStats) (any, *Error) {
+	if cErr := c.checkCapabilities(req); cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.checkPasswordSchemes(req.Request); cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.checkProtected(req.Request); cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.checkPolicy(req.Request); cErr != nil {
+		return nil, cErr
+	}
+	if sr, ok := req.Request.(*ldap.SearchRequest); ok {
+		c.applyTimeLimit(sr)
+		applySizeLimit(sr, c.maxResults(req))
+	}
+	c.applyDerefAliases(req)
+	c.traceRequest(req.Name, req.Request)
+	conn, release, cErr := c.openConnection(stats)
 	if cErr != nil {
 		return nil, cErr
 	}
-	defer c.ldapClient.Close()
-	result, err := c.ldapClient.PasswordModify(pmr)
+	var result any
+	var err error
+	defer func() { release(err) }()
+	if req.Timeout > 0 {
+		conn.SetTimeout(req.Timeout)
+	}
+
+	// the outcome of every operation let through by the circuit breaker is recorded once, also if the operation
+	// returns early, so a probe operation always closes or opens the circuit again
+	defer func() { c.breaker.record(err) }()
+	switch r := req.Request.(type) {
+	case *ldap.SearchRequest:
+		result, err = conn.Search(r)
+	case *ldap.AddRequest:
+		err = conn.Add(r)
+	case *ldap.DelRequest:
+		err = c.executeDelete(conn, r)
+	case *ldap.ModifyRequest:
+		err = conn.Modify(r)
+	case *ldap.ModifyDNRequest:
+		err = conn.ModifyDN(r)
+	case *ldap.PasswordModifyRequest:
+		result, err = conn.PasswordModify(r)
+	case *CompareRequest:
+		result, err = conn.Compare(r.DN, r.Attribute, r.Value)
+	case *PagedSearchRequest:
+		cErr, err = c.executePagedSearch(conn, r, c.maxResults(req))
+		return nil, cErr
+	case *BatchRequest:
+		result, err = c.executeBatch(conn, r)
+		return result, nil
+	default:
+		return nil, WrapError(errors.InternalServerErrorf(unsupportedOperationErrMsg, req.Name, req.Request))
+	}
+	if searchResult, ok := result.(*ldap.SearchResult); ok && searchResult != nil {
+		if cErr := checkResultLimit(c.maxResults(req), len(searchResult.Entries)); cErr != nil {
+			return nil, cErr
+		}
+	}
 	if err != nil {
-		return nil, c.handleLdapError(err)
+		return nil, c.handleLdapError(err, requestSecrets(req.Request)...)
 	}
 	return result, nil
 }
 
-// connect validates the connection details and attempts to connect to the ldap server.
+// applyTimeLimit sets the time limit of a search request based on the RequestTimeout set in the client Config,
+// unless a time limit is already set on the request.
+func (c *Client) applyTimeLimit(sr *ldap.SearchRequest) {
+	timeout := c.getConfig().RequestTimeout
+	if sr.TimeLimit == 0 && timeout > 0 {
+		sr.TimeLimit = int(math.Ceil(timeout.Seconds()))
+	}
+}
+
+// applyDerefAliases sets the alias dereferencing policy of a search or paged search request based on the
+// DerefAliases set in the client Config. As ldap.NeverDerefAliases is the zero value, a search request with
+// ldap.NeverDerefAliases uses the configured policy, unless the policy was set with WithDerefAliases. A search request
+// with any other policy is left unchanged.
+func (c *Client) applyDerefAliases(req *OperationRequest) {
+	if sr := searchRequestOf(req); sr != nil {
+		sr.DerefAliases = c.derefAliases(req, sr)
+	}
+}
+
+// derefAliases returns the alias dereferencing policy the search request is sent with, see applyDerefAliases.
+func (c *Client) derefAliases(req *OperationRequest, sr *ldap.SearchRequest) int {
+	if req.DerefAliases == nil && sr.DerefAliases == ldap.NeverDerefAliases {
+		return c.getConfig().DerefAliases
+	}
+	return sr.DerefAliases
+}
+
+// dialTimeout returns the DialTimeout or the default dial timeout if the DialTimeout is not set.
+func (cnf Config) dialTimeout() time.Duration {
+	if cnf.DialTimeout > 0 {
+		return cnf.DialTimeout
+	}
+	return defaultDialTimeout
+}
+
+// userObjectFilter returns the UserSearchFilter or the default filter matching the user entries if the
+// UserSearchFilter is not set.
+func (cnf Config) userObjectFilter() string {
+	if cnf.UserSearchFilter != "" {
+		return cnf.UserSearchFilter
+	}
+	return userObjectClassFilter
+}
+
+// usersFilter returns the filter which matches all the user entries.
+func (cnf Config) usersFilter() string {
+	if cnf.UserSearchFilter != "" {
+		return cnf.UserSearchFilter
+	}
+	return userSearchFilter
+}
+
+// groupObjectFilter returns the GroupSearchFilter or the default filter matching the group entries if the
+// GroupSearchFilter is not set.
+func (cnf Config) groupObjectFilter() string {
+	if cnf.GroupSearchFilter != "" {
+		return cnf.GroupSearchFilter
+	}
+	return groupObjectClassFilter
+}
+
+// groupsFilter returns the filter which matches all the group entries.
+func (cnf Config) groupsFilter() string {
+	if cnf.GroupSearchFilter != "" {
+		return cnf.GroupSearchFilter
+	}
+	return groupSearchFilter
+}
+
+// requestTimeout returns the RequestTimeout or the default request timeout if the RequestTimeout is not set.
+func (cnf Config) requestTimeout() time.Duration {
+	if cnf.RequestTimeout > 0 {
+		return cnf.RequestTimeout
+	}
+	return defaultRequestTimeout
+}
+
+// connect validates the connection details and opens a new authenticated connection with the ldap server.
+// The caller is responsible for closing the returned connection.
 // The method returns an error if connection to the ldap server fails.
-func (c *Client) connect() *errors.Error {
-	if cErr := c.validate(); cErr != nil {
-		return cErr
+func (c *Client) connect() (ldap.Client, *Error) {
+	return c.connectWithStats(&connectionStats{})
+}
+
+// connectWithStats opens a new authenticated connection like connect and collects how the connection was
+// established in the stats.
+func (c *Client) connectWithStats(stats *connectionStats) (ldap.Client, *Error) {
+	if c.lifecycle.isClosed() {
+		return nil, clientClosedError()
+	}
+	if cErr := c.loadCredentials(); cErr != nil {
+		return nil, cErr
+	}
+	config, cErr := c.getConfig().withURL()
+	if cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.validate(config); cErr != nil {
+		return nil, cErr
+	}
+	if cErr := c.breaker.allow(); cErr != nil {
+		return nil, cErr
 	}
 
-	ldapUrl := fmt.Sprintf(ldapUrlFormat, c.Config.Protocol, c.Config.Hostname, c.Config.Port)
-	logger.Debug(fmt.Sprintf(connectionMsg, ldapUrl))
+	logger.Debug(fmt.Sprintf(connectionMsg, config.server()))
 
-	if !c.unitTesting {
-		if cErr := c.dial(); cErr != nil {
-			return cErr
+	conn := c.ldapClient
+	if c.unitTesting {
+		stats.server, stats.reused = config.server(), true
+	} else {
+		var cErr *Error
+		if conn, cErr = c.dial(config, stats); cErr != nil {
+			return nil, cErr
 		}
 	}
 
-	if cErr := c.bind(); cErr != nil {
-		return cErr
+	if cErr := c.bind(conn, config, stats); cErr != nil {
+		if !c.unitTesting {
+			conn.Close()
+		}
+		return nil, cErr
 	}
 	logger.Debug(connectionSuccessMsg)
 
-	return nil
+	return conn, nil
+}
+
+// getConfig returns a copy of the client Config which is safe to be used while the Config is being updated.
+func (c *Client) getConfig() Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Config
 }
 
 // validate validates the ldap client configuration.
-func (c *Client) validate() *errors.Error {
-	if cErr := config.Validate(&c.Config); cErr != nil {
-		return errors.BadRequestError(cErr.Message)
+func (c *Client) validate(cnf Config) *Error {
+	if cnf.Protocol == ProtocolLdapi {
+		// the port is not used for connecting to a unix socket and the hostname defaults to the default socket path
+		cnf.Hostname, cnf.Port = cnf.socketPath(), "-"
 	}
-	return nil
+	if cnf.SRVDomain != "" {
+		if cnf.Protocol == ProtocolLdapi {
+			return WrapError(errors.BadRequestError(srvLdapiErrMsg))
+		}
+		// the hostname and the port are resolved from the SRV records of the domain
+		cnf.Hostname, cnf.Port = cnf.SRVDomain, "-"
+	}
+	if cErr := config.Validate(&cnf); cErr != nil {
+		return WrapError(errors.BadRequestError(cErr.Message))
+	}
+	if _, ok := ldap.DerefMap[cnf.DerefAliases]; !ok {
+		return WrapError(errors.BadRequestError(fmt.Sprintf(invalidDerefAliasesErrMsg, cnf.DerefAliases, ldap.DerefMap)))
+	}
+	if cnf.UserSearchFilter != "" {
+		if _, err := ldap.CompileFilter(cnf.UserSearchFilter); err != nil {
+			return WrapError(errors.BadRequestError(fmt.Sprintf(invalidSearchFilterErrMsg, userSearchFilterParam,
+				cnf.UserSearchFilter, err)))
+		}
+	}
+	if cnf.GroupSearchFilter != "" {
+		if _, err := ldap.CompileFilter(cnf.GroupSearchFilter); err != nil {
+			return WrapError(errors.BadRequestError(fmt.Sprintf(invalidSearchFilterErrMsg, groupSearchFilterParam,
+				cnf.GroupSearchFilter, err)))
+		}
+	}
+	if cErr := cnf.validateTLS(); cErr != nil {
+		return cErr
+	}
+	return cnf.validateTLSFiles()
 }
 
 // dial creates a new connection with an LDAP server based on the client Config.
-func (c *Client) dial() *errors.Error {
-	var err error
-	if c.Config.Protocol == "ldap" {
-		c.ldapClient, err = ldap.Dial("tcp", fmt.Sprintf("%s:%s", c.Config.Hostname, c.Config.Port))
+// The dial is aborted after the DialTimeout and every request sent on the connection is aborted after the
+// RequestTimeout set in the client Config.
+// If a custom Dialer is set using WithDialer, the Dialer is responsible for enforcing the dial timeout.
+// If a SRVDomain is set the LDAP servers are discovered using DNS.
+func (c *Client) dial(config Config, stats *connectionStats) (ldap.Client, *Error) {
+	var conn *ldap.Conn
+	if config.SRVDomain != "" {
+		var cErr *Error
+		if conn, cErr = c.dialDiscovered(config, stats); cErr != nil {
+			return nil, cErr
+		}
 	} else {
-		c.ldapClient, err = ldap.DialTLS("tcp", fmt.Sprintf("%s:%s", c.Config.Hostname, c.Config.Port), nil)
+		var err error
+		stats.server = config.url()
+		if conn, err = c.dialServer(config); err != nil {
+			c.breaker.record(err)
+			return nil, c.handleLdapError(err)
+		}
 	}
+	conn.SetTimeout(config.requestTimeout())
+	return conn, nil
+}
+
+// dialServer creates a new connection with the LDAP server set in the config.
+func (c *Client) dialServer(config Config) (*ldap.Conn, error) {
+	tlsConfig, err := c.tlsConfig(config)
 	if err != nil {
-		return c.handleLdapError(err)
+		return nil, err
+	}
+	switch dialer := c.dialer.(type) {
+	case nil:
+		return ldap.DialURL(config.url(), ldap.DialWithDialer(&net.Dialer{Timeout: config.dialTimeout()}),
+			ldap.DialWithTLSConfig(tlsConfig))
+	case *net.Dialer:
+		return ldap.DialURL(config.url(), ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+	default:
+		return dialWithDialer(dialer, config, tlsConfig)
 	}
-	return nil
 }
 
 // bind authenticates to an LDAP server using the bind credentials set in the client Config.
-func (c *Client) bind() *errors.Error {
-	if err := c.ldapClient.Bind(c.Config.BindUser, c.Config.BindPassword); err != nil {
+// If the bind fails because of invalid credentials the bind is retried once with refreshed credentials
+// when a CredentialsProvider is configured.
+func (c *Client) bind(conn ldap.Client, config Config, stats *connectionStats) *Error {
+	err := conn.Bind(config.BindUser, config.BindPassword)
+	if err != nil {
+		err = c.rebind(conn, err, stats)
+	}
+	if err != nil {
+		c.breaker.record(err)
 		return c.handleLdapError(err)
 	}
 	return nil
 }
 
 // handleLdapError validates the errors returned by the ldap client and returns the appropriate rest error.
-func (c *Client) handleLdapError(err error) *errors.Error {
+// The result code, the matched DN and the diagnostic message returned by the server are set as the Details of the
+// error. The BindPassword and the secrets of the request are redacted from the
+// messages which are logged and returned.
+func (c *Client) handleLdapError(err error, secrets ...string) *Error {
+	r := c.redactor(secrets...)
+	cErr := c.restError(err, r)
+	if details := newErrorDetails(err); details != nil {
+		details.DiagnosticMessage = r.redact(details.DiagnosticMessage)
+		logger.Debug(r.redact(details.String()))
+		cErr.Details = details
+	}
+	return cErr
+}
+
+// restError returns the rest error for an error returned by the ldap client.
+func (c *Client) restError(err error, r redactor) *Error {
 	errStr := err.Error()
 
 	switch {
 
 	case strings.Contains(errStr, ldap.LDAPResultCodeMap[ldap.LDAPResultInvalidCredentials]),
 		strings.Contains(errStr, ldap.LDAPResultCodeMap[ldap.LDAPResultInvalidDNSyntax]):
-		return errors.UnauthorizedError(ldap.LDAPResultCodeMap[ldap.LDAPResultInvalidCredentials])
+		return WrapError(errors.UnauthorizedError(ldap.LDAPResultCodeMap[ldap.LDAPResultInvalidCredentials]))
 
 	case strings.Contains(errStr, ldap.LDAPResultCodeMap[ldap.LDAPResultInsufficientAccessRights]):
-		return errors.ForbiddenError(ldap.LDAPResultCodeMap[ldap.LDAPResultInsufficientAccessRights])
+		return WrapError(errors.ForbiddenError(ldap.LDAPResultCodeMap[ldap.LDAPResultInsufficientAccessRights]))
 
 	case strings.Contains(errStr, ldap.LDAPResultCodeMap[ldap.LDAPResultEntryAlreadyExists]):
-		return errors.BadRequestError(ldap.LDAPResultCodeMap[ldap.LDAPResultEntryAlreadyExists])
+		return WrapError(errors.BadRequestError(ldap.LDAPResultCodeMap[ldap.LDAPResultEntryAlreadyExists]))
 
 	case strings.Contains(errStr, ldap.LDAPResultCodeMap[ldap.LDAPResultNoSuchObject]):
-		return errors.NotFoundError(ldap.LDAPResultCodeMap[ldap.LDAPResultNoSuchObject])
+		return WrapError(errors.NotFoundError(ldap.LDAPResultCodeMap[ldap.LDAPResultNoSuchObject]))
+
+	case strings.Contains(errStr, ldap.LDAPResultCodeMap[ldap.LDAPResultAssertionFailed]):
+		return assertionFailedError()
 
 	default:
-		logger.Error(err.Error())
-		return errors.InternalServerError(err.Error())
+		message := r.redact(err.Error())
+		logger.Error(message)
+		return WrapError(errors.InternalServerError(message))
+	}
+}
+
+// rawAttributeValue returns the first raw value of an attribute of the entry or nil if the attribute is not set.
+// Unlike GetAttributeValue the raw value is not converted to a string, which keeps binary values intact.
+func rawAttributeValue(entry *ldap.Entry, attr string) []byte {
+	if values := rawAttributeValues(entry, attr); len(values) > 0 {
+		return values[0]
+	}
+	return nil
+}
+
+// rawAttributeValues returns the raw values of an attribute of the entry or nil if the attribute is not set.
+func rawAttributeValues(entry *ldap.Entry, attr string) [][]byte {
+	values := entry.GetRawAttributeValues(attr)
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// binaryToStrings converts binary attribute values to the string values expected by the ldap requests.
+func binaryToStrings(values [][]byte) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, string(value))
 	}
+	return result
 }
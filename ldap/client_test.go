@@ -4,7 +4,9 @@ import (
 	err "errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/atselvan/go-utils/utils/errors"
 	"github.com/atselvan/ldap-go-lib/mocks"
@@ -25,18 +27,20 @@ var (
 		BindPassword: "somePassword",
 	}
 
-	methodNameBind   = "Bind"
-	methodNameClose  = "Close"
-	methodNameSearch = "Search"
-	methodNameAdd    = "Add"
-	methodNameDelete = "Del"
-	methodNameModify = "Modify"
+	methodNameBind     = "Bind"
+	methodNameClose    = "Close"
+	methodNameSearch   = "Search"
+	methodNameAdd      = "Add"
+	methodNameDelete   = "Del"
+	methodNameModify   = "Modify"
+	methodNameModifyDN = "ModifyDN"
 
 	ldapInvalidCredentialsErr = ldap.NewError(ldap.LDAPResultInvalidCredentials, err.New(""))
 	ldapInsufficientRightsErr = ldap.NewError(ldap.LDAPResultInsufficientAccessRights, err.New(""))
 	ldapEntryAlreadyExistsErr = ldap.NewError(ldap.LDAPResultEntryAlreadyExists, err.New(""))
 	ldapNoSuchObjectErr       = ldap.NewError(ldap.LDAPResultNoSuchObject, err.New(""))
 	ldapNetworkErr            = ldap.NewError(ldap.ErrorNetwork, err.New(""))
+	ldapAssertionFailedErr    = ldap.NewError(ldap.LDAPResultAssertionFailed, err.New(""))
 )
 
 func TestNewClient(t *testing.T) {
@@ -48,6 +52,83 @@ func TestNewClient(t *testing.T) {
 	assert.Equal(t, testConfig, client.Config)
 }
 
+func TestNewClientE(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, cErr := NewClientE(testConfig)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testConfig, client.Config)
+	})
+
+	t.Run("missing mandatory field", func(t *testing.T) {
+		config := testConfig
+		config.Hostname = ""
+		client, cErr := NewClientE(config)
+		assert.Nil(t, client)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		config := testConfig
+		config.Protocol, config.Hostname, config.Port = "", "", ""
+		config.URL = "ldaps://ldap.company.com"
+		client, cErr := NewClientE(config)
+		assert.Nil(t, cErr)
+		assert.NotNil(t, client)
+
+		config.URL = "http://ldap.company.com"
+		client, cErr = NewClientE(config)
+		assert.Nil(t, client)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("credentials provider", func(t *testing.T) {
+		config := testConfig
+		config.BindUser, config.BindPassword = "", ""
+		client, cErr := NewClientE(config, WithCredentialsProvider(func() (string, string, error) {
+			return testConfig.BindUser, testConfig.BindPassword, nil
+		}))
+		assert.Nil(t, cErr)
+		assert.Equal(t, testConfig.BindUser, client.getConfig().BindUser)
+	})
+
+	t.Run("credentials provider error", func(t *testing.T) {
+		client, cErr := NewClientE(testConfig, WithCredentialsProvider(func() (string, string, error) {
+			return "", "", fmt.Errorf("vault is sealed")
+		}))
+		assert.Nil(t, client)
+		assert.Contains(t, cErr.Message, "vault is sealed")
+	})
+
+	t.Run("invalid uid policy", func(t *testing.T) {
+		config := testConfig
+		config.UidPolicy = UidPolicy{Patterns: map[string]string{UserTypePersonal: "^[A-Z"}}
+		client, cErr := NewClientE(config)
+		assert.Nil(t, client)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Contains(t, cErr.Message, "Invalid uid pattern '^[A-Z'")
+
+		config.UidPolicy = UidPolicy{Patterns: map[string]string{"robot": ".*"}}
+		_, cErr = NewClientE(config)
+		assert.Contains(t, cErr.Message, "Invalid user type 'robot'")
+	})
+
+	t.Run("invalid group name policy", func(t *testing.T) {
+		config := testConfig
+		config.GroupNamePolicies = []GroupNamePolicy{{Ou: testOrganizationUnit1, Pattern: "(nexus"}}
+		client, cErr := NewClientE(config)
+		assert.Nil(t, client)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Contains(t, cErr.Message, "Invalid group name pattern '(nexus'")
+	})
+
+	t.Run("invalid user search filter", func(t *testing.T) {
+		config := testConfig
+		config.UserSearchFilter = "posixAccount"
+		_, cErr := NewClientE(config)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+}
+
 func TestClient_SetProtocol(t *testing.T) {
 	config := Config{}
 	client := NewClient(config)
@@ -73,6 +154,12 @@ func TestClient_SetHostname(t *testing.T) {
 	assert.Equal(t, testConfig.Hostname, client.Config.Hostname)
 }
 
+func TestClient_SetURL(t *testing.T) {
+	config := Config{}
+	client := NewClient(config).SetURL("ldaps://[fd00::1]:1636")
+	assert.Equal(t, "ldaps://[fd00::1]:1636", client.Config.URL)
+}
+
 func TestClient_SetPort(t *testing.T) {
 	config := Config{}
 	client := NewClient(config).SetPort(testConfig.Port)
@@ -119,7 +206,7 @@ func TestClient_connect(t *testing.T) {
 	t.Run("validation error", func(t *testing.T) {
 		config := Config{}
 		client := NewClient(config)
-		cErr := client.connect()
+		_, cErr := client.connect()
 		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
 		assert.Equal(t, http.StatusBadRequest, cErr.Status)
 		assert.Equal(t, fmt.Sprintf(
@@ -139,7 +226,7 @@ func TestClient_connect(t *testing.T) {
 
 	t.Run("dial error", func(t *testing.T) {
 		client := NewClient(testConfig).SetProtocol(ProtocolLdap)
-		cErr := client.connect()
+		_, cErr := client.connect()
 		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
 		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
 		assert.Contains(t, cErr.Message, ldapNetworkErr.Error())
@@ -147,7 +234,7 @@ func TestClient_connect(t *testing.T) {
 
 	t.Run("dial error", func(t *testing.T) {
 		client := NewClient(testConfig)
-		cErr := client.connect()
+		_, cErr := client.connect()
 		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
 		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
 		assert.Contains(t, cErr.Message, ldapNetworkErr.Error())
@@ -159,7 +246,7 @@ func TestClient_connect(t *testing.T) {
 
 		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(ldapInvalidCredentialsErr)
 
-		cErr := client.connect()
+		_, cErr := client.connect()
 		assert.Equal(t, errors.ErrCodeUnauthorized, cErr.Code)
 		assert.Equal(t, http.StatusUnauthorized, cErr.Status)
 		assert.Equal(t, ldap.LDAPResultCodeMap[ldap.LDAPResultInvalidCredentials], cErr.Message)
@@ -257,6 +344,13 @@ func TestClient_handleLdapError(t *testing.T) {
 		assert.Equal(t, ldap.LDAPResultCodeMap[ldap.LDAPResultNoSuchObject], cErr.Message)
 	})
 
+	t.Run("assertion failed error", func(t *testing.T) {
+		cErr := client.handleLdapError(ldapAssertionFailedErr)
+		assert.Equal(t, ErrCodeAssertionFailed, cErr.Code)
+		assert.Equal(t, http.StatusPreconditionFailed, cErr.Status)
+		assert.Equal(t, ldap.LDAPResultCodeMap[ldap.LDAPResultAssertionFailed], cErr.Message)
+	})
+
 	t.Run("internal server error", func(t *testing.T) {
 		cErr := client.handleLdapError(ldapNetworkErr)
 		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
@@ -264,3 +358,264 @@ func TestClient_handleLdapError(t *testing.T) {
 		assert.Contains(t, cErr.Message, ldapNetworkErr.Error())
 	})
 }
+
+func TestClient_concurrentOperations(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	provider := func() (string, string, error) {
+		return testConfig.BindUser, testConfig.BindPassword, nil
+	}
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), WithCredentialsProvider(provider), UnitTesting())
+	um := usersManager{Client: client}
+	sr := um.getUsersSearchRequest(userSearchFilter)
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, sr).Return(&getUsersSearchResult, nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users, cErr := client.Users.GetAll()
+			assert.Nil(t, cErr)
+			assert.Len(t, users, 4)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_concurrentConfigUpdates(t *testing.T) {
+	client := NewClient(testConfig)
+	um := usersManager{Client: client}
+	gm := groupsManager{Client: client}
+	oum := organizationalUnitsManager{Client: client}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.SetBindCredentials(testConfig.BindUser, testConfig.BindPassword)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, testConfig.UserBaseDN, um.userBaseDN())
+			assert.Equal(t, testConfig.GroupBaseDN, gm.groupBaseDN())
+			assert.Equal(t, testConfig.GroupBaseDN, oum.groupBaseDN())
+			assert.Equal(t, "uid=C00001,"+testConfig.UserBaseDN, gm.getUniqueMemberDn("C00001"))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConfig_timeouts(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		config := Config{}
+		assert.Equal(t, defaultDialTimeout, config.dialTimeout())
+		assert.Equal(t, defaultRequestTimeout, config.requestTimeout())
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		config := Config{DialTimeout: time.Second, RequestTimeout: 2 * time.Second}
+		assert.Equal(t, time.Second, config.dialTimeout())
+		assert.Equal(t, 2*time.Second, config.requestTimeout())
+	})
+}
+
+func TestClient_applyTimeLimit(t *testing.T) {
+	t.Run("request timeout set", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.RequestTimeout = 1500 * time.Millisecond
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		expected := um.getUsersSearchRequest(userSearchFilter)
+		expected.TimeLimit = 2
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, expected).Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.GetAll()
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 4)
+	})
+
+	t.Run("time limit already set", func(t *testing.T) {
+		config := testConfig
+		config.RequestTimeout = time.Minute
+		client := NewClient(config)
+		sr := &ldap.SearchRequest{TimeLimit: 5}
+		client.applyTimeLimit(sr)
+		assert.Equal(t, 5, sr.TimeLimit)
+	})
+
+	t.Run("request timeout not set", func(t *testing.T) {
+		client := NewClient(testConfig)
+		sr := &ldap.SearchRequest{}
+		client.applyTimeLimit(sr)
+		assert.Equal(t, 0, sr.TimeLimit)
+	})
+}
+
+func TestClient_applyDerefAliases(t *testing.T) {
+	t.Run("deref aliases set", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.DerefAliases = ldap.DerefInSearching
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		expected := um.getUsersSearchRequest(userSearchFilter)
+		expected.DerefAliases = ldap.DerefInSearching
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, expected).Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.GetAll()
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 4)
+	})
+
+	t.Run("deref aliases already set", func(t *testing.T) {
+		config := testConfig
+		config.DerefAliases = ldap.DerefInSearching
+		client := NewClient(config)
+		sr := &ldap.SearchRequest{DerefAliases: ldap.DerefAlways}
+		client.applyDerefAliases(&OperationRequest{Request: sr})
+		assert.Equal(t, ldap.DerefAlways, sr.DerefAliases)
+	})
+
+	t.Run("never deref aliases is the zero value", func(t *testing.T) {
+		config := testConfig
+		config.DerefAliases = ldap.DerefInSearching
+		client := NewClient(config)
+		sr := &ldap.SearchRequest{DerefAliases: ldap.NeverDerefAliases}
+		client.applyDerefAliases(&OperationRequest{Request: sr})
+		assert.Equal(t, ldap.DerefInSearching, sr.DerefAliases)
+	})
+
+	t.Run("never deref aliases set with a request option", func(t *testing.T) {
+		config := testConfig
+		config.DerefAliases = ldap.DerefInSearching
+		client := NewClient(config)
+		req := &OperationRequest{Request: &PagedSearchRequest{SearchRequest: &ldap.SearchRequest{}}}
+		WithDerefAliases(ldap.NeverDerefAliases)(req)
+		client.applyDerefAliases(req)
+		assert.Equal(t, ldap.NeverDerefAliases, req.Request.(*PagedSearchRequest).SearchRequest.DerefAliases)
+	})
+
+	t.Run("never deref aliases on a search", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.DerefAliases = ldap.DerefAlways
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.(UsersReader).GetAllWithOptions(WithDerefAliases(ldap.NeverDerefAliases))
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 4)
+	})
+
+	t.Run("invalid deref aliases", func(t *testing.T) {
+		config := testConfig
+		config.DerefAliases = 7
+		client := NewClient(config)
+		cErr := client.validate(client.getConfig())
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+}
+
+func TestConfig_userFilters(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		config := Config{}
+		assert.Equal(t, userObjectClassFilter, config.userObjectFilter())
+		assert.Equal(t, userSearchFilter, config.usersFilter())
+	})
+
+	t.Run("user search filter", func(t *testing.T) {
+		config := Config{UserSearchFilter: "(objectClass=posixAccount)"}
+		assert.Equal(t, "(objectClass=posixAccount)", config.userObjectFilter())
+		assert.Equal(t, "(objectClass=posixAccount)", config.usersFilter())
+	})
+
+	t.Run("group search filter", func(t *testing.T) {
+		config := Config{GroupSearchFilter: "(objectClass=groupOfNames)"}
+		assert.Equal(t, "(objectClass=groupOfNames)", config.groupObjectFilter())
+		assert.Equal(t, "(objectClass=groupOfNames)", config.groupsFilter())
+		assert.Equal(t, groupObjectClassFilter, Config{}.groupObjectFilter())
+		assert.Equal(t, groupSearchFilter, Config{}.groupsFilter())
+	})
+
+	t.Run("invalid group search filter", func(t *testing.T) {
+		config := testConfig
+		config.GroupSearchFilter = "(objectClass=groupOfNames"
+		client := NewClient(config)
+		cErr := client.validate(client.getConfig())
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Contains(t, cErr.Message, "Invalid group search filter")
+	})
+
+	t.Run("invalid user search filter", func(t *testing.T) {
+		config := testConfig
+		config.UserSearchFilter = "objectClass=posixAccount"
+		client := NewClient(config)
+		cErr := client.validate(client.getConfig())
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Contains(t, cErr.Message, "Invalid user search filter 'objectClass=posixAccount'")
+	})
+}
+
+func TestClient_UsersIn(t *testing.T) {
+	serviceAccountsDN := "ou=service-accounts,o=company"
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	um := usersManager{Client: client, baseDN: serviceAccountsDN}
+	sr := um.getUserSearchRequest("uid=" + testUser1.Uid + "," + serviceAccountsDN)
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, sr).Return(getUserSearchResult, nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	user, cErr := client.UsersIn(serviceAccountsDN).Get(testUser1.Uid)
+	assert.Nil(t, cErr)
+	assert.Equal(t, testUser1.Uid, user.Uid)
+	assert.Equal(t, serviceAccountsDN, um.getUsersSearchRequest(userSearchFilter).BaseDN)
+	assert.Equal(t, testConfig.UserBaseDN, (&usersManager{Client: client}).userBaseDN())
+}
+
+func TestClient_GroupsIn(t *testing.T) {
+	teamsDN := "ou=teams,o=company"
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	gm := groupsManager{Client: client, baseDN: teamsDN}
+	oum := organizationalUnitsManager{Client: client, baseDN: teamsDN}
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(&ldap.SearchResult{
+		Entries: []*ldap.Entry{ldap.NewEntry("ou=team-a,"+teamsDN, map[string][]string{
+			OrganizationalUnitAttr: {"team-a"},
+		})},
+	}, nil)
+	ldapMock.On(methodNameDelete, ldap.NewDelRequest("cn="+testGroupCn1+",ou=team-a,"+teamsDN, nil)).Return(nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	cErr := client.GroupsIn(teamsDN).Delete(testGroupCn1, "team-a")
+	assert.Nil(t, cErr)
+	assert.Equal(t, "uid=C00001,"+testConfig.UserBaseDN, gm.getUniqueMemberDn("C00001"))
+	assert.Equal(t, teamsDN, oum.getSearchRequest().BaseDN)
+}
+
+func TestClient_OrganizationalUnitsIn(t *testing.T) {
+	teamsDN := "ou=teams,o=company"
+	client := NewClient(testConfig)
+	oum, ok := client.OrganizationalUnitsIn(teamsDN).(*organizationalUnitsManager)
+	assert.True(t, ok)
+	assert.Equal(t, "ou=team-a,"+teamsDN, oum.getDN("team-a"))
+}
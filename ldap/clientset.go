@@ -0,0 +1,131 @@
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	clientNotFoundMsg           = "LDAP client profile '%s' was not found. Available profiles are %v"
+	clientSetConfigLoadErrMsg   = "Unable to load the LDAP client profiles from '%s': %v"
+	unsupportedConfigFileErrMsg = "unsupported file extension '%s', supported extensions are .json, .yaml and .yml"
+)
+
+type (
+	// ClientSetConfig represents the connection details of multiple LDAP directories.
+	ClientSetConfig struct {
+		// Default is the name of the profile returned by ClientSet.Default.
+		Default string `json:"default" yaml:"default"`
+		// Profiles are the connection details of the LDAP directories keyed by the profile name,
+		// e.g. prod, staging, dc-eu and dc-us.
+		Profiles map[string]Config `json:"profiles" yaml:"profiles"`
+	}
+
+	// ClientSet holds a named Client for every LDAP directory an application interacts with.
+	// A ClientSet is safe for concurrent use by multiple goroutines.
+	ClientSet struct {
+		mu          sync.RWMutex
+		clients     map[string]*Client
+		defaultName string
+	}
+)
+
+// NewClientSet returns a ClientSet with a Client for every profile in the ClientSetConfig.
+// The ClientOptions are applied to every Client.
+func NewClientSet(config ClientSetConfig, opts ...ClientOption) *ClientSet {
+	cs := &ClientSet{
+		clients:     make(map[string]*Client, len(config.Profiles)),
+		defaultName: config.Default,
+	}
+	for name, profile := range config.Profiles {
+		cs.clients[name] = NewClient(profile, opts...)
+	}
+	return cs
+}
+
+// LoadClientSet reads the ClientSetConfig from a JSON or YAML file and returns a ClientSet with a Client for every
+// profile in the file. The ClientOptions are applied to every Client.
+// The method returns an error if the file can not be read or parsed.
+func LoadClientSet(path string, opts ...ClientOption) (*ClientSet, *Error) {
+	config, cErr := LoadClientSetConfig(path)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return NewClientSet(*config, opts...), nil
+}
+
+// LoadClientSetConfig reads the ClientSetConfig from a JSON or YAML file.
+// The format of the file is determined by the file extension.
+// The method returns an error if the file can not be read or parsed.
+func LoadClientSetConfig(path string) (*ClientSetConfig, *Error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, WrapError(errors.Newf(errors.ErrCodeConfigLoad, 0, clientSetConfigLoadErrMsg, path, err))
+	}
+	config := &ClientSetConfig{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, config)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, config)
+	default:
+		err = fmt.Errorf(unsupportedConfigFileErrMsg, ext)
+	}
+	if err != nil {
+		return nil, WrapError(errors.Newf(errors.ErrCodeConfigLoad, 0, clientSetConfigLoadErrMsg, path, err))
+	}
+	return config, nil
+}
+
+// Get returns the Client of the profile with the name.
+// The method returns an error if the profile does not exist.
+func (cs *ClientSet) Get(name string) (*Client, *Error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	client, ok := cs.clients[name]
+	if !ok {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(clientNotFoundMsg, name, cs.names())))
+	}
+	return client, nil
+}
+
+// Default returns the Client of the default profile.
+// The method returns an error if the default profile does not exist.
+func (cs *ClientSet) Default() (*Client, *Error) {
+	cs.mu.RLock()
+	name := cs.defaultName
+	cs.mu.RUnlock()
+	return cs.Get(name)
+}
+
+// Add adds the Client to the ClientSet under the name. An existing Client with the same name is replaced.
+func (cs *ClientSet) Add(name string, client *Client) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.clients[name] = client
+}
+
+// Names returns the sorted names of all the profiles in the ClientSet.
+func (cs *ClientSet) Names() []string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.names()
+}
+
+// names returns the sorted names of all the profiles. The caller must hold the lock.
+func (cs *ClientSet) names() []string {
+	names := make([]string, 0, len(cs.clients))
+	for name := range cs.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
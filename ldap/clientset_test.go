@@ -0,0 +1,134 @@
+package ldap
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testClientSetConfig = ClientSetConfig{
+		Default: "prod",
+		Profiles: map[string]Config{
+			"prod":    testConfig,
+			"staging": testConfig,
+		},
+	}
+
+	testClientSetYAML = `default: dc-eu
+profiles:
+  dc-eu:
+    protocol: ldaps
+    hostname: ldap-eu.company.com
+    port: "636"
+    baseDN: company
+    userBaseDN: ou=users,o=company
+    groupBaseDN: ou=projects,o=company
+    bindUser: cn=root,o=company
+    bindPassword: somePassword
+    dialTimeout: 5s
+  dc-us:
+    protocol: ldaps
+    hostname: ldap-us.company.com
+    port: "636"
+`
+
+	testClientSetJSON = `{"default": "prod", "profiles": {"prod": {"protocol": "ldap", "hostname": "ldap.company.com", "port": "389"}}}`
+)
+
+func TestNewClientSet(t *testing.T) {
+	cs := NewClientSet(testClientSetConfig, WithCircuitBreaker(3, time.Second))
+	assert.Equal(t, []string{"prod", "staging"}, cs.Names())
+
+	client, cErr := cs.Get("staging")
+	assert.Nil(t, cErr)
+	assert.Equal(t, testConfig, client.Config)
+	assert.NotNil(t, client.breaker)
+
+	defaultClient, cErr := cs.Default()
+	assert.Nil(t, cErr)
+	prodClient, _ := cs.Get("prod")
+	assert.Same(t, prodClient, defaultClient)
+}
+
+func TestClientSet_Get(t *testing.T) {
+	cs := NewClientSet(testClientSetConfig)
+
+	t.Run("profile not found", func(t *testing.T) {
+		client, cErr := cs.Get("dc-us")
+		assert.Nil(t, client)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, "LDAP client profile 'dc-us' was not found. Available profiles are [prod staging]",
+			cErr.Message)
+	})
+
+	t.Run("added client", func(t *testing.T) {
+		added := NewClient(testConfig)
+		cs.Add("dc-us", added)
+		client, cErr := cs.Get("dc-us")
+		assert.Nil(t, cErr)
+		assert.Same(t, added, client)
+	})
+}
+
+func TestLoadClientSet(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("yaml", func(t *testing.T) {
+		path := filepath.Join(dir, "ldap.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(testClientSetYAML), 0o600))
+
+		cs, cErr := LoadClientSet(path)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{"dc-eu", "dc-us"}, cs.Names())
+
+		client, cErr := cs.Default()
+		assert.Nil(t, cErr)
+		assert.Equal(t, "ldap-eu.company.com", client.Config.Hostname)
+		assert.Equal(t, "cn=root,o=company", client.Config.BindUser)
+		assert.Equal(t, 5*time.Second, client.Config.DialTimeout)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(dir, "ldap.json")
+		assert.NoError(t, os.WriteFile(path, []byte(testClientSetJSON), 0o600))
+
+		cs, cErr := LoadClientSet(path)
+		assert.Nil(t, cErr)
+		client, cErr := cs.Get("prod")
+		assert.Nil(t, cErr)
+		assert.Equal(t, ProtocolLdap, client.Config.Protocol)
+		assert.Equal(t, "389", client.Config.Port)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		cs, cErr := LoadClientSet(filepath.Join(dir, "missing.yaml"))
+		assert.Nil(t, cs)
+		assert.Equal(t, errors.ErrCodeConfigLoad, cErr.Code)
+	})
+
+	t.Run("unsupported extension", func(t *testing.T) {
+		path := filepath.Join(dir, "ldap.toml")
+		assert.NoError(t, os.WriteFile(path, []byte(""), 0o600))
+
+		cs, cErr := LoadClientSet(path)
+		assert.Nil(t, cs)
+		assert.Equal(t, errors.ErrCodeConfigLoad, cErr.Code)
+		assert.Contains(t, cErr.Message, "unsupported file extension '.toml'")
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		cs, cErr := LoadClientSet(path)
+		assert.Nil(t, cs)
+		assert.Equal(t, errors.ErrCodeConfigLoad, cErr.Code)
+	})
+}
@@ -0,0 +1,214 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ControlTypeProxyAuthz is the OID of the proxied authorization control defined in RFC 4370.
+	ControlTypeProxyAuthz = "2.16.840.1.113730.3.4.18"
+	// ControlTypeAssertion is the OID of the assertion control defined in RFC 4528.
+	ControlTypeAssertion = "1.3.6.1.1.12"
+	// ControlTypeRelaxRules is the OID of the relax rules control defined in draft-zeilenga-ldap-relax.
+	ControlTypeRelaxRules = "1.3.6.1.4.1.4203.666.5.12"
+
+	// ErrCodeAssertionFailed is the code of the error returned if the filter of an assertion control does not match
+	// the target entry of an operation.
+	ErrCodeAssertionFailed = "ASSERTION_FAILED"
+
+	proxyAuthzDNPrefix = "dn:"
+	assertionParam     = "assertion"
+	invalidAssertion   = "Invalid assertion filter '%s': %v"
+)
+
+type (
+	// RequestOption customises a single LDAP request before it is passed to the middleware chain.
+	RequestOption func(req *OperationRequest)
+
+	// ControlProxyAuthz implements the proxied authorization control defined in RFC 4370.
+	// The control requests the server to execute an operation using the authorization identity of AuthzID
+	// instead of the identity of the bind user. The control is always critical.
+	ControlProxyAuthz struct {
+		// AuthzID is the authorization identity, e.g. "dn:uid=C00001,ou=users,o=company".
+		// An empty AuthzID represents the anonymous identity.
+		AuthzID string
+	}
+
+	// ControlAssertion implements the assertion control defined in RFC 4528.
+	// The control makes a write conditional: the server only executes the operation if the Filter matches the
+	// target entry, otherwise the operation fails with an ErrCodeAssertionFailed error. The control is always
+	// critical.
+	ControlAssertion struct {
+		// Filter is the assertion, e.g. "(uniqueMember=uid=NO_SUCH_USER,ou=users,o=company)".
+		Filter string
+
+		filter *ber.Packet
+	}
+
+	// ControlRelaxRules implements the relax rules control defined in draft-zeilenga-ldap-relax.
+	// The control requests the server to relax the data and the service rules, e.g. to allow the modification of
+	// operational attributes such as pwdChangedTime or structural object classes. The control is always critical.
+	ControlRelaxRules struct{}
+)
+
+// WithControls attaches the controls to an LDAP search, paged search, add, delete, modify, modify dn or batch request.
+// The controls are appended to the controls already set on the request.
+// Password modify requests do not support controls and are left unchanged.
+func WithControls(controls ...ldap.Control) RequestOption {
+	return func(req *OperationRequest) {
+		switch r := req.Request.(type) {
+		case *ldap.SearchRequest:
+			r.Controls = append(r.Controls, controls...)
+		case *ldap.AddRequest:
+			r.Controls = append(r.Controls, controls...)
+		case *ldap.DelRequest:
+			r.Controls = append(r.Controls, controls...)
+		case *ldap.ModifyRequest:
+			r.Controls = append(r.Controls, controls...)
+		case *ldap.ModifyDNRequest:
+			r.Controls = append(r.Controls, controls...)
+		case *PagedSearchRequest:
+			r.SearchRequest.Controls = append(r.SearchRequest.Controls, controls...)
+		case *BatchRequest:
+			for _, request := range r.Requests {
+				WithControls(controls...)(&OperationRequest{Request: request})
+			}
+		}
+	}
+}
+
+// WithManageDsaIT attaches the ManageDsaIT control defined in RFC 3296 to an LDAP modify request, or to the modify
+// requests of a batch, so referral objects are modified as regular entries instead of being followed.
+// Other requests are left unchanged.
+func WithManageDsaIT() RequestOption {
+	return withModifyControls(ldap.NewControlManageDsaIT(true))
+}
+
+// WithRelaxRules attaches the relax rules control to an LDAP modify request, or to the modify requests of a batch,
+// so operational attributes can be modified during maintenance tasks. The bind user requires the manage privilege on
+// the server. Other requests are left unchanged.
+func WithRelaxRules() RequestOption {
+	return withModifyControls(NewControlRelaxRules())
+}
+
+// withModifyControls attaches the controls to an LDAP modify request or to the modify requests of a batch.
+func withModifyControls(controls ...ldap.Control) RequestOption {
+	return func(req *OperationRequest) {
+		switch r := req.Request.(type) {
+		case *ldap.ModifyRequest:
+			r.Controls = append(r.Controls, controls...)
+		case *BatchRequest:
+			for _, request := range r.Requests {
+				if mr, ok := request.(*ldap.ModifyRequest); ok {
+					mr.Controls = append(mr.Controls, controls...)
+				}
+			}
+		}
+	}
+}
+
+// WithProxyAuthz attaches the proxied authorization control to every LDAP operation executed by the client,
+// so the operations are authorized and audited as the entry with the distinguished name dn while the client
+// keeps using its own bind credentials. The bind user requires the proxy authorization privilege on the server.
+// Use a separate Client for every end user on whose behalf operations are executed.
+func WithProxyAuthz(dn string) ClientOption {
+	return func(c *Client) {
+		c.requestOptions = append(c.requestOptions, WithControls(NewControlProxyAuthz(dn)))
+	}
+}
+
+// NewControlProxyAuthz returns a ControlProxyAuthz control for the entry with the distinguished name dn.
+func NewControlProxyAuthz(dn string) *ControlProxyAuthz {
+	if dn == "" {
+		return &ControlProxyAuthz{}
+	}
+	return &ControlProxyAuthz{AuthzID: proxyAuthzDNPrefix + dn}
+}
+
+// GetControlType returns the OID.
+func (c *ControlProxyAuthz) GetControlType() string {
+	return ControlTypeProxyAuthz
+}
+
+// Encode returns the ber packet representation.
+func (c *ControlProxyAuthz) Encode() *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Control")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, ControlTypeProxyAuthz, "Control Type (Proxied Authorization)"))
+	packet.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, true, "Criticality"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, c.AuthzID, "Control Value (Authorization Identity)"))
+	return packet
+}
+
+// String returns a human-readable description.
+func (c *ControlProxyAuthz) String() string {
+	return fmt.Sprintf("Control Type: Proxied Authorization (%q)  Criticality: true  AuthzID: %s",
+		ControlTypeProxyAuthz, c.AuthzID)
+}
+
+// NewControlAssertion returns a ControlAssertion with the filter. Attach the control to a write with WithControls.
+// The method returns an error:
+//   - if the filter is not a valid LDAP search filter
+func NewControlAssertion(filter string) (*ControlAssertion, *Error) {
+	packet, err := ldap.CompileFilter(filter)
+	if err != nil {
+		return nil, invalidParameterError(assertionParam, fmt.Sprintf(invalidAssertion, filter, err))
+	}
+	return &ControlAssertion{Filter: filter, filter: packet}, nil
+}
+
+// GetControlType returns the OID.
+func (c *ControlAssertion) GetControlType() string {
+	return ControlTypeAssertion
+}
+
+// Encode returns the ber packet representation.
+func (c *ControlAssertion) Encode() *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Control")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, ControlTypeAssertion, "Control Type (Assertion)"))
+	packet.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, true, "Criticality"))
+	value := ber.Encode(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, nil, "Control Value (Assertion)")
+	if c.filter != nil {
+		value.AppendChild(c.filter)
+	}
+	packet.AppendChild(value)
+	return packet
+}
+
+// String returns a human-readable description.
+func (c *ControlAssertion) String() string {
+	return fmt.Sprintf("Control Type: Assertion (%q)  Criticality: true  Filter: %s", ControlTypeAssertion, c.Filter)
+}
+
+// NewControlRelaxRules returns a ControlRelaxRules control.
+func NewControlRelaxRules() *ControlRelaxRules {
+	return &ControlRelaxRules{}
+}
+
+// GetControlType returns the OID.
+func (c *ControlRelaxRules) GetControlType() string {
+	return ControlTypeRelaxRules
+}
+
+// Encode returns the ber packet representation.
+func (c *ControlRelaxRules) Encode() *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Control")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, ControlTypeRelaxRules, "Control Type (Relax Rules)"))
+	packet.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, true, "Criticality"))
+	return packet
+}
+
+// String returns a human-readable description.
+func (c *ControlRelaxRules) String() string {
+	return fmt.Sprintf("Control Type: Relax Rules (%q)  Criticality: true", ControlTypeRelaxRules)
+}
+
+// assertionFailedError returns the error returned if the filter of an assertion control does not match.
+func assertionFailedError() *Error {
+	return WrapError(errors.New(ErrCodeAssertionFailed, http.StatusPreconditionFailed,
+		ldap.LDAPResultCodeMap[ldap.LDAPResultAssertionFailed]))
+}
@@ -0,0 +1,172 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithControls(t *testing.T) {
+	manageDsaIT := ldap.NewControlManageDsaIT(true)
+	paging := ldap.NewControlPaging(100)
+
+	t.Run("search request", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		sr := um.getUsersSearchRequest(userSearchFilter)
+		expected := um.getUsersSearchRequest(userSearchFilter)
+		expected.Controls = []ldap.Control{manageDsaIT, paging}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, expected).Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.doLDAPSearch(sr, WithControls(manageDsaIT), WithControls(paging))
+		assert.Nil(t, cErr)
+		assert.Len(t, result.Entries, 4)
+	})
+
+	t.Run("delete request", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		dr := ldap.NewDelRequest(testConfig.UserBaseDN, nil)
+		expected := ldap.NewDelRequest(testConfig.UserBaseDN, []ldap.Control{manageDsaIT})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, expected).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.doLDAPDelete(dr, WithControls(manageDsaIT))
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("add and modify requests", func(t *testing.T) {
+		ar := ldap.NewAddRequest(testConfig.UserBaseDN, nil)
+		mr := ldap.NewModifyRequest(testConfig.UserBaseDN, []ldap.Control{paging})
+
+		WithControls(manageDsaIT)(&OperationRequest{Name: OperationAdd, Request: ar})
+		WithControls(manageDsaIT)(&OperationRequest{Name: OperationModify, Request: mr})
+		assert.Equal(t, []ldap.Control{manageDsaIT}, ar.Controls)
+		assert.Equal(t, []ldap.Control{paging, manageDsaIT}, mr.Controls)
+	})
+
+	t.Run("password modify request", func(t *testing.T) {
+		pmr := ldap.NewPasswordModifyRequest(testConfig.UserBaseDN, "", "")
+		WithControls(manageDsaIT)(&OperationRequest{Name: OperationPasswordModify, Request: pmr})
+		assert.Equal(t, ldap.NewPasswordModifyRequest(testConfig.UserBaseDN, "", ""), pmr)
+	})
+}
+
+func TestWithProxyAuthz(t *testing.T) {
+	userDN := "uid=C00001,ou=users,o=company"
+
+	t.Run("control attached to operations", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithProxyAuthz(userDN))
+		dr := ldap.NewDelRequest(testConfig.UserBaseDN, nil)
+		expected := ldap.NewDelRequest(testConfig.UserBaseDN, []ldap.Control{NewControlProxyAuthz(userDN)})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, expected).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.doLDAPDelete(dr)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("client options applied before request options", func(t *testing.T) {
+		var controls []ldap.Control
+		capture := func(next Operation) Operation {
+			return func(req *OperationRequest) (any, *Error) {
+				controls = req.Request.(*ldap.DelRequest).Controls
+				return nil, nil
+			}
+		}
+		client := NewClient(testConfig, WithProxyAuthz(userDN), WithMiddleware(capture))
+		manageDsaIT := ldap.NewControlManageDsaIT(true)
+
+		cErr := client.doLDAPDelete(ldap.NewDelRequest(testConfig.UserBaseDN, nil), WithControls(manageDsaIT))
+		assert.Nil(t, cErr)
+		assert.Equal(t, []ldap.Control{NewControlProxyAuthz(userDN), manageDsaIT}, controls)
+	})
+}
+
+func TestControlProxyAuthz(t *testing.T) {
+	t.Run("dn", func(t *testing.T) {
+		control := NewControlProxyAuthz("uid=C00001,ou=users,o=company")
+		assert.Equal(t, ControlTypeProxyAuthz, control.GetControlType())
+		assert.Equal(t, "dn:uid=C00001,ou=users,o=company", control.AuthzID)
+
+		packet := control.Encode()
+		assert.Len(t, packet.Children, 3)
+		assert.Equal(t, ControlTypeProxyAuthz, packet.Children[0].Value)
+		assert.Equal(t, true, packet.Children[1].Value)
+		assert.Equal(t, "dn:uid=C00001,ou=users,o=company", packet.Children[2].Value)
+		assert.Contains(t, control.String(), ControlTypeProxyAuthz)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		control := NewControlProxyAuthz("")
+		assert.Equal(t, "", control.AuthzID)
+	})
+}
+
+func TestControlAssertion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		filter := "(uniqueMember=uid=NO_SUCH_USER,ou=users,o=company)"
+		control, cErr := NewControlAssertion(filter)
+		assert.Nil(t, cErr)
+		assert.Equal(t, ControlTypeAssertion, control.GetControlType())
+		assert.Equal(t, filter, control.Filter)
+
+		compiled, err := ldap.CompileFilter(filter)
+		assert.Nil(t, err)
+		packet := control.Encode()
+		assert.Len(t, packet.Children, 3)
+		assert.Equal(t, ControlTypeAssertion, packet.Children[0].Value)
+		assert.Equal(t, true, packet.Children[1].Value)
+		assert.Equal(t, compiled.Bytes(), packet.Children[2].Children[0].Bytes())
+		assert.Contains(t, control.String(), filter)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		control, cErr := NewControlAssertion("uniqueMember=")
+		assert.Nil(t, control)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, assertionParam, cErr.Validation.Fields[0].Field)
+	})
+}
+
+func TestWithManageDsaIT(t *testing.T) {
+	mr := ldap.NewModifyRequest(testSubtreeDN, nil)
+	dr := ldap.NewDelRequest(testSubtreeDN, nil)
+	batchModify := ldap.NewModifyRequest(testSubtreeDN, nil)
+	batch := &BatchRequest{Requests: []any{batchModify, ldap.NewDelRequest(testSubtreeDN, nil)}}
+
+	for _, request := range []any{mr, dr, batch} {
+		WithManageDsaIT()(&OperationRequest{Request: request})
+	}
+	assert.Equal(t, []ldap.Control{ldap.NewControlManageDsaIT(true)}, mr.Controls)
+	assert.Empty(t, dr.Controls)
+	assert.Equal(t, []ldap.Control{ldap.NewControlManageDsaIT(true)}, batchModify.Controls)
+	assert.Empty(t, batch.Requests[1].(*ldap.DelRequest).Controls)
+}
+
+func TestControlRelaxRules(t *testing.T) {
+	control := NewControlRelaxRules()
+	assert.Equal(t, ControlTypeRelaxRules, control.GetControlType())
+
+	packet := control.Encode()
+	assert.Len(t, packet.Children, 2)
+	assert.Equal(t, ControlTypeRelaxRules, packet.Children[0].Value)
+	assert.Equal(t, true, packet.Children[1].Value)
+	assert.Contains(t, control.String(), ControlTypeRelaxRules)
+
+	mr := ldap.NewModifyRequest(testSubtreeDN, nil)
+	WithRelaxRules()(&OperationRequest{Request: mr})
+	assert.Equal(t, []ldap.Control{control}, mr.Controls)
+}
@@ -0,0 +1,93 @@
+package ldap
+
+import (
+	"sync"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	credentialsProviderErrMsg = "Unable to retrieve the LDAP bind credentials from the credentials provider: %v"
+	credentialsRotatedMsg     = "LDAP bind failed with invalid credentials, retrying with refreshed credentials"
+)
+
+type (
+	// CredentialsProvider returns the bind credentials to be used for authenticating to LDAP.
+	// The provider is invoked before the first bind and again when a bind fails with invalid credentials,
+	// which allows the bind password to be rotated without re-creating the Client.
+	CredentialsProvider func() (bindUser, bindPassword string, err error)
+)
+
+// WithCredentialsProvider sets a CredentialsProvider which is used for retrieving the bind credentials.
+// The credentials returned by the provider override the bind credentials set in the client Config.
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentialsProvider = provider
+	}
+}
+
+// loadCredentials sets the bind credentials from the CredentialsProvider if the credentials are not set yet.
+func (c *Client) loadCredentials() *Error {
+	if c.credentialsProvider == nil {
+		return nil
+	}
+	c.mu.RLock()
+	loaded := c.credentialsLoaded
+	c.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	return c.refreshCredentials()
+}
+
+// refreshCredentials invokes the CredentialsProvider and updates the bind credentials in the client Config.
+func (c *Client) refreshCredentials() *Error {
+	bindUser, bindPassword, err := c.credentialsProvider()
+	if err != nil {
+		return WrapError(errors.InternalServerErrorf(credentialsProviderErrMsg, err))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Config.BindUser = bindUser
+	c.Config.BindPassword = bindPassword
+	c.credentialsLoaded = true
+	return nil
+}
+
+// rebind retries the bind once with refreshed credentials if the previous bind failed because of invalid
+// credentials and a CredentialsProvider is configured.
+// The method returns the original bind error if a retry is not possible.
+func (c *Client) rebind(conn ldap.Client, err error, stats *connectionStats) error {
+	if c.credentialsProvider == nil || !ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return err
+	}
+	logger.Info(credentialsRotatedMsg)
+	if cErr := c.refreshCredentials(); cErr != nil {
+		return err
+	}
+	config := c.getConfig()
+	stats.retries++
+	return conn.Bind(config.BindUser, config.BindPassword)
+}
+
+// boundAs returns a copy of the client which binds with the bind credentials instead of the credentials of the
+// client. The copy shares everything else with the client, e.g. the policy, the protected dns, the middleware, the
+// observers and the lifecycle, so its operations are checked, traced and drained like the operations of the client.
+// The CredentialsProvider of the client is not used by the copy, identical searches of the copy and the client are
+// not collapsed, as their results may differ, and the copy does not use the connection pool of the client.
+func (c *Client) boundAs(bindUser, bindPassword string) *Client {
+	c.capabilitiesMu.Lock()
+	c.mu.RLock()
+	bound := *c
+	c.mu.RUnlock()
+	c.capabilitiesMu.Unlock()
+	bound.mu = &sync.RWMutex{}
+	bound.Config.BindUser = bindUser
+	bound.Config.BindPassword = bindPassword
+	bound.credentialsProvider = nil
+	bound.searchFlight = nil
+	bound.pool = nil
+	return &bound
+}
@@ -0,0 +1,125 @@
+package ldap
+
+import (
+	err "errors"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testRotatedBindPassword = "rotatedPassword"
+)
+
+func TestWithCredentialsProvider(t *testing.T) {
+	provider := func() (string, string, error) {
+		return testConfig.BindUser, testConfig.BindPassword, nil
+	}
+	client := NewClient(testConfig, WithCredentialsProvider(provider))
+	assert.NotNil(t, client.credentialsProvider)
+	assert.False(t, client.credentialsLoaded)
+}
+
+func TestClient_bind(t *testing.T) {
+	t.Run("credentials loaded from provider", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.BindPassword = ""
+		provider := func() (string, string, error) {
+			return testConfig.BindUser, testConfig.BindPassword, nil
+		}
+		client := NewClient(config, WithLDAPClient(ldapMock), WithCredentialsProvider(provider), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Once()
+
+		_, cErr := client.connect()
+		assert.Nil(t, cErr)
+		assert.True(t, client.credentialsLoaded)
+		assert.Equal(t, testConfig.BindPassword, client.Config.BindPassword)
+	})
+
+	t.Run("rebind with rotated credentials", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		calls := 0
+		provider := func() (string, string, error) {
+			calls++
+			if calls == 1 {
+				return testConfig.BindUser, testConfig.BindPassword, nil
+			}
+			return testConfig.BindUser, testRotatedBindPassword, nil
+		}
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), WithCredentialsProvider(provider), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).
+			Return(ldapInvalidCredentialsErr).Once()
+		ldapMock.On(methodNameBind, testConfig.BindUser, testRotatedBindPassword).Return(nil).Once()
+
+		_, cErr := client.connect()
+		assert.Nil(t, cErr)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, testRotatedBindPassword, client.Config.BindPassword)
+	})
+
+	t.Run("rebind fails", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		provider := func() (string, string, error) {
+			return testConfig.BindUser, testConfig.BindPassword, nil
+		}
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), WithCredentialsProvider(provider), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).
+			Return(ldapInvalidCredentialsErr).Twice()
+
+		_, cErr := client.connect()
+		assert.Equal(t, errors.ErrCodeUnauthorized, cErr.Code)
+		assert.Equal(t, http.StatusUnauthorized, cErr.Status)
+		assert.Equal(t, ldap.LDAPResultCodeMap[ldap.LDAPResultInvalidCredentials], cErr.Message)
+	})
+
+	t.Run("no rebind without provider", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).
+			Return(ldapInvalidCredentialsErr).Once()
+
+		_, cErr := client.connect()
+		assert.Equal(t, errors.ErrCodeUnauthorized, cErr.Code)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		provider := func() (string, string, error) {
+			return "", "", err.New("vault sealed")
+		}
+		client := NewClient(testConfig, WithCredentialsProvider(provider), UnitTesting())
+
+		_, cErr := client.connect()
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+		assert.Contains(t, cErr.Message, "vault sealed")
+	})
+}
+
+func TestClient_boundAs(t *testing.T) {
+	provider := func() (string, string, error) {
+		return testConfig.BindUser, testConfig.BindPassword, nil
+	}
+	tracer := func(trace RequestTrace) {}
+	client := NewClient(testConfig, WithCredentialsProvider(provider), WithPolicy(selfServicePolicy),
+		WithRequestTracer(tracer), WithSearchDeduplication())
+
+	bound := client.boundAs("uid=C00001,ou=users,o=company", "secret")
+	assert.Equal(t, "uid=C00001,ou=users,o=company", bound.Config.BindUser)
+	assert.Equal(t, "secret", bound.Config.BindPassword)
+	assert.Equal(t, testConfig.BindUser, client.Config.BindUser)
+	assert.Nil(t, bound.credentialsProvider)
+	assert.Nil(t, bound.searchFlight)
+	assert.NotNil(t, bound.policy)
+	assert.NotNil(t, bound.requestTracer)
+	assert.Same(t, client.lifecycle, bound.lifecycle)
+	assert.NotSame(t, client.mu, bound.mu)
+}
@@ -0,0 +1,255 @@
+package ldap
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// CSVActionCreate is the action of a CSV row of a user which does not exist yet.
+	CSVActionCreate = "create"
+	// CSVActionUpdate is the action of a CSV row of an existing user of which attributes differ.
+	CSVActionUpdate = "update"
+	// CSVActionUnchanged is the action of a CSV row of an existing user of which all the attributes are up-to-date.
+	CSVActionUnchanged = "unchanged"
+
+	columnsParam = "columns"
+
+	csvWriteErrMsg        = "Writing the CSV failed: %v"
+	csvReadErrMsg         = "Reading the CSV failed: %v"
+	invalidCSVColumnMsg   = "Invalid column '%s'. Valid columns are %v"
+	duplicateCSVColumnMsg = "Duplicate column '%s'"
+	csvUidColumnMissing   = "The CSV does not have a uid column"
+)
+
+type (
+	// CSVImportOptions configure an ImportCSV.
+	CSVImportOptions struct {
+		// DryRun validates the rows and reports the actions without changing LDAP.
+		DryRun bool
+		// Progress is invoked after every row. The Total of the Progress is 0 because the rows are read one by
+		// one. Optional.
+		Progress ProgressFunc
+	}
+
+	// CSVImportResult summarizes an ImportCSV.
+	CSVImportResult struct {
+		Created   int `json:"created"`
+		Updated   int `json:"updated"`
+		Unchanged int `json:"unchanged"`
+		Failed    int `json:"failed"`
+		// Rows holds the result of every row of the CSV, excluding the header.
+		Rows []CSVRowResult `json:"rows"`
+		// Cancelled is true if the import was cancelled by the Progress func before all the rows were read.
+		Cancelled bool `json:"cancelled,omitempty"`
+	}
+
+	// CSVRowResult is the result of importing a single row of a CSV.
+	CSVRowResult struct {
+		// Line is the line number of the row in the CSV, the header is line 1.
+		Line int    `json:"line"`
+		Uid  string `json:"uid"`
+		// Action is the action performed for the row, or in a dry-run the action which would be performed.
+		// The action is empty if the row could not be read.
+		Action string `json:"action"`
+		// Attributes are the attributes which are updated.
+		Attributes []string `json:"attributes,omitempty"`
+		// Error is set if the row failed.
+		Error *Error `json:"error,omitempty"`
+	}
+)
+
+// userCSVColumns are the columns which can be imported from a CSV, exported columns exclude the userPassword.
+var userCSVColumns = append(append([]string{}, userAttributes...), userPasswordAttr)
+
+// ExportCSV writes the user entries as CSV to w. The first row is a header with the names of the columns.
+// params:
+//
+//	w = the writer the CSV is written to
+//	columns = the attributes to export, e.g. "uid", "mail". Defaults to all the user attributes except the password.
+//
+// The user entries are retrieved page by page, so the memory usage does not grow with the number of users.
+// The method returns an error:
+//   - if a column is not valid
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+//   - if writing to w fails
+func (um *usersManager) ExportCSV(w io.Writer, columns ...string) *Error {
+	validColumns := um.attributes()
+	if len(columns) == 0 {
+		columns = validColumns
+	}
+	for _, column := range columns {
+		if !slice.EntryExists(validColumns, column) {
+			return invalidParameterError(columnsParam, fmt.Sprintf(invalidCSVColumnMsg, column, validColumns))
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return WrapError(errors.InternalServerErrorf(csvWriteErrMsg, err))
+	}
+	cErr := um.Client.doLDAPPagedSearch(um.getUsersSearchRequest(um.Client.getConfig().usersFilter()), defaultPageSize,
+		func(result *ldap.SearchResult) *Error {
+			for _, user := range um.parseSearchResult(result) {
+				record := make([]string, 0, len(columns))
+				for _, column := range columns {
+					record = append(record, userAttributeValue(user, column))
+				}
+				if err := cw.Write(record); err != nil {
+					return WrapError(errors.InternalServerErrorf(csvWriteErrMsg, err))
+				}
+			}
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return WrapError(errors.InternalServerErrorf(csvWriteErrMsg, err))
+			}
+			return nil
+		})
+	if cErr != nil {
+		return cErr
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return WrapError(errors.InternalServerErrorf(csvWriteErrMsg, err))
+	}
+	return nil
+}
+
+// ImportCSV creates or updates the user entries of a CSV read from r.
+// The first row is a header with the names of the columns, which are the user attributes and optionally the
+// userPassword. The uid column is mandatory. Users which do not exist yet are created, the row of a new user must
+// contain all the attributes which are required by Create. Users which exist are updated when the value of a column
+// differs from the value in LDAP, the userPassword of existing users is never updated.
+// A row which fails does not stop the import, the error of every row is reported in the CSVImportResult.
+// The method returns an error:
+//   - if the CSV cannot be read or the header is not valid
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) ImportCSV(r io.Reader, opts CSVImportOptions) (*CSVImportResult, *Error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	columns, err := cr.Read()
+	if err != nil {
+		return nil, WrapError(errors.BadRequestError(fmt.Sprintf(csvReadErrMsg, err)))
+	}
+	if cErr := validateCSVColumns(columns, append(um.attributes(), userPasswordAttr)); cErr != nil {
+		return nil, cErr
+	}
+
+	users, cErr := um.getAll()
+	if cErr != nil {
+		return nil, cErr
+	}
+	existingUsers := make(map[string]User, len(users))
+	for _, user := range users {
+		existingUsers[strings.ToLower(user.Uid)] = user
+	}
+
+	result := &CSVImportResult{}
+	tracker := newProgressTracker(opts.Progress, 0)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			if parseErr, ok := err.(*csv.ParseError); ok && parseErr.Err == csv.ErrFieldCount {
+				row := CSVRowResult{Line: line, Error: WrapError(errors.BadRequestError(err.Error()))}
+				result.addRow(row)
+				if !tracker.doneItem(row.Error) {
+					result.Cancelled = true
+					break
+				}
+				continue
+			}
+			return result, WrapError(errors.BadRequestError(fmt.Sprintf(csvReadErrMsg, err)))
+		}
+
+		var user User
+		for i, column := range columns {
+			setUserAttributeValue(&user, column, record[i])
+		}
+		row := CSVRowResult{Line: line, Uid: user.Uid}
+		if existing, ok := existingUsers[strings.ToLower(user.Uid)]; ok {
+			row.Attributes = changedUserAttributes(existing, user, columns)
+			row.Action, row.Error = um.importExistingUser(existing, user, row.Attributes, opts)
+		} else {
+			row.Action = CSVActionCreate
+			row.Error = um.importNewUser(user, opts)
+			if row.Error == nil {
+				existingUsers[strings.ToLower(user.Uid)] = user
+			}
+		}
+		result.addRow(row)
+		if !tracker.doneItem(row.Error) {
+			result.Cancelled = true
+			break
+		}
+	}
+	return result, nil
+}
+
+// importNewUser creates a user of a CSV row.
+func (um *usersManager) importNewUser(user User, opts CSVImportOptions) *Error {
+	if !opts.DryRun {
+		return um.Create(user)
+	}
+	if cErr := um.validateUser(user); cErr != nil {
+		return cErr
+	}
+	return um.validateUniqueness(user)
+}
+
+// importExistingUser updates the changed attributes of an existing user of a CSV row.
+func (um *usersManager) importExistingUser(existing, user User, attrs []string,
+	opts CSVImportOptions) (string, *Error) {
+	if len(attrs) == 0 {
+		return CSVActionUnchanged, nil
+	}
+	if opts.DryRun {
+		return CSVActionUpdate, um.validateUpdate(mergeUserAttributes(existing, user, attrs))
+	}
+	_, cErr := um.update(existing, user, attrs)
+	return CSVActionUpdate, cErr
+}
+
+// addRow adds the result of a row and updates the counters.
+func (r *CSVImportResult) addRow(row CSVRowResult) {
+	r.Rows = append(r.Rows, row)
+	switch {
+	case row.Error != nil:
+		r.Failed++
+	case row.Action == CSVActionCreate:
+		r.Created++
+	case row.Action == CSVActionUpdate:
+		r.Updated++
+	default:
+		r.Unchanged++
+	}
+}
+
+// validateCSVColumns checks if the columns of the CSV header are valid columns and include the uid.
+func validateCSVColumns(columns, validColumns []string) *Error {
+	seen := map[string]bool{}
+	for _, column := range columns {
+		if !slice.EntryExists(validColumns, column) {
+			return invalidParameterError(columnsParam, fmt.Sprintf(invalidCSVColumnMsg, column, validColumns))
+		}
+		if seen[column] {
+			return invalidParameterError(columnsParam, fmt.Sprintf(duplicateCSVColumnMsg, column))
+		}
+		seen[column] = true
+	}
+	if !seen[userIdAttr] {
+		return invalidParameterError(columnsParam, csvUidColumnMissing)
+	}
+	return nil
+}
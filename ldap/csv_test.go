@@ -0,0 +1,233 @@
+package ldap
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testImportCSV = `uid,altUid,cn,sn,displayName,mail,userPassword,status
+C00001,john.doe,John,Doe,John Doe,john.doe@company.com,,Active
+C00002,jane.doe,Jane,Doe,Jane Doe,jane.doe@example.com,,Deleted
+C00099,max.doe,Max,Doe,Max Doe,max.doe@company.com,somePassword,Active
+C00100,mia.doe,Mia,Doe,Mia Doe,mia.doe@company.com,,Active
+C00101,mia.doe
+`
+
+func TestUsersManager_ExportCSV(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var buf bytes.Buffer
+		cErr := client.Users.(UsersExporter).ExportCSV(&buf, userIdAttr, mailAttr)
+		assert.Nil(t, cErr)
+		assert.Equal(t, "uid,mail\n"+
+			"C00001,john.doe@company.com\n"+
+			"C00002,jane.doe@company.com\n"+
+			"ABC_BUILDER,abc@company.com\n"+
+			"nxrm-ado-agent,abc@company.com\n", buf.String())
+	})
+
+	t.Run("default columns", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(getUsersEmptySearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var buf bytes.Buffer
+		cErr := client.Users.(UsersExporter).ExportCSV(&buf)
+		assert.Nil(t, cErr)
+		assert.Equal(t, strings.Join(userAttributes, ",")+"\n", buf.String())
+	})
+
+	t.Run("invalid column", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UsersExporter).ExportCSV(&bytes.Buffer{}, userIdAttr, userPasswordAttr)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(columnsParam).Code)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UsersExporter).ExportCSV(&bytes.Buffer{})
+		assert.NotNil(t, cErr)
+	})
+}
+
+func TestUsersManager_ImportCSV(t *testing.T) {
+	newUser := User{
+		Uid:          "C00099",
+		AltUid:       "max.doe",
+		Cn:           "Max",
+		Sn:           "Doe",
+		DisplayName:  "Max Doe",
+		Mail:         "max.doe@company.com",
+		UserPassword: "somePassword",
+		Status:       UserStatusActive,
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader(testImportCSV), CSVImportOptions{DryRun: true})
+		assert.Nil(t, cErr)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 1, result.Unchanged)
+		assert.Equal(t, 2, result.Failed)
+		assert.Len(t, result.Rows, 5)
+
+		assert.Equal(t, CSVRowResult{Line: 2, Uid: testUser1.Uid, Action: CSVActionUnchanged}, result.Rows[0])
+		assert.Equal(t, CSVRowResult{Line: 3, Uid: testUser2.Uid, Action: CSVActionUpdate,
+			Attributes: []string{mailAttr}}, result.Rows[1])
+		assert.Equal(t, CSVRowResult{Line: 4, Uid: newUser.Uid, Action: CSVActionCreate}, result.Rows[2])
+
+		assert.Equal(t, CSVActionCreate, result.Rows[3].Action)
+		assert.Equal(t, 5, result.Rows[3].Line)
+		assert.Equal(t, FieldErrCodeMissing, result.Rows[3].Error.Validation.Field(userPasswordAttr).Code)
+
+		assert.Equal(t, 6, result.Rows[4].Line)
+		assert.Equal(t, "", result.Rows[4].Action)
+		assert.Equal(t, errors.ErrCodeBadRequest, result.Rows[4].Error.Code)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var reported []Progress
+		result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader(testImportCSV), CSVImportOptions{
+			DryRun: true,
+			Progress: func(progress Progress) bool {
+				reported = append(reported, progress)
+				return progress.Processed < 2
+			},
+		})
+		assert.Nil(t, cErr)
+		assert.True(t, result.Cancelled)
+		assert.Len(t, result.Rows, 2)
+		assert.Equal(t, []Progress{{Processed: 1}, {Processed: 2}}, reported)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser2.Uid), nil)
+		mr.Replace(mailAttr, []string{"jane.doe@example.com"})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameAdd, um.getAddRequest(newUser)).Return(nil)
+		ldapMock.On("PasswordModify",
+			um.getPasswordModifyRequest(newUser.Uid, newUser.UserPassword, newUser.UserPassword)).Return(nil, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		csv := "uid,altUid,cn,sn,displayName,mail,userPassword,status\n" +
+			"C00002,jane.doe,Jane,Doe,Jane Doe,jane.doe@example.com,,Deleted\n" +
+			"C00099,max.doe,Max,Doe,Max Doe,max.doe@company.com,somePassword,Active\n"
+		result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader(csv), CSVImportOptions{})
+		assert.Nil(t, cErr)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 0, result.Failed)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(CommonNameAttr, []string{"Johnny"})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameModify, mr).Return(ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader("uid,cn\nc00001,Johnny\n"), CSVImportOptions{})
+		assert.Nil(t, cErr)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, CSVActionUpdate, result.Rows[0].Action)
+		assert.Equal(t, []string{CommonNameAttr}, result.Rows[0].Attributes)
+		assert.NotNil(t, result.Rows[0].Error)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		for csv, msg := range map[string]string{
+			"mail,cn\n":       csvUidColumnMissing,
+			"uid,photo\n":     fmt.Sprintf(invalidCSVColumnMsg, "photo", userCSVColumns),
+			"uid,mail,mail\n": fmt.Sprintf(duplicateCSVColumnMsg, mailAttr),
+		} {
+			result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader(csv), CSVImportOptions{})
+			assert.Nil(t, result)
+			assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+			assert.Equal(t, msg, cErr.Message)
+		}
+	})
+
+	t.Run("empty csv", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader(""), CSVImportOptions{})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("get all error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProvisioner).ImportCSV(strings.NewReader(testImportCSV), CSVImportOptions{})
+		assert.Nil(t, result)
+		assert.NotNil(t, cErr)
+	})
+}
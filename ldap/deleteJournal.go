@@ -0,0 +1,189 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	journalEmptyErrMsg    = "The delete journal is empty"
+	journalNotFoundErrMsg = "The delete journal does not contain an entry with the id %d"
+	journalInvalidErrMsg  = "The delete journal entry %d does not contain an LDIF record"
+)
+
+type (
+	// JournalEntry is an entry deleted by the client, as recorded in the delete journal.
+	JournalEntry struct {
+		// Id identifies the entry in the journal, the ids increase with every delete.
+		Id        int64     `json:"id"`
+		Dn        string    `json:"dn"`
+		DeletedAt time.Time `json:"deletedAt"`
+		// LDIF is the LDIF record of the entry with all its user attributes, as read right before the delete. The
+		// secret attributes, e.g. the userPassword, are not recorded.
+		LDIF string `json:"ldif"`
+	}
+
+	// deleteJournal keeps the entries deleted by the client, up to the DeleteJournalSize set in the client Config.
+	// The oldest entries are dropped when the journal is full.
+	deleteJournal struct {
+		mu      sync.Mutex
+		entries []JournalEntry
+		lastId  int64
+	}
+)
+
+// DeleteJournal returns the entries recorded in the delete journal of the client, the oldest entry first. The journal
+// is empty if the DeleteJournalSize is not set in the client Config.
+func (c *Client) DeleteJournal() []JournalEntry {
+	c.journal.mu.Lock()
+	defer c.journal.mu.Unlock()
+	return append([]JournalEntry{}, c.journal.entries...)
+}
+
+// UndoLastDelete restores the entry which was deleted last by the client from the delete journal and returns it.
+// Calling UndoLastDelete repeatedly restores the entries in the reverse order of their deletion, e.g. an
+// organizational unit deleted recursively is restored before the entries within it.
+// The method returns an error:
+//   - if the delete journal is empty
+//   - if an entry with the same dn exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (c *Client) UndoLastDelete() (*JournalEntry, *Error) {
+	c.journal.mu.Lock()
+	if len(c.journal.entries) == 0 {
+		c.journal.mu.Unlock()
+		return nil, WrapError(errors.NotFoundError(journalEmptyErrMsg))
+	}
+	entry := c.journal.entries[len(c.journal.entries)-1]
+	c.journal.mu.Unlock()
+	if cErr := c.RestoreFromJournal(entry.Id); cErr != nil {
+		return nil, cErr
+	}
+	return &entry, nil
+}
+
+// RestoreFromJournal adds the entry with the id of the delete journal back to LDAP with all the user attributes it
+// had when it was deleted except the secret attributes and removes it from the journal, e.g. a restored user has no
+// password. The operational attributes, e.g. the entryUUID and the modifyTimestamp, are set by the LDAP server.
+// params:
+//
+//	id = the id of the journal entry
+//
+// The method returns an error:
+//   - if the delete journal does not contain the entry
+//   - if an entry with the same dn exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (c *Client) RestoreFromJournal(id int64) *Error {
+	entry, ok := c.journal.get(id)
+	if !ok {
+		return WrapError(errors.NotFoundError(fmt.Sprintf(journalNotFoundErrMsg, id)))
+	}
+	entries, cErr := parseLDIF(strings.NewReader(entry.LDIF))
+	if cErr != nil {
+		return cErr
+	}
+	if len(entries) != 1 {
+		return WrapError(errors.InternalServerErrorf(journalInvalidErrMsg, id))
+	}
+	if cErr := c.doLDAPAdd(getRestoreAddRequest(entries[0])); cErr != nil {
+		return cErr
+	}
+	c.journal.remove(id)
+	return nil
+}
+
+// executeDelete deletes the entry of the delete request using the connection. If the DeleteJournalSize is set in the
+// client Config the entry is read before it is deleted and recorded in the delete journal once it is deleted. The
+// entry is not deleted if it cannot be read.
+func (c *Client) executeDelete(conn ldap.Client, dr *ldap.DelRequest) error {
+	size := c.getConfig().DeleteJournalSize
+	if size <= 0 {
+		return conn.Del(dr)
+	}
+	entry, err := c.readJournalEntry(conn, dr.DN)
+	if err != nil {
+		return err
+	}
+	if err := conn.Del(dr); err != nil {
+		return err
+	}
+	if entry != nil {
+		c.journal.record(entry, size)
+	}
+	return nil
+}
+
+// readJournalEntry reads the entry with all its user attributes using the connection. The entry is nil if it does
+// not exist.
+func (c *Client) readJournalEntry(conn ldap.Client, entryDN string) (*ldap.Entry, error) {
+	sr := ldap.NewSearchRequest(entryDN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		"(objectClass=*)", []string{backupAllUserAttributes}, nil)
+	c.traceRequest(OperationSearch, sr)
+	result, err := conn.Search(sr)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Entries) == 0 {
+		return nil, nil
+	}
+	return result.Entries[0], nil
+}
+
+// record adds a deleted entry without its secret attributes to the journal and drops the oldest entries which exceed
+// the size of the journal.
+func (j *deleteJournal) record(entry *ldap.Entry, size int) {
+	recorded := &ldap.Entry{DN: entry.DN}
+	for _, attribute := range entry.Attributes {
+		if !isSecretAttribute(attribute.Name) {
+			recorded.Attributes = append(recorded.Attributes, attribute)
+		}
+	}
+	var ldif strings.Builder
+	writeLDIFEntry(&ldif, recorded)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.lastId++
+	j.entries = append(j.entries, JournalEntry{
+		Id:        j.lastId,
+		Dn:        entry.DN,
+		DeletedAt: time.Now(),
+		LDIF:      strings.TrimPrefix(ldif.String(), "\n"),
+	})
+	if len(j.entries) > size {
+		j.entries = append([]JournalEntry{}, j.entries[len(j.entries)-size:]...)
+	}
+}
+
+// get returns the journal entry with the id and whether it exists.
+func (j *deleteJournal) get(id int64) (JournalEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, entry := range j.entries {
+		if entry.Id == id {
+			return entry, true
+		}
+	}
+	return JournalEntry{}, false
+}
+
+// remove removes the journal entry with the id, if it exists.
+func (j *deleteJournal) remove(id int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i, entry := range j.entries {
+		if entry.Id == id {
+			j.entries = append(j.entries[:i], j.entries[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func getJournalConfig(size int) Config {
+	config := testConfig
+	config.DeleteJournalSize = size
+	return config
+}
+
+// mockJournaledDelete mocks the read and the delete of the entry of a user.
+func mockJournaledDelete(ldapMock *mocks.Client, uid string) {
+	userDN := "uid=" + uid + ",ou=users,o=company"
+	ldapMock.On(methodNameSearch, searchRequestWithBaseDN(userDN)).Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry(userDN, map[string][]string{
+			objectClassAttr:  {"inetOrgPerson", "top"},
+			userIdAttr:       {uid},
+			CommonNameAttr:   {"Jöhn Doe"},
+			userPasswordAttr: {"{SSHA}c2VjcmV0aGFzaA=="},
+		}),
+	}}, nil).Once()
+	ldapMock.On(methodNameDelete, ldap.NewDelRequest(userDN, nil)).Return(nil).Once()
+}
+
+func TestClient_DeleteJournal(t *testing.T) {
+	t.Run("record", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getJournalConfig(2), WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		for _, uid := range []string{"C00001", "C00002", "C00003"} {
+			mockJournaledDelete(ldapMock, uid)
+		}
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.Delete("C00001"))
+		assert.Nil(t, client.Users.Delete("C00002"))
+		results, cErr := client.doLDAPBatch([]any{ldap.NewDelRequest("uid=C00003,ou=users,o=company", nil)})
+		assert.Nil(t, cErr)
+		assert.Nil(t, results[0])
+
+		journal := client.DeleteJournal()
+		assert.Len(t, journal, 2)
+		assert.Equal(t, int64(2), journal[0].Id)
+		assert.Equal(t, "uid=C00003,ou=users,o=company", journal[1].Dn)
+		assert.Contains(t, journal[1].LDIF, "dn: uid=C00003,ou=users,o=company\n")
+		assert.Contains(t, journal[1].LDIF, "uid: C00003\n")
+		assert.Contains(t, journal[1].LDIF, "cn:: SsO2aG4gRG9l\n")
+		assert.NotContains(t, journal[1].LDIF, userPasswordAttr)
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getJournalConfig(10), WithLDAPClient(ldapMock), UnitTesting())
+		em := entriesManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, em.getSubtreeSearchRequest(testSubtreeDN)).
+			Return(getSubtreeSearchResult, nil).Once()
+		for _, entry := range getSubtreeSearchResult.Entries {
+			ldapMock.On(methodNameSearch, searchRequestWithBaseDN(entry.DN)).
+				Return(&ldap.SearchResult{Entries: []*ldap.Entry{entry}}, nil).Once()
+			ldapMock.On(methodNameDelete, ldap.NewDelRequest(entry.DN, nil)).Return(nil).Once()
+		}
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Entries.DeleteSubtree(testSubtreeDN))
+		journal := client.DeleteJournal()
+		assert.Len(t, journal, len(getSubtreeSearchResult.Entries))
+		assert.Equal(t, testSubtreeDN, journal[len(journal)-1].Dn)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getJournalConfig(2), WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN("uid=C00001,ou=users,o=company")).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.Delete("C00001")
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+		assert.Empty(t, client.DeleteJournal())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest("uid=C00001,ou=users,o=company", nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.Delete("C00001"))
+		assert.Empty(t, client.DeleteJournal())
+	})
+}
+
+func TestClient_UndoLastDelete(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(getJournalConfig(10), WithLDAPClient(ldapMock), UnitTesting())
+
+	ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+	mockJournaledDelete(ldapMock, "C00001")
+	mockJournaledDelete(ldapMock, "C00002")
+	ldapMock.On(methodNameAdd, &ldap.AddRequest{
+		DN: "uid=C00002,ou=users,o=company",
+		Attributes: []ldap.Attribute{
+			{Type: CommonNameAttr, Vals: []string{"Jöhn Doe"}},
+			{Type: objectClassAttr, Vals: []string{"inetOrgPerson", "top"}},
+			{Type: userIdAttr, Vals: []string{"C00002"}},
+		},
+	}).Return(nil).Once()
+	ldapMock.On(methodNameClose).Return(nil)
+
+	assert.Nil(t, client.Users.Delete("C00001"))
+	assert.Nil(t, client.Users.Delete("C00002"))
+
+	entry, cErr := client.UndoLastDelete()
+	assert.Nil(t, cErr)
+	assert.Equal(t, "uid=C00002,ou=users,o=company", entry.Dn)
+	assert.Len(t, client.DeleteJournal(), 1)
+
+	cErr = client.RestoreFromJournal(entry.Id)
+	assert.Equal(t, http.StatusNotFound, cErr.Status)
+
+	client.journal.remove(1)
+	_, cErr = client.UndoLastDelete()
+	assert.Equal(t, http.StatusNotFound, cErr.Status)
+}
@@ -0,0 +1,163 @@
+package ldap
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// scrambledPasswordLength is the number of random bytes of a scrambled password.
+	scrambledPasswordLength = 32
+
+	invalidDeprovisionStatusMsg = "Invalid status '%s'. A user can only be deprovisioned with the status %v"
+	scramblePasswordErrMsg      = "Generating a random password failed: %v"
+)
+
+// deprovisionStatusList are the statuses a user can be deprovisioned with.
+var deprovisionStatusList = []string{UserStatusDeleted, UserStatusDisabled}
+
+type (
+	// DeprovisionOptions configure a Deprovision.
+	DeprovisionOptions struct {
+		// Status is the status the user is set to, UserStatusDeleted or UserStatusDisabled. Defaults to
+		// UserStatusDeleted.
+		Status string
+		// ArchiveDN is the dn of the container the user entry is moved to. Optional, if empty the user entry is
+		// not moved.
+		ArchiveDN string
+	}
+
+	// DeprovisionResult reports the steps taken by a Deprovision.
+	DeprovisionResult struct {
+		Uid string `json:"uid"`
+		// Groups are the groups the user was removed from.
+		Groups []GroupMembership `json:"groups"`
+		// Status is the status the user was set to.
+		Status string `json:"status,omitempty"`
+		// PasswordScrambled is true if the password of the user was replaced with a random password.
+		PasswordScrambled bool `json:"passwordScrambled"`
+		// Dn is the dn of the user entry after the deprovisioning, which differs from the original dn if the user
+		// entry was moved to the ArchiveDN.
+		Dn string `json:"dn,omitempty"`
+	}
+)
+
+// Deprovision offboards a user following the standard runbook:
+//   - the user is removed from all the groups
+//   - the status of the user is set to Deleted or Disabled
+//   - the password of the user is replaced with a random password, which is not returned
+//   - optionally the user entry is moved to an archive container
+//
+// params:
+//
+//	uid = user identifier
+//	opts = the options of the deprovisioning, e.g. the status and the archive container
+//
+// The steps are executed in the order above. If a step fails, the steps taken until then are reported in the
+// DeprovisionResult together with the error, so the deprovisioning can be retried.
+// Once the user entry is moved to the archive container it is no longer managed by the UsersManager, use
+// client.UsersIn(archiveDN) to manage the archived user entries. If the ArchiveDN is the ArchiveBaseDN set in the
+// client Config, the user entry can be moved back with Unarchive.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Deprovision(uid string, opts DeprovisionOptions) (*DeprovisionResult, *Error) {
+	status := opts.Status
+	if status == "" {
+		status = UserStatusDeleted
+	}
+	if cErr := um.validateUid(uid); cErr != nil {
+		return nil, cErr
+	}
+	if !slice.EntryExists(deprovisionStatusList, status) {
+		return nil, invalidParameterError(statusAttr, fmt.Sprintf(invalidDeprovisionStatusMsg, status,
+			deprovisionStatusList))
+	}
+	user, cErr := um.get(uid)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	result := &DeprovisionResult{Uid: user.Uid}
+	if result.Groups, cErr = um.removeFromAllGroups(user.Uid); cErr != nil {
+		return result, cErr
+	}
+
+	mr := ldap.NewModifyRequest(um.getDN(user.Uid), nil)
+	mr.Replace(statusAttr, []string{status})
+	if cErr := um.Client.doLDAPModify(mr); cErr != nil {
+		return result, cErr
+	}
+	result.Status = status
+	um.emit(EventUserUpdated, user.Uid)
+
+	password, cErr := scrambledPassword()
+	if cErr != nil {
+		return result, cErr
+	}
+	if _, cErr := um.SetNewPassword(user.Uid, password); cErr != nil {
+		return result, cErr
+	}
+	result.PasswordScrambled = true
+
+	result.Dn = um.getDN(user.Uid)
+	if opts.ArchiveDN != "" {
+		mdr := ldap.NewModifyDNRequest(result.Dn, dn.RDN(userIdAttr, user.Uid), true, opts.ArchiveDN)
+		if cErr := um.Client.doLDAPModifyDN(mdr); cErr != nil {
+			return result, cErr
+		}
+		result.Dn = dn.Join(dn.RDN(userIdAttr, user.Uid), opts.ArchiveDN)
+	}
+	um.emit(EventUserDeprovisioned, user.Uid)
+	return result, nil
+}
+
+// memberGroups returns the groups the user is a member of.
+func (um *usersManager) memberGroups(uid string) ([]GroupMembership, *Error) {
+	memberDn := dn.Join(dn.RDN(userIdAttr, uid), um.Client.getConfig().UserBaseDN)
+	groups, cErr := um.Client.Groups.GetFilter(
+		fmt.Sprintf("(&%s(%s=%s))", um.Client.getConfig().groupsFilter(), uniqueMemberAttr, ldap.EscapeFilter(memberDn)))
+	if cErr != nil {
+		return nil, cErr
+	}
+	memberships := make([]GroupMembership, 0, len(groups))
+	for _, group := range groups {
+		memberships = append(memberships, GroupMembership{Cn: group.Cn, Ou: strings.Join(group.OuPath, OuPathSeparator)})
+	}
+	return memberships, nil
+}
+
+// removeFromAllGroups removes the user from all the groups the user is a member of and returns the groups the user
+// was removed from, also when the removal from a group fails.
+func (um *usersManager) removeFromAllGroups(uid string) ([]GroupMembership, *Error) {
+	removed := []GroupMembership{}
+	groups, cErr := um.memberGroups(uid)
+	if cErr != nil {
+		return removed, cErr
+	}
+	for _, group := range groups {
+		if cErr := um.Client.Groups.RemoveMembers(group.Cn, group.Ou, []string{uid}); cErr != nil {
+			return removed, cErr
+		}
+		removed = append(removed, group)
+	}
+	return removed, nil
+}
+
+// scrambledPassword returns a random password.
+func scrambledPassword() (string, *Error) {
+	password := make([]byte, scrambledPasswordLength)
+	if _, err := rand.Read(password); err != nil {
+		return "", WrapError(errors.InternalServerErrorf(scramblePasswordErrMsg, err))
+	}
+	return base64.RawURLEncoding.EncodeToString(password), nil
+}
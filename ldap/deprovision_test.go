@@ -0,0 +1,121 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testArchiveDN = "ou=archive,dc=example,dc=com"
+
+func TestUsersManager_Deprovision(t *testing.T) {
+	setup := func(t *testing.T) (*mocks.Client, *Client) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		um := usersManager{Client: client}
+		gm := groupsManager{Client: client}
+		filter := fmt.Sprintf("(&%s(%s=%s))", groupSearchFilter, uniqueMemberAttr,
+			ldap.EscapeFilter(gm.getUniqueMemberDn(testUser1.Uid)))
+		removeMemberRequest := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		removeMemberRequest.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser1.Uid)})
+		removeMemberRequest.Add(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", filter)).Return(getGroupSearchResult2, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult2, nil)
+		ldapMock.On(methodNameModify, removeMemberRequest).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+		return ldapMock, client
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock, client := setup(t)
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(statusAttr, []string{UserStatusDisabled})
+		var events []EventType
+		for _, eventType := range eventTypes {
+			client.On(eventType, func(event Event) { events = append(events, event.Type) })
+		}
+
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).Return(nil, nil)
+		ldapMock.On(methodNameModifyDN,
+			ldap.NewModifyDNRequest(um.getDN(testUser1.Uid), "uid="+testUser1.Uid, true, testArchiveDN)).Return(nil)
+
+		result, cErr := client.Users.(UserLifecycleManager).Deprovision(testUser1.Uid,
+			DeprovisionOptions{Status: UserStatusDisabled, ArchiveDN: testArchiveDN})
+		assert.Nil(t, cErr)
+		assert.Equal(t, &DeprovisionResult{
+			Uid:               testUser1.Uid,
+			Groups:            []GroupMembership{{Cn: testGroupCn1, Ou: testOrganizationUnit1}},
+			Status:            UserStatusDisabled,
+			PasswordScrambled: true,
+			Dn:                "uid=" + testUser1.Uid + "," + testArchiveDN,
+		}, result)
+		assert.Equal(t, []EventType{
+			EventGroupMembersRemoved, EventUserUpdated, EventUserPasswordChanged, EventUserDeprovisioned,
+		}, events)
+	})
+
+	t.Run("password error", func(t *testing.T) {
+		ldapMock, client := setup(t)
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(statusAttr, []string{UserStatusDeleted})
+
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).
+			Return(nil, ldapNoSuchObjectErr)
+
+		result, cErr := client.Users.(UserLifecycleManager).Deprovision(testUser1.Uid, DeprovisionOptions{})
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, UserStatusDeleted, result.Status)
+		assert.Len(t, result.Groups, 1)
+		assert.False(t, result.PasswordScrambled)
+		assert.Empty(t, result.Dn)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		result, cErr := client.Users.(UserLifecycleManager).Deprovision(testUser1.Uid, DeprovisionOptions{Status: UserStatusActive})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(invalidDeprovisionStatusMsg, UserStatusActive, deprovisionStatusList),
+			cErr.Validation.Field(statusAttr).Message)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserLifecycleManager).Deprovision(testUser1.Uid, DeprovisionOptions{})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestScrambledPassword(t *testing.T) {
+	password1, cErr := scrambledPassword()
+	assert.Nil(t, cErr)
+	password2, _ := scrambledPassword()
+	assert.NotEqual(t, password1, password2)
+	assert.Len(t, password1, 43)
+}
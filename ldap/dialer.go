@@ -0,0 +1,141 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	ldapiUrlFormat = "%s://%s"
+
+	defaultLdapiSocketPath = "/var/run/slapd/ldapi"
+	defaultLdapPort        = "389"
+	defaultLdapsPort       = "636"
+
+	invalidURLErrMsg       = "Invalid LDAP URL '%s': %v"
+	invalidURLSchemeErrMsg = "Invalid LDAP URL '%s'. The scheme must be one of %v"
+)
+
+type (
+	// Dialer opens network connections with the LDAP server.
+	// *net.Dialer and the dialers of golang.org/x/net/proxy (SOCKS5) implement this interface.
+	Dialer interface {
+		Dial(network, address string) (net.Conn, error)
+	}
+
+	// DialerFunc is an adapter to allow the use of an ordinary function as a Dialer.
+	DialerFunc func(network, address string) (net.Conn, error)
+)
+
+// Dial calls f(network, address).
+func (f DialerFunc) Dial(network, address string) (net.Conn, error) {
+	return f(network, address)
+}
+
+// WithDialer overrides the dialer which is used for opening network connections with the LDAP server.
+// A *net.Dialer can be used to customise e.g. the local address or the resolver, any other Dialer can be used for
+// tunneling connections through a proxy or for replacing the transport during testing.
+// For the ldaps protocol the TLS handshake is performed on top of the connection returned by the Dialer.
+func WithDialer(dialer Dialer) ClientOption {
+	return func(c *Client) {
+		c.dialer = dialer
+	}
+}
+
+// url returns the LDAP URL of the server. IPv6 addresses are enclosed in brackets.
+// For the ldapi protocol the Hostname is the path of the unix socket.
+func (cnf Config) url() string {
+	if cnf.Protocol == ProtocolLdapi {
+		return fmt.Sprintf(ldapiUrlFormat, cnf.Protocol, cnf.socketPath())
+	}
+	return (&url.URL{Scheme: cnf.Protocol, Host: net.JoinHostPort(cnf.host(), cnf.Port)}).String()
+}
+
+// host returns the Hostname without the brackets of an IPv6 address, e.g. fd00::1 for [fd00::1].
+func (cnf Config) host() string {
+	if strings.HasPrefix(cnf.Hostname, "[") && strings.HasSuffix(cnf.Hostname, "]") {
+		return cnf.Hostname[1 : len(cnf.Hostname)-1]
+	}
+	return cnf.Hostname
+}
+
+// withURL returns a copy of the config with the Protocol, the Hostname and the Port of the URL, if a URL is set.
+// The port defaults to the default port of the protocol if the URL does not have one and the path of the URL, e.g.
+// a base dn, is ignored.
+// The method returns an error if the URL cannot be parsed or the scheme is not an LDAP protocol.
+func (cnf Config) withURL() (Config, *Error) {
+	if cnf.URL == "" {
+		return cnf, nil
+	}
+	scheme, rest, found := strings.Cut(cnf.URL, "://")
+	if !found {
+		return cnf, WrapError(errors.BadRequestError(fmt.Sprintf(invalidURLSchemeErrMsg, cnf.URL, validProtocols)))
+	}
+	switch strings.ToLower(scheme) {
+	case ProtocolLdapi:
+		// the host of an ldapi URL is the percent-encoded path of the unix socket, e.g. ldapi://%2Fvar%2Frun%2Fldapi
+		socketPath, err := url.PathUnescape(rest)
+		if err != nil {
+			return cnf, WrapError(errors.BadRequestError(fmt.Sprintf(invalidURLErrMsg, cnf.URL, err)))
+		}
+		cnf.Protocol, cnf.Hostname, cnf.Port = ProtocolLdapi, socketPath, ""
+	case ProtocolLdap, ProtocolLdaps:
+		u, err := url.Parse(cnf.URL)
+		if err != nil {
+			return cnf, WrapError(errors.BadRequestError(fmt.Sprintf(invalidURLErrMsg, cnf.URL, err)))
+		}
+		cnf.Protocol, cnf.Hostname, cnf.Port = strings.ToLower(scheme), u.Hostname(), u.Port()
+		if cnf.Port == "" {
+			cnf.Port = defaultLdapPort
+			if cnf.Protocol == ProtocolLdaps {
+				cnf.Port = defaultLdapsPort
+			}
+		}
+	default:
+		return cnf, WrapError(errors.BadRequestError(fmt.Sprintf(invalidURLSchemeErrMsg, cnf.URL, validProtocols)))
+	}
+	return cnf, nil
+}
+
+// socketPath returns the path of the unix socket used by the ldapi protocol.
+func (cnf Config) socketPath() string {
+	if cnf.Hostname == "" {
+		return defaultLdapiSocketPath
+	}
+	return cnf.Hostname
+}
+
+// networkAddress returns the network and the address used for dialing the LDAP server.
+func (cnf Config) networkAddress() (string, string) {
+	if cnf.Protocol == ProtocolLdapi {
+		return "unix", cnf.socketPath()
+	}
+	return "tcp", net.JoinHostPort(cnf.host(), cnf.Port)
+}
+
+// dialWithDialer opens a connection with the LDAP server using a custom Dialer.
+func dialWithDialer(dialer Dialer, config Config, tlsConfig *tls.Config) (*ldap.Conn, error) {
+	network, address := config.networkAddress()
+	conn, err := dialer.Dial(network, address)
+	if err != nil {
+		return nil, ldap.NewError(ldap.ErrorNetwork, err)
+	}
+	isTLS := config.Protocol == ProtocolLdaps
+	if isTLS {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, ldap.NewError(ldap.ErrorNetwork, err)
+		}
+		conn = tlsConn
+	}
+	ldapConn := ldap.NewConn(conn, isTLS)
+	ldapConn.Start()
+	return ldapConn, nil
+}
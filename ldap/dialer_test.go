@@ -0,0 +1,164 @@
+package ldap
+
+import (
+	err "errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDialer(t *testing.T) {
+	dialer := &net.Dialer{}
+	client := NewClient(testConfig, WithDialer(dialer))
+	assert.Equal(t, dialer, client.dialer)
+}
+
+func TestConfig_url(t *testing.T) {
+	t.Run("ldaps", func(t *testing.T) {
+		assert.Equal(t, "ldaps://ldap.company.com:636", testConfig.url())
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		config := testConfig
+		config.Hostname = "fd00::1"
+		assert.Equal(t, "ldaps://[fd00::1]:636", config.url())
+
+		config.Hostname = "[fd00::1]"
+		assert.Equal(t, "ldaps://[fd00::1]:636", config.url())
+
+		network, address := config.networkAddress()
+		assert.Equal(t, "tcp", network)
+		assert.Equal(t, "[fd00::1]:636", address)
+	})
+
+	t.Run("ldapi", func(t *testing.T) {
+		config := testConfig
+		config.Protocol = ProtocolLdapi
+		config.Hostname = "/tmp/slapd.sock"
+		assert.Equal(t, "ldapi:///tmp/slapd.sock", config.url())
+
+		network, address := config.networkAddress()
+		assert.Equal(t, "unix", network)
+		assert.Equal(t, "/tmp/slapd.sock", address)
+	})
+
+	t.Run("ldapi default socket path", func(t *testing.T) {
+		config := testConfig
+		config.Protocol = ProtocolLdapi
+		config.Hostname = ""
+		assert.Equal(t, "ldapi://"+defaultLdapiSocketPath, config.url())
+	})
+}
+
+func TestConfig_withURL(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		config, cErr := testConfig.withURL()
+		assert.Nil(t, cErr)
+		assert.Equal(t, testConfig, config)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tests := []struct {
+			url, protocol, hostname, port string
+		}{
+			{"ldap://ldap.company.com", ProtocolLdap, "ldap.company.com", "389"},
+			{"ldaps://ldap.company.com", ProtocolLdaps, "ldap.company.com", "636"},
+			{"LDAPS://ldap.company.com:1636/o=company", ProtocolLdaps, "ldap.company.com", "1636"},
+			{"ldap://10.0.0.1:10389", ProtocolLdap, "10.0.0.1", "10389"},
+			{"ldaps://[fd00::1]:1636", ProtocolLdaps, "fd00::1", "1636"},
+			{"ldap://[fd00::1]", ProtocolLdap, "fd00::1", "389"},
+			{"ldapi://%2Fvar%2Frun%2Fslapd%2Fldapi", ProtocolLdapi, "/var/run/slapd/ldapi", ""},
+		}
+		for _, test := range tests {
+			config := testConfig
+			config.URL = test.url
+			config, cErr := config.withURL()
+			assert.Nil(t, cErr, test.url)
+			assert.Equal(t, test.protocol, config.Protocol, test.url)
+			assert.Equal(t, test.hostname, config.Hostname, test.url)
+			assert.Equal(t, test.port, config.Port, test.url)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, url := range []string{"ldap.company.com:389", "http://ldap.company.com", "ldap://[fd00::1", "ldapi://%zz"} {
+			config := testConfig
+			config.URL = url
+			_, cErr := config.withURL()
+			assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code, url)
+		}
+	})
+}
+
+func TestClient_validate(t *testing.T) {
+	t.Run("ldapi without hostname and port", func(t *testing.T) {
+		config := testConfig
+		config.Protocol = ProtocolLdapi
+		config.Hostname = ""
+		config.Port = ""
+		client := NewClient(config)
+		assert.Nil(t, client.validate(client.getConfig()))
+	})
+
+	t.Run("srv domain without hostname and port", func(t *testing.T) {
+		client := NewClient(testSRVConfig)
+		assert.Nil(t, client.validate(client.getConfig()))
+	})
+
+	t.Run("srv domain with ldapi", func(t *testing.T) {
+		config := testSRVConfig
+		config.Protocol = ProtocolLdapi
+		client := NewClient(config)
+		cErr := client.validate(client.getConfig())
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("ldap without port", func(t *testing.T) {
+		config := testConfig
+		config.Protocol = ProtocolLdap
+		config.Port = ""
+		client := NewClient(config)
+		cErr := client.validate(client.getConfig())
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestClient_dial(t *testing.T) {
+	t.Run("custom dialer error", func(t *testing.T) {
+		var gotNetwork, gotAddress string
+		dialer := DialerFunc(func(network, address string) (net.Conn, error) {
+			gotNetwork, gotAddress = network, address
+			return nil, err.New("proxy unreachable")
+		})
+		client := NewClient(testConfig, WithDialer(dialer))
+
+		_, cErr := client.connect()
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+		assert.Contains(t, cErr.Message, "proxy unreachable")
+		assert.Equal(t, "tcp", gotNetwork)
+		assert.Equal(t, "ldap.company.com:636", gotAddress)
+	})
+
+	t.Run("custom dialer transport", func(t *testing.T) {
+		var gotNetwork, gotAddress string
+		dialer := DialerFunc(func(network, address string) (net.Conn, error) {
+			gotNetwork, gotAddress = network, address
+			server, conn := net.Pipe()
+			server.Close()
+			return conn, nil
+		})
+		config := testConfig
+		config.Protocol = ProtocolLdapi
+		config.Hostname = "/tmp/slapd.sock"
+		client := NewClient(config, WithDialer(dialer))
+
+		_, cErr := client.connect()
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+		assert.Equal(t, "unix", gotNetwork)
+		assert.Equal(t, "/tmp/slapd.sock", gotAddress)
+	})
+}
@@ -0,0 +1,110 @@
+package ldap
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	srvService = "ldap"
+	srvProto   = "tcp"
+	srvFormat  = "_%s._%s.%s"
+
+	srvLookupErrMsg         = "Unable to resolve the LDAP servers of the domain '%s': %v"
+	srvNoServersErrMsg      = "No LDAP servers are published for the domain '%s'"
+	srvLdapiErrMsg          = "The SRVDomain cannot be used with the ldapi protocol"
+	srvServerFailedMsg      = "Connection to the LDAP server %s failed, trying the next server: %v"
+	srvServersDiscoveredMsg = "Discovered the LDAP servers %v of the domain '%s'"
+)
+
+// LookupSRVFunc resolves the SRV records of a service, see net.LookupSRV.
+type LookupSRVFunc func(service, proto, name string) (string, []*net.SRV, error)
+
+// WithLookupSRV overrides the function which resolves the SRV records of the SRVDomain set in the client Config,
+// e.g. to query a specific DNS server using a net.Resolver. Defaults to net.LookupSRV.
+func WithLookupSRV(lookupSRV LookupSRVFunc) ClientOption {
+	return func(c *Client) {
+		c.lookupSRV = lookupSRV
+	}
+}
+
+// srvName returns the name of the SRV records of the LDAP servers of the SRVDomain, e.g. _ldap._tcp.company.com.
+func (cnf Config) srvName() string {
+	return fmt.Sprintf(srvFormat, srvService, srvProto, cnf.SRVDomain)
+}
+
+// server returns the LDAP URL of the server or the name of the SRV records if the servers are discovered.
+func (cnf Config) server() string {
+	if cnf.SRVDomain != "" {
+		return cnf.srvName()
+	}
+	return cnf.url()
+}
+
+// discoverServers resolves the SRV records of the SRVDomain set in the client Config and returns a Config for every
+// LDAP server, ordered by priority and randomized by weight within a priority as described in RFC 2782.
+// The port of the SRV record is used unless a Port is set in the client Config, e.g. 636 for the ldaps protocol.
+func (c *Client) discoverServers(config Config) ([]Config, *Error) {
+	lookupSRV := c.lookupSRV
+	if lookupSRV == nil {
+		lookupSRV = net.LookupSRV
+	}
+	_, records, err := lookupSRV(srvService, srvProto, config.SRVDomain)
+	if err != nil {
+		return nil, WrapError(errors.InternalServerError(fmt.Sprintf(srvLookupErrMsg, config.SRVDomain, err)))
+	}
+	var servers []Config
+	var urls []string
+	for _, record := range records {
+		// a single record with the target "." means the service is not available in the domain
+		target := strings.TrimSuffix(record.Target, ".")
+		if target == "" {
+			continue
+		}
+		server := config
+		server.SRVDomain = ""
+		server.Hostname = target
+		if server.Port == "" {
+			server.Port = strconv.Itoa(int(record.Port))
+		}
+		servers = append(servers, server)
+		urls = append(urls, server.url())
+	}
+	if len(servers) == 0 {
+		return nil, WrapError(errors.InternalServerError(fmt.Sprintf(srvNoServersErrMsg, config.SRVDomain)))
+	}
+	logger.Debug(fmt.Sprintf(srvServersDiscoveredMsg, urls, config.SRVDomain))
+	return servers, nil
+}
+
+// dialDiscovered opens a connection with the first LDAP server of the SRVDomain which is reachable. The servers are
+// tried in the order of their priority and weight, the error of the last server is returned if none is reachable.
+// Every server which is not reachable counts as a retry in the stats. A failure to resolve the servers counts as a
+// network error for the circuit breaker.
+func (c *Client) dialDiscovered(config Config, stats *connectionStats) (*ldap.Conn, *Error) {
+	servers, cErr := c.discoverServers(config)
+	if cErr != nil {
+		// no server is reachable if the servers cannot be resolved, which also fails the probe of a half-open circuit
+		c.breaker.record(ldap.NewError(ldap.ErrorNetwork, fmt.Errorf("%s", cErr.Message)))
+		return nil, cErr
+	}
+	var err error
+	for i, server := range servers {
+		var conn *ldap.Conn
+		stats.server = server.url()
+		if conn, err = c.dialServer(server); err == nil {
+			stats.retries += i
+			return conn, nil
+		}
+		logger.Debug(fmt.Sprintf(srvServerFailedMsg, server.url(), err))
+	}
+	stats.retries += len(servers) - 1
+	c.breaker.record(err)
+	return nil, c.handleLdapError(err)
+}
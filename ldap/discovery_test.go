@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	err "errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSRVConfig = func() Config {
+		config := testConfig
+		config.Protocol = ProtocolLdap
+		config.Hostname = ""
+		config.Port = ""
+		config.SRVDomain = "company.com"
+		return config
+	}()
+
+	testSRVRecords = []*net.SRV{
+		{Target: "dc1.company.com.", Port: 389, Priority: 0, Weight: 100},
+		{Target: "dc2.company.com.", Port: 3268, Priority: 0, Weight: 50},
+		{Target: "dc3.company.com.", Port: 389, Priority: 10, Weight: 0},
+	}
+
+	testLookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		return "_ldap._tcp." + name + ".", testSRVRecords, nil
+	}
+)
+
+func TestWithLookupSRV(t *testing.T) {
+	client := NewClient(testSRVConfig, WithLookupSRV(testLookupSRV))
+	assert.NotNil(t, client.lookupSRV)
+}
+
+func TestClient_discoverServers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var gotService, gotProto, gotName string
+		client := NewClient(testSRVConfig, WithLookupSRV(func(service, proto, name string) (string, []*net.SRV, error) {
+			gotService, gotProto, gotName = service, proto, name
+			return testLookupSRV(service, proto, name)
+		}))
+
+		servers, cErr := client.discoverServers(client.getConfig())
+		assert.Nil(t, cErr)
+		assert.Equal(t, "ldap", gotService)
+		assert.Equal(t, "tcp", gotProto)
+		assert.Equal(t, "company.com", gotName)
+		assert.Len(t, servers, 3)
+		assert.Equal(t, "ldap://dc1.company.com:389", servers[0].url())
+		assert.Equal(t, "ldap://dc2.company.com:3268", servers[1].url())
+		assert.Equal(t, "ldap://dc3.company.com:389", servers[2].url())
+		assert.Empty(t, servers[0].SRVDomain)
+	})
+
+	t.Run("port override", func(t *testing.T) {
+		config := testSRVConfig
+		config.Protocol = ProtocolLdaps
+		config.Port = "636"
+		client := NewClient(config, WithLookupSRV(testLookupSRV))
+
+		servers, cErr := client.discoverServers(client.getConfig())
+		assert.Nil(t, cErr)
+		assert.Equal(t, "ldaps://dc2.company.com:636", servers[1].url())
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		client := NewClient(testSRVConfig, WithLookupSRV(func(string, string, string) (string, []*net.SRV, error) {
+			return "", nil, err.New("no such host")
+		}))
+
+		servers, cErr := client.discoverServers(client.getConfig())
+		assert.Nil(t, servers)
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+		assert.Contains(t, cErr.Message, "no such host")
+	})
+
+	t.Run("service not available", func(t *testing.T) {
+		client := NewClient(testSRVConfig, WithLookupSRV(func(string, string, string) (string, []*net.SRV, error) {
+			return "", []*net.SRV{{Target: "."}}, nil
+		}))
+
+		servers, cErr := client.discoverServers(client.getConfig())
+		assert.Nil(t, servers)
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+	})
+}
+
+func TestClient_dialDiscovered(t *testing.T) {
+	t.Run("failover", func(t *testing.T) {
+		var addresses []string
+		dialer := DialerFunc(func(network, address string) (net.Conn, error) {
+			addresses = append(addresses, address)
+			if len(addresses) == 1 {
+				return nil, err.New("connection refused")
+			}
+			server, conn := net.Pipe()
+			server.Close()
+			return conn, nil
+		})
+		client := NewClient(testSRVConfig, WithDialer(dialer), WithLookupSRV(testLookupSRV))
+
+		// the connection is established with the second server, the bind fails on the closed transport
+		_, cErr := client.connect()
+		assert.NotNil(t, cErr)
+		assert.Equal(t, []string{"dc1.company.com:389", "dc2.company.com:3268"}, addresses)
+	})
+
+	t.Run("all servers unreachable", func(t *testing.T) {
+		var addresses []string
+		dialer := DialerFunc(func(network, address string) (net.Conn, error) {
+			addresses = append(addresses, address)
+			return nil, err.New("connection refused")
+		})
+		client := NewClient(testSRVConfig, WithDialer(dialer), WithLookupSRV(testLookupSRV))
+
+		_, cErr := client.connect()
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+		assert.Contains(t, cErr.Message, "connection refused")
+		assert.Len(t, addresses, 3)
+	})
+
+	t.Run("discovery failure while the circuit is half-open", func(t *testing.T) {
+		lookups := 0
+		lookupSRV := func(service, proto, name string) (string, []*net.SRV, error) {
+			lookups++
+			if lookups == 1 {
+				return "", nil, err.New("no such host")
+			}
+			return testLookupSRV(service, proto, name)
+		}
+		dialer := DialerFunc(func(network, address string) (net.Conn, error) {
+			return nil, err.New("connection refused")
+		})
+		now := time.Now()
+		client := NewClient(testSRVConfig, WithDialer(dialer), WithLookupSRV(lookupSRV),
+			WithCircuitBreaker(1, time.Minute))
+		client.breaker.now = func() time.Time { return now }
+		client.breaker.record(ldapNetworkErr)
+
+		// the probe let through after the cooldown fails to resolve the servers, which opens the circuit again
+		now = now.Add(time.Minute)
+		_, cErr := client.connect()
+		assert.Contains(t, cErr.Message, "no such host")
+		assert.Equal(t, CircuitOpen, client.CircuitBreakerState())
+
+		// the next probe is let through once the cooldown elapsed again
+		now = now.Add(time.Minute)
+		_, cErr = client.connect()
+		assert.Contains(t, cErr.Message, "connection refused")
+		assert.Equal(t, 2, lookups)
+	})
+}
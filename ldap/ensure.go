@@ -0,0 +1,78 @@
+package ldap
+
+import (
+	"net/http"
+)
+
+// Ensure creates a user entry in LDAP if it does not exist, or updates the attributes of the existing user entry which
+// differ from the user, so it can be called repeatedly with the same user, e.g. by a provisioning pipeline.
+// params:
+//
+//	user = the desired user entry, the user must contain all the attributes required by Create
+//
+// The password of an existing user is never updated and an empty value removes an optional attribute.
+// The method returns the change which was made, including the ChangeSet of an update, or nil if the user entry already
+// matches the user.
+// The method returns an error:
+//   - if any validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Ensure(user User) (*UserChange, *Error) {
+	existing, cErr := um.get(user.Uid)
+	if cErr != nil {
+		if cErr.Status != http.StatusNotFound {
+			return nil, cErr
+		}
+		if cErr := um.Create(user); cErr != nil {
+			return nil, cErr
+		}
+		return &UserChange{Action: UserChangeCreate, Uid: user.Uid}, nil
+	}
+	changed := changedUserAttributes(*existing, user, um.attributes())
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	changes, cErr := um.update(*existing, user, changed)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return &UserChange{Action: UserChangeUpdate, Uid: existing.Uid, Attributes: changed, Changes: changes}, nil
+}
+
+// Ensure creates a group entry in LDAP if it does not exist, or adds the missing members to and removes the members
+// which are not listed from the existing group entry, so it can be called repeatedly with the same members.
+// params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists or should be created
+//	memberIds: the ids or the DNs of all the members of the group
+//
+// The method returns the changes which were made, which are empty if the group entry already has the members.
+// If a change fails the changes made until then are returned together with the error.
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) Ensure(cn, ou string, memberIds []string) ([]GroupChange, *Error) {
+	if cErr := gm.validateGroup(cn, ou); cErr != nil {
+		return nil, cErr
+	}
+	groups, cErr := gm.get(cn, ou)
+	if cErr != nil && cErr.Status != http.StatusNotFound {
+		return nil, cErr
+	}
+	current := make(map[string]Group, len(groups))
+	for _, group := range groups {
+		current[memberKey(group.Dn)] = group
+	}
+
+	changes := []GroupChange{}
+	for _, change := range gm.diffGroup(gm.groupSnapshot(GroupDefinition{Cn: cn, Ou: ou, Members: memberIds}), current) {
+		if cErr := gm.applyGroupChange(change); cErr != nil {
+			return changes, cErr
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
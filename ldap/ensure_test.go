@@ -0,0 +1,164 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersManager_Ensure(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameAdd, um.getAddRequest(testUser1)).Return(nil)
+		ldapMock.On("PasswordModify", um.getPasswordModifyRequest(testUser1.Uid, testUser1.UserPassword,
+			testUser1.UserPassword)).Return(nil, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		change, cErr := client.Users.(UserProvisioner).Ensure(testUser1)
+		assert.Nil(t, cErr)
+		assert.Equal(t, &UserChange{Action: UserChangeCreate, Uid: testUser1.Uid}, change)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser1
+		user.Mail = "new.mail@company.com"
+		updateRequest := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		updateRequest.Replace(mailAttr, []string{user.Mail})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameModify, updateRequest).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		change, cErr := client.Users.(UserProvisioner).Ensure(user)
+		assert.Nil(t, cErr)
+		assert.Equal(t, &UserChange{Action: UserChangeUpdate, Uid: testUser1.Uid, Attributes: []string{mailAttr},
+			Changes: ChangeSet{{Attribute: mailAttr, Old: []string{testUser1.Mail}, New: []string{user.Mail}}}},
+			change)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		change, cErr := client.Users.(UserProvisioner).Ensure(testUser1)
+		assert.Nil(t, cErr)
+		assert.Nil(t, change)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		change, cErr := client.Users.(UserProvisioner).Ensure(testUser1)
+		assert.Nil(t, change)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		change, cErr := client.Users.(UserProvisioner).Ensure(User{})
+		assert.Nil(t, change)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+}
+
+func TestGroupsManager_Ensure(t *testing.T) {
+	memberDn := func(uid string) string {
+		return "uid=" + uid + "," + testConfig.UserBaseDN
+	}
+
+	t.Run("create", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameAdd, gm.getAddRequest(testGroupCn1, testOrganizationUnit1, []string{testUser1.Uid})).
+			Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupProvisioner).Ensure(testGroupCn1, testOrganizationUnit1, []string{"c00001"})
+		assert.Nil(t, cErr)
+		assert.Equal(t, []GroupChange{
+			{Action: GroupChangeCreate, Cn: testGroupCn1, Ou: testOrganizationUnit1, MemberIds: []string{testUser1.Uid}},
+		}, changes)
+	})
+
+	t.Run("converge members", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		addRequest := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		addRequest.Add(uniqueMemberAttr, []string{memberDn(testUser2.Uid)})
+		addRequest.Delete(uniqueMemberAttr, []string{memberDn(noSuchUserGroupMemberCn)})
+		removeRequest := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		removeRequest.Delete(uniqueMemberAttr, []string{memberDn(testUser1.Uid)})
+		removeRequest.Add(uniqueMemberAttr, []string{memberDn(noSuchUserGroupMemberCn)})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult2, nil)
+		ldapMock.On(methodNameModify, addRequest).Return(nil)
+		ldapMock.On(methodNameModify, removeRequest).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupProvisioner).Ensure(testGroupCn1, testOrganizationUnit1, []string{testUser2.Uid})
+		assert.Nil(t, cErr)
+		assert.Equal(t, []GroupChange{
+			{Action: GroupChangeAddMembers, Cn: testGroupCn1, Ou: testOrganizationUnit1, MemberIds: []string{testUser2.Uid}},
+			{Action: GroupChangeRemoveMembers, Cn: testGroupCn1, Ou: testOrganizationUnit1, MemberIds: []string{testUser1.Uid}},
+		}, changes)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult2, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupProvisioner).Ensure(testGroupCn1, testOrganizationUnit1, []string{"c00001"})
+		assert.Nil(t, cErr)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		changes, cErr := client.Groups.(GroupProvisioner).Ensure("", "", nil)
+		assert.Nil(t, changes)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+}
@@ -0,0 +1,254 @@
+package ldap
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	EntitlementFormatJSON EntitlementFormat = "json"
+	EntitlementFormatCSV  EntitlementFormat = "csv"
+
+	mappingsParam = "mappings"
+	formatParam   = "format"
+
+	// entitlementPermissionSeparator separates the permissions of a user for an application in a CSV cell.
+	entitlementPermissionSeparator = ";"
+
+	invalidMappingPatternMsg     = "Invalid group pattern '%s' of the entitlement mapping: %v"
+	missingMappingApplicationMsg = "The entitlement mapping of the group pattern '%s' does not have an application"
+	invalidEntitlementFormatMsg  = "Invalid format '%s'. Valid formats are %v"
+)
+
+// entitlementFormats are the formats an entitlement matrix can be exported in.
+var entitlementFormats = []string{string(EntitlementFormatJSON), string(EntitlementFormatCSV)}
+
+type (
+	// EntitlementFormat is the format an entitlement matrix is exported in.
+	EntitlementFormat string
+
+	// EntitlementMapping maps the groups whose name matches a pattern to a permission of an application.
+	EntitlementMapping struct {
+		// Ou is the name or path of the organizational unit of the groups. Optional, the groups of all the
+		// organizational units are mapped by default.
+		Ou string `json:"ou" yaml:"ou"`
+		// GroupPattern is a regular expression the name of the groups must match, e.g. "^nexus-(.+)-(read|write)$".
+		GroupPattern string `json:"groupPattern" yaml:"groupPattern"`
+		// Application is the application the members of the groups are entitled to, e.g. "nexus". The submatches of
+		// the GroupPattern can be referenced as $1, $2, etc.
+		Application string `json:"application" yaml:"application"`
+		// Permission is the permission the members of the groups have in the application, e.g. "$2". The
+		// submatches of the GroupPattern can be referenced as $1, $2, etc. Defaults to the name of the group.
+		Permission string `json:"permission" yaml:"permission"`
+	}
+
+	// EntitlementExportOptions configure an ExportEntitlements.
+	EntitlementExportOptions struct {
+		// Mappings map the groups to the applications and their permissions. A group can match several mappings.
+		Mappings []EntitlementMapping
+		// Format is the format of the export. Defaults to EntitlementFormatJSON.
+		Format EntitlementFormat
+		// IncludeUnentitled also exports the users who are not entitled to any application.
+		IncludeUnentitled bool
+	}
+
+	// UserEntitlements represents a row of an entitlement matrix, the permissions of a user per application.
+	UserEntitlements struct {
+		Uid          string              `json:"uid"`
+		DisplayName  string              `json:"displayName,omitempty"`
+		Mail         string              `json:"mail,omitempty"`
+		Entitlements map[string][]string `json:"entitlements"`
+	}
+
+	// entitlementMatcher is an EntitlementMapping with its compiled GroupPattern.
+	entitlementMatcher struct {
+		EntitlementMapping
+		pattern *regexp.Regexp
+	}
+)
+
+// ExportEntitlements writes the entitlement matrix of the users to w, the permissions of every user per application
+// derived from the groups the user is a direct member of, e.g. for access-review tooling.
+// params:
+//
+//	w 		= the writer the matrix is written to
+//	opts 	= the mappings of the groups to the applications and the format of the export
+//
+// With EntitlementFormatJSON a UserEntitlements JSON object is written per user as newline delimited JSON. With
+// EntitlementFormatCSV the first row is a header with the uid, displayName and mail columns followed by a column per
+// application, the permissions of a user for an application are separated by ";".
+// The groups and then the users are retrieved page by page. Only the entitlements of the users are kept in memory,
+// the users are written as they are received.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+//   - if writing to w fails
+func (gm *groupsManager) ExportEntitlements(w io.Writer, opts EntitlementExportOptions) *Error {
+	matchers, cErr := opts.validate()
+	if cErr != nil {
+		return cErr
+	}
+	entitlements, applications, cErr := gm.getEntitlements(matchers)
+	if cErr != nil {
+		return cErr
+	}
+
+	um := &usersManager{Client: gm.Client}
+	sr := um.getUsersSearchRequest(gm.Client.getConfig().usersFilter())
+	sr.Attributes = []string{userIdAttr, displayNameAttr, mailAttr}
+	var writeRow func(row UserEntitlements) *Error
+	var flush func() *Error
+	if opts.Format == EntitlementFormatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(append([]string{userIdAttr, displayNameAttr, mailAttr}, applications...)); err != nil {
+			return WrapError(errors.InternalServerErrorf(csvWriteErrMsg, err))
+		}
+		writeRow = func(row UserEntitlements) *Error {
+			record := []string{row.Uid, row.DisplayName, row.Mail}
+			for _, application := range applications {
+				record = append(record, strings.Join(row.Entitlements[application], entitlementPermissionSeparator))
+			}
+			if err := cw.Write(record); err != nil {
+				return WrapError(errors.InternalServerErrorf(csvWriteErrMsg, err))
+			}
+			return nil
+		}
+		flush = func() *Error {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return WrapError(errors.InternalServerErrorf(csvWriteErrMsg, err))
+			}
+			return nil
+		}
+	} else {
+		enc := json.NewEncoder(w)
+		writeRow = func(row UserEntitlements) *Error {
+			return encodeJSONLine(enc, row, nil)
+		}
+		flush = func() *Error {
+			return nil
+		}
+	}
+
+	cErr = gm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, e := range result.Entries {
+			userEntitlements := entitlements[dnKey(e.DN)]
+			if len(userEntitlements) == 0 && !opts.IncludeUnentitled {
+				continue
+			}
+			row := UserEntitlements{
+				Uid:          e.GetAttributeValue(userIdAttr),
+				DisplayName:  e.GetAttributeValue(displayNameAttr),
+				Mail:         e.GetAttributeValue(mailAttr),
+				Entitlements: map[string][]string{},
+			}
+			for application, permissions := range userEntitlements {
+				sorted := append([]string{}, permissions...)
+				sort.Strings(sorted)
+				row.Entitlements[application] = sorted
+			}
+			if cErr := writeRow(row); cErr != nil {
+				return cErr
+			}
+		}
+		return flush()
+	})
+	if cErr != nil {
+		return cErr
+	}
+	return flush()
+}
+
+// getEntitlements retrieves the groups page by page and returns the permissions per application of the members of
+// the groups matched by the matchers, keyed by the dn of the members, and the sorted names of the applications.
+func (gm *groupsManager) getEntitlements(matchers []entitlementMatcher) (map[string]map[string][]string, []string,
+	*Error) {
+	entitlements := map[string]map[string][]string{}
+	applications := map[string]bool{}
+	sr := gm.getSearchRequest("", "", gm.Client.getConfig().groupsFilter())
+	sr.Attributes = []string{CommonNameAttr, uniqueMemberAttr}
+	cErr := gm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, group := range gm.parseSearchResult(result) {
+			for _, matcher := range matchers {
+				application, permission, ok := matcher.match(group)
+				if !ok {
+					continue
+				}
+				applications[application] = true
+				for _, member := range group.Members {
+					key := dnKey(member)
+					if entitlements[key] == nil {
+						entitlements[key] = map[string][]string{}
+					}
+					if !slice.EntryExists(entitlements[key][application], permission) {
+						entitlements[key][application] = append(entitlements[key][application], permission)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if cErr != nil {
+		return nil, nil, cErr
+	}
+	names := make([]string, 0, len(applications))
+	for application := range applications {
+		names = append(names, application)
+	}
+	sort.Strings(names)
+	return entitlements, names, nil
+}
+
+// match returns the application and the permission the members of the group are entitled to and whether the group
+// is matched.
+func (m entitlementMatcher) match(group Group) (string, string, bool) {
+	if m.Ou != "" && !strings.EqualFold(m.Ou, group.Ou) && !strings.EqualFold(m.Ou, strings.Join(group.OuPath,
+		OuPathSeparator)) {
+		return "", "", false
+	}
+	submatches := m.pattern.FindStringSubmatchIndex(group.Cn)
+	if submatches == nil {
+		return "", "", false
+	}
+	application := string(m.pattern.ExpandString(nil, m.Application, group.Cn, submatches))
+	permission := group.Cn
+	if m.Permission != "" {
+		permission = string(m.pattern.ExpandString(nil, m.Permission, group.Cn, submatches))
+	}
+	return application, permission, application != ""
+}
+
+// validate checks if the options are valid and returns the compiled mappings.
+func (opts EntitlementExportOptions) validate() ([]entitlementMatcher, *Error) {
+	if len(opts.Mappings) == 0 {
+		return nil, missingParametersError([]string{mappingsParam})
+	}
+	if opts.Format != "" && !slice.EntryExists(entitlementFormats, string(opts.Format)) {
+		return nil, invalidParameterError(formatParam, fmt.Sprintf(invalidEntitlementFormatMsg, opts.Format,
+			entitlementFormats))
+	}
+	matchers := make([]entitlementMatcher, 0, len(opts.Mappings))
+	for _, mapping := range opts.Mappings {
+		pattern, err := regexp.Compile(mapping.GroupPattern)
+		if err != nil {
+			return nil, invalidParameterError(mappingsParam, fmt.Sprintf(invalidMappingPatternMsg,
+				mapping.GroupPattern, err))
+		}
+		if strings.TrimSpace(mapping.Application) == "" {
+			return nil, invalidParameterError(mappingsParam, fmt.Sprintf(missingMappingApplicationMsg,
+				mapping.GroupPattern))
+		}
+		matchers = append(matchers, entitlementMatcher{EntitlementMapping: mapping, pattern: pattern})
+	}
+	return matchers, nil
+}
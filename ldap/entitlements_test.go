@@ -0,0 +1,120 @@
+package ldap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var testEntitlementMappings = []EntitlementMapping{
+	{GroupPattern: "^nexus-(.+)-(read|write)$", Application: "nexus", Permission: "$1:$2"},
+	{Ou: "jenkins", GroupPattern: "^jenkins-", Application: "jenkins"},
+}
+
+func getEntitlementGroupEntry(cn, ou string, members ...string) *ldap.Entry {
+	return ldap.NewEntry("cn="+cn+",ou="+ou+",ou=projects,o=company",
+		map[string][]string{CommonNameAttr: {cn}, uniqueMemberAttr: members})
+}
+
+func getEntitlementUserEntry(uid, mail string) *ldap.Entry {
+	return ldap.NewEntry("uid="+uid+",ou=users,o=company", map[string][]string{userIdAttr: {uid}, mailAttr: {mail}})
+}
+
+func mockEntitlementSearches(ldapMock *mocks.Client, client *Client) {
+	ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, searchRequestWithBaseDN("ou=projects,o=company")).
+		Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			getEntitlementGroupEntry("nexus-maven-read", "nexus", "uid=C00001,ou=users,o=company",
+				"UID=C00002, OU=users, O=company"),
+			getEntitlementGroupEntry("nexus-maven-write", "nexus", "uid=C00001,ou=users,o=company"),
+			getEntitlementGroupEntry("jenkins-admins", "jenkins", "uid=C00002,ou=users,o=company"),
+			getEntitlementGroupEntry("jenkins-admins", "other", "uid=C00001,ou=users,o=company"),
+		}}, nil)
+	ldapMock.On(methodNameSearch, searchRequestWithBaseDN(client.Config.UserBaseDN)).
+		Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			getEntitlementUserEntry("C00001", "john.doe@company.com"),
+			getEntitlementUserEntry("C00002", "jane.doe@company.com"),
+			getEntitlementUserEntry("C00003", "abc@company.com"),
+		}}, nil)
+	ldapMock.On(methodNameClose).Return(nil)
+}
+
+func TestGroupsManager_ExportEntitlements(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mockEntitlementSearches(ldapMock, client)
+
+		var buf bytes.Buffer
+		assert.Nil(t, client.Groups.(GroupsExporter).ExportEntitlements(&buf, EntitlementExportOptions{Mappings: testEntitlementMappings}))
+		assert.Equal(t, `{"uid":"C00001","mail":"john.doe@company.com","entitlements":{"nexus":["maven:read","maven:write"]}}
+{"uid":"C00002","mail":"jane.doe@company.com","entitlements":{"jenkins":["jenkins-admins"],"nexus":["maven:read"]}}
+`, buf.String())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mockEntitlementSearches(ldapMock, client)
+
+		var buf bytes.Buffer
+		assert.Nil(t, client.Groups.(GroupsExporter).ExportEntitlements(&buf, EntitlementExportOptions{
+			Mappings:          testEntitlementMappings,
+			Format:            EntitlementFormatCSV,
+			IncludeUnentitled: true,
+		}))
+		assert.Equal(t, `uid,displayName,mail,jenkins,nexus
+C00001,,john.doe@company.com,,maven:read;maven:write
+C00002,,jane.doe@company.com,jenkins-admins,maven:read
+C00003,,abc@company.com,,
+`, buf.String())
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN("ou=projects,o=company")).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var buf bytes.Buffer
+		assert.NotNil(t, client.Groups.(GroupsExporter).ExportEntitlements(&buf, EntitlementExportOptions{Mappings: testEntitlementMappings}))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("missing mappings", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.Groups.(GroupsExporter).ExportEntitlements(&bytes.Buffer{}, EntitlementExportOptions{})
+		assert.Equal(t, missingParametersError([]string{mappingsParam}), cErr)
+	})
+
+	t.Run("invalid mapping", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.Groups.(GroupsExporter).ExportEntitlements(&bytes.Buffer{}, EntitlementExportOptions{
+			Mappings: []EntitlementMapping{{GroupPattern: "nexus-(", Application: "nexus"}},
+		})
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(mappingsParam).Code)
+
+		cErr = client.Groups.(GroupsExporter).ExportEntitlements(&bytes.Buffer{}, EntitlementExportOptions{
+			Mappings: []EntitlementMapping{{GroupPattern: "^nexus-"}},
+		})
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(mappingsParam).Code)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.Groups.(GroupsExporter).ExportEntitlements(&bytes.Buffer{}, EntitlementExportOptions{
+			Mappings: testEntitlementMappings,
+			Format:   "xlsx",
+		})
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(formatParam).Code)
+	})
+}
@@ -0,0 +1,296 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	noAttributes     = "1.1"
+	allEntriesFilter = "(objectClass=*)"
+
+	entryNotFoundMsg         = "Entry with dn = '%s' was not found"
+	subtreeDeleteFallbackMsg = "The subtree delete control is not supported by the LDAP server, deleting the entries of '%s' one by one"
+)
+
+type (
+	// EntriesManager describes the interface which needs to be implemented for performing operations on
+	// arbitrary LDAP entries identified by their distinguished name.
+	EntriesManager interface {
+		Get(dn string, attributes ...string) (*ldap.Entry, *Error)
+		Search(baseDN, searchFilter string, attributes ...string) ([]*ldap.Entry, *Error)
+		Add(dn string, attributes map[string][]string, opts ...RequestOption) *Error
+		Modify(dn string, attributes map[string][]string, opts ...RequestOption) *Error
+		Delete(dn string, opts ...RequestOption) *Error
+		DeleteSubtree(dn string) *Error
+	}
+
+	// entriesManager implements the EntriesManager interface.
+	entriesManager struct {
+		Client *Client
+	}
+)
+
+// Get retrieves an entry from LDAP.
+// params:
+//
+//	dn = the distinguished name of the entry
+//	attributes = the attributes of the entry to retrieve. Defaults to all the user attributes.
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the entry is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (em *entriesManager) Get(dn string, attributes ...string) (*ldap.Entry, *Error) {
+	if strings.TrimSpace(dn) == "" {
+		return nil, missingParametersError([]string{"dn"})
+	}
+	result, cErr := em.Client.doLDAPSearch(em.getSearchRequest(dn, ldap.ScopeBaseObject, allEntriesFilter,
+		attributes))
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(entryNotFoundMsg, dn)))
+		}
+		return nil, cErr
+	}
+	if len(result.Entries) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(entryNotFoundMsg, dn)))
+	}
+	return result.Entries[0], nil
+}
+
+// Search retrieves the entries from LDAP below the baseDN which match the searchFilter.
+// params:
+//
+//	baseDN = the distinguished name of the entry the search starts from, the entry itself is included
+//	searchFilter = the LDAP search filter, e.g. "(objectClass=applicationProcess)"
+//	attributes = the attributes of the entries to retrieve. Defaults to all the user attributes.
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the baseDN is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (em *entriesManager) Search(baseDN, searchFilter string, attributes ...string) ([]*ldap.Entry, *Error) {
+	var missingParams []string
+	if strings.TrimSpace(baseDN) == "" {
+		missingParams = append(missingParams, "dn")
+	}
+	if strings.TrimSpace(searchFilter) == "" {
+		missingParams = append(missingParams, searchFilterParam)
+	}
+	if len(missingParams) > 0 {
+		return nil, missingParametersError(missingParams)
+	}
+	result, cErr := em.Client.doLDAPSearch(em.getSearchRequest(baseDN, ldap.ScopeWholeSubtree, searchFilter,
+		attributes))
+	if cErr != nil {
+		return nil, cErr
+	}
+	return result.Entries, nil
+}
+
+// Add adds a new entry to LDAP.
+// params:
+//
+//	dn = the distinguished name of the entry
+//	attributes = the attributes of the entry including the objectClass attribute
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the entry already exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (em *entriesManager) Add(dn string, attributes map[string][]string, opts ...RequestOption) *Error {
+	if strings.TrimSpace(dn) == "" {
+		return missingParametersError([]string{"dn"})
+	}
+	if len(attributes[objectClassAttr]) == 0 {
+		return missingParametersError([]string{objectClassAttr})
+	}
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ar := ldap.NewAddRequest(dn, nil)
+	for _, name := range names {
+		ar.Attribute(name, attributes[name])
+	}
+	return em.Client.doLDAPAdd(ar, opts...)
+}
+
+// Modify replaces the values of attributes of an entry in LDAP.
+// params:
+//
+//	dn = the distinguished name of the entry
+//	attributes = the attributes to replace, an attribute without values is removed from the entry
+//	opts = the options of the request, e.g. WithManageDsaIT to modify a referral object or WithRelaxRules to modify
+//	operational attributes
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the entry is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (em *entriesManager) Modify(dn string, attributes map[string][]string, opts ...RequestOption) *Error {
+	if strings.TrimSpace(dn) == "" {
+		return missingParametersError([]string{"dn"})
+	}
+	if len(attributes) == 0 {
+		return missingParametersError([]string{attributesParam})
+	}
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	mr := ldap.NewModifyRequest(dn, nil)
+	for _, name := range names {
+		mr.Replace(name, attributes[name])
+	}
+	if cErr := em.Client.doLDAPModify(mr, opts...); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(entryNotFoundMsg, dn)))
+		}
+		return cErr
+	}
+	return nil
+}
+
+// Delete deletes a leaf entry from LDAP, use DeleteSubtree to delete an entry including the entries below it.
+// params:
+//
+//	dn = the distinguished name of the entry
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the entry is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (em *entriesManager) Delete(dn string, opts ...RequestOption) *Error {
+	if strings.TrimSpace(dn) == "" {
+		return missingParametersError([]string{"dn"})
+	}
+	if cErr := em.Client.doLDAPDelete(ldap.NewDelRequest(dn, nil), opts...); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(entryNotFoundMsg, dn)))
+		}
+		return cErr
+	}
+	return nil
+}
+
+// DeleteSubtree deletes an entry from LDAP including all the entries below it.
+// params:
+//
+//	dn = the distinguished name of the root entry of the subtree
+//
+// The subtree is deleted in a single request using the subtree delete control. If the control is not supported
+// by the LDAP server or the DeleteJournalSize is set in the client Config, the entries of the subtree are deleted one
+// by one starting with the deepest entries, so every entry is recorded in the delete journal.
+// The method returns an error:
+//   - if a validation fails
+//   - if the entry is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (em *entriesManager) DeleteSubtree(dn string) *Error {
+	if strings.TrimSpace(dn) == "" {
+		return missingParametersError([]string{"dn"})
+	}
+	var cErr *Error
+	if em.Client.getConfig().DeleteJournalSize > 0 {
+		cErr = em.deleteSubtreeEntries(dn)
+	} else {
+		cErr = em.Client.doLDAPDelete(ldap.NewDelRequest(dn, nil), WithControls(ldap.NewControlSubtreeDelete()))
+	}
+	if cErr != nil && strings.Contains(cErr.Message,
+		ldap.LDAPResultCodeMap[ldap.LDAPResultUnavailableCriticalExtension]) {
+		logger.Info(fmt.Sprintf(subtreeDeleteFallbackMsg, dn))
+		cErr = em.deleteSubtreeEntries(dn)
+	}
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(entryNotFoundMsg, dn)))
+		}
+		return cErr
+	}
+	return nil
+}
+
+// deleteSubtreeEntries searches all the entries of the subtree and deletes them depth-first, so every entry is a
+// leaf entry at the moment it is deleted.
+// The subtree is not deleted if a protected entry may be within it.
+func (em *entriesManager) deleteSubtreeEntries(dn string) *Error {
+	if cErr := em.Client.checkProtectedSubtree(dn, OperationDelete); cErr != nil {
+		return cErr
+	}
+	result, cErr := em.Client.doLDAPSearch(em.getSubtreeSearchRequest(dn))
+	if cErr != nil {
+		return cErr
+	}
+	for _, entryDN := range em.sortByDepth(result.Entries) {
+		if cErr := em.Client.doLDAPDelete(ldap.NewDelRequest(entryDN, nil)); cErr != nil {
+			return cErr
+		}
+	}
+	return nil
+}
+
+// getSearchRequest returns a ldap search request for the entries within the scope of the baseDN which match the
+// searchFilter.
+func (em *entriesManager) getSearchRequest(baseDN string, scope int, searchFilter string,
+	attributes []string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		baseDN,
+		scope,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		searchFilter,
+		attributes,
+		nil,
+	)
+}
+
+// getSubtreeSearchRequest returns a ldap search request to get the distinguished names of all the entries
+// of a subtree.
+func (em *entriesManager) getSubtreeSearchRequest(dn string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		allEntriesFilter,
+		[]string{noAttributes},
+		nil,
+	)
+}
+
+// sortByDepth returns the distinguished names of the entries ordered from the deepest entry to the root entry.
+func (em *entriesManager) sortByDepth(entries []*ldap.Entry) []string {
+	depths := make(map[string]int, len(entries))
+	dns := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		depth := strings.Count(entry.DN, ",")
+		if parsedDN, err := ldap.ParseDN(entry.DN); err == nil {
+			depth = len(parsedDN.RDNs)
+		}
+		depths[entry.DN] = depth
+		dns = append(dns, entry.DN)
+	}
+	sort.SliceStable(dns, func(i, j int) bool {
+		return depths[dns[i]] > depths[dns[j]]
+	})
+	return dns
+}
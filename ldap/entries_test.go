@@ -0,0 +1,281 @@
+package ldap
+
+import (
+	err "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testSubtreeDN = "ou=test-ou-1,ou=projects,o=company"
+
+	ldapUnavailableCriticalExtensionErr = ldap.NewError(ldap.LDAPResultUnavailableCriticalExtension, err.New(""))
+
+	getSubtreeSearchResult = &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry(testSubtreeDN, nil),
+			ldap.NewEntry("cn=group-1,"+testSubtreeDN, nil),
+			ldap.NewEntry("ou=nested,"+testSubtreeDN, nil),
+			ldap.NewEntry("cn=group-2,ou=nested,"+testSubtreeDN, nil),
+		},
+	}
+)
+
+func TestWithEntriesManager(t *testing.T) {
+	em := &entriesManager{}
+	client := NewClient(testConfig, WithEntriesManager(em))
+	assert.Equal(t, em, client.Entries)
+}
+
+func TestEntriesManager_DeleteSubtree(t *testing.T) {
+	getTreeDeleteRequest := func(dn string) *ldap.DelRequest {
+		return ldap.NewDelRequest(dn, []ldap.Control{ldap.NewControlSubtreeDelete()})
+	}
+
+	t.Run("validate", func(t *testing.T) {
+		client := NewClient(testConfig)
+		cErr := client.Entries.DeleteSubtree(" ")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("subtree delete control", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, getTreeDeleteRequest(testSubtreeDN)).Return(nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.DeleteSubtree(testSubtreeDN)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("depth-first delete", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		em := entriesManager{Client: client}
+
+		var deleted []string
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, getTreeDeleteRequest(testSubtreeDN)).
+			Return(ldapUnavailableCriticalExtensionErr).Once()
+		ldapMock.On(methodNameSearch, em.getSubtreeSearchRequest(testSubtreeDN)).
+			Return(getSubtreeSearchResult, nil).Once()
+		for _, entry := range getSubtreeSearchResult.Entries {
+			dn := entry.DN
+			ldapMock.On(methodNameDelete, ldap.NewDelRequest(dn, nil)).Return(nil).Once().
+				Run(func(_ mock.Arguments) { deleted = append(deleted, dn) })
+		}
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.DeleteSubtree(testSubtreeDN)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{
+			"cn=group-2,ou=nested," + testSubtreeDN,
+			"cn=group-1," + testSubtreeDN,
+			"ou=nested," + testSubtreeDN,
+			testSubtreeDN,
+		}, deleted)
+	})
+
+	t.Run("entry not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, getTreeDeleteRequest(testSubtreeDN)).Return(ldapNoSuchObjectErr).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.DeleteSubtree(testSubtreeDN)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(entryNotFoundMsg, testSubtreeDN), cErr.Message)
+	})
+
+	t.Run("depth-first delete error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		em := entriesManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, getTreeDeleteRequest(testSubtreeDN)).
+			Return(ldapUnavailableCriticalExtensionErr).Once()
+		ldapMock.On(methodNameSearch, em.getSubtreeSearchRequest(testSubtreeDN)).
+			Return(getSubtreeSearchResult, nil).Once()
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest("cn=group-2,ou=nested,"+testSubtreeDN, nil)).
+			Return(ldapInsufficientRightsErr).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.DeleteSubtree(testSubtreeDN)
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+}
+
+func TestEntriesManager_Get(t *testing.T) {
+	em := entriesManager{Client: NewClient(testConfig)}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, em.getSearchRequest(testSubtreeDN, ldap.ScopeBaseObject, allEntriesFilter,
+			[]string{OrganizationalUnitAttr})).Return(getSubtreeSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		entry, cErr := client.Entries.Get(testSubtreeDN, OrganizationalUnitAttr)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testSubtreeDN, entry.DN)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		entry, cErr := client.Entries.Get(testSubtreeDN)
+		assert.Nil(t, entry)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(entryNotFoundMsg, testSubtreeDN), cErr.Message)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		entry, cErr := NewClient(testConfig).Entries.Get("")
+		assert.Nil(t, entry)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestEntriesManager_Search(t *testing.T) {
+	em := entriesManager{Client: NewClient(testConfig)}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, em.getSearchRequest(testSubtreeDN, ldap.ScopeWholeSubtree, allEntriesFilter,
+			nil)).Return(getSubtreeSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		entries, cErr := client.Entries.Search(testSubtreeDN, allEntriesFilter)
+		assert.Nil(t, cErr)
+		assert.Len(t, entries, 4)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		entries, cErr := NewClient(testConfig).Entries.Search("", "")
+		assert.Nil(t, entries)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Len(t, cErr.Validation.Fields, 2)
+	})
+}
+
+func TestEntriesManager_Add(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest("cn=request,"+testSubtreeDN, nil)
+		ar.Attribute(CommonNameAttr, []string{"request"})
+		ar.Attribute("description", []string{"some description"})
+		ar.Attribute(objectClassAttr, []string{"applicationProcess", "top"})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.Add("cn=request,"+testSubtreeDN, map[string][]string{
+			objectClassAttr: {"applicationProcess", "top"},
+			CommonNameAttr:  {"request"},
+			"description":   {"some description"},
+		})
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("missing object class", func(t *testing.T) {
+		cErr := NewClient(testConfig).Entries.Add("cn=request,"+testSubtreeDN, map[string][]string{
+			CommonNameAttr: {"request"},
+		})
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(objectClassAttr).Code)
+	})
+}
+
+func TestEntriesManager_Modify(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest("cn=request,"+testSubtreeDN, nil)
+		mr.Replace("description", []string{})
+		mr.Replace("pwdChangedTime", []string{"20261016120000Z"})
+		mr.Controls = []ldap.Control{ldap.NewControlManageDsaIT(true), NewControlRelaxRules()}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.Modify("cn=request,"+testSubtreeDN, map[string][]string{
+			"pwdChangedTime": {"20261016120000Z"},
+			"description":    {},
+		}, WithManageDsaIT(), WithRelaxRules())
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest(testSubtreeDN, nil)
+		mr.Replace("description", []string{"some description"})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.Modify(testSubtreeDN, map[string][]string{"description": {"some description"}})
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(entryNotFoundMsg, testSubtreeDN), cErr.Message)
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		cErr := NewClient(testConfig).Entries.Modify(testSubtreeDN, nil)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(attributesParam).Code)
+	})
+}
+
+func TestEntriesManager_Delete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(testSubtreeDN, nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Entries.Delete(testSubtreeDN))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(testSubtreeDN, nil)).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Entries.Delete(testSubtreeDN)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
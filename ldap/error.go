@@ -0,0 +1,72 @@
+package ldap
+
+import (
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+type (
+	// utilsError is the rest error of go-utils embedded in the Error.
+	utilsError = errors.Error
+
+	// Error is the error returned by the client and the managers. It embeds the rest error of go-utils, so its Code,
+	// Status, Message and TraceId are read like before, and carries the details of the error when they are known.
+	// The details are also reachable with errors.As, e.g.
+	//
+	//	var validationErr *ldap.ValidationError
+	//	if errors.As(cErr, &validationErr) {
+	//		...
+	//	}
+	Error struct {
+		utilsError
+		// Details are the details of an error returned by the LDAP server.
+		Details *ErrorDetails `json:"details,omitempty"`
+		// Validation lists the fields which failed the validation of the input of a method.
+		Validation *ValidationError `json:"validation,omitempty"`
+		// TooManyResults holds the details of a search which matched more entries than allowed.
+		TooManyResults *TooManyResultsError `json:"tooManyResults,omitempty"`
+	}
+)
+
+// WrapError returns the Error of a rest error of go-utils without details or nil if the rest error is nil, e.g. for
+// a custom UsersManager which returns the errors of another library.
+func WrapError(cErr *errors.Error) *Error {
+	if cErr == nil {
+		return nil
+	}
+	return &Error{utilsError: *cErr}
+}
+
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	if e == nil {
+		return ""
+	}
+	return e.Message
+}
+
+// RestError returns the rest error of go-utils embedded in the error, e.g. to render it as the response of an API,
+// or nil if the error is nil.
+func (e *Error) RestError() *errors.Error {
+	if e == nil {
+		return nil
+	}
+	return &e.utilsError
+}
+
+// Unwrap returns the details carried by the error, so they can be retrieved with errors.As.
+func (e *Error) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	var errs []error
+	if e.Details != nil {
+		errs = append(errs, e.Details)
+	}
+	if e.Validation != nil {
+		errs = append(errs, e.Validation)
+	}
+	if e.TooManyResults != nil {
+		errs = append(errs, e.TooManyResults)
+	}
+	return errs
+}
@@ -0,0 +1,50 @@
+package ldap
+
+import (
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const errorDetailsMsg = "LDAP Result Code %d %q, matched DN: '%s', diagnostic message: '%s'"
+
+type (
+	// ErrorDetails holds the details of an error returned by the LDAP server, which are not part of the message of
+	// the error returned by the client. The details are set as the Details of the Error, errors which are replaced by
+	// a more specific error, e.g. the not found error returned for a missing user, do not carry the details.
+	ErrorDetails struct {
+		// ResultCode is the LDAP result code of the operation, e.g. 32 for "No Such Object".
+		ResultCode uint16 `json:"resultCode"`
+		// MatchedDN is the distinguished name of the deepest existing entry of the target DN when the target entry
+		// does not exist.
+		MatchedDN string `json:"matchedDN,omitempty"`
+		// DiagnosticMessage is the additional information about the error returned by the LDAP server.
+		DiagnosticMessage string `json:"diagnosticMessage,omitempty"`
+	}
+)
+
+// String returns a human-readable description of the details.
+func (d *ErrorDetails) String() string {
+	return fmt.Sprintf(errorDetailsMsg, d.ResultCode, ldap.LDAPResultCodeMap[d.ResultCode], d.MatchedDN,
+		d.DiagnosticMessage)
+}
+
+// Error returns the human-readable description of the details, so the details can be retrieved from an Error with
+// errors.As.
+func (d *ErrorDetails) Error() string {
+	return d.String()
+}
+
+// newErrorDetails returns the ErrorDetails of an error returned by the go-ldap client or nil if the error is not
+// an LDAP result.
+func newErrorDetails(err error) *ErrorDetails {
+	ldapErr, ok := err.(*ldap.Error)
+	if !ok {
+		return nil
+	}
+	details := &ErrorDetails{ResultCode: ldapErr.ResultCode, MatchedDN: ldapErr.MatchedDN}
+	if ldapErr.Err != nil {
+		details.DiagnosticMessage = ldapErr.Err.Error()
+	}
+	return details
+}
@@ -0,0 +1,57 @@
+package ldap
+
+import (
+	err "errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorDetails(t *testing.T) {
+	t.Run("ldap error", func(t *testing.T) {
+		client := NewClient(testConfig)
+		ldapErr := &ldap.Error{
+			ResultCode: ldap.LDAPResultNoSuchObject,
+			MatchedDN:  "ou=projects,o=company",
+			Err:        err.New("entry does not exist"),
+		}
+
+		cErr := client.handleLdapError(ldapErr)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, ldap.LDAPResultCodeMap[ldap.LDAPResultNoSuchObject], cErr.Message)
+		assert.Equal(t, &ErrorDetails{
+			ResultCode:        ldap.LDAPResultNoSuchObject,
+			MatchedDN:         "ou=projects,o=company",
+			DiagnosticMessage: "entry does not exist",
+		}, cErr.Details)
+	})
+
+	t.Run("not an ldap error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.handleLdapError(err.New("connection reset"))
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+		assert.Nil(t, cErr.Details)
+	})
+
+	t.Run("errors.As", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		var details *ErrorDetails
+		cErr := client.handleLdapError(ldap.NewError(ldap.LDAPResultNoSuchObject, err.New("entry does not exist")))
+		assert.True(t, err.As(cErr, &details))
+		assert.Equal(t, uint16(ldap.LDAPResultNoSuchObject), details.ResultCode)
+	})
+}
+
+func TestErrorDetails_String(t *testing.T) {
+	details := &ErrorDetails{
+		ResultCode:        ldap.LDAPResultNoSuchObject,
+		MatchedDN:         "ou=projects,o=company",
+		DiagnosticMessage: "entry does not exist",
+	}
+	assert.Equal(t, "LDAP Result Code 32 \"No Such Object\", matched DN: 'ou=projects,o=company', "+
+		"diagnostic message: 'entry does not exist'", details.String())
+}
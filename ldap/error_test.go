@@ -0,0 +1,42 @@
+package ldap
+
+import (
+	"encoding/json"
+	err "errors"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapError(t *testing.T) {
+	restErr := errors.NotFoundError("not found")
+
+	cErr := WrapError(restErr)
+	assert.Equal(t, http.StatusNotFound, cErr.Status)
+	assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+	assert.Equal(t, "not found", cErr.Error())
+	assert.Equal(t, restErr, cErr.RestError())
+
+	assert.Nil(t, WrapError(nil))
+}
+
+func TestError(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		cErr := invalidParameterError(statusAttr, "invalid status")
+
+		body, jErr := json.Marshal(cErr)
+		assert.Nil(t, jErr)
+		assert.JSONEq(t, `{"code":"BAD_REQUEST","status":400,"message":"invalid status","traceId":"",`+
+			`"validation":{"fields":[{"field":"status","code":"INVALID","message":"invalid status"}]}}`, string(body))
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		var cErr *Error
+		var validationErr *ValidationError
+		assert.Equal(t, "", cErr.Error())
+		assert.Nil(t, cErr.RestError())
+		assert.False(t, err.As(cErr, &validationErr))
+	})
+}
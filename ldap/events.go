@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/logger"
+)
+
+const eventHandlerPanicMsg = "Event handler for the event '%s' of '%s' panicked: %v"
+
+// EventType is the type of an event which is emitted after a successful write operation.
+type EventType string
+
+const (
+	EventUserCreated               EventType = "user.created"
+	EventUserUpdated               EventType = "user.updated"
+	EventUserDeleted               EventType = "user.deleted"
+	EventUserPasswordChanged       EventType = "user.password_changed"
+	EventUserPhotoChanged          EventType = "user.photo_changed"
+	EventUserCertificatesChanged   EventType = "user.certificates_changed"
+	EventUserDeprovisioned         EventType = "user.deprovisioned"
+	EventUserArchived              EventType = "user.archived"
+	EventUserUnarchived            EventType = "user.unarchived"
+	EventGroupCreated              EventType = "group.created"
+	EventGroupUpdated              EventType = "group.updated"
+	EventGroupDeleted              EventType = "group.deleted"
+	EventGroupMembersAdded         EventType = "group.members_added"
+	EventGroupMembersRemoved       EventType = "group.members_removed"
+	EventGroupOwnersChanged        EventType = "group.owners_changed"
+	EventOrganizationalUnitDeleted EventType = "organizational_unit.deleted"
+	EventHostCreated               EventType = "host.created"
+	EventHostUpdated               EventType = "host.updated"
+	EventHostDeleted               EventType = "host.deleted"
+	EventSudoRoleCreated           EventType = "sudo_role.created"
+	EventSudoRoleUpdated           EventType = "sudo_role.updated"
+	EventSudoRoleDeleted           EventType = "sudo_role.deleted"
+	EventNetgroupCreated           EventType = "netgroup.created"
+	EventNetgroupUpdated           EventType = "netgroup.updated"
+	EventNetgroupDeleted           EventType = "netgroup.deleted"
+	EventAutomountMapCreated       EventType = "automount_map.created"
+	EventAutomountMapDeleted       EventType = "automount_map.deleted"
+	EventAutomountCreated          EventType = "automount.created"
+	EventAutomountUpdated          EventType = "automount.updated"
+	EventAutomountDeleted          EventType = "automount.deleted"
+)
+
+// eventTypes are all the event types which are emitted by the client.
+var eventTypes = []EventType{
+	EventUserCreated,
+	EventUserUpdated,
+	EventUserDeleted,
+	EventUserPasswordChanged,
+	EventUserPhotoChanged,
+	EventUserCertificatesChanged,
+	EventUserDeprovisioned,
+	EventUserArchived,
+	EventUserUnarchived,
+	EventGroupCreated,
+	EventGroupUpdated,
+	EventGroupDeleted,
+	EventGroupMembersAdded,
+	EventGroupMembersRemoved,
+	EventGroupOwnersChanged,
+	EventOrganizationalUnitDeleted,
+	EventHostCreated,
+	EventHostUpdated,
+	EventHostDeleted,
+	EventSudoRoleCreated,
+	EventSudoRoleUpdated,
+	EventSudoRoleDeleted,
+	EventNetgroupCreated,
+	EventNetgroupUpdated,
+	EventNetgroupDeleted,
+	EventAutomountMapCreated,
+	EventAutomountMapDeleted,
+	EventAutomountCreated,
+	EventAutomountUpdated,
+	EventAutomountDeleted,
+}
+
+type (
+	// Event describes a successful write operation on an LDAP entry.
+	// Uid is set for user events, Cn and Ou are set for group events, Ou is set for organizational unit events and Cn is
+	// set for host, sudo role and netgroup events.
+	Event struct {
+		Type      EventType `json:"type"`
+		Dn        string    `json:"dn"`
+		Uid       string    `json:"uid,omitempty"`
+		Cn        string    `json:"cn,omitempty"`
+		Ou        string    `json:"ou,omitempty"`
+		MemberIds []string  `json:"memberIds,omitempty"`
+		// Changes are the changes of the attributes of the entry, set for the EventUserUpdated events.
+		Changes ChangeSet `json:"changes,omitempty"`
+		Time    time.Time `json:"time"`
+	}
+
+	// EventHandler is invoked with the event after a successful write operation.
+	EventHandler func(event Event)
+
+	// eventHandlers holds the event handlers registered per event type.
+	eventHandlers struct {
+		mu       sync.RWMutex
+		handlers map[EventType][]EventHandler
+	}
+)
+
+// WithEventHandler registers the handler for the events of the event type.
+func WithEventHandler(eventType EventType, handler EventHandler) ClientOption {
+	return func(c *Client) {
+		c.On(eventType, handler)
+	}
+}
+
+// On registers the handler for the events of the event type.
+// The handlers are invoked synchronously in the order of registration after the write operation succeeded, so a
+// handler which publishes the event to e.g. a message broker should not block for long. A handler which panics
+// does not affect the outcome of the write operation or the other handlers.
+func (c *Client) On(eventType EventType, handler EventHandler) {
+	c.events.add(eventType, handler)
+}
+
+// emit invokes the handlers registered for the event type of the event.
+func (c *Client) emit(event Event) {
+	event.Time = time.Now().UTC()
+	for _, handler := range c.events.get(event.Type) {
+		invokeEventHandler(handler, event)
+	}
+}
+
+// invokeEventHandler invokes the handler and recovers and logs a panic of the handler.
+func invokeEventHandler(handler EventHandler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf(eventHandlerPanicMsg, event.Type, event.Dn, r)
+		}
+	}()
+	handler(event)
+}
+
+// add registers the handler for the event type.
+func (eh *eventHandlers) add(eventType EventType, handler EventHandler) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	if eh.handlers == nil {
+		eh.handlers = make(map[EventType][]EventHandler)
+	}
+	eh.handlers[eventType] = append(eh.handlers[eventType], handler)
+}
+
+// get returns the handlers registered for the event type.
+func (eh *eventHandlers) get(eventType EventType) []EventHandler {
+	eh.mu.RLock()
+	defer eh.mu.RUnlock()
+	return eh.handlers[eventType]
+}
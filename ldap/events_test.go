@@ -0,0 +1,93 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_On(t *testing.T) {
+	t.Run("handlers are invoked in order", func(t *testing.T) {
+		var invoked []string
+		client := NewClient(testConfig, WithEventHandler(EventUserCreated, func(event Event) {
+			invoked = append(invoked, "first")
+		}))
+		client.On(EventUserCreated, func(event Event) {
+			invoked = append(invoked, "second")
+			assert.Equal(t, EventUserCreated, event.Type)
+			assert.False(t, event.Time.IsZero())
+		})
+		client.On(EventUserDeleted, func(event Event) {
+			invoked = append(invoked, "other")
+		})
+
+		client.emit(Event{Type: EventUserCreated})
+		assert.Equal(t, []string{"first", "second"}, invoked)
+	})
+
+	t.Run("handler panics", func(t *testing.T) {
+		invoked := false
+		client := NewClient(testConfig)
+		client.On(EventUserCreated, func(event Event) {
+			panic("broker unavailable")
+		})
+		client.On(EventUserCreated, func(event Event) {
+			invoked = true
+		})
+
+		assert.NotPanics(t, func() { client.emit(Event{Type: EventUserCreated}) })
+		assert.True(t, invoked)
+	})
+}
+
+func TestClient_emit(t *testing.T) {
+	t.Run("user deleted", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		var events []Event
+		client.On(EventUserDeleted, func(event Event) {
+			events = append(events, event)
+		})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, um.getDeleteRequest(testUser1.Uid)).Return(nil).Once()
+		ldapMock.On(methodNameDelete, um.getDeleteRequest(testUser2.Uid)).Return(ldapNoSuchObjectErr).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.Delete(testUser1.Uid))
+		assert.NotNil(t, client.Users.Delete(testUser2.Uid))
+		assert.Len(t, events, 1)
+		assert.Equal(t, testUser1.Uid, events[0].Uid)
+		assert.Equal(t, um.getDN(testUser1.Uid), events[0].Dn)
+	})
+
+	t.Run("group members added", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+		gm := groupsManager{Client: client}
+		var events []Event
+		client.On(EventGroupMembersAdded, func(event Event) {
+			events = append(events, event)
+		})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult1, nil)
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Add(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser3.Uid)})
+		mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.AddMembers(testGroupCn1, testOrganizationUnit1, []string{testUser3.Uid}))
+		assert.Len(t, events, 1)
+		assert.Equal(t, EventGroupMembersAdded, events[0].Type)
+		assert.Equal(t, testGroupCn1, events[0].Cn)
+		assert.Equal(t, testOrganizationUnit1, events[0].Ou)
+		assert.Equal(t, []string{testUser3.Uid}, events[0].MemberIds)
+	})
+}
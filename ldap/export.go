@@ -0,0 +1,139 @@
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	fieldsParam = "fields"
+
+	jsonWriteErrMsg     = "Writing the JSON failed: %v"
+	invalidJSONFieldMsg = "Invalid field '%s'. Valid fields are %v"
+)
+
+var (
+	// userJSONFields are the names of the fields of a user which can be selected for an export.
+	userJSONFields = jsonFieldNames(User{})
+	// groupJSONFields are the names of the fields of a group which can be selected for an export.
+	groupJSONFields = jsonFieldNames(Group{})
+)
+
+// ExportJSON writes the user entries to w as newline delimited JSON, one JSON object per user.
+// params:
+//
+//	w = the writer the JSON is written to
+//	fields = the JSON fields of the users to export, e.g. "uid", "mail". Defaults to all the fields.
+//
+// The user entries are retrieved page by page, so the memory usage does not grow with the number of users.
+// The method returns an error:
+//   - if a field is not valid
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+//   - if writing to w fails
+func (um *usersManager) ExportJSON(w io.Writer, fields ...string) *Error {
+	if cErr := validateJSONFields(fields, userJSONFields); cErr != nil {
+		return cErr
+	}
+	enc := json.NewEncoder(w)
+	return um.Client.doLDAPPagedSearch(um.getUsersSearchRequest(um.Client.getConfig().usersFilter()), defaultPageSize,
+		func(result *ldap.SearchResult) *Error {
+			for _, user := range um.parseSearchResult(result) {
+				if cErr := encodeJSONLine(enc, user, fields); cErr != nil {
+					return cErr
+				}
+			}
+			return nil
+		})
+}
+
+// ExportJSON writes the group entries to w as newline delimited JSON, one JSON object per group.
+// params:
+//
+//	w = the writer the JSON is written to
+//	fields = the JSON fields of the groups to export, e.g. "Cn", "Members". Defaults to all the fields.
+//
+// The group entries are retrieved page by page, so the memory usage does not grow with the number of groups.
+// The method returns an error:
+//   - if a field is not valid
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+//   - if writing to w fails
+func (gm *groupsManager) ExportJSON(w io.Writer, fields ...string) *Error {
+	if cErr := validateJSONFields(fields, groupJSONFields); cErr != nil {
+		return cErr
+	}
+	enc := json.NewEncoder(w)
+	return gm.Client.doLDAPPagedSearch(gm.getSearchRequest("", "", gm.Client.getConfig().groupsFilter()), defaultPageSize,
+		func(result *ldap.SearchResult) *Error {
+			for _, group := range gm.parseSearchResult(result) {
+				if cErr := encodeJSONLine(enc, group, fields); cErr != nil {
+					return cErr
+				}
+			}
+			return nil
+		})
+}
+
+// encodeJSONLine writes the value as a single line of JSON. If fields are set only these fields are written.
+func encodeJSONLine(enc *json.Encoder, value any, fields []string) *Error {
+	if len(fields) > 0 {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return WrapError(errors.InternalServerErrorf(jsonWriteErrMsg, err))
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return WrapError(errors.InternalServerErrorf(jsonWriteErrMsg, err))
+		}
+		selected := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if raw, ok := all[field]; ok {
+				selected[field] = raw
+			}
+		}
+		value = selected
+	}
+	if err := enc.Encode(value); err != nil {
+		return WrapError(errors.InternalServerErrorf(jsonWriteErrMsg, err))
+	}
+	return nil
+}
+
+// validateJSONFields checks if the fields are valid JSON fields.
+func validateJSONFields(fields, validFields []string) *Error {
+	for _, field := range fields {
+		if !slice.EntryExists(validFields, field) {
+			return invalidParameterError(fieldsParam, fmt.Sprintf(invalidJSONFieldMsg, field, validFields))
+		}
+	}
+	return nil
+}
+
+// jsonFieldNames returns the names of the JSON fields of a struct.
+func jsonFieldNames(value any) []string {
+	var names []string
+	t := reflect.TypeOf(value)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,111 @@
+package ldap
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsersManager_ExportJSON(t *testing.T) {
+	t.Run("all fields", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var buf bytes.Buffer
+		assert.Nil(t, client.Users.(UsersExporter).ExportJSON(&buf))
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		assert.Len(t, lines, 4)
+		var user User
+		assert.Nil(t, json.Unmarshal([]byte(lines[0]), &user))
+		expected := testUser1
+		expected.UserPassword = ""
+		assert.Equal(t, expected, user)
+	})
+
+	t.Run("selected fields", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var buf bytes.Buffer
+		assert.Nil(t, client.Users.(UsersExporter).ExportJSON(&buf, userIdAttr, mailAttr))
+		assert.Equal(t, `{"mail":"john.doe@company.com","uid":"C00001"}
+{"mail":"jane.doe@company.com","uid":"C00002"}
+{"mail":"abc@company.com","uid":"ABC_BUILDER"}
+{"mail":"abc@company.com","uid":"nxrm-ado-agent"}
+`, buf.String())
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UsersExporter).ExportJSON(&bytes.Buffer{}, "unknown")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(fieldsParam).Code)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.NotNil(t, client.Users.(UsersExporter).ExportJSON(&bytes.Buffer{}))
+	})
+}
+
+func TestGroupsManager_ExportJSON(t *testing.T) {
+	t.Run("pages", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		firstPage := &ldap.SearchResult{Entries: getFilteredGroupSearchResult.Entries[:1]}
+		control := ldap.NewControlPaging(0)
+		control.SetCookie([]byte("page-2"))
+		firstPage.Controls = []ldap.Control{control}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(firstPage, nil).Once()
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: getFilteredGroupSearchResult.Entries[1:]}, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var buf bytes.Buffer
+		assert.Nil(t, client.Groups.(GroupsExporter).ExportJSON(&buf, "Ou", "Cn"))
+		assert.Equal(t, `{"Cn":"group1","Ou":"test-ou-1"}
+{"Cn":"group1","Ou":"test-ou-2"}
+`, buf.String())
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Groups.(GroupsExporter).ExportJSON(&bytes.Buffer{}, "cn")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	assert.Equal(t, []string{"Dn", "Ou", "OuPath", "Cn", "Members", "Owners", "Description", "BusinessCategory",
+		"ReviewDate", "Revision"}, jsonFieldNames(Group{}))
+	assert.Contains(t, jsonFieldNames(User{}), "atlUid")
+}
@@ -0,0 +1,84 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	descriptionAttr      = "description"
+	businessCategoryAttr = "businessCategory"
+)
+
+// GroupMetadata is the human context of a group, e.g. for access reviews and audits.
+type GroupMetadata struct {
+	// Description describes the purpose of the group.
+	Description string `json:"description,omitempty"`
+	// BusinessCategory labels the group, e.g. with the business unit or the application the group grants access to.
+	BusinessCategory []string `json:"businessCategory,omitempty"`
+}
+
+// CreateWithMetadata adds a new group entry with a description and business categories in LDAP.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group should be created
+//	memberIds: a list of memberIds to be added as a unique member in the group
+//	metadata: the description and the business categories of the group
+//
+// The method returns the same errors as Create.
+func (gm *groupsManager) CreateWithMetadata(cn, ou string, memberIds []string, metadata GroupMetadata) *Error {
+	return gm.create(cn, ou, memberIds, metadata)
+}
+
+// UpdateMetadata replaces the description and the business categories of an existing group entry in LDAP. An empty
+// description or business category list removes the attribute from the group.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	metadata: the description and the business categories of the group
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) UpdateMetadata(cn, ou string, metadata GroupMetadata) *Error {
+	if err := gm.validateGroup(cn, ou); err != nil {
+		return err
+	}
+	mr := gm.getModifyRequest(cn, ou)
+	mr.Replace(descriptionAttr, metadata.descriptionValues())
+	mr.Replace(businessCategoryAttr, append([]string{}, metadata.BusinessCategory...))
+	if cErr := gm.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou)))
+		}
+		return cErr
+	}
+	gm.emit(EventGroupUpdated, cn, ou, nil)
+	return nil
+}
+
+// addAttributes adds the attributes of the metadata which are set to the add request.
+func (m GroupMetadata) addAttributes(ar *ldap.AddRequest) {
+	if values := m.descriptionValues(); len(values) > 0 {
+		ar.Attribute(descriptionAttr, values)
+	}
+	if len(m.BusinessCategory) > 0 {
+		ar.Attribute(businessCategoryAttr, m.BusinessCategory)
+	}
+}
+
+// descriptionValues returns the values of the description attribute, which are empty if the description is not set.
+func (m GroupMetadata) descriptionValues() []string {
+	if m.Description == "" {
+		return []string{}
+	}
+	return []string{m.Description}
+}
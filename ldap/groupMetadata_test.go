@@ -0,0 +1,111 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testGroupMetadata = GroupMetadata{
+	Description:      "Developers of the payments application",
+	BusinessCategory: []string{"payments", "developers"},
+}
+
+func TestGroupsManager_CreateWithMetadata(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		ar := gm.getAddRequest(testGroupCn1, testOrganizationUnit1, []string{testUser1.Uid})
+		ar.Attribute(descriptionAttr, []string{testGroupMetadata.Description})
+		ar.Attribute(businessCategoryAttr, testGroupMetadata.BusinessCategory)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupProvisioner).CreateWithMetadata(testGroupCn1, testOrganizationUnit1, []string{testUser1.Uid},
+			testGroupMetadata)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Groups.(GroupProvisioner).CreateWithMetadata("", "", nil, testGroupMetadata)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestGroupsManager_UpdateMetadata(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(descriptionAttr, []string{testGroupMetadata.Description})
+		mr.Replace(businessCategoryAttr, testGroupMetadata.BusinessCategory)
+		var events []EventType
+		client.On(EventGroupUpdated, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupProvisioner).UpdateMetadata(testGroupCn1, testOrganizationUnit1, testGroupMetadata))
+		assert.Equal(t, []EventType{EventGroupUpdated}, events)
+	})
+
+	t.Run("clear metadata", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(descriptionAttr, []string{})
+		mr.Replace(businessCategoryAttr, []string{})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupProvisioner).UpdateMetadata(testGroupCn1, testOrganizationUnit1, GroupMetadata{}))
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupProvisioner).UpdateMetadata(testGroupCn1, testOrganizationUnit1, testGroupMetadata)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(groupNotFoundMsg, testGroupCn1, testOrganizationUnit1), cErr.Message)
+	})
+}
+
+func TestGroupsManager_parseSearchResult_metadata(t *testing.T) {
+	gm := groupsManager{Client: NewClient(testConfig)}
+	entry := getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, testUniqueMembers2)
+	entry.Attributes = append(entry.Attributes,
+		&ldap.EntryAttribute{Name: descriptionAttr, Values: []string{testGroupMetadata.Description}},
+		&ldap.EntryAttribute{Name: businessCategoryAttr, Values: testGroupMetadata.BusinessCategory})
+
+	groups := gm.parseSearchResult(&ldap.SearchResult{Entries: []*ldap.Entry{entry,
+		getGroupLDAPEntry(testGroupCn2, testOrganizationUnit1, testUniqueMembers2)}})
+	assert.Equal(t, testGroupMetadata.Description, groups[0].Description)
+	assert.Equal(t, testGroupMetadata.BusinessCategory, groups[0].BusinessCategory)
+	assert.Empty(t, groups[1].Description)
+	assert.Nil(t, groups[1].BusinessCategory)
+}
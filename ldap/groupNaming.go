@@ -0,0 +1,150 @@
+package ldap
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	groupNamePrefixMsg         = "The group name '%s' in the organizational unit '%s' must start with '%s'"
+	groupNamePatternMsg        = "The group name '%s' in the organizational unit '%s' must match the pattern '%s'"
+	invalidGroupNamePatternMsg = "Invalid group name pattern '%s' of the organizational unit '%s': %v"
+)
+
+type (
+	// GroupNamePolicy describes the naming convention of the groups in an organizational unit.
+	GroupNamePolicy struct {
+		// Ou is the name or path of the organizational unit the policy applies to. A policy without Ou applies to
+		// the organizational units which have no policy of their own.
+		Ou string `json:"ou" yaml:"ou"`
+		// Prefix the group names must start with, e.g. "nexus-". Optional.
+		Prefix string `json:"prefix" yaml:"prefix"`
+		// Pattern is a regular expression the group names must match, e.g. "^[a-z][a-z0-9-]*$". Optional.
+		Pattern string `json:"pattern" yaml:"pattern"`
+	}
+
+	// GroupNameViolation reports an existing group of which the name does not conform to the GroupNamePolicy of its
+	// organizational unit.
+	GroupNameViolation struct {
+		Cn      string `json:"cn" yaml:"cn"`
+		Ou      string `json:"ou" yaml:"ou"`
+		Message string `json:"message" yaml:"message"`
+	}
+)
+
+// ValidateName checks if a group name conforms to the GroupNamePolicies set in the client Config.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists or is created
+//
+// The policy of the organizational unit applies, or the policy without Ou if the organizational unit has no policy
+// of its own. If no policy applies every group name is valid.
+// The method returns an error:
+//   - if a parameter is missing
+//   - if the group name does not conform to the policy
+//   - if the pattern of the policy is not a valid regular expression
+func (gm *groupsManager) ValidateName(cn, ou string) *Error {
+	var missingParams []string
+
+	if strings.TrimSpace(cn) == "" {
+		missingParams = append(missingParams, CommonNameAttr)
+	}
+	if strings.TrimSpace(ou) == "" {
+		missingParams = append(missingParams, OrganizationalUnitAttr)
+	}
+	if len(missingParams) > 0 {
+		return missingParametersError(missingParams)
+	}
+	message, cErr := gm.checkName(cn, ou)
+	if cErr != nil {
+		return cErr
+	}
+	if message != "" {
+		return invalidParameterError(CommonNameAttr, message)
+	}
+	return nil
+}
+
+// NonConformingGroups retrieves all the group entries from LDAP and reports the groups of which the name does not
+// conform to the GroupNamePolicies set in the client Config.
+// The method returns an error:
+//   - if the pattern of a policy is not a valid regular expression
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) NonConformingGroups() ([]GroupNameViolation, *Error) {
+	violations := []GroupNameViolation{}
+	if len(gm.Client.getConfig().GroupNamePolicies) == 0 {
+		return violations, nil
+	}
+	groups, cErr := gm.get("", "")
+	if cErr != nil {
+		return nil, cErr
+	}
+	for _, group := range groups {
+		ou := strings.Join(group.OuPath, OuPathSeparator)
+		message, cErr := gm.checkName(group.Cn, ou)
+		if cErr != nil {
+			return nil, cErr
+		}
+		if message != "" {
+			violations = append(violations, GroupNameViolation{Cn: group.Cn, Ou: ou, Message: message})
+		}
+	}
+	return violations, nil
+}
+
+// checkName returns the message explaining why the group name does not conform to the policy of the organizational
+// unit, or an empty message if the group name conforms.
+func (gm *groupsManager) checkName(cn, ou string) (string, *Error) {
+	policy, ok := gm.namePolicy(ou)
+	if !ok {
+		return "", nil
+	}
+	if !strings.HasPrefix(cn, policy.Prefix) {
+		return fmt.Sprintf(groupNamePrefixMsg, cn, ou, policy.Prefix), nil
+	}
+	if policy.Pattern == "" {
+		return "", nil
+	}
+	pattern, err := regexp.Compile(policy.Pattern)
+	if err != nil {
+		return "", WrapError(errors.InternalServerErrorf(invalidGroupNamePatternMsg, policy.Pattern, policy.Ou, err))
+	}
+	if !pattern.MatchString(cn) {
+		return fmt.Sprintf(groupNamePatternMsg, cn, ou, policy.Pattern), nil
+	}
+	return "", nil
+}
+
+// validate checks if the Pattern of the policy compiles.
+func (p GroupNamePolicy) validate() *Error {
+	if _, err := regexp.Compile(p.Pattern); err != nil {
+		return WrapError(errors.BadRequestError(fmt.Sprintf(invalidGroupNamePatternMsg, p.Pattern, p.Ou, err)))
+	}
+	return nil
+}
+
+// namePolicy returns the GroupNamePolicy which applies to the organizational unit.
+func (gm *groupsManager) namePolicy(ou string) (GroupNamePolicy, bool) {
+	var fallback *GroupNamePolicy
+	policies := gm.Client.getConfig().GroupNamePolicies
+	for i, policy := range policies {
+		if policy.Ou == "" {
+			if fallback == nil {
+				fallback = &policies[i]
+			}
+			continue
+		}
+		if strings.EqualFold(policy.Ou, ou) {
+			return policy, true
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return GroupNamePolicy{}, false
+}
@@ -0,0 +1,140 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// getGroupNamingConfig returns the test config with a policy for test-ou-1 and a fallback policy.
+func getGroupNamingConfig() Config {
+	config := testConfig
+	config.GroupNamePolicies = []GroupNamePolicy{
+		{Prefix: "team-"},
+		{Ou: testOrganizationUnit1, Pattern: "^group[0-9]+$"},
+	}
+	return config
+}
+
+func TestGroupsManager_ValidateName(t *testing.T) {
+	t.Run("no policies", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		assert.Nil(t, client.Groups.(GroupNamingManager).ValidateName("Any Name", testOrganizationUnit1))
+	})
+
+	t.Run("conforms to the policy of the ou", func(t *testing.T) {
+		client := NewClient(getGroupNamingConfig())
+
+		assert.Nil(t, client.Groups.(GroupNamingManager).ValidateName(testGroupCn1, "TEST-OU-1"))
+	})
+
+	t.Run("does not match the pattern", func(t *testing.T) {
+		client := NewClient(getGroupNamingConfig())
+
+		cErr := client.Groups.(GroupNamingManager).ValidateName("team-a", testOrganizationUnit1)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(groupNamePatternMsg, "team-a", testOrganizationUnit1, "^group[0-9]+$"),
+			cErr.Message)
+		validationErr := cErr.Validation
+		assert.Equal(t, CommonNameAttr, validationErr.Fields[0].Field)
+	})
+
+	t.Run("fallback policy", func(t *testing.T) {
+		client := NewClient(getGroupNamingConfig())
+
+		assert.Nil(t, client.Groups.(GroupNamingManager).ValidateName("team-a", "other-ou"))
+
+		cErr := client.Groups.(GroupNamingManager).ValidateName(testGroupCn1, "other-ou")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(groupNamePrefixMsg, testGroupCn1, "other-ou", "team-"), cErr.Message)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		config := testConfig
+		config.GroupNamePolicies = []GroupNamePolicy{{Ou: testOrganizationUnit1, Pattern: "["}}
+		client := NewClient(config)
+
+		cErr := client.Groups.(GroupNamingManager).ValidateName(testGroupCn1, testOrganizationUnit1)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		client := NewClient(getGroupNamingConfig())
+
+		cErr := client.Groups.(GroupNamingManager).ValidateName("", " ")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{CommonNameAttr, OrganizationalUnitAttr}), cErr.Message)
+	})
+
+	t.Run("create rejects a non conforming name", func(t *testing.T) {
+		client := NewClient(getGroupNamingConfig(), WithoutOrganizationalUnitValidation())
+
+		cErr := client.Groups.Create("Group-1", testOrganizationUnit1, nil)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(groupNamePatternMsg, "Group-1", testOrganizationUnit1, "^group[0-9]+$"),
+			cErr.Message)
+	})
+}
+
+func TestGroupsManager_NonConformingGroups(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getGroupNamingConfig(), WithLDAPClient(ldapMock), UnitTesting())
+		gm := groupsManager{Client: client}
+		searchResult := &ldap.SearchResult{Entries: []*ldap.Entry{
+			getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, testUniqueMembers2),
+			getGroupLDAPEntry("team-a", testOrganizationUnit1, testUniqueMembers2),
+			getGroupLDAPEntry("team-b", "other-ou", testUniqueMembers2),
+			getGroupLDAPEntry(testGroupCn2, "other-ou", testUniqueMembers2),
+		}}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", groupSearchFilter)).Return(searchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		violations, cErr := client.Groups.(GroupNamingManager).NonConformingGroups()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []GroupNameViolation{
+			{
+				Cn:      "team-a",
+				Ou:      testOrganizationUnit1,
+				Message: fmt.Sprintf(groupNamePatternMsg, "team-a", testOrganizationUnit1, "^group[0-9]+$"),
+			},
+			{
+				Cn:      testGroupCn2,
+				Ou:      "other-ou",
+				Message: fmt.Sprintf(groupNamePrefixMsg, testGroupCn2, "other-ou", "team-"),
+			},
+		}, violations)
+	})
+
+	t.Run("no policies", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		violations, cErr := client.Groups.(GroupNamingManager).NonConformingGroups()
+		assert.Nil(t, cErr)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getGroupNamingConfig(), WithLDAPClient(ldapMock), UnitTesting())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", groupSearchFilter)).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		violations, cErr := client.Groups.(GroupNamingManager).NonConformingGroups()
+		assert.Nil(t, violations)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+}
@@ -0,0 +1,142 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	ownerAttr    = "owner"
+	ownerIdParam = "ownerId"
+)
+
+// SetOwners replaces the owners of an existing group entry in LDAP.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	ownerIds: the uids of the owners, an empty list removes all the owners of the group
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) SetOwners(cn, ou string, ownerIds []string) *Error {
+	if err := gm.validateGroup(cn, ou); err != nil {
+		return err
+	}
+	owners := []string{}
+	for _, ownerId := range ownerIds {
+		if strings.TrimSpace(ownerId) == "" {
+			return missingParametersError([]string{ownerIdParam})
+		}
+		owners = append(owners, gm.memberDn(ownerId))
+	}
+	mr := gm.getModifyRequest(cn, ou)
+	mr.Replace(ownerAttr, owners)
+	return gm.modifyOwners(cn, ou, mr)
+}
+
+// AddOwner adds an owner to an existing group entry in LDAP. Nothing is changed if the user already owns the group.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	ownerId: the uid of the owner
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) AddOwner(cn, ou, ownerId string) *Error {
+	owner, group, cErr := gm.getOwnerAndGroup(cn, ou, ownerId)
+	if cErr != nil {
+		return cErr
+	}
+	if _, ok := findMember(group.Owners, owner); ok {
+		return nil
+	}
+	mr := gm.getModifyRequest(cn, ou)
+	mr.Add(ownerAttr, []string{owner})
+	return gm.modifyOwners(cn, ou, mr)
+}
+
+// RemoveOwner removes an owner from an existing group entry in LDAP. Nothing is changed if the user does not own
+// the group.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	ownerId: the uid of the owner
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) RemoveOwner(cn, ou, ownerId string) *Error {
+	owner, group, cErr := gm.getOwnerAndGroup(cn, ou, ownerId)
+	if cErr != nil {
+		return cErr
+	}
+	owner, ok := findMember(group.Owners, owner)
+	if !ok {
+		return nil
+	}
+	mr := gm.getModifyRequest(cn, ou)
+	mr.Delete(ownerAttr, []string{owner})
+	return gm.modifyOwners(cn, ou, mr)
+}
+
+// GetOwnedBy retrieves the group entries from LDAP which are owned by the user.
+// Params:
+//
+//	uid: user identifier
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) GetOwnedBy(uid string) ([]Group, *Error) {
+	if strings.TrimSpace(uid) == "" {
+		return nil, missingParametersError([]string{userIdAttr})
+	}
+	return gm.GetFilter(fmt.Sprintf("(&%s(%s=%s))", gm.Client.getConfig().groupsFilter(), ownerAttr,
+		ldap.EscapeFilter(gm.memberDn(uid))))
+}
+
+// getOwnerAndGroup validates the owner and returns the dn of the owner and the group.
+func (gm *groupsManager) getOwnerAndGroup(cn, ou, ownerId string) (string, *Group, *Error) {
+	if err := gm.validateGroup(cn, ou); err != nil {
+		return "", nil, err
+	}
+	if strings.TrimSpace(ownerId) == "" {
+		return "", nil, missingParametersError([]string{ownerIdParam})
+	}
+	groups, cErr := gm.get(cn, ou)
+	if cErr != nil {
+		return "", nil, cErr
+	}
+	return gm.memberDn(ownerId), &groups[0], nil
+}
+
+// modifyOwners executes the modify request which changes the owners of the group and emits the owners changed event.
+func (gm *groupsManager) modifyOwners(cn, ou string, mr *ldap.ModifyRequest) *Error {
+	if cErr := gm.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou)))
+		}
+		return cErr
+	}
+	gm.emit(EventGroupOwnersChanged, cn, ou, nil)
+	return nil
+}
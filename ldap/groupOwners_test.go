@@ -0,0 +1,181 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// getOwnedGroupSearchResult returns a search result with group1 in test-ou-1 owned by the owners.
+func getOwnedGroupSearchResult(owners ...string) *ldap.SearchResult {
+	entry := getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, testUniqueMembers2)
+	entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{Name: ownerAttr, Values: owners})
+	return &ldap.SearchResult{Entries: []*ldap.Entry{entry}}
+}
+
+func TestGroupsManager_SetOwners(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(ownerAttr, []string{gm.getUniqueMemberDn(testUser1.Uid), gm.getUniqueMemberDn(testUser3.Uid)})
+		var events []Event
+		client.On(EventGroupOwnersChanged, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupOwnersManager).SetOwners(testGroupCn1, testOrganizationUnit1, []string{"c00001", testUser3.Uid})
+		assert.Nil(t, cErr)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(ownerAttr, []string{})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupOwnersManager).SetOwners(testGroupCn1, testOrganizationUnit1, nil)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(groupNotFoundMsg, testGroupCn1, testOrganizationUnit1), cErr.Message)
+	})
+
+	t.Run("missing owner id", func(t *testing.T) {
+		client := NewClient(testConfig, WithoutOrganizationalUnitValidation())
+
+		cErr := client.Groups.(GroupOwnersManager).SetOwners(testGroupCn1, testOrganizationUnit1, []string{" "})
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(ownerIdParam).Code)
+	})
+}
+
+func TestGroupsManager_AddOwner(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Add(ownerAttr, []string{gm.getUniqueMemberDn(testUser3.Uid)})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getOwnedGroupSearchResult(gm.getUniqueMemberDn(testUser1.Uid)), nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupOwnersManager).AddOwner(testGroupCn1, testOrganizationUnit1, testUser3.Uid))
+	})
+
+	t.Run("already an owner", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getOwnedGroupSearchResult(gm.getUniqueMemberDn(testUser1.Uid)), nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupOwnersManager).AddOwner(testGroupCn1, testOrganizationUnit1, testUser1.Uid))
+	})
+
+	t.Run("missing owner id", func(t *testing.T) {
+		client := NewClient(testConfig, WithoutOrganizationalUnitValidation())
+
+		cErr := client.Groups.(GroupOwnersManager).AddOwner(testGroupCn1, testOrganizationUnit1, "")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestGroupsManager_RemoveOwner(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Delete(ownerAttr, []string{gm.getUniqueMemberDn(testUser1.Uid)})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getOwnedGroupSearchResult(gm.getUniqueMemberDn(testUser1.Uid)), nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupOwnersManager).RemoveOwner(testGroupCn1, testOrganizationUnit1, testUser1.Uid))
+	})
+
+	t.Run("not an owner", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getOwnedGroupSearchResult(), nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupOwnersManager).RemoveOwner(testGroupCn1, testOrganizationUnit1, testUser1.Uid))
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupOwnersManager).RemoveOwner(testGroupCn1, testOrganizationUnit1, testUser1.Uid)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestGroupsManager_GetOwnedBy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		gm := groupsManager{Client: client}
+		filter := "(&(&(objectClass=groupOfUniqueNames))(owner=uid=C00001,ou=users,o=company))"
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", filter)).
+			Return(getOwnedGroupSearchResult(gm.getUniqueMemberDn(testUser1.Uid)), nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		groups, cErr := client.Groups.(GroupOwnersManager).GetOwnedBy("c00001")
+		assert.Nil(t, cErr)
+		assert.Len(t, groups, 1)
+		assert.Equal(t, testGroupCn1, groups[0].Cn)
+		assert.Equal(t, []string{gm.getUniqueMemberDn(testUser1.Uid)}, groups[0].Owners)
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		groups, cErr := client.Groups.(GroupOwnersManager).GetOwnedBy("")
+		assert.Nil(t, groups)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
@@ -0,0 +1,88 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	groupReviewDateAttrParam = "groupReviewDateAttr"
+
+	dueForReviewSearchFilter = "(&%s(|(!(%s=*))(%s<=%s)))"
+)
+
+// SetReviewDate sets the date an existing group entry in LDAP is due for an access review. The review date is
+// stored in the GroupReviewDateAttr set in the client Config.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	reviewDate: the date the group is due for review, a zero time removes the review date
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if the GroupReviewDateAttr is not set in the client Config
+//   - if the organizational unit is not found
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) SetReviewDate(cn, ou string, reviewDate time.Time) *Error {
+	reviewDateAttr, cErr := gm.reviewDateAttr()
+	if cErr != nil {
+		return cErr
+	}
+	if err := gm.validateGroup(cn, ou); err != nil {
+		return err
+	}
+	values := []string{}
+	if !reviewDate.IsZero() {
+		values = append(values, reviewDate.UTC().Format(generalizedTimeLayout))
+	}
+	mr := gm.getModifyRequest(cn, ou)
+	mr.Replace(reviewDateAttr, values)
+	if cErr := gm.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou)))
+		}
+		return cErr
+	}
+	gm.emit(EventGroupUpdated, cn, ou, nil)
+	return nil
+}
+
+// DueForReview retrieves the group entries from LDAP of which the review date is not after the cutoff time, including
+// the group entries which have no review date and were therefore never reviewed.
+// The method returns an error:
+//   - if the GroupReviewDateAttr is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) DueForReview(before time.Time) ([]Group, *Error) {
+	reviewDateAttr, cErr := gm.reviewDateAttr()
+	if cErr != nil {
+		return nil, cErr
+	}
+	groups, cErr := gm.GetFilter(fmt.Sprintf(dueForReviewSearchFilter, gm.Client.getConfig().groupsFilter(), reviewDateAttr,
+		reviewDateAttr, before.UTC().Format(generalizedTimeLayout)))
+	if cErr != nil {
+		return nil, cErr
+	}
+	due := []Group{}
+	for _, group := range groups {
+		if group.ReviewDate == nil || !group.ReviewDate.After(before) {
+			due = append(due, group)
+		}
+	}
+	return due, nil
+}
+
+// reviewDateAttr returns the GroupReviewDateAttr set in the client Config or an error if it is not set.
+func (gm *groupsManager) reviewDateAttr() (string, *Error) {
+	reviewDateAttr := gm.Client.getConfig().GroupReviewDateAttr
+	if reviewDateAttr == "" {
+		return "", missingParametersError([]string{groupReviewDateAttrParam})
+	}
+	return reviewDateAttr, nil
+}
@@ -0,0 +1,120 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const testGroupReviewDateAttr = "reviewDate"
+
+// getReviewedGroupLDAPEntry returns a group entry with the review date.
+func getReviewedGroupLDAPEntry(cn, reviewDate string) *ldap.Entry {
+	entry := getGroupLDAPEntry(cn, testOrganizationUnit1, testUniqueMembers2)
+	if reviewDate != "" {
+		entry.Attributes = append(entry.Attributes,
+			&ldap.EntryAttribute{Name: testGroupReviewDateAttr, Values: []string{reviewDate}})
+	}
+	return entry
+}
+
+func TestGroupsManager_SetReviewDate(t *testing.T) {
+	config := testConfig
+	config.GroupReviewDateAttr = testGroupReviewDateAttr
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(testGroupReviewDateAttr, []string{"20250630220000Z"})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupReviewManager).SetReviewDate(testGroupCn1, testOrganizationUnit1,
+			time.Date(2025, 7, 1, 0, 0, 0, 0, time.FixedZone("CEST", 7200)))
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("remove review date", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(testGroupReviewDateAttr, []string{})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupReviewManager).SetReviewDate(testGroupCn1, testOrganizationUnit1, time.Time{})
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(groupNotFoundMsg, testGroupCn1, testOrganizationUnit1), cErr.Message)
+	})
+
+	t.Run("review date attribute not set", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Groups.(GroupReviewManager).SetReviewDate(testGroupCn1, testOrganizationUnit1, time.Now())
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(groupReviewDateAttrParam).Code)
+	})
+}
+
+func TestGroupsManager_DueForReview(t *testing.T) {
+	config := testConfig
+	config.GroupReviewDateAttr = testGroupReviewDateAttr
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		gm := groupsManager{Client: client}
+		filter := "(&(&(objectClass=groupOfUniqueNames))(|(!(reviewDate=*))(reviewDate<=20250101000000Z)))"
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", filter)).Return(&ldap.SearchResult{
+			Entries: []*ldap.Entry{
+				getReviewedGroupLDAPEntry(testGroupCn1, "20241201000000Z"),
+				getReviewedGroupLDAPEntry(testGroupCn2, ""),
+				getReviewedGroupLDAPEntry(testCN, "20250102000000Z"),
+			},
+		}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		groups, cErr := client.Groups.(GroupReviewManager).DueForReview(before)
+		assert.Nil(t, cErr)
+		assert.Len(t, groups, 2)
+		assert.Equal(t, testGroupCn1, groups[0].Cn)
+		assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), *groups[0].ReviewDate)
+		assert.Equal(t, testGroupCn2, groups[1].Cn)
+		assert.Nil(t, groups[1].ReviewDate)
+	})
+
+	t.Run("review date attribute not set", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		groups, cErr := client.Groups.(GroupReviewManager).DueForReview(before)
+		assert.Nil(t, groups)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestGroupsManager_getSearchRequest_reviewDate(t *testing.T) {
+	config := testConfig
+	config.GroupReviewDateAttr = testGroupReviewDateAttr
+
+	assert.Contains(t, (&groupsManager{Client: NewClient(config)}).getSearchRequest("", "", groupSearchFilter).Attributes,
+		testGroupReviewDateAttr)
+	assert.NotContains(t,
+		(&groupsManager{Client: NewClient(testConfig)}).getSearchRequest("", "", groupSearchFilter).Attributes,
+		testGroupReviewDateAttr)
+}
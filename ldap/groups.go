@@ -2,12 +2,14 @@ package ldap
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/atselvan/go-utils/utils/errors"
 	"github.com/atselvan/go-utils/utils/logger"
-	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/atselvan/ldap-go-lib/dn"
 	"github.com/go-ldap/ldap/v3"
 )
 
@@ -15,34 +17,109 @@ const (
 	noSuchUserGroupMemberCn               = "NO_SUCH_USER"
 	groupAlreadyExistsMsg                 = "Group with cn = '%s' and ou = '%s' already exists"
 	groupNotFoundMsg                      = "Group with cn = '%s' and ou = '%s' was not found"
-	invalidOrganizationalUnitErrMsg       = "Invalid organizational unit '%s'. Valid values are %v"
 	uniqueMemberWillBeAddedToGroupMsg     = "UniqueMember '%s' will be added to the group '%s'"
 	uniqueMemberWillBeRemovedFromGroupMsg = "UniqueMember '%s' will be removed from the group '%s'"
 )
 
 type (
 	// GroupsManager describes the interface that needs to be implemented for performing operations on LDAP groups.
+	// The further operations on the groups are described by the optional interfaces GroupsReader, GroupProvisioner,
+	// GroupsSnapshotManager, GroupOwnersManager, GroupReviewManager, GroupNamingManager and GroupsExporter, which are
+	// implemented by the GroupsManager of the client, e.g.
+	//
+	//	client.Groups.(ldap.GroupsReader).IsMemberOf(uid, cn, ou)
 	GroupsManager interface {
-		GetAll() ([]Group, *errors.Error)
-		Get(cn, ou string) ([]Group, *errors.Error)
-		GetFilter(searchFilter string) ([]Group, *errors.Error)
-		Create(cn, ou string, memberIds []string) *errors.Error
-		Delete(cn, ou string) *errors.Error
-		AddMembers(cn, ou string, memberIds []string) *errors.Error
-		RemoveMembers(cn, ou string, memberIds []string) *errors.Error
+		GetAll() ([]Group, *Error)
+		Get(cn, ou string) ([]Group, *Error)
+		GetFilter(searchFilter string) ([]Group, *Error)
+		Create(cn, ou string, memberIds []string) *Error
+		Delete(cn, ou string) *Error
+		AddMembers(cn, ou string, memberIds []string) *Error
+		RemoveMembers(cn, ou string, memberIds []string) *Error
+	}
+
+	// GroupsReader describes the read operations on the groups with request options, e.g. WithAttributes or
+	// WithTimeout, the paged search of the groups, the membership checks and the reads of their history.
+	GroupsReader interface {
+		GetAllWithOptions(opts ...RequestOption) ([]Group, *Error)
+		GetWithOptions(cn, ou string, opts ...RequestOption) ([]Group, *Error)
+		GetFilterWithOptions(searchFilter string, opts ...RequestOption) ([]Group, *Error)
+		Search(searchFilter string, opts ...RequestOption) (*GroupsResult, *Error)
+		IsMemberOf(uid, cn, ou string) (bool, *Error)
+		IsMemberOfAny(uid string, groups []GroupMembership) (bool, *Error)
+		History(cn, ou string) ([]ChangeRecord, *Error)
+	}
+
+	// GroupProvisioner describes the operations which create, update and delete the groups idempotently,
+	// conditionally or with metadata.
+	GroupProvisioner interface {
+		Ensure(cn, ou string, memberIds []string) ([]GroupChange, *Error)
+		ReplaceMembers(cn, ou string, memberIds []string, revision string) *Error
+		CreateWithMetadata(cn, ou string, memberIds []string, metadata GroupMetadata) *Error
+		UpdateMetadata(cn, ou string, metadata GroupMetadata) *Error
+		DeleteWithOptions(cn, ou string, opts ...RequestOption) *Error
+	}
+
+	// GroupsSnapshotManager describes the operations which back up the groups and converge them to a snapshot or a
+	// manifest.
+	GroupsSnapshotManager interface {
+		Snapshot(ou string) (*GroupsSnapshot, *Error)
+		Restore(snapshot *GroupsSnapshot, dryRun bool) ([]GroupChange, *Error)
+		ApplyManifest(r io.Reader, dryRun bool) ([]GroupChange, *Error)
+	}
+
+	// GroupOwnersManager describes the operations on the owners of the groups.
+	GroupOwnersManager interface {
+		SetOwners(cn, ou string, ownerIds []string) *Error
+		AddOwner(cn, ou, ownerId string) *Error
+		RemoveOwner(cn, ou, ownerId string) *Error
+		GetOwnedBy(uid string) ([]Group, *Error)
+	}
+
+	// GroupReviewManager describes the operations on the access review dates of the groups.
+	GroupReviewManager interface {
+		SetReviewDate(cn, ou string, reviewDate time.Time) *Error
+		DueForReview(before time.Time) ([]Group, *Error)
+	}
+
+	// GroupNamingManager describes the checks of the names of the groups against the GroupNamePolicies.
+	GroupNamingManager interface {
+		ValidateName(cn, ou string) *Error
+		NonConformingGroups() ([]GroupNameViolation, *Error)
+	}
+
+	// GroupsExporter describes the exports of the groups.
+	GroupsExporter interface {
+		ExportJSON(w io.Writer, fields ...string) *Error
+		ExportEntitlements(w io.Writer, opts EntitlementExportOptions) *Error
 	}
 
 	// groupsManager implements GroupsManager.
 	groupsManager struct {
 		Client *Client
+		// baseDN overrides the GroupBaseDN set in the client Config.
+		baseDN string
 	}
 
 	// Group represents an LDAP group.
+	// Ou is the organizational unit the group is located in and OuPath is the chain of organizational units
+	// between the GroupBaseDN and the group, starting with the top-level organizational unit.
+	// Owners are the dns of the users who are accountable for the group.
+	// Description and BusinessCategory are the optional metadata of the group, see GroupMetadata.
+	// ReviewDate is the date the group is due for an access review, it is only read if the GroupReviewDateAttr is
+	// set in the client Config.
 	Group struct {
-		Dn      string
-		Ou      string
-		Cn      string
-		Members []string
+		Dn               string
+		Ou               string
+		OuPath           []string
+		Cn               string
+		Members          []string
+		Owners           []string
+		Description      string
+		BusinessCategory []string
+		ReviewDate       *time.Time
+		// Revision identifies the revision of the group entry retrieved from LDAP, see ReplaceMembers.
+		Revision string
 	}
 )
 
@@ -53,8 +130,14 @@ type (
 //   - if the group is not found
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (gm *groupsManager) GetAll() ([]Group, *errors.Error) {
-	return gm.Get("", "")
+func (gm *groupsManager) GetAll() ([]Group, *Error) {
+	return gm.GetWithOptions("", "")
+}
+
+// GetAllWithOptions retrieves all the group entries like GetAll with the options applied to the search request,
+// see GetWithOptions.
+func (gm *groupsManager) GetAllWithOptions(opts ...RequestOption) ([]Group, *Error) {
+	return gm.GetWithOptions("", "", opts...)
 }
 
 // Get retrieves a list of group entries from LDAP.
@@ -62,7 +145,8 @@ func (gm *groupsManager) GetAll() ([]Group, *errors.Error) {
 // params:
 //
 //	cn = common name of the group
-//	ou = organization unit within which the group is contained
+//	ou = organization unit within which the group is contained, nested organizational units are addressed by
+//	     an organizational unit path, e.g. "department-1/team-a"
 //
 // The method returns an error:
 //   - if any validation fails
@@ -70,16 +154,45 @@ func (gm *groupsManager) GetAll() ([]Group, *errors.Error) {
 //   - if the group is not found
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (gm *groupsManager) Get(cn, ou string) ([]Group, *errors.Error) {
+func (gm *groupsManager) Get(cn, ou string) ([]Group, *Error) {
+	return gm.GetWithOptions(cn, ou)
+}
+
+// GetWithOptions retrieves a list of group entries from LDAP like Get.
+// params:
+//
+//	cn = common name of the group
+//	ou = organization unit within which the group is contained, see Get
+//	opts = options applied to the search request, e.g. WithScope(ldap.ScopeSingleLevel) to only retrieve the groups
+//	       directly within the organizational unit
+//
+// The group entries retrieved without request options are cached if WithCache is set on the client.
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) GetWithOptions(cn, ou string, opts ...RequestOption) ([]Group, *Error) {
+	if cache := gm.cache(opts); cache != nil {
+		return cache.groups(cn, ou, func() ([]Group, *Error) { return gm.get(cn, ou) })
+	}
+	return gm.get(cn, ou, opts...)
+}
+
+// get validates the ou and retrieves a list of group entries from LDAP with the options applied to the search
+// request, bypassing the cache.
+func (gm *groupsManager) get(cn, ou string, opts ...RequestOption) ([]Group, *Error) {
 	if ou != "" {
 		if cErr := gm.validateGroupOu(ou); cErr != nil {
 			return nil, cErr
 		}
 	}
-	result, cErr := gm.Client.doLDAPSearch(gm.getSearchRequest(cn, ou, groupSearchFilter))
+	result, cErr := gm.Client.doLDAPSearch(gm.getSearchRequest(cn, ou, gm.Client.getConfig().groupsFilter()),
+		opts...)
 	if cErr != nil {
 		if cErr.Status == http.StatusNotFound {
-			return nil, errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou))
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou)))
 		}
 		return nil, cErr
 	}
@@ -93,8 +206,14 @@ func (gm *groupsManager) Get(cn, ou string) ([]Group, *errors.Error) {
 //   - if the group is not found
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (gm *groupsManager) GetFilter(searchFilter string) ([]Group, *errors.Error) {
-	result, err := gm.Client.doLDAPSearch(gm.getSearchRequest("", "", searchFilter))
+func (gm *groupsManager) GetFilter(searchFilter string) ([]Group, *Error) {
+	return gm.GetFilterWithOptions(searchFilter)
+}
+
+// GetFilterWithOptions filters and gets a list of group entries like GetFilter with the options applied to the
+// search request, e.g. WithTimeout.
+func (gm *groupsManager) GetFilterWithOptions(searchFilter string, opts ...RequestOption) ([]Group, *Error) {
+	result, err := gm.Client.doLDAPSearch(gm.getSearchRequest("", "", searchFilter), opts...)
 
 	if err != nil {
 		return nil, err
@@ -102,6 +221,28 @@ func (gm *groupsManager) GetFilter(searchFilter string) ([]Group, *errors.Error)
 	return gm.parseSearchResult(result), nil
 }
 
+// Search retrieves the group entries from LDAP which match the searchFilter together with the response controls
+// and the referrals returned by the LDAP server.
+// params:
+//
+//	searchFilter = the LDAP search filter, e.g. "(&(cn=app*)(objectClass=groupOfUniqueNames))"
+//	opts = options applied to the search request, e.g. WithControls to request server side sorting
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) Search(searchFilter string, opts ...RequestOption) (*GroupsResult, *Error) {
+	if strings.TrimSpace(searchFilter) == "" {
+		return nil, missingParametersError([]string{searchFilterParam})
+	}
+	result, cErr := gm.Client.doLDAPSearch(gm.getSearchRequest("", "", searchFilter), opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return &GroupsResult{Groups: gm.parseSearchResult(result), ResultControls: newResultControls(result)}, nil
+}
+
 // Create adds a new group entry in LDAP
 // Params:
 //
@@ -116,20 +257,32 @@ func (gm *groupsManager) GetFilter(searchFilter string) ([]Group, *errors.Error)
 //   - if the group already exists
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (gm *groupsManager) Create(cn, ou string, memberIds []string) *errors.Error {
+func (gm *groupsManager) Create(cn, ou string, memberIds []string) *Error {
+	return gm.create(cn, ou, memberIds, GroupMetadata{})
+}
+
+// create adds a new group entry with the metadata in LDAP.
+func (gm *groupsManager) create(cn, ou string, memberIds []string, metadata GroupMetadata) *Error {
 	if err := gm.validateGroup(cn, ou); err != nil {
 		return err
 	}
-	if len(memberIds) == 0 {
-		memberIds = append(memberIds, noSuchUserGroupMemberCn)
+	if err := gm.ValidateName(cn, ou); err != nil {
+		return err
 	}
-	if cErr := gm.Client.doLDAPAdd(gm.getAddRequest(cn, ou, memberIds)); cErr != nil {
+	uniqueMemberIds := memberIds
+	if len(uniqueMemberIds) == 0 {
+		uniqueMemberIds = []string{noSuchUserGroupMemberCn}
+	}
+	ar := gm.getAddRequest(cn, ou, uniqueMemberIds)
+	metadata.addAttributes(ar)
+	if cErr := gm.Client.doLDAPAdd(ar); cErr != nil {
 		if cErr.Status == http.StatusBadRequest {
-			return errors.ConflictError(fmt.Sprintf(groupAlreadyExistsMsg, cn, ou))
+			return WrapError(errors.ConflictError(fmt.Sprintf(groupAlreadyExistsMsg, cn, ou)))
 		} else {
 			return cErr
 		}
 	}
+	gm.emit(EventGroupCreated, cn, ou, memberIds)
 	return nil
 }
 
@@ -145,17 +298,25 @@ func (gm *groupsManager) Create(cn, ou string, memberIds []string) *errors.Error
 //   - if the group is not found
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (gm *groupsManager) Delete(cn, ou string) *errors.Error {
+func (gm *groupsManager) Delete(cn, ou string) *Error {
+	return gm.DeleteWithOptions(cn, ou)
+}
+
+// DeleteWithOptions deletes an existing group entry from LDAP like Delete with the options applied to the delete
+// request, e.g. WithControls with a ControlAssertion to only delete the group if the filter of the assertion matches
+// the group entry.
+func (gm *groupsManager) DeleteWithOptions(cn, ou string, opts ...RequestOption) *Error {
 	if err := gm.validateGroup(cn, ou); err != nil {
 		return err
 	}
-	if cErr := gm.Client.doLDAPDelete(gm.getDeleteRequest(cn, ou)); cErr != nil {
+	if cErr := gm.Client.doLDAPDelete(gm.getDeleteRequest(cn, ou), opts...); cErr != nil {
 		if cErr.Status == http.StatusNotFound {
-			return errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou))
+			return WrapError(errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou)))
 		} else {
 			return cErr
 		}
 	}
+	gm.emit(EventGroupDeleted, cn, ou, nil)
 	return nil
 }
 
@@ -175,22 +336,25 @@ func (gm *groupsManager) Delete(cn, ou string) *errors.Error {
 //   - if the group is not found
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (gm *groupsManager) AddMembers(cn, ou string, memberIds []string) *errors.Error {
-	var uniqueMembers []string
+func (gm *groupsManager) AddMembers(cn, ou string, memberIds []string) *Error {
+	var uniqueMembers, addedMemberIds []string
 	if err := gm.validateGroup(cn, ou); err != nil {
 		return err
 	}
-	result, cErr := gm.Get(cn, ou)
+	result, cErr := gm.get(cn, ou)
 	if cErr != nil {
 		return cErr
 	}
 	group := result[0]
 	mr := gm.getModifyRequest(cn, ou)
 	for _, memberId := range memberIds {
-		uniqueMember := gm.getUniqueMemberDn(strings.ToUpper(memberId))
-		if !slice.EntryExists(group.Members, uniqueMember) {
+		uniqueMember := gm.memberDn(memberId)
+		_, isMember := findMember(group.Members, uniqueMember)
+		_, isAdded := findMember(uniqueMembers, uniqueMember)
+		if !isMember && !isAdded {
 			logger.Info(fmt.Sprintf(uniqueMemberWillBeAddedToGroupMsg, uniqueMember, gm.getDN(cn, ou)))
 			uniqueMembers = append(uniqueMembers, uniqueMember)
+			addedMemberIds = append(addedMemberIds, gm.canonicalMember(memberId))
 		}
 	}
 	if len(uniqueMembers) > 0 {
@@ -203,6 +367,10 @@ func (gm *groupsManager) AddMembers(cn, ou string, memberIds []string) *errors.E
 	if cErr := gm.Client.doLDAPModify(mr); cErr != nil {
 		return cErr
 	}
+	if len(addedMemberIds) > 0 {
+		gm.invalidateMemberships(cn, ou, addedMemberIds)
+		gm.emit(EventGroupMembersAdded, cn, ou, addedMemberIds)
+	}
 	return nil
 }
 
@@ -222,24 +390,26 @@ func (gm *groupsManager) AddMembers(cn, ou string, memberIds []string) *errors.E
 //   - if the group is not found
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (gm *groupsManager) RemoveMembers(cn, ou string, memberIds []string) *errors.Error {
-	var uniqueMembers []string
+func (gm *groupsManager) RemoveMembers(cn, ou string, memberIds []string) *Error {
+	var uniqueMembers, removedMemberIds []string
 	if err := gm.validateGroup(cn, ou); err != nil {
 		return err
 	}
-	result, cErr := gm.Get(cn, ou)
+	result, cErr := gm.get(cn, ou)
 	if cErr != nil {
 		return cErr
 	}
 	group := result[0]
 	mr := gm.getModifyRequest(cn, ou)
 	for _, memberId := range memberIds {
-		uniqueMember := gm.getUniqueMemberDn(strings.ToUpper(memberId))
-		if slice.EntryExists(group.Members, uniqueMember) {
-			if memberId != noSuchUserGroupMemberCn {
-				logger.Info(fmt.Sprintf(uniqueMemberWillBeRemovedFromGroupMsg, uniqueMember, gm.getDN(cn, ou)))
+		uniqueMember := gm.memberDn(memberId)
+		member, isMember := findMember(group.Members, uniqueMember)
+		if _, isRemoved := findMember(uniqueMembers, uniqueMember); isMember && !isRemoved {
+			if !strings.EqualFold(memberId, noSuchUserGroupMemberCn) {
+				logger.Info(fmt.Sprintf(uniqueMemberWillBeRemovedFromGroupMsg, member, gm.getDN(cn, ou)))
+				removedMemberIds = append(removedMemberIds, gm.canonicalMember(memberId))
 			}
-			uniqueMembers = append(uniqueMembers, uniqueMember)
+			uniqueMembers = append(uniqueMembers, member)
 		}
 	}
 	if len(uniqueMembers) > 0 {
@@ -252,28 +422,172 @@ func (gm *groupsManager) RemoveMembers(cn, ou string, memberIds []string) *error
 	if cErr := gm.Client.doLDAPModify(mr); cErr != nil {
 		return cErr
 	}
+	if len(removedMemberIds) > 0 {
+		gm.invalidateMemberships(cn, ou, removedMemberIds)
+		gm.emit(EventGroupMembersRemoved, cn, ou, removedMemberIds)
+	}
+	return nil
+}
+
+// ReplaceMembers replaces all the uniqueMember(s) of an existing group entry in LDAP
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	memberIds: the ids or the DNs of all the members of the group
+//	revision: the Revision of the group entry the change is based on, empty to replace the members unconditionally
+//
+// If the revision is set, the members are only replaced if the group entry was not modified since the revision was
+// retrieved, which prevents lost updates from concurrent changes. The revision is checked immediately before the
+// members are replaced.
+// If NO memberIds are provided then the default unique member NO_SUCH_USER will be the only member of the group.
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if the group is not found
+//   - if the group entry was modified since the revision
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) ReplaceMembers(cn, ou string, memberIds []string, revision string) *Error {
+	var uniqueMembers []string
+	if err := gm.validateGroup(cn, ou); err != nil {
+		return err
+	}
+	result, cErr := gm.get(cn, ou)
+	if cErr != nil {
+		return cErr
+	}
+	group := result[0]
+	if cErr := checkRevision(group.Dn, revision, group.Revision); cErr != nil {
+		return cErr
+	}
+	for _, memberId := range memberIds {
+		uniqueMember := gm.memberDn(memberId)
+		if _, isAdded := findMember(uniqueMembers, uniqueMember); !isAdded {
+			uniqueMembers = append(uniqueMembers, uniqueMember)
+		}
+	}
+	addedMemberIds := gm.memberIds(uniqueMembers, group.Members)
+	removedMemberIds := gm.memberIds(group.Members, uniqueMembers)
+	if len(addedMemberIds) == 0 && len(removedMemberIds) == 0 {
+		return nil
+	}
+	if len(uniqueMembers) == 0 {
+		uniqueMembers = []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)}
+	}
+	mr := gm.getModifyRequest(cn, ou)
+	mr.Replace(uniqueMemberAttr, uniqueMembers)
+	if cErr := gm.Client.doLDAPModify(mr); cErr != nil {
+		return cErr
+	}
+	gm.invalidateMemberships(cn, ou, append(addedMemberIds, removedMemberIds...))
+	if len(addedMemberIds) > 0 {
+		gm.emit(EventGroupMembersAdded, cn, ou, addedMemberIds)
+	}
+	if len(removedMemberIds) > 0 {
+		gm.emit(EventGroupMembersRemoved, cn, ou, removedMemberIds)
+	}
 	return nil
 }
 
+// findMember returns the member of the members which has the same distinguished name as the memberDn, ignoring the
+// case and the insignificant spaces of the distinguished names, and whether such a member was found.
+func findMember(members []string, memberDn string) (string, bool) {
+	key := memberKey(memberDn)
+	for _, member := range members {
+		if memberKey(member) == key {
+			return member, true
+		}
+	}
+	return "", false
+}
+
+// emit emits an event of the event type for the group entry.
+func (gm *groupsManager) emit(eventType EventType, cn, ou string, memberIds []string) {
+	gm.Client.emit(Event{Type: eventType, Dn: gm.getDN(cn, ou), Cn: cn, Ou: ou, MemberIds: memberIds})
+}
+
 // getDN returns the formatted domain name of a ldap group
 func (gm *groupsManager) getDN(cn, ou string) string {
 	if cn != "" && ou != "" {
-		return fmt.Sprintf("%s=%s,%s=%s,%s", CommonNameAttr, cn, OrganizationalUnitAttr, ou,
-			gm.Client.Config.GroupBaseDN)
+		return dn.Join(dn.RDN(CommonNameAttr, cn), ouPathRDNs(ou), gm.groupBaseDN())
 	} else if cn == "" && ou != "" {
-		return fmt.Sprintf("%s=%s,%s", OrganizationalUnitAttr, ou, gm.Client.Config.GroupBaseDN)
+		return dn.Join(ouPathRDNs(ou), gm.groupBaseDN())
 	} else {
-		return gm.Client.Config.GroupBaseDN
+		return gm.groupBaseDN()
+	}
+}
+
+// groupBaseDN returns the base dn of the group entries managed by the groupsManager.
+func (gm *groupsManager) groupBaseDN() string {
+	if gm.baseDN != "" {
+		return gm.baseDN
+	}
+	return gm.Client.getConfig().GroupBaseDN
+}
+
+// organizationalUnits returns the OrganizationalUnitsTreeManager which manages the organizational units
+// within the base dn of the groupsManager. The OrganizationalUnitsManager of the client is used if it implements
+// OrganizationalUnitsTreeManager.
+func (gm *groupsManager) organizationalUnits() OrganizationalUnitsTreeManager {
+	if gm.baseDN != "" {
+		return &organizationalUnitsManager{Client: gm.Client, baseDN: gm.baseDN}
+	}
+	if oum, ok := gm.Client.OrganizationalUnits.(OrganizationalUnitsTreeManager); ok {
+		return oum
+	}
+	return &organizationalUnitsManager{Client: gm.Client}
+}
+
+// canonicalMemberId returns the member id as it is used in the unique member dn. The member id is converted by the
+// normalizer set with WithMemberIdNormalizer, kept as is if PreserveMemberCase is enabled in the client Config, or
+// upper-cased otherwise.
+func (c *Client) canonicalMemberId(memberId string) string {
+	if c.memberIdNormalizer != nil {
+		return c.memberIdNormalizer(memberId)
+	}
+	if c.getConfig().PreserveMemberCase {
+		return memberId
 	}
+	return strings.ToUpper(memberId)
+}
+
+// memberDn returns the unique member dn of a member. A member which is a dn, e.g. of a nested group or of a user
+// in another container, is used as is, any other member is a uid within the UserBaseDN.
+func (gm *groupsManager) memberDn(member string) string {
+	if dn.IsDN(member) {
+		return member
+	}
+	return gm.getUniqueMemberDn(gm.Client.canonicalMemberId(member))
+}
+
+// canonicalMember returns the member as it is reported in the events, the dn of a member which is a dn or the
+// canonical member id of a uid.
+func (gm *groupsManager) canonicalMember(member string) string {
+	if dn.IsDN(member) {
+		return member
+	}
+	return gm.Client.canonicalMemberId(member)
 }
 
 // getUniqueMemberDn returns the formatted unique member domain name
 func (gm *groupsManager) getUniqueMemberDn(memberId string) string {
-	return fmt.Sprintf("%s=%s,%s", userIdAttr, memberId, gm.Client.Config.UserBaseDN)
+	return dn.Join(dn.RDN(userIdAttr, memberId), gm.Client.getConfig().UserBaseDN)
 }
 
 // getSearchRequest returns a ldap search request
 func (gm *groupsManager) getSearchRequest(cn, ou, groupSearchFilter string) *ldap.SearchRequest {
+	attributes := []string{
+		CommonNameAttr,
+		uniqueMemberAttr,
+		ownerAttr,
+		descriptionAttr,
+		businessCategoryAttr,
+	}
+	if reviewDateAttr := gm.Client.getConfig().GroupReviewDateAttr; reviewDateAttr != "" {
+		attributes = append(attributes, reviewDateAttr)
+	}
+	attributes = append(attributes, gm.Client.revisionAttr())
 	return ldap.NewSearchRequest(
 		gm.getDN(cn, ou),
 		ldap.ScopeWholeSubtree,
@@ -282,10 +596,7 @@ func (gm *groupsManager) getSearchRequest(cn, ou, groupSearchFilter string) *lda
 		0,
 		false,
 		groupSearchFilter,
-		[]string{
-			CommonNameAttr,
-			uniqueMemberAttr,
-		},
+		attributes,
 		nil,
 	)
 }
@@ -293,7 +604,7 @@ func (gm *groupsManager) getSearchRequest(cn, ou, groupSearchFilter string) *lda
 func (gm *groupsManager) getAddRequest(cn, ou string, memberIds []string) *ldap.AddRequest {
 	var uniqueMembers []string
 	for _, memberId := range memberIds {
-		uniqueMember := gm.getUniqueMemberDn(strings.ToUpper(memberId))
+		uniqueMember := gm.memberDn(memberId)
 		uniqueMembers = append(uniqueMembers, uniqueMember)
 	}
 	dn := gm.getDN(cn, ou)
@@ -315,12 +626,33 @@ func (gm *groupsManager) getDeleteRequest(cn, ou string) *ldap.DelRequest {
 // parseSearchResult parses the ldap search result and retrieves the group entries.
 func (gm *groupsManager) parseSearchResult(result *ldap.SearchResult) []Group {
 	var groups []Group
+	reviewDateAttr := gm.Client.getConfig().GroupReviewDateAttr
 	for _, entry := range result.Entries {
+		ouPath, _ := dn.ExtractOUPath(entry.DN, gm.groupBaseDN())
+		var ou string
+		if len(ouPath) > 0 {
+			ou = ouPath[len(ouPath)-1]
+		}
 		group := Group{
-			Dn:      entry.DN,
-			Ou:      strings.Replace(strings.Split(entry.DN, ",")[1], OrganizationalUnitAttrValuePrefix, "", -1),
-			Cn:      entry.GetAttributeValue(CommonNameAttr),
-			Members: entry.GetAttributeValues(uniqueMemberAttr),
+			Dn:       entry.DN,
+			Ou:       ou,
+			OuPath:   ouPath,
+			Cn:       entry.GetAttributeValue(CommonNameAttr),
+			Members:  entry.GetAttributeValues(uniqueMemberAttr),
+			Revision: entry.GetAttributeValue(gm.Client.revisionAttr()),
+		}
+		// the owners and the business categories are optional, groups without them are parsed with nil values
+		if owners := entry.GetAttributeValues(ownerAttr); len(owners) > 0 {
+			group.Owners = owners
+		}
+		group.Description = entry.GetAttributeValue(descriptionAttr)
+		if categories := entry.GetAttributeValues(businessCategoryAttr); len(categories) > 0 {
+			group.BusinessCategory = categories
+		}
+		if reviewDateAttr != "" {
+			if reviewDate, ok := parseGeneralizedTime(entry.GetAttributeValue(reviewDateAttr)); ok {
+				group.ReviewDate = &reviewDate
+			}
 		}
 		groups = append(groups, group)
 	}
@@ -328,7 +660,7 @@ func (gm *groupsManager) parseSearchResult(result *ldap.SearchResult) []Group {
 }
 
 // validateGroup checks if required information is provided for a ldap group
-func (gm *groupsManager) validateGroup(cn, ou string) *errors.Error {
+func (gm *groupsManager) validateGroup(cn, ou string) *Error {
 	var missingParams []string
 
 	if strings.TrimSpace(cn) == "" {
@@ -338,7 +670,7 @@ func (gm *groupsManager) validateGroup(cn, ou string) *errors.Error {
 		missingParams = append(missingParams, OrganizationalUnitAttr)
 	}
 	if len(missingParams) > 0 {
-		return errors.BadRequestErrorf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter], missingParams)
+		return missingParametersError(missingParams)
 	}
 	if err := gm.validateGroupOu(ou); err != nil {
 		return err
@@ -347,13 +679,9 @@ func (gm *groupsManager) validateGroup(cn, ou string) *errors.Error {
 }
 
 // validateGroupOu checks if the ldap organizational unit is valid
-func (gm *groupsManager) validateGroupOu(ou string) *errors.Error {
-	organizationalUnits, cErr := gm.Client.OrganizationalUnits.GetAll()
-	if cErr != nil {
-		return cErr
-	}
-	if !slice.EntryExists(organizationalUnits, ou) {
-		return errors.BadRequestError(fmt.Sprintf(invalidOrganizationalUnitErrMsg, ou, organizationalUnits))
+func (gm *groupsManager) validateGroupOu(ou string) *Error {
+	if gm.Client.skipOrgUnitValidation {
+		return nil
 	}
-	return nil
+	return gm.organizationalUnits().Validate(ou)
 }
@@ -0,0 +1,149 @@
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	GroupChangeDelete GroupChangeAction = "delete"
+
+	invalidManifestMsg   = "Invalid groups manifest: %v"
+	duplicateGroupDefMsg = "Group with cn = '%s' and ou = '%s' is defined more than once"
+	groupDefinitionParam = "groups"
+)
+
+type (
+	// GroupsManifest represents the desired groups and their members, e.g. kept in version control.
+	GroupsManifest struct {
+		// Ou is the name or the path of the organizational unit managed by the manifest, empty for all the groups.
+		// It is the default organizational unit of the group definitions.
+		Ou string `json:"ou,omitempty"`
+		// Prune deletes the groups within Ou which are not defined in the manifest.
+		Prune  bool              `json:"prune,omitempty"`
+		Groups []GroupDefinition `json:"groups"`
+	}
+
+	// GroupDefinition represents a group and its members in a GroupsManifest.
+	GroupDefinition struct {
+		Cn string `json:"cn"`
+		// Ou is the path of the organizational unit of the group, defaults to the Ou of the manifest.
+		Ou string `json:"ou,omitempty"`
+		// Members are the ids of the members of the group.
+		Members []string `json:"members"`
+	}
+)
+
+// ReadGroupsManifest reads a GroupsManifest in the JSON format from r.
+// The method returns an error:
+//   - if the manifest is not valid JSON or contains unknown fields
+//   - if a group is defined without a cn or an ou or is defined more than once
+func ReadGroupsManifest(r io.Reader) (*GroupsManifest, *Error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	manifest := &GroupsManifest{}
+	if err := decoder.Decode(manifest); err != nil {
+		return nil, WrapError(errors.BadRequestError(fmt.Sprintf(invalidManifestMsg, err)))
+	}
+	defined := make(map[string]bool, len(manifest.Groups))
+	for i := range manifest.Groups {
+		definition := &manifest.Groups[i]
+		if definition.Ou == "" {
+			definition.Ou = manifest.Ou
+		}
+		var missingParams []string
+		if strings.TrimSpace(definition.Cn) == "" {
+			missingParams = append(missingParams, CommonNameAttr)
+		}
+		if strings.TrimSpace(definition.Ou) == "" {
+			missingParams = append(missingParams, OrganizationalUnitAttr)
+		}
+		if len(missingParams) > 0 {
+			return nil, missingParametersError(missingParams)
+		}
+		key := strings.ToLower(definition.Cn + OuPathSeparator + definition.Ou)
+		if defined[key] {
+			return nil, invalidParameterError(groupDefinitionParam,
+				fmt.Sprintf(duplicateGroupDefMsg, definition.Cn, definition.Ou))
+		}
+		defined[key] = true
+	}
+	return manifest, nil
+}
+
+// ApplyManifest converges the group entries in LDAP to a GroupsManifest read from r: the groups which do not exist
+// are created, the missing members are added and the members which are not defined are removed. If Prune is set in
+// the manifest, the groups within the organizational unit of the manifest which are not defined are deleted.
+// params:
+//
+//	r: the reader of the manifest in the JSON format, see GroupsManifest
+//	dryRun: if true, the changes are only computed and returned without changing LDAP
+//
+// The method returns the changes which were made, or which would be made in case of a dry run. If a change fails
+// the changes made until then are returned together with the error.
+// The method returns an error:
+//   - if the manifest is not valid
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) ApplyManifest(r io.Reader, dryRun bool) ([]GroupChange, *Error) {
+	manifest, cErr := ReadGroupsManifest(r)
+	if cErr != nil {
+		return nil, cErr
+	}
+	groups, cErr := gm.get("", manifest.Ou)
+	if cErr != nil {
+		return nil, cErr
+	}
+	current := make(map[string]Group, len(groups))
+	for _, group := range groups {
+		current[memberKey(group.Dn)] = group
+	}
+
+	var desired []GroupChange
+	defined := make(map[string]bool, len(manifest.Groups))
+	for _, definition := range manifest.Groups {
+		definedGroup := gm.groupSnapshot(definition)
+		defined[memberKey(definedGroup.Dn)] = true
+		desired = append(desired, gm.diffGroup(definedGroup, current)...)
+	}
+	if manifest.Prune {
+		for _, group := range groups {
+			if !defined[memberKey(group.Dn)] {
+				desired = append(desired, GroupChange{
+					Action: GroupChangeDelete, Cn: group.Cn, Ou: strings.Join(group.OuPath, OuPathSeparator),
+				})
+			}
+		}
+	}
+
+	changes := []GroupChange{}
+	for _, change := range desired {
+		if !dryRun {
+			if cErr := gm.applyGroupChange(change); cErr != nil {
+				return changes, cErr
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// groupSnapshot returns the GroupSnapshot of the group defined in a manifest.
+func (gm *groupsManager) groupSnapshot(definition GroupDefinition) GroupSnapshot {
+	members := make([]string, 0, len(definition.Members))
+	for _, memberId := range definition.Members {
+		members = append(members, gm.memberDn(memberId))
+	}
+	return GroupSnapshot{
+		Dn:      gm.getDN(definition.Cn, definition.Ou),
+		Cn:      definition.Cn,
+		Ou:      definition.Ou,
+		Members: members,
+	}
+}
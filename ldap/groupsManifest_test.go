@@ -0,0 +1,125 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGroupsManifest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		manifest, cErr := ReadGroupsManifest(strings.NewReader(
+			`{"ou": "test-ou-1", "prune": true, "groups": [{"cn": "group1", "members": ["c00001"]},
+			{"cn": "group1", "ou": "test-ou-2", "members": []}]}`))
+		assert.Nil(t, cErr)
+		assert.Equal(t, &GroupsManifest{Ou: testOrganizationUnit1, Prune: true, Groups: []GroupDefinition{
+			{Cn: testGroupCn1, Ou: testOrganizationUnit1, Members: []string{"c00001"}},
+			{Cn: testGroupCn1, Ou: testOrganizationUnit2, Members: []string{}},
+		}}, manifest)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, cErr := ReadGroupsManifest(strings.NewReader(`{"groups": [{"name": "group1"}]}`))
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("missing ou", func(t *testing.T) {
+		_, cErr := ReadGroupsManifest(strings.NewReader(`{"groups": [{"cn": "group1"}]}`))
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(OrganizationalUnitAttr).Code)
+	})
+
+	t.Run("duplicate group", func(t *testing.T) {
+		_, cErr := ReadGroupsManifest(strings.NewReader(
+			`{"ou": "test-ou-1", "groups": [{"cn": "group1"}, {"cn": "Group1"}]}`))
+		assert.Equal(t, fmt.Sprintf(duplicateGroupDefMsg, "Group1", testOrganizationUnit1), cErr.Message)
+	})
+}
+
+func TestGroupsManager_ApplyManifest(t *testing.T) {
+	memberDn := func(uid string) string {
+		return "uid=" + uid + "," + testConfig.UserBaseDN
+	}
+	currentSearchResult := &ldap.SearchResult{Entries: []*ldap.Entry{
+		getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, []string{memberDn(testUser1.Uid), memberDn(testUser2.Uid)}),
+		getGroupLDAPEntry(testCN, testOrganizationUnit1, []string{memberDn(testUser1.Uid)}),
+	}}
+	manifest := `{"ou": "test-ou-1", "prune": true, "groups": [
+		{"cn": "group1", "members": ["c00001", "abc_builder"]},
+		{"cn": "group2", "members": ["C00002"]}
+	]}`
+
+	t.Run("dry run", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", testOrganizationUnit1, groupSearchFilter)).
+			Return(currentSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupsSnapshotManager).ApplyManifest(strings.NewReader(manifest), true)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []GroupChange{
+			{Action: GroupChangeAddMembers, Cn: testGroupCn1, Ou: testOrganizationUnit1, MemberIds: []string{testUser3.Uid}},
+			{Action: GroupChangeRemoveMembers, Cn: testGroupCn1, Ou: testOrganizationUnit1, MemberIds: []string{testUser2.Uid}},
+			{Action: GroupChangeCreate, Cn: testGroupCn2, Ou: testOrganizationUnit1, MemberIds: []string{testUser2.Uid}},
+			{Action: GroupChangeDelete, Cn: testCN, Ou: testOrganizationUnit1},
+		}, changes)
+	})
+
+	t.Run("create and prune", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		manifest := `{"ou": "test-ou-1", "prune": true, "groups": [
+			{"cn": "group1", "members": ["C00001", "C00002"]},
+			{"cn": "group2", "members": ["C00002"]}
+		]}`
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", testOrganizationUnit1, groupSearchFilter)).
+			Return(currentSearchResult, nil)
+		ldapMock.On(methodNameAdd, gm.getAddRequest(testGroupCn2, testOrganizationUnit1, []string{testUser2.Uid})).
+			Return(nil)
+		ldapMock.On(methodNameDelete, gm.getDeleteRequest(testCN, testOrganizationUnit1)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupsSnapshotManager).ApplyManifest(strings.NewReader(manifest), false)
+		assert.Nil(t, cErr)
+		assert.Len(t, changes, 2)
+	})
+
+	t.Run("change error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		manifest := `{"ou": "test-ou-1", "groups": [{"cn": "group2", "members": ["C00002"]}]}`
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", testOrganizationUnit1, groupSearchFilter)).
+			Return(currentSearchResult, nil)
+		ldapMock.On(methodNameAdd, gm.getAddRequest(testGroupCn2, testOrganizationUnit1, []string{testUser2.Uid})).
+			Return(ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupsSnapshotManager).ApplyManifest(strings.NewReader(manifest), false)
+		assert.NotNil(t, cErr)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		changes, cErr := client.Groups.(GroupsSnapshotManager).ApplyManifest(strings.NewReader("groups:"), false)
+		assert.Nil(t, changes)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
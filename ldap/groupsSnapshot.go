@@ -0,0 +1,211 @@
+package ldap
+
+import (
+	"strings"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	GroupChangeCreate        GroupChangeAction = "create"
+	GroupChangeAddMembers    GroupChangeAction = "addMembers"
+	GroupChangeRemoveMembers GroupChangeAction = "removeMembers"
+)
+
+type (
+	// GroupsSnapshot represents the groups and their memberships within an organizational unit at a point in time.
+	GroupsSnapshot struct {
+		// Ou is the name or the path of the organizational unit of the snapshot, empty for all the groups.
+		Ou        string          `json:"ou,omitempty"`
+		CreatedAt time.Time       `json:"createdAt"`
+		Groups    []GroupSnapshot `json:"groups"`
+	}
+
+	// GroupSnapshot represents a group and its members in a GroupsSnapshot.
+	GroupSnapshot struct {
+		Dn string `json:"dn"`
+		Cn string `json:"cn"`
+		// Ou is the path of the organizational unit of the group.
+		Ou      string   `json:"ou"`
+		Members []string `json:"members"`
+	}
+
+	// GroupChangeAction is the type of change made to a group when a GroupsSnapshot is restored.
+	GroupChangeAction string
+
+	// GroupChange represents a change made to a group when a GroupsSnapshot is restored.
+	GroupChange struct {
+		Action    GroupChangeAction `json:"action"`
+		Cn        string            `json:"cn"`
+		Ou        string            `json:"ou"`
+		MemberIds []string          `json:"memberIds,omitempty"`
+	}
+)
+
+// Snapshot retrieves all the group entries and their members within an organizational unit from LDAP.
+// params:
+//
+//	ou: name or path of the organizational unit, if empty all the group entries are retrieved
+//
+// The snapshot can be serialized to JSON or to LDIF using GroupsSnapshot.LDIF.
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) Snapshot(ou string) (*GroupsSnapshot, *Error) {
+	groups, cErr := gm.get("", ou)
+	if cErr != nil {
+		return nil, cErr
+	}
+	snapshot := &GroupsSnapshot{Ou: ou, CreatedAt: time.Now().UTC(), Groups: []GroupSnapshot{}}
+	for _, group := range groups {
+		snapshot.Groups = append(snapshot.Groups, GroupSnapshot{
+			Dn:      group.Dn,
+			Cn:      group.Cn,
+			Ou:      strings.Join(group.OuPath, OuPathSeparator),
+			Members: group.Members,
+		})
+	}
+	return snapshot, nil
+}
+
+// Restore reconciles the group entries in LDAP with a snapshot: the groups of the snapshot which do not exist are
+// created, the members which were removed since the snapshot was taken are added back and the members which were
+// added since the snapshot was taken are removed. Groups which are not part of the snapshot are left unchanged.
+// params:
+//
+//	snapshot: the snapshot to restore
+//	dryRun: if true, the changes are only computed and returned without changing LDAP
+//
+// The method returns the changes which were made, or which would be made in case of a dry run. If a change fails
+// the changes made until then are returned together with the error.
+// The method returns an error:
+//   - if any validation fails
+//   - if the organizational unit is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) Restore(snapshot *GroupsSnapshot, dryRun bool) ([]GroupChange, *Error) {
+	if snapshot == nil {
+		return nil, missingParametersError([]string{"snapshot"})
+	}
+	groups, cErr := gm.get("", snapshot.Ou)
+	if cErr != nil {
+		return nil, cErr
+	}
+	current := make(map[string]Group, len(groups))
+	for _, group := range groups {
+		current[memberKey(group.Dn)] = group
+	}
+
+	changes := []GroupChange{}
+	for _, snapshotGroup := range snapshot.Groups {
+		for _, change := range gm.diffGroup(snapshotGroup, current) {
+			if !dryRun {
+				if cErr := gm.applyGroupChange(change); cErr != nil {
+					return changes, cErr
+				}
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// diffGroup returns the changes required to restore the group of a snapshot.
+func (gm *groupsManager) diffGroup(snapshotGroup GroupSnapshot, current map[string]Group) []GroupChange {
+	group, ok := current[memberKey(snapshotGroup.Dn)]
+	if !ok {
+		return []GroupChange{{
+			Action:    GroupChangeCreate,
+			Cn:        snapshotGroup.Cn,
+			Ou:        snapshotGroup.Ou,
+			MemberIds: gm.memberIds(snapshotGroup.Members, nil),
+		}}
+	}
+	var changes []GroupChange
+	if added := gm.memberIds(snapshotGroup.Members, group.Members); len(added) > 0 {
+		changes = append(changes, GroupChange{
+			Action: GroupChangeAddMembers, Cn: snapshotGroup.Cn, Ou: snapshotGroup.Ou, MemberIds: added,
+		})
+	}
+	if removed := gm.memberIds(group.Members, snapshotGroup.Members); len(removed) > 0 {
+		changes = append(changes, GroupChange{
+			Action: GroupChangeRemoveMembers, Cn: snapshotGroup.Cn, Ou: snapshotGroup.Ou, MemberIds: removed,
+		})
+	}
+	return changes
+}
+
+// applyGroupChange makes a change to a group entry in LDAP.
+func (gm *groupsManager) applyGroupChange(change GroupChange) *Error {
+	switch change.Action {
+	case GroupChangeCreate:
+		return gm.Create(change.Cn, change.Ou, change.MemberIds)
+	case GroupChangeAddMembers:
+		return gm.AddMembers(change.Cn, change.Ou, change.MemberIds)
+	case GroupChangeDelete:
+		return gm.Delete(change.Cn, change.Ou)
+	default:
+		return gm.RemoveMembers(change.Cn, change.Ou, change.MemberIds)
+	}
+}
+
+// memberIds returns the ids of the members which are not part of the excluded members. The uid is returned for the
+// members within the UserBaseDN and the dn for any other member. The placeholder member NO_SUCH_USER is never
+// returned.
+func (gm *groupsManager) memberIds(members, excluded []string) []string {
+	excludedKeys := make(map[string]bool, len(excluded))
+	for _, member := range excluded {
+		excludedKeys[memberKey(member)] = true
+	}
+	var memberIds []string
+	for _, member := range members {
+		memberId := memberIdFromDn(member)
+		if !dn.EqualFold(gm.getUniqueMemberDn(memberId), member) {
+			memberId = member
+		}
+		if excludedKeys[memberKey(member)] || strings.EqualFold(memberId, noSuchUserGroupMemberCn) {
+			continue
+		}
+		memberIds = append(memberIds, memberId)
+	}
+	return memberIds
+}
+
+// LDIF returns the group entries of the snapshot in the LDAP Data Interchange Format defined in RFC 2849.
+func (s *GroupsSnapshot) LDIF() string {
+	var sb strings.Builder
+	sb.WriteString(ldifVersion)
+	for _, group := range s.Groups {
+		sb.WriteString("\n")
+		writeLDIFAttribute(&sb, "dn", group.Dn)
+		for _, objectClass := range defaultObjectClassesGroup {
+			writeLDIFAttribute(&sb, objectClassAttr, objectClass)
+		}
+		writeLDIFAttribute(&sb, CommonNameAttr, group.Cn)
+		for _, member := range group.Members {
+			writeLDIFAttribute(&sb, uniqueMemberAttr, member)
+		}
+	}
+	return sb.String()
+}
+
+// memberIdFromDn returns the value of the first attribute of the relative distinguished name of a member.
+func memberIdFromDn(memberDn string) string {
+	parsedDN, err := ldap.ParseDN(memberDn)
+	if err != nil || len(parsedDN.RDNs) == 0 || len(parsedDN.RDNs[0].Attributes) == 0 {
+		return memberDn
+	}
+	return parsedDN.RDNs[0].Attributes[0].Value
+}
+
+// memberKey returns the key used to compare distinguished names ignoring case and insignificant spaces.
+func memberKey(memberDn string) string {
+	if normalized, cErr := dn.Normalize(memberDn); cErr == nil {
+		return strings.ToLower(normalized)
+	}
+	return strings.ToLower(memberDn)
+}
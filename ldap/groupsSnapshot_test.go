@@ -0,0 +1,142 @@
+package ldap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupsManager_Snapshot(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	oum := organizationalUnitsManager{Client: client}
+	gm := groupsManager{Client: client}
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil)
+	ldapMock.On(methodNameSearch, gm.getSearchRequest("", testOrganizationUnit1, groupSearchFilter)).
+		Return(getGroupsOuNotEmptySearchResult, nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	snapshot, cErr := client.Groups.(GroupsSnapshotManager).Snapshot(testOrganizationUnit1)
+	assert.Nil(t, cErr)
+	assert.Equal(t, testOrganizationUnit1, snapshot.Ou)
+	assert.Len(t, snapshot.Groups, 2)
+	assert.Equal(t, gm.getDN(testGroupCn1, testOrganizationUnit1), snapshot.Groups[0].Dn)
+	assert.Equal(t, testOrganizationUnit1, snapshot.Groups[0].Ou)
+	assert.Equal(t, testUniqueMembers1, snapshot.Groups[0].Members)
+
+	data, err := json.Marshal(snapshot)
+	assert.Nil(t, err)
+	var decoded GroupsSnapshot
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, snapshot.Groups, decoded.Groups)
+}
+
+func TestGroupsManager_Restore(t *testing.T) {
+	memberDn := func(uid string) string {
+		return "uid=" + uid + "," + testConfig.UserBaseDN
+	}
+	currentSearchResult := &ldap.SearchResult{Entries: []*ldap.Entry{
+		getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, []string{memberDn(testUser1.Uid), memberDn(testUser2.Uid)}),
+	}}
+
+	t.Run("dry run", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		snapshot := &GroupsSnapshot{Ou: testOrganizationUnit1, Groups: []GroupSnapshot{
+			{
+				Dn:      gm.getDN(testGroupCn1, testOrganizationUnit1),
+				Cn:      testGroupCn1,
+				Ou:      testOrganizationUnit1,
+				Members: []string{"UID=" + testUser1.Uid + ", OU=users,O=company", memberDn(testUser3.Uid)},
+			},
+			{
+				Dn:      gm.getDN(testGroupCn2, testOrganizationUnit1),
+				Cn:      testGroupCn2,
+				Ou:      testOrganizationUnit1,
+				Members: []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)},
+			},
+		}}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", testOrganizationUnit1, groupSearchFilter)).
+			Return(currentSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupsSnapshotManager).Restore(snapshot, true)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []GroupChange{
+			{Action: GroupChangeAddMembers, Cn: testGroupCn1, Ou: testOrganizationUnit1, MemberIds: []string{testUser3.Uid}},
+			{Action: GroupChangeRemoveMembers, Cn: testGroupCn1, Ou: testOrganizationUnit1, MemberIds: []string{testUser2.Uid}},
+			{Action: GroupChangeCreate, Cn: testGroupCn2, Ou: testOrganizationUnit1},
+		}, changes)
+	})
+
+	t.Run("create missing group", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		snapshot := &GroupsSnapshot{Ou: testOrganizationUnit1, Groups: []GroupSnapshot{{
+			Dn:      gm.getDN(testGroupCn2, testOrganizationUnit1),
+			Cn:      testGroupCn2,
+			Ou:      testOrganizationUnit1,
+			Members: []string{memberDn(testUser3.Uid)},
+		}}}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", testOrganizationUnit1, groupSearchFilter)).
+			Return(currentSearchResult, nil)
+		ldapMock.On(methodNameAdd, gm.getAddRequest(testGroupCn2, testOrganizationUnit1, []string{testUser3.Uid})).
+			Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupsSnapshotManager).Restore(snapshot, false)
+		assert.Nil(t, cErr)
+		assert.Len(t, changes, 1)
+	})
+
+	t.Run("missing snapshot", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		changes, cErr := client.Groups.(GroupsSnapshotManager).Restore(nil, true)
+		assert.Nil(t, changes)
+		assert.NotNil(t, cErr)
+	})
+}
+
+func TestGroupsSnapshot_LDIF(t *testing.T) {
+	snapshot := &GroupsSnapshot{Groups: []GroupSnapshot{{
+		Dn:      "cn=group1,ou=test-ou-1,ou=projects,o=company",
+		Cn:      "group1",
+		Members: []string{"uid=C00001,ou=users,o=company", "uid=Jürgen,ou=users,o=company"},
+	}}}
+
+	assert.Equal(t, `version: 1
+
+dn: cn=group1,ou=test-ou-1,ou=projects,o=company
+objectClass: groupOfUniqueNames
+objectClass: top
+cn: group1
+uniqueMember: uid=C00001,ou=users,o=company
+uniqueMember:: dWlkPUrDvHJnZW4sb3U9dXNlcnMsbz1jb21wYW55
+`, snapshot.LDIF())
+}
+
+func TestGroupsManager_memberIds(t *testing.T) {
+	gm := groupsManager{Client: NewClient(testConfig)}
+	serviceAccountDn := "uid=nxrm-ado-agent,ou=service-accounts,o=company"
+	members := []string{
+		gm.getUniqueMemberDn(testUser1.Uid),
+		"UID=c00002, " + testConfig.UserBaseDN,
+		serviceAccountDn,
+		gm.getUniqueMemberDn(noSuchUserGroupMemberCn),
+	}
+
+	assert.Equal(t, []string{testUser1.Uid, "c00002", serviceAccountDn}, gm.memberIds(members, nil))
+	assert.Equal(t, []string{"c00002"}, gm.memberIds(members, []string{members[0], serviceAccountDn}))
+}
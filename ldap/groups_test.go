@@ -3,6 +3,7 @@ package ldap
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/atselvan/go-utils/utils/errors"
@@ -25,6 +26,11 @@ var (
 	testUniqueMembers2 = []string{
 		fmt.Sprintf("%s=%s,%s", userIdAttr, testUser1.Uid, testConfig.UserBaseDN),
 	}
+	// testUniqueMembers3 holds the members of testUniqueMembers2 stored with a different case and spacing.
+	testUniqueMembers3 = []string{
+		fmt.Sprintf("UID=%s, %s", strings.ToLower(testUser1.Uid), testConfig.UserBaseDN),
+		fmt.Sprintf("%s=%s,%s", userIdAttr, testUser2.Uid, testConfig.UserBaseDN),
+	}
 
 	getGroupsOuEmptySearchResult = &ldap.SearchResult{
 		Entries: []*ldap.Entry{
@@ -95,6 +101,71 @@ func TestGroupsManager_getDN(t *testing.T) {
 		dn := gm.getDN(testCN, "")
 		assert.Equal(t, gm.Client.Config.GroupBaseDN, dn)
 	})
+
+	t.Run("ou path", func(t *testing.T) {
+		dn := gm.getDN(testCN, "department-1/team-a")
+		assert.Equal(t, "cn="+testCN+",ou=team-a,ou=department-1,"+gm.Client.Config.GroupBaseDN, dn)
+	})
+
+	t.Run("special characters", func(t *testing.T) {
+		dn := gm.getDN("group, 1", "team+a")
+		assert.Equal(t, `cn=group\, 1,ou=team\+a,`+gm.Client.Config.GroupBaseDN, dn)
+	})
+}
+
+func TestGroupsManager_validateGroupOu(t *testing.T) {
+	ouPath := "department-1/team-a"
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		gm := groupsManager{Client: client}
+		oum := organizationalUnitsManager{Client: client}
+		ouDN := oum.getDN(ouPath)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getTreeSearchRequest(ouDN)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(ouDN, nil)}}, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", ouPath, groupSearchFilter)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("cn="+testGroupCn1+","+ouDN, map[string][]string{CommonNameAttr: {testGroupCn1}}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		groups, cErr := client.Groups.Get("", ouPath)
+		assert.Nil(t, cErr)
+		assert.Len(t, groups, 1)
+		assert.Equal(t, []string{"department-1", "team-a"}, groups[0].OuPath)
+	})
+
+	t.Run("organizational unit not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getTreeSearchRequest(oum.getDN(ouPath))).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		groups, cErr := client.Groups.Get("", ouPath)
+		assert.Nil(t, groups)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(invalidOrganizationalUnitPathErrMsg, ouPath), cErr.Message)
+	})
+}
+
+func TestGroupsManager_parseSearchResult(t *testing.T) {
+	client := NewClient(testConfig)
+	gm := groupsManager{Client: client}
+	entry := ldap.NewEntry(gm.getDN(testCN, "team, a"), nil)
+
+	nestedEntry := ldap.NewEntry("cn=group-1,ou=team-a,ou=department-1,"+testConfig.GroupBaseDN, nil)
+
+	groups := gm.parseSearchResult(&ldap.SearchResult{Entries: []*ldap.Entry{entry, nestedEntry}})
+	assert.Equal(t, "team, a", groups[0].Ou)
+	assert.Equal(t, []string{"team, a"}, groups[0].OuPath)
+	assert.Equal(t, "team-a", groups[1].Ou)
+	assert.Equal(t, []string{"department-1", "team-a"}, groups[1].OuPath)
 }
 
 func TestGroupsManager_GetAll(t *testing.T) {
@@ -210,6 +281,25 @@ func TestGroupsManager_Get(t *testing.T) {
 			assert.NotNil(t, groups)
 			assert.Len(t, groups, 1)
 		})
+
+		t.Run("single level scope and group search filter", func(t *testing.T) {
+			ldapMock := mocks.NewClient(t)
+			config := testConfig
+			config.GroupSearchFilter = "(objectClass=groupOfNames)"
+			client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+
+			gm := groupsManager{Client: client}
+			sr := gm.getSearchRequest("", testOrganizationUnit1, "(objectClass=groupOfNames)")
+			sr.Scope = ldap.ScopeSingleLevel
+
+			ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+			ldapMock.On(methodNameSearch, sr).Return(getGroupsOuNotEmptySearchResult, nil)
+			ldapMock.On(methodNameClose).Return(nil)
+
+			groups, cErr := client.Groups.(GroupsReader).GetWithOptions("", testOrganizationUnit1, WithScope(ldap.ScopeSingleLevel))
+			assert.Nil(t, cErr)
+			assert.Len(t, groups, 2)
+		})
 	})
 
 	t.Run("user not found", func(t *testing.T) {
@@ -294,6 +384,64 @@ func TestGroupsManager_GetFilter(t *testing.T) {
 	})
 }
 
+func TestGroupsManager_Search(t *testing.T) {
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		result, cErr := client.Groups.(GroupsReader).Search(" ")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(
+			errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{searchFilterParam},
+		), cErr.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		gm := groupsManager{Client: client}
+		searchFilter := fmt.Sprintf(WildcardGroupsSearchFilter, testGroupCn1)
+		sorting := ldap.NewControlServerSideSortingWithSortKeys([]*ldap.SortKey{{AttributeType: CommonNameAttr}})
+		sr := gm.getSearchRequest("", "", searchFilter)
+		sr.Controls = []ldap.Control{sorting}
+		sortResult := &ldap.ControlServerSideSortingResult{Result: ldap.ControlServerSideSortingCodeSuccess}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(&ldap.SearchResult{
+			Entries:   getFilteredGroupSearchResult.Entries,
+			Referrals: []string{"ldap://ldap2.company.com/ou=projects,o=company"},
+			Controls:  []ldap.Control{sortResult},
+		}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Groups.(GroupsReader).Search(searchFilter, WithControls(sorting))
+		assert.Nil(t, cErr)
+		assert.Len(t, result.Groups, 2)
+		assert.Equal(t, testGroupCn1, result.Groups[0].Cn)
+		assert.Equal(t, []string{"ldap://ldap2.company.com/ou=projects,o=company"}, result.Referrals)
+		assert.Equal(t, sortResult, result.SortResult())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		gm := groupsManager{Client: client}
+		searchFilter := fmt.Sprintf(WildcardGroupsSearchFilter, testGroupCn1)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", searchFilter)).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Groups.(GroupsReader).Search(searchFilter)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+}
+
 func TestGroupsManager_Create(t *testing.T) {
 
 	t.Run("validate", func(t *testing.T) {
@@ -502,6 +650,24 @@ func TestGroupsManager_Delete(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, cErr.Status)
 		assert.Equal(t, ldap.LDAPResultCodeMap[ldap.LDAPResultInsufficientAccessRights], cErr.Message)
 	})
+
+	t.Run("assertion failed", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		assertion, cErr := NewControlAssertion("(uniqueMember=" + gm.getUniqueMemberDn(noSuchUserGroupMemberCn) + ")")
+		assert.Nil(t, cErr)
+		dr := gm.getDeleteRequest(testGroupCn1, testOrganizationUnit1)
+		dr.Controls = []ldap.Control{assertion}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, dr).Return(ldapAssertionFailedErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr = client.Groups.(GroupProvisioner).DeleteWithOptions(testGroupCn1, testOrganizationUnit1, WithControls(assertion))
+		assert.Equal(t, ErrCodeAssertionFailed, cErr.Code)
+		assert.Equal(t, http.StatusPreconditionFailed, cErr.Status)
+	})
 }
 
 func TestGroupsManager_AddMembers(t *testing.T) {
@@ -561,6 +727,55 @@ func TestGroupsManager_AddMembers(t *testing.T) {
 			assert.Nil(t, cErr)
 		})
 
+		t.Run("with members which are dns", func(t *testing.T) {
+			ldapMock := mocks.NewClient(t)
+			client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+				WithoutOrganizationalUnitValidation())
+
+			gm := groupsManager{Client: client}
+			nestedGroupDn := gm.getDN(testGroupCn2, testOrganizationUnit1)
+			serviceAccountDn := "uid=nxrm-ado-agent,ou=service-accounts,o=company"
+			var events []Event
+			client.On(EventGroupMembersAdded, func(event Event) { events = append(events, event) })
+
+			ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+			ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+				Return(getGroupSearchResult2, nil)
+			mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+			mr.Add(uniqueMemberAttr, []string{nestedGroupDn, serviceAccountDn, gm.getUniqueMemberDn(testUser2.Uid)})
+			mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+			ldapMock.On(methodNameModify, mr).Return(nil)
+			ldapMock.On(methodNameClose).Return(nil).Return(nil)
+
+			cErr := client.Groups.AddMembers(testGroupCn1, testOrganizationUnit1,
+				[]string{nestedGroupDn, serviceAccountDn, testUser2.Uid, gm.getUniqueMemberDn(testUser1.Uid)})
+			assert.Nil(t, cErr)
+			assert.Equal(t, []string{nestedGroupDn, serviceAccountDn, testUser2.Uid}, events[0].MemberIds)
+		})
+
+		t.Run("with existing member stored in a different case", func(t *testing.T) {
+			ldapMock := mocks.NewClient(t)
+			client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+				WithoutOrganizationalUnitValidation())
+
+			gm := groupsManager{Client: client}
+
+			ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+			ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+				Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+					getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, testUniqueMembers3),
+				}}, nil)
+			mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+			mr.Add(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser3.Uid)})
+			mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+			ldapMock.On(methodNameModify, mr).Return(nil)
+			ldapMock.On(methodNameClose).Return(nil).Return(nil)
+
+			cErr := client.Groups.AddMembers(testGroupCn1, testOrganizationUnit1,
+				[]string{testUser1.Uid, testUser3.Uid, "abc_builder"})
+			assert.Nil(t, cErr)
+		})
+
 		t.Run("with no member", func(t *testing.T) {
 			ldapMock := mocks.NewClient(t)
 			client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
@@ -696,6 +911,28 @@ func TestGroupsManager_RemoveMembers(t *testing.T) {
 			assert.Nil(t, cErr)
 		})
 
+		t.Run("with existing member stored in a different case", func(t *testing.T) {
+			ldapMock := mocks.NewClient(t)
+			client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+				WithoutOrganizationalUnitValidation())
+
+			gm := groupsManager{Client: client}
+
+			ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+			ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+				Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+					getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, testUniqueMembers3),
+				}}, nil)
+			mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+			mr.Delete(uniqueMemberAttr, []string{testUniqueMembers3[0]})
+			ldapMock.On(methodNameModify, mr).Return(nil)
+			ldapMock.On(methodNameClose).Return(nil).Return(nil)
+
+			cErr := client.Groups.RemoveMembers(testGroupCn1, testOrganizationUnit1,
+				[]string{testUser1.Uid, "c00001"})
+			assert.Nil(t, cErr)
+		})
+
 		t.Run("with all member(s)", func(t *testing.T) {
 			ldapMock := mocks.NewClient(t)
 			client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
@@ -800,3 +1037,128 @@ func getGroupLDAPEntry(cn, ou string, uniqueMembers []string) *ldap.Entry {
 		Attributes: attributes,
 	}
 }
+
+func TestGroupsManager_ReplaceMembers(t *testing.T) {
+	entry := getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, testUniqueMembers2)
+	entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(modifyTimestampAttr, []string{testRevision}))
+	searchResult := &ldap.SearchResult{Entries: []*ldap.Entry{entry}}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		var events []Event
+		client.On(EventGroupMembersAdded, func(event Event) { events = append(events, event) })
+		client.On(EventGroupMembersRemoved, func(event Event) { events = append(events, event) })
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser2.Uid), gm.getUniqueMemberDn(testUser3.Uid)})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(searchResult, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupProvisioner).ReplaceMembers(testGroupCn1, testOrganizationUnit1,
+			[]string{testUser2.Uid, "abc_builder", testUser3.Uid}, testRevision)
+		assert.Nil(t, cErr)
+		assert.Len(t, events, 2)
+		assert.Equal(t, []string{testUser2.Uid, testUser3.Uid}, events[0].MemberIds)
+		assert.Equal(t, []string{testUser1.Uid}, events[1].MemberIds)
+	})
+
+	t.Run("no members", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Replace(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(searchResult, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupProvisioner).ReplaceMembers(testGroupCn1, testOrganizationUnit1, nil, ""))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(searchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.(GroupProvisioner).ReplaceMembers(testGroupCn1, testOrganizationUnit1, []string{"c00001"}, ""))
+	})
+
+	t.Run("modified since the revision", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(searchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.(GroupProvisioner).ReplaceMembers(testGroupCn1, testOrganizationUnit1, []string{testUser2.Uid},
+			"20261016110000Z")
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Groups.(GroupProvisioner).ReplaceMembers("", testOrganizationUnit1, nil, "")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestClient_canonicalMemberId(t *testing.T) {
+	t.Run("upper-cased by default", func(t *testing.T) {
+		client := NewClient(testConfig)
+		assert.Equal(t, "NXRM-ADO-AGENT", client.canonicalMemberId("nxrm-ado-agent"))
+	})
+
+	t.Run("preserve member case", func(t *testing.T) {
+		config := testConfig
+		config.PreserveMemberCase = true
+		client := NewClient(config)
+		assert.Equal(t, "nxrm-ado-agent", client.canonicalMemberId("nxrm-ado-agent"))
+	})
+
+	t.Run("normalizer", func(t *testing.T) {
+		config := testConfig
+		config.PreserveMemberCase = true
+		client := NewClient(config, WithMemberIdNormalizer(strings.ToLower))
+		assert.Equal(t, "nxrm-ado-agent", client.canonicalMemberId("NXRM-ADO-Agent"))
+	})
+
+	t.Run("add members preserving the case", func(t *testing.T) {
+		config := testConfig
+		config.PreserveMemberCase = true
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		gm := groupsManager{Client: client}
+		var events []Event
+		client.On(EventGroupMembersAdded, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult2, nil)
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Add(uniqueMemberAttr, []string{"uid=nxrm-ado-agent," + testConfig.UserBaseDN})
+		mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.AddMembers(testGroupCn1, testOrganizationUnit1, []string{"nxrm-ado-agent"})
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{"nxrm-ado-agent"}, events[0].MemberIds)
+	})
+}
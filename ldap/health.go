@@ -0,0 +1,140 @@
+package ldap
+
+import (
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	rootDSESearchFilter = "(objectClass=*)"
+
+	vendorNameAttr           = "vendorName"
+	vendorVersionAttr        = "vendorVersion"
+	namingContextsAttr       = "namingContexts"
+	supportedControlAttr     = "supportedControl"
+	supportedExtensionAttr   = "supportedExtension"
+	supportedLDAPVersionAttr = "supportedLDAPVersion"
+
+	defaultHealthProbeAttr = descriptionAttr
+)
+
+var (
+	// HealthCheckTimeout is the maximum amount of time a health check is allowed to take.
+	HealthCheckTimeout = 5 * time.Second
+
+	rootDSEAttributes = []string{
+		vendorNameAttr,
+		vendorVersionAttr,
+		namingContextsAttr,
+		supportedControlAttr,
+		supportedExtensionAttr,
+		supportedLDAPVersionAttr,
+	}
+)
+
+type (
+	// HealthReport represents the health of the LDAP server as seen by the client.
+	// Writable is only set if the HealthProbeDN is set in the client Config, see HealthReport.
+	HealthReport struct {
+		Healthy        bool          `json:"healthy"`
+		Latency        time.Duration `json:"latency"`
+		VendorName     string        `json:"vendorName,omitempty"`
+		VendorVersion  string        `json:"vendorVersion,omitempty"`
+		NamingContexts []string      `json:"namingContexts,omitempty"`
+		Writable       *bool         `json:"writable,omitempty"`
+	}
+)
+
+// Ping checks if the LDAP server is reachable by binding and reading the RootDSE entry.
+// The check is aborted if it takes longer than HealthCheckTimeout.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (c *Client) Ping() *Error {
+	_, cErr := c.getRootDSE()
+	return cErr
+}
+
+// HealthReport checks the health of the LDAP server and returns a report that includes the latency of a
+// RootDSE read and the server vendor. If the HealthProbeDN is set in the client Config, the report also includes
+// whether the server accepts write operations from the bind user, which is checked by writing the current time to the
+// HealthProbeAttr of the HealthProbeDN entry. No write is sent if the HealthProbeDN is not set.
+// The report is returned even if the server is not healthy, in which case the error is returned as well.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (c *Client) HealthReport() (*HealthReport, *Error) {
+	report := &HealthReport{}
+
+	start := time.Now()
+	entry, cErr := c.getRootDSE()
+	report.Latency = time.Since(start)
+	if cErr != nil {
+		return report, cErr
+	}
+
+	report.Healthy = true
+	report.VendorName = entry.GetAttributeValue(vendorNameAttr)
+	report.VendorVersion = entry.GetAttributeValue(vendorVersionAttr)
+	report.NamingContexts = entry.GetAttributeValues(namingContextsAttr)
+	if probeDN := c.getConfig().HealthProbeDN; probeDN != "" {
+		writable := c.isWritable(probeDN)
+		report.Writable = &writable
+	}
+
+	return report, nil
+}
+
+// getRootDSE reads the RootDSE entry of the LDAP server like any other search, so the read is seen by the middleware
+// and the OperationObserver. The request is aborted if the server does not respond within HealthCheckTimeout.
+func (c *Client) getRootDSE() (*ldap.Entry, *Error) {
+	result, cErr := c.doLDAPSearch(c.getRootDSESearchRequest(), WithTimeout(HealthCheckTimeout))
+	if cErr != nil {
+		return nil, cErr
+	}
+	if len(result.Entries) == 0 {
+		return &ldap.Entry{}, nil
+	}
+	return result.Entries[0], nil
+}
+
+// isWritable replaces the health probe attribute of the probe entry with the current time to check if the server
+// accepts write operations. The request is executed like any other write, e.g. it is subject to the ProtectedDNs and
+// the policy, and the server is considered read-only if the write fails for any reason.
+func (c *Client) isWritable(probeDN string) bool {
+	mr := ldap.NewModifyRequest(probeDN, nil)
+	mr.Replace(c.healthProbeAttr(), []string{time.Now().UTC().Format(generalizedTimeLayout)})
+	return c.doLDAPModify(mr) == nil
+}
+
+// healthProbeAttr returns the HealthProbeAttr or the default health probe attribute if the HealthProbeAttr is not set.
+func (c *Client) healthProbeAttr() string {
+	if attr := c.getConfig().HealthProbeAttr; attr != "" {
+		return attr
+	}
+	return defaultHealthProbeAttr
+}
+
+// getRootDSESearchRequest returns a ldap search request to read the RootDSE entry.
+func (c *Client) getRootDSESearchRequest() *ldap.SearchRequest {
+	return &ldap.SearchRequest{
+		BaseDN:       "",
+		Scope:        ldap.ScopeBaseObject,
+		DerefAliases: ldap.NeverDerefAliases,
+		SizeLimit:    0,
+		TimeLimit:    int(HealthCheckTimeout.Seconds()),
+		TypesOnly:    false,
+		Filter:       rootDSESearchFilter,
+		Attributes:   rootDSEAttributes,
+		Controls:     nil,
+	}
+}
+
+// isRootDSESearch checks if the request is a search for the RootDSE entry.
+func isRootDSESearch(request any) bool {
+	sr, ok := request.(*ldap.SearchRequest)
+	return ok && sr.BaseDN == "" && sr.Scope == ldap.ScopeBaseObject
+}
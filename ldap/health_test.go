@@ -0,0 +1,181 @@
+package ldap
+
+import (
+	err "errors"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	methodNameSetTimeout = "SetTimeout"
+
+	ldapReferralErr = ldap.NewError(ldap.LDAPResultReferral, err.New(""))
+
+	testHealthProbeConfig = func() Config {
+		config := testConfig
+		config.HealthProbeDN = "cn=health-probe,o=company"
+		return config
+	}()
+
+	isHealthProbeRequest = mock.MatchedBy(func(mr *ldap.ModifyRequest) bool {
+		return mr.DN == testHealthProbeConfig.HealthProbeDN && len(mr.Changes) == 1 &&
+			mr.Changes[0].Operation == ldap.ReplaceAttribute &&
+			mr.Changes[0].Modification.Type == defaultHealthProbeAttr && len(mr.Changes[0].Modification.Vals) == 1
+	})
+
+	getRootDSESearchResult = &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry("", map[string][]string{
+				vendorNameAttr:     {"OpenLDAP"},
+				vendorVersionAttr:  {"2.6.7"},
+				namingContextsAttr: {"o=company"},
+			}),
+		},
+	}
+)
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Ping()
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("bind error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(ldapInvalidCredentialsErr)
+
+		cErr := client.Ping()
+		assert.Equal(t, errors.ErrCodeUnauthorized, cErr.Code)
+		assert.Equal(t, http.StatusUnauthorized, cErr.Status)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(nil, ldapNetworkErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Ping()
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	})
+}
+
+func TestClient_HealthReport(t *testing.T) {
+	t.Run("without write probe", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		report, cErr := client.HealthReport()
+		assert.Nil(t, cErr)
+		assert.True(t, report.Healthy)
+		assert.Nil(t, report.Writable)
+		assert.Equal(t, "OpenLDAP", report.VendorName)
+		assert.Equal(t, "2.6.7", report.VendorVersion)
+		assert.Equal(t, []string{"o=company"}, report.NamingContexts)
+		ldapMock.AssertNotCalled(t, methodNameModify, mock.Anything)
+	})
+
+	t.Run("writable", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHealthProbeConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil)
+		ldapMock.On(methodNameModify, isHealthProbeRequest).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		report, cErr := client.HealthReport()
+		assert.Nil(t, cErr)
+		assert.True(t, report.Healthy)
+		assert.True(t, *report.Writable)
+	})
+
+	t.Run("custom probe attribute", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testHealthProbeConfig
+		config.HealthProbeAttr = "lastProbeTime"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil)
+		ldapMock.On(methodNameModify, mock.MatchedBy(func(mr *ldap.ModifyRequest) bool {
+			return mr.Changes[0].Modification.Type == "lastProbeTime"
+		})).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		report, cErr := client.HealthReport()
+		assert.Nil(t, cErr)
+		assert.True(t, *report.Writable)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHealthProbeConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil)
+		ldapMock.On(methodNameModify, isHealthProbeRequest).Return(ldapReferralErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		report, cErr := client.HealthReport()
+		assert.Nil(t, cErr)
+		assert.True(t, report.Healthy)
+		assert.False(t, *report.Writable)
+	})
+
+	t.Run("refused by the policy", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHealthProbeConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithPolicy(selfServicePolicy))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		report, cErr := client.HealthReport()
+		assert.Nil(t, cErr)
+		assert.True(t, report.Healthy)
+		assert.False(t, *report.Writable)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(ldapInvalidCredentialsErr)
+
+		report, cErr := client.HealthReport()
+		assert.NotNil(t, report)
+		assert.False(t, report.Healthy)
+		assert.Equal(t, errors.ErrCodeUnauthorized, cErr.Code)
+	})
+}
@@ -0,0 +1,178 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	managerAttr = "manager"
+
+	// maxManagementChainLength limits the length of a management chain in case the chain is not terminated.
+	maxManagementChainLength = 100
+
+	directReportsSearchFilter = "(&%s(%s=%s))"
+	managementCycleMsg        = "The management chain of the user with uid = '%s' contains a cycle at '%s', the chain is cut"
+	managerCycleMsg           = "The user with uid = '%s' cannot be managed by '%s', since the user is in its management chain"
+)
+
+// SetManager sets the manager of an existing user entry in LDAP.
+// param:
+//
+//	uid 	= user identifier
+//	manager = dn of the manager or uid of a user within the UserBaseDN, an empty manager removes the manager
+//
+// The management chain of the manager is checked before it is set, a user cannot be its own manager or manage any
+// of its managers.
+// The method returns an error:
+//   - if a validation fails
+//   - if the manager would create a cycle in the management chain
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) SetManager(uid, manager string) *Error {
+	if cErr := um.validateUid(uid); cErr != nil {
+		return cErr
+	}
+	mr := ldap.NewModifyRequest(um.getDN(uid), nil)
+	if strings.TrimSpace(manager) == "" {
+		mr.Replace(managerAttr, []string{})
+	} else {
+		managerDN := um.managerDn(manager)
+		if cErr := um.validateManager(uid, managerDN); cErr != nil {
+			return cErr
+		}
+		mr.Replace(managerAttr, []string{managerDN})
+	}
+	if cErr := um.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return cErr
+	}
+	um.emit(EventUserUpdated, uid)
+	return nil
+}
+
+// validateManager checks that the user is neither the manager nor in the management chain of the manager.
+func (um *usersManager) validateManager(uid, managerDN string) *Error {
+	userKey := dnKey(um.getDN(uid))
+	visited := map[string]bool{}
+	for chainDN := managerDN; chainDN != "" && len(visited) < maxManagementChainLength; {
+		key := dnKey(chainDN)
+		if key == userKey {
+			return invalidParameterError(managerAttr, fmt.Sprintf(managerCycleMsg, uid, managerDN))
+		}
+		if visited[key] {
+			break
+		}
+		visited[key] = true
+		manager, cErr := um.getByDN(chainDN)
+		if cErr != nil {
+			if cErr.Status == http.StatusNotFound {
+				break
+			}
+			return cErr
+		}
+		chainDN = manager.Manager
+	}
+	return nil
+}
+
+// GetDirectReports retrieves the users whose manager is the user from LDAP.
+// param:
+//
+//	uid 	= user identifier of the manager
+//	opts 	= options applied to the search request, e.g. WithAttributes or WithTimeout
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetDirectReports(uid string, opts ...RequestOption) ([]User, *Error) {
+	if cErr := um.validateUid(uid); cErr != nil {
+		return nil, cErr
+	}
+	sr := um.getUsersSearchRequest(fmt.Sprintf(directReportsSearchFilter, um.Client.getConfig().usersFilter(),
+		managerAttr, ldap.EscapeFilter(um.getDN(uid))))
+	result, cErr := um.Client.doLDAPSearch(sr, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return um.parseSearchResult(result), nil
+}
+
+// GetManagementChain retrieves the managers of a user from LDAP, starting with the direct manager of the user and
+// ending with the user who has no manager.
+// param:
+//
+//	uid = user identifier
+//
+// The chain ends early if a manager is not found. If the chain contains a cycle, e.g. two users who are each other's
+// manager, the chain is cut before the first user which appears twice and a warning is logged.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetManagementChain(uid string) ([]User, *Error) {
+	user, cErr := um.get(uid)
+	if cErr != nil {
+		return nil, cErr
+	}
+	chain := []User{}
+	visited := map[string]bool{dnKey(um.getDN(user.Uid)): true}
+	managerDN := user.Manager
+	for managerDN != "" && len(chain) < maxManagementChainLength {
+		if visited[dnKey(managerDN)] {
+			logger.Warnf(managementCycleMsg, uid, managerDN)
+			break
+		}
+		visited[dnKey(managerDN)] = true
+		manager, cErr := um.getByDN(managerDN)
+		if cErr != nil {
+			if cErr.Status == http.StatusNotFound {
+				break
+			}
+			return nil, cErr
+		}
+		chain = append(chain, *manager)
+		managerDN = manager.Manager
+	}
+	return chain, nil
+}
+
+// getByDN retrieves the user entry with the dn from LDAP.
+func (um *usersManager) getByDN(userDN string) (*User, *Error) {
+	result, cErr := um.Client.doLDAPSearch(um.getUserSearchRequest(userDN))
+	if cErr != nil {
+		return nil, cErr
+	}
+	if len(result.Entries) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(entryNotFoundMsg, userDN)))
+	}
+	return &(um.parseSearchResult(result))[0], nil
+}
+
+// managerDn returns the dn of the manager, which is used as is if it is a dn or is the uid of a user within the
+// UserBaseDN otherwise.
+func (um *usersManager) managerDn(manager string) string {
+	if dn.IsDN(manager) {
+		return manager
+	}
+	return um.getDN(manager)
+}
+
+// dnKey returns a key which is equal for equal distinguished names, ignoring the case and insignificant spaces.
+func dnKey(userDN string) string {
+	if normalized, cErr := dn.Normalize(userDN); cErr == nil {
+		return strings.ToLower(normalized)
+	}
+	return strings.ToLower(userDN)
+}
@@ -0,0 +1,200 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getManagedUserEntry(uid, managerDN string) *ldap.Entry {
+	attributes := map[string][]string{userIdAttr: {uid}, CommonNameAttr: {uid}}
+	if managerDN != "" {
+		attributes[managerAttr] = []string{managerDN}
+	}
+	return ldap.NewEntry("uid="+uid+",ou=users,o=company", attributes)
+}
+
+func TestUsersManager_SetManager(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := &usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(managerAttr, []string{um.getDN("C00002")})
+		var events []Event
+		client.On(EventUserUpdated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN("C00002"))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getManagedUserEntry("C00002", um.getDN("C00003"))}}, nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN("C00003"))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getManagedUserEntry("C00003", "")}}, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, "C00002"))
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("remove manager", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest((&usersManager{Client: client}).getDN(testUser1.Uid), nil)
+		mr.Replace(managerAttr, []string{})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, ""))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := &usersManager{Client: client}
+		managerDN := "UID=C00002, OU=users, O=company"
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(managerDN)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getManagedUserEntry("C00002", "UID=C00001,OU=users,O=company"),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := um.SetManager(testUser1.Uid, managerDN)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(managerAttr).Code)
+	})
+
+	t.Run("own manager", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, testUser1.Uid)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(managerAttr).Code)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, "")
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+}
+
+func TestUsersManager_GetDirectReports(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		managerDN := (&usersManager{Client: client}).getDN(testUser1.Uid)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Contains(t, sr.Filter, "(manager="+managerDN+")")
+				assert.Contains(t, sr.Attributes, managerAttr)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getManagedUserEntry("C00002", managerDN),
+				getManagedUserEntry("C00003", managerDN),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		reports, cErr := client.Users.(UserHierarchyManager).GetDirectReports(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Len(t, reports, 2)
+		assert.Equal(t, "C00002", reports[0].Uid)
+		assert.Equal(t, managerDN, reports[0].Manager)
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		reports, cErr := client.Users.(UserHierarchyManager).GetDirectReports("")
+		assert.Nil(t, reports)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+}
+
+func TestUsersManager_GetManagementChain(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := &usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN(testUser1.Uid))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getManagedUserEntry(testUser1.Uid, um.getDN("C00002"))}}, nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN("C00002"))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getManagedUserEntry("C00002", um.getDN("C00003"))}}, nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN("C00003"))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getManagedUserEntry("C00003", "")}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chain, cErr := client.Users.(UserHierarchyManager).GetManagementChain(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Len(t, chain, 2)
+		assert.Equal(t, "C00002", chain[0].Uid)
+		assert.Equal(t, "C00003", chain[1].Uid)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := &usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN(testUser1.Uid))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getManagedUserEntry(testUser1.Uid, um.getDN("C00002"))}}, nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN("C00002"))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getManagedUserEntry("C00002", "UID=C00001, OU=users, O=company"),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chain, cErr := client.Users.(UserHierarchyManager).GetManagementChain(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Len(t, chain, 1)
+		assert.Equal(t, "C00002", chain[0].Uid)
+	})
+
+	t.Run("manager not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := &usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN(testUser1.Uid))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getManagedUserEntry(testUser1.Uid, um.getDN("C00002"))}}, nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(um.getDN("C00002"))).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chain, cErr := client.Users.(UserHierarchyManager).GetManagementChain(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Empty(t, chain)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chain, cErr := client.Users.(UserHierarchyManager).GetManagementChain(testUser1.Uid)
+		assert.Nil(t, chain)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+}
@@ -0,0 +1,100 @@
+package ldap
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/dn"
+)
+
+const historyFeature = "history of the entries, set the AccessLogBaseDN or the DeletedObjectsDN in the client Config"
+
+// History retrieves the changes made to a user entry in LDAP, ordered by the time they were made.
+// params:
+//
+//	uid = the uid of the user
+//
+// The changes are read from the accesslog if the AccessLogBaseDN is set in the client Config. The deletion of the
+// user is read from the Active Directory Deleted Objects container if the DeletedObjectsDN is set in the client
+// Config, which does not record the other changes. The history of a deleted user can be retrieved as long as the
+// server keeps the accesslog or the tombstones.
+// The method returns an error:
+//   - if a validation fails
+//   - if neither the AccessLogBaseDN nor the DeletedObjectsDN is set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) History(uid string) ([]ChangeRecord, *Error) {
+	if cErr := um.validateUid(uid); cErr != nil {
+		return nil, cErr
+	}
+	return um.Client.entryHistory(um.getDN(uid))
+}
+
+// History retrieves the changes made to a group entry in LDAP, ordered by the time they were made, e.g. to find out
+// who added or removed the members of the group.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists or existed
+//
+// The changes are read from the accesslog if the AccessLogBaseDN is set in the client Config. The deletion of the
+// group is read from the Active Directory Deleted Objects container if the DeletedObjectsDN is set in the client
+// Config, which does not record the other changes. The organizational unit is not validated, since it might have
+// been deleted together with the group.
+// The method returns an error:
+//   - if any validation fails
+//   - if neither the AccessLogBaseDN nor the DeletedObjectsDN is set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) History(cn, ou string) ([]ChangeRecord, *Error) {
+	var missingParams []string
+	if strings.TrimSpace(cn) == "" {
+		missingParams = append(missingParams, CommonNameAttr)
+	}
+	if strings.TrimSpace(ou) == "" {
+		missingParams = append(missingParams, OrganizationalUnitAttr)
+	}
+	if len(missingParams) > 0 {
+		return nil, missingParametersError(missingParams)
+	}
+	return gm.Client.entryHistory(gm.getDN(cn, ou))
+}
+
+// entryHistory retrieves the changes made to the entry from the accesslog and the deletion of the entry from the
+// Active Directory Deleted Objects container, depending on which of them is set in the client Config.
+func (c *Client) entryHistory(entryDN string) ([]ChangeRecord, *Error) {
+	cnf := c.getConfig()
+	if cnf.AccessLogBaseDN == "" && cnf.DeletedObjectsDN == "" {
+		return nil, unsupportedFeatureError(historyFeature)
+	}
+	changes := []ChangeRecord{}
+	if cnf.AccessLogBaseDN != "" {
+		records, cErr := c.ChangeLog.GetChanges(ChangeLogQuery{Dn: entryDN})
+		if cErr != nil {
+			return nil, cErr
+		}
+		changes = append(changes, records...)
+	}
+	if cnf.DeletedObjectsDN != "" {
+		deleted, cErr := (&tombstonesManager{Client: c}).getActiveDirectoryDeleted(cnf.DeletedObjectsDN, time.Time{})
+		if cErr != nil {
+			return nil, cErr
+		}
+		for _, entry := range deleted {
+			if !dn.EqualFold(entry.Dn, entryDN) {
+				continue
+			}
+			changes = append(changes, ChangeRecord{
+				Dn:        entry.Dn,
+				Type:      ChangeTypeDelete,
+				Time:      entry.DeletedAt,
+				ChangedBy: entry.DeletedBy,
+			})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Time.Before(changes[j].Time)
+	})
+	return changes, nil
+}
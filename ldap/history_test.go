@@ -0,0 +1,131 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsersManager_History(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testTombstonesConfig, WithLDAPClient(ldapMock), UnitTesting())
+		userDn := (&usersManager{Client: client}).getDN(testUser1.Uid)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(testTombstonesConfig.AccessLogBaseDN)).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(objectClass=auditWriteObject)(reqResult=0)(reqDN="+userDn+"))", sr.Filter)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getAccessLogEntry("20240102080000.000000Z", ChangeTypeModify, userDn, map[string][]string{
+					reqModAttr: {"mail:= john.doe@company.com"},
+				}),
+				getAccessLogEntry("20240101080000.000000Z", ChangeTypeAdd, userDn, nil),
+			}}, nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(testTombstonesConfig.DeletedObjectsDN)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("CN=John Doe\\0ADEL:0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0,CN=Deleted Objects,DC=company,DC=com",
+					map[string][]string{
+						lastKnownParentAttr: {"OU=users,DC=company,DC=com"},
+						whenChangedAttr:     {"20240103120000.0Z"},
+					}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Users.(UsersReader).History(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, ChangeTypeAdd, changes[0].Type)
+		assert.Equal(t, ChangeTypeModify, changes[1].Type)
+	})
+
+	t.Run("deleted in active directory", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.UserBaseDN = "OU=users,DC=company,DC=com"
+		config.DeletedObjectsDN = "CN=Deleted Objects,DC=company,DC=com"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("UID=C00001\\0ADEL:0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0,CN=Deleted Objects,DC=company,DC=com",
+					map[string][]string{
+						lastKnownParentAttr: {"OU=users,DC=company,DC=com"},
+						whenChangedAttr:     {"20240103120000.0Z"},
+					}),
+				ldap.NewEntry("UID=C00002\\0ADEL:1f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0,CN=Deleted Objects,DC=company,DC=com",
+					map[string][]string{
+						lastKnownParentAttr: {"OU=users,DC=company,DC=com"},
+						whenChangedAttr:     {"20240104120000.0Z"},
+					}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Users.(UsersReader).History(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []ChangeRecord{{
+			Dn:   "UID=C00001,OU=users,DC=company,DC=com",
+			Type: ChangeTypeDelete,
+			Time: time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC),
+		}}, changes)
+	})
+
+	t.Run("history not supported", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		changes, cErr := client.Users.(UsersReader).History(testUser1.Uid)
+		assert.Nil(t, changes)
+		assert.Equal(t, ErrCodeUnsupportedFeature, cErr.Code)
+		assert.Equal(t, http.StatusNotImplemented, cErr.Status)
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		client := NewClient(testChangeLogConfig, UnitTesting())
+
+		changes, cErr := client.Users.(UsersReader).History("")
+		assert.Nil(t, changes)
+		assert.Equal(t, missingParametersError([]string{userIdAttr}), cErr)
+	})
+}
+
+func TestGroupsManager_History(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testChangeLogConfig, WithLDAPClient(ldapMock), UnitTesting())
+		groupDn := (&groupsManager{Client: client}).getDN("admins", "projects")
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(objectClass=auditWriteObject)(reqResult=0)(reqDN="+groupDn+"))", sr.Filter)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getAccessLogEntry("20240102080000.000000Z", ChangeTypeModify, groupDn, map[string][]string{
+					reqModAttr: {"member:- uid=C00001,ou=users,o=company"},
+				}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := client.Groups.(GroupsReader).History("admins", "projects")
+		assert.Nil(t, cErr)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, "cn=root,o=company", changes[0].ChangedBy)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		client := NewClient(testChangeLogConfig, UnitTesting())
+
+		changes, cErr := client.Groups.(GroupsReader).History("", "")
+		assert.Nil(t, changes)
+		assert.Equal(t, missingParametersError([]string{CommonNameAttr, OrganizationalUnitAttr}), cErr)
+	})
+}
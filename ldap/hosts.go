@@ -0,0 +1,362 @@
+package ldap
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	ipHostNumberAttr = "ipHostNumber"
+	macAddressAttr   = "macAddress"
+
+	// hostObjectClassFilter matches the host entries.
+	hostObjectClassFilter = "(objectClass=ipHost)"
+	// hostFilterTemplate combines the filter matching the host entries with an attribute value assertion.
+	hostFilterTemplate = "(&(%s=%s)" + hostObjectClassFilter + ")"
+
+	hostBaseDNParam = "hostBaseDN"
+
+	hostNotFoundMsg         = "Host with cn = '%s' was not found"
+	hostAlreadyExistsMsg    = "Host with cn = '%s' already exists"
+	invalidIPAddressErrMsg  = "'%s' is not a valid IP address"
+	invalidMACAddressErrMsg = "'%s' is not a valid MAC address"
+)
+
+var defaultObjectClassesHost = []string{
+	"device",
+	"ipHost",
+	"ieee802Device",
+	"top",
+}
+
+type (
+	// HostsManager describes the interface which needs to be implemented for managing the machine entries, e.g. the
+	// asset records of servers and workstations, stored as ipHost devices within the HostBaseDN set in the client
+	// Config.
+	HostsManager interface {
+		GetAll(opts ...RequestOption) ([]Host, *Error)
+		Get(cn string) (*Host, *Error)
+		GetByIPAddress(ipAddress string) ([]Host, *Error)
+		GetByMACAddress(macAddress string) ([]Host, *Error)
+		Create(host Host) *Error
+		Update(host Host) *Error
+		Delete(cn string) *Error
+	}
+
+	// hostsManager implements the HostsManager interface.
+	hostsManager struct {
+		Client *Client
+	}
+
+	// Host represents a machine entry in LDAP.
+	Host struct {
+		Dn string `json:"dn"`
+		// Cn is the host name of the machine.
+		Cn string `json:"cn"`
+		// IPAddresses are the IPv4 or IPv6 addresses of the machine, at least one is mandatory.
+		IPAddresses []string `json:"ipAddresses"`
+		// MACAddresses are the MAC addresses of the network interfaces of the machine.
+		MACAddresses []string `json:"macAddresses,omitempty"`
+		// Owner is the dn of the user or the group who is accountable for the machine.
+		Owner       string `json:"owner,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+)
+
+// WithHostsManager overrides the default HostsManager.
+func WithHostsManager(hm HostsManager) ClientOption {
+	return func(c *Client) {
+		c.Hosts = hm
+	}
+}
+
+// GetAll retrieves all the host entries from LDAP.
+// The method returns an error:
+//   - if the HostBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (hm *hostsManager) GetAll(opts ...RequestOption) ([]Host, *Error) {
+	return hm.search(hostObjectClassFilter, opts...)
+}
+
+// Get retrieves a host entry from LDAP.
+// params:
+//
+//	cn = the host name of the machine
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the HostBaseDN is not set in the client Config
+//   - if the host is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (hm *hostsManager) Get(cn string) (*Host, *Error) {
+	if strings.TrimSpace(cn) == "" {
+		return nil, missingParametersError([]string{CommonNameAttr})
+	}
+	hosts, cErr := hm.search(fmt.Sprintf(hostFilterTemplate, CommonNameAttr, ldap.EscapeFilter(cn)))
+	if cErr != nil {
+		return nil, cErr
+	}
+	if len(hosts) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(hostNotFoundMsg, cn)))
+	}
+	return &hosts[0], nil
+}
+
+// GetByIPAddress retrieves the host entries from LDAP which have the IP address.
+// params:
+//
+//	ipAddress = an IPv4 or IPv6 address
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the HostBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (hm *hostsManager) GetByIPAddress(ipAddress string) ([]Host, *Error) {
+	if strings.TrimSpace(ipAddress) == "" {
+		return nil, missingParametersError([]string{ipHostNumberAttr})
+	}
+	if net.ParseIP(ipAddress) == nil {
+		return nil, invalidParameterError(ipHostNumberAttr, fmt.Sprintf(invalidIPAddressErrMsg, ipAddress))
+	}
+	return hm.search(fmt.Sprintf(hostFilterTemplate, ipHostNumberAttr, ldap.EscapeFilter(ipAddress)))
+}
+
+// GetByMACAddress retrieves the host entries from LDAP which have the MAC address.
+// params:
+//
+//	macAddress = a MAC address, e.g. "00:1a:2b:3c:4d:5e"
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the HostBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (hm *hostsManager) GetByMACAddress(macAddress string) ([]Host, *Error) {
+	if strings.TrimSpace(macAddress) == "" {
+		return nil, missingParametersError([]string{macAddressAttr})
+	}
+	hardwareAddr, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return nil, invalidParameterError(macAddressAttr, fmt.Sprintf(invalidMACAddressErrMsg, macAddress))
+	}
+	return hm.search(fmt.Sprintf(hostFilterTemplate, macAddressAttr, ldap.EscapeFilter(hardwareAddr.String())))
+}
+
+// Create creates a new host entry in LDAP.
+// params:
+//
+//	host = the host, the Cn and at least one IP address are mandatory
+//
+// The MAC addresses are stored in the canonical lower-case, colon separated format.
+// The method returns an error:
+//   - if a validation fails
+//   - if the HostBaseDN is not set in the client Config
+//   - if the host already exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (hm *hostsManager) Create(host Host) *Error {
+	hostBaseDN, cErr := hm.hostBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	macAddresses, cErr := hm.validateHost(host)
+	if cErr != nil {
+		return cErr
+	}
+	ar := ldap.NewAddRequest(hm.getDN(hostBaseDN, host.Cn), nil)
+	ar.Attribute(objectClassAttr, defaultObjectClassesHost)
+	ar.Attribute(CommonNameAttr, []string{host.Cn})
+	ar.Attribute(ipHostNumberAttr, host.IPAddresses)
+	if len(macAddresses) > 0 {
+		ar.Attribute(macAddressAttr, macAddresses)
+	}
+	if host.Owner != "" {
+		ar.Attribute(ownerAttr, []string{host.Owner})
+	}
+	if host.Description != "" {
+		ar.Attribute(descriptionAttr, []string{host.Description})
+	}
+	if cErr := hm.Client.doLDAPAdd(ar); cErr != nil {
+		if cErr.Status == http.StatusBadRequest {
+			return WrapError(errors.ConflictError(fmt.Sprintf(hostAlreadyExistsMsg, host.Cn)))
+		}
+		return cErr
+	}
+	hm.emit(EventHostCreated, hostBaseDN, host.Cn)
+	return nil
+}
+
+// Update replaces the IP addresses, the MAC addresses, the owner and the description of an existing host entry in
+// LDAP. The MAC addresses, the owner and the description are removed if they are not set.
+// params:
+//
+//	host = the host, the Cn and at least one IP address are mandatory
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the HostBaseDN is not set in the client Config
+//   - if the host is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (hm *hostsManager) Update(host Host) *Error {
+	hostBaseDN, cErr := hm.hostBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	macAddresses, cErr := hm.validateHost(host)
+	if cErr != nil {
+		return cErr
+	}
+	mr := ldap.NewModifyRequest(hm.getDN(hostBaseDN, host.Cn), nil)
+	mr.Replace(ipHostNumberAttr, host.IPAddresses)
+	mr.Replace(macAddressAttr, macAddresses)
+	mr.Replace(ownerAttr, nonEmptyValues(host.Owner))
+	mr.Replace(descriptionAttr, nonEmptyValues(host.Description))
+	if cErr := hm.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(hostNotFoundMsg, host.Cn)))
+		}
+		return cErr
+	}
+	hm.emit(EventHostUpdated, hostBaseDN, host.Cn)
+	return nil
+}
+
+// Delete deletes an existing host entry from LDAP.
+// params:
+//
+//	cn = the host name of the machine
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the HostBaseDN is not set in the client Config
+//   - if the host is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (hm *hostsManager) Delete(cn string) *Error {
+	hostBaseDN, cErr := hm.hostBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(cn) == "" {
+		return missingParametersError([]string{CommonNameAttr})
+	}
+	if cErr := hm.Client.doLDAPDelete(ldap.NewDelRequest(hm.getDN(hostBaseDN, cn), nil)); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(hostNotFoundMsg, cn)))
+		}
+		return cErr
+	}
+	hm.emit(EventHostDeleted, hostBaseDN, cn)
+	return nil
+}
+
+// search retrieves the host entries within the HostBaseDN which match the search filter.
+func (hm *hostsManager) search(searchFilter string, opts ...RequestOption) ([]Host, *Error) {
+	hostBaseDN, cErr := hm.hostBaseDN()
+	if cErr != nil {
+		return nil, cErr
+	}
+	sr := ldap.NewSearchRequest(
+		hostBaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		searchFilter,
+		[]string{CommonNameAttr, ipHostNumberAttr, macAddressAttr, ownerAttr, descriptionAttr},
+		nil,
+	)
+	hosts := []Host{}
+	cErr = hm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			hosts = append(hosts, Host{
+				Dn:           entry.DN,
+				Cn:           entry.GetAttributeValue(CommonNameAttr),
+				IPAddresses:  entry.GetAttributeValues(ipHostNumberAttr),
+				MACAddresses: entry.GetAttributeValues(macAddressAttr),
+				Owner:        entry.GetAttributeValue(ownerAttr),
+				Description:  entry.GetAttributeValue(descriptionAttr),
+			})
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return hosts, nil
+}
+
+// validateHost validates the mandatory fields, the IP addresses and the MAC addresses of the host and returns the
+// MAC addresses in the canonical format.
+func (hm *hostsManager) validateHost(host Host) ([]string, *Error) {
+	var missingParams []string
+	if strings.TrimSpace(host.Cn) == "" {
+		missingParams = append(missingParams, CommonNameAttr)
+	}
+	if len(host.IPAddresses) == 0 {
+		missingParams = append(missingParams, ipHostNumberAttr)
+	}
+	if len(missingParams) > 0 {
+		return nil, missingParametersError(missingParams)
+	}
+	var fieldErrs []FieldError
+	for _, ipAddress := range host.IPAddresses {
+		if net.ParseIP(ipAddress) == nil {
+			fieldErrs = append(fieldErrs, FieldError{Field: ipHostNumberAttr, Code: FieldErrCodeInvalid,
+				Message: fmt.Sprintf(invalidIPAddressErrMsg, ipAddress)})
+		}
+	}
+	macAddresses := make([]string, 0, len(host.MACAddresses))
+	for _, macAddress := range host.MACAddresses {
+		hardwareAddr, err := net.ParseMAC(macAddress)
+		if err != nil {
+			fieldErrs = append(fieldErrs, FieldError{Field: macAddressAttr, Code: FieldErrCodeInvalid,
+				Message: fmt.Sprintf(invalidMACAddressErrMsg, macAddress)})
+			continue
+		}
+		macAddresses = append(macAddresses, hardwareAddr.String())
+	}
+	if len(fieldErrs) > 0 {
+		return nil, invalidFieldsError(fieldErrs)
+	}
+	return macAddresses, nil
+}
+
+// emit emits an event of the event type for the host entry.
+func (hm *hostsManager) emit(eventType EventType, hostBaseDN, cn string) {
+	hm.Client.emit(Event{Type: eventType, Dn: hm.getDN(hostBaseDN, cn), Cn: cn})
+}
+
+// getDN returns the dn of the host entry.
+func (hm *hostsManager) getDN(hostBaseDN, cn string) string {
+	return dn.Join(dn.RDN(CommonNameAttr, cn), hostBaseDN)
+}
+
+// hostBaseDN returns the HostBaseDN set in the client Config or an error if it is not set.
+func (hm *hostsManager) hostBaseDN() (string, *Error) {
+	hostBaseDN := hm.Client.getConfig().HostBaseDN
+	if hostBaseDN == "" {
+		return "", missingParametersError([]string{hostBaseDNParam})
+	}
+	return hostBaseDN, nil
+}
+
+// nonEmptyValues returns the value as a single value list or an empty list if the value is empty, e.g. to remove
+// an optional attribute with a replace modification.
+func nonEmptyValues(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return []string{value}
+}
@@ -0,0 +1,286 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testHostConfig = func() Config {
+		config := testConfig
+		config.HostBaseDN = "ou=hosts,o=company"
+		return config
+	}()
+
+	testHost = Host{
+		Dn:           "cn=web01,ou=hosts,o=company",
+		Cn:           "web01",
+		IPAddresses:  []string{"10.0.0.1", "fd00::1"},
+		MACAddresses: []string{"00:1a:2b:3c:4d:5e"},
+		Owner:        "uid=C00001,ou=users,o=company",
+		Description:  "Web server",
+	}
+
+	getHostSearchResult = &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry(testHost.Dn, map[string][]string{
+				CommonNameAttr:   {testHost.Cn},
+				ipHostNumberAttr: testHost.IPAddresses,
+				macAddressAttr:   testHost.MACAddresses,
+				ownerAttr:        {testHost.Owner},
+				descriptionAttr:  {testHost.Description},
+			}),
+		},
+	}
+)
+
+func TestHostsManager_GetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, testHostConfig.HostBaseDN, sr.BaseDN)
+				assert.Equal(t, hostObjectClassFilter, sr.Filter)
+			}).
+			Return(getHostSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		hosts, cErr := client.Hosts.GetAll()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []Host{testHost}, hosts)
+	})
+
+	t.Run("host base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		hosts, cErr := client.Hosts.GetAll()
+		assert.Nil(t, hosts)
+		assert.Equal(t, missingParametersError([]string{hostBaseDNParam}), cErr)
+	})
+}
+
+func TestHostsManager_Get(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(cn=web01)(objectClass=ipHost))", sr.Filter)
+			}).
+			Return(getHostSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		host, cErr := client.Hosts.Get(testHost.Cn)
+		assert.Nil(t, cErr)
+		assert.Equal(t, &testHost, host)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(&ldap.SearchResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		host, cErr := client.Hosts.Get(testHost.Cn)
+		assert.Nil(t, host)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testHostConfig, UnitTesting())
+
+		host, cErr := client.Hosts.Get(" ")
+		assert.Nil(t, host)
+		assert.Equal(t, missingParametersError([]string{CommonNameAttr}), cErr)
+	})
+}
+
+func TestHostsManager_GetByIPAddress(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(ipHostNumber=fd00::1)(objectClass=ipHost))", sr.Filter)
+			}).
+			Return(getHostSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		hosts, cErr := client.Hosts.GetByIPAddress("fd00::1")
+		assert.Nil(t, cErr)
+		assert.Equal(t, []Host{testHost}, hosts)
+	})
+
+	t.Run("invalid ip address", func(t *testing.T) {
+		client := NewClient(testHostConfig, UnitTesting())
+
+		hosts, cErr := client.Hosts.GetByIPAddress("10.0.0.*")
+		assert.Nil(t, hosts)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(ipHostNumberAttr).Code)
+	})
+}
+
+func TestHostsManager_GetByMACAddress(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(macAddress=00:1a:2b:3c:4d:5e)(objectClass=ipHost))", sr.Filter)
+			}).
+			Return(getHostSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		hosts, cErr := client.Hosts.GetByMACAddress("00-1A-2B-3C-4D-5E")
+		assert.Nil(t, cErr)
+		assert.Equal(t, []Host{testHost}, hosts)
+	})
+
+	t.Run("invalid mac address", func(t *testing.T) {
+		client := NewClient(testHostConfig, UnitTesting())
+
+		hosts, cErr := client.Hosts.GetByMACAddress("00:1a")
+		assert.Nil(t, hosts)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(macAddressAttr).Code)
+	})
+}
+
+func TestHostsManager_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest(testHost.Dn, nil)
+		ar.Attribute(objectClassAttr, defaultObjectClassesHost)
+		ar.Attribute(CommonNameAttr, []string{testHost.Cn})
+		ar.Attribute(ipHostNumberAttr, testHost.IPAddresses)
+		ar.Attribute(macAddressAttr, testHost.MACAddresses)
+		ar.Attribute(ownerAttr, []string{testHost.Owner})
+		ar.Attribute(descriptionAttr, []string{testHost.Description})
+		var events []Event
+		client.On(EventHostCreated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		host := testHost
+		host.MACAddresses = []string{"00:1A:2B:3C:4D:5E"}
+		assert.Nil(t, client.Hosts.Create(host))
+		assert.Len(t, events, 1)
+		assert.Equal(t, testHost.Dn, events[0].Dn)
+		assert.Equal(t, testHost.Cn, events[0].Cn)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).Return(ldapEntryAlreadyExistsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Hosts.Create(testHost)
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testHostConfig, UnitTesting())
+
+		cErr := client.Hosts.Create(Host{})
+		assert.Equal(t, missingParametersError([]string{CommonNameAttr, ipHostNumberAttr}), cErr)
+
+		cErr = client.Hosts.Create(Host{Cn: testHost.Cn, IPAddresses: []string{"10.0.0"},
+			MACAddresses: []string{"invalid"}})
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Len(t, cErr.Validation.Fields, 2)
+	})
+}
+
+func TestHostsManager_Update(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest(testHost.Dn, nil)
+		mr.Replace(ipHostNumberAttr, []string{"10.0.0.2"})
+		mr.Replace(macAddressAttr, []string{})
+		mr.Replace(ownerAttr, []string{})
+		mr.Replace(descriptionAttr, []string{})
+		var events []EventType
+		client.On(EventHostUpdated, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Hosts.Update(Host{Cn: testHost.Cn, IPAddresses: []string{"10.0.0.2"}}))
+		assert.Equal(t, []EventType{EventHostUpdated}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Hosts.Update(testHost)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestHostsManager_Delete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var events []EventType
+		client.On(EventHostDeleted, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(testHost.Dn, nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Hosts.Delete(testHost.Cn))
+		assert.Equal(t, []EventType{EventHostDeleted}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testHostConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, mock.AnythingOfType("*ldap.DelRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Hosts.Delete(testHost.Cn)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("host base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.Hosts.Delete(testHost.Cn)
+		assert.Equal(t, missingParametersError([]string{hostBaseDNParam}), cErr)
+	})
+}
@@ -0,0 +1,174 @@
+package ldap
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	idSequenceFilter  = "(%s=%s*)"
+	idExistsFilter    = "(%s=%s)"
+	maxPatternIDCount = 1000
+
+	emptyGeneratedIDMsg    = "The %s generated for the user is empty"
+	missingEmployeeNumMsg  = "The %s cannot be derived from the employee number, the employee number of the user is not set"
+	patternIDExhaustedMsg  = "No free %s was found for the pattern '%s' after %d attempts"
+	sequenceIDExhaustedMsg = "The sequence of the %s with the prefix '%s' is exhausted"
+)
+
+// idPatternPlaceholder matches the placeholders of a PatternIDGenerator, e.g. {sn} or {cn:1}.
+var idPatternPlaceholder = regexp.MustCompile(`\{(\w+)(?::(\d+))?}`)
+
+type (
+	// IDGenerator generates the uid or the altUid of a new user whose uid or altUid is not set, see WithUidGenerator
+	// and WithAltUidGenerator.
+	IDGenerator interface {
+		// GenerateID returns the value of the attr, uid or altUid, of the new user. The users are the users the new
+		// user is created in, e.g. to find the ids which are already used.
+		GenerateID(users UsersReader, attr string, user User) (string, *Error)
+	}
+
+	// IDGeneratorFunc is a function which implements the IDGenerator interface.
+	IDGeneratorFunc func(users UsersReader, attr string, user User) (string, *Error)
+
+	// idGenerators holds the generators of the uid and the altUid of a client.
+	idGenerators struct {
+		uid    IDGenerator
+		altUid IDGenerator
+	}
+)
+
+// GenerateID calls the function.
+func (f IDGeneratorFunc) GenerateID(users UsersReader, attr string, user User) (string, *Error) {
+	return f(users, attr, user)
+}
+
+// WithUidGenerator sets the IDGenerator which generates the uid of a new user whose uid is not set, e.g. by
+// Users.Create.
+func WithUidGenerator(generator IDGenerator) ClientOption {
+	return func(c *Client) {
+		c.idGenerators.uid = generator
+	}
+}
+
+// WithAltUidGenerator sets the IDGenerator which generates the altUid of a new user whose altUid is not set, e.g. by
+// Users.Create.
+func WithAltUidGenerator(generator IDGenerator) ClientOption {
+	return func(c *Client) {
+		c.idGenerators.altUid = generator
+	}
+}
+
+// SequenceIDGenerator returns an IDGenerator which generates the next id of a numbered sequence, the prefix followed
+// by the number padded with zeros to the digits, e.g. "C00042" for the prefix "C" and 5 digits. The next number is
+// one more than the highest number used by the users in the directory.
+// Concurrent creations of users can generate the same id, the creation of the second user then fails with a conflict
+// error and can be retried.
+func SequenceIDGenerator(prefix string, digits int) IDGenerator {
+	return IDGeneratorFunc(func(users UsersReader, attr string, user User) (string, *Error) {
+		result, cErr := users.Search(Filterf(idSequenceFilter, attr, prefix), WithAttributes(attr))
+		if cErr != nil {
+			return "", cErr
+		}
+		highest := 0
+		for _, existing := range result.Users {
+			suffix, ok := strings.CutPrefix(userAttributeValue(existing, attr), prefix)
+			if !ok {
+				continue
+			}
+			if number, err := strconv.Atoi(suffix); err == nil && number > highest {
+				highest = number
+			}
+		}
+		id := fmt.Sprintf("%s%0*d", prefix, digits, highest+1)
+		if len(id) > len(prefix)+digits {
+			return "", WrapError(errors.InternalServerError(fmt.Sprintf(sequenceIDExhaustedMsg, attr, prefix)))
+		}
+		return id, nil
+	})
+}
+
+// PatternIDGenerator returns an IDGenerator which generates the id from the attributes of the user according to the
+// pattern. The placeholders {attr} are replaced by the value of the attribute and {attr:n} by its first n characters,
+// e.g. "{cn:1}{sn}" generates "jdoe" for John Doe. The id is lowercased and the whitespace is removed. A number
+// starting at 2 is appended if the id is already used by a user in the directory, e.g. "jdoe2".
+func PatternIDGenerator(pattern string) IDGenerator {
+	return IDGeneratorFunc(func(users UsersReader, attr string, user User) (string, *Error) {
+		base := idPatternPlaceholder.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+			match := idPatternPlaceholder.FindStringSubmatch(placeholder)
+			value := []rune(userAttributeValue(user, match[1]))
+			if n, err := strconv.Atoi(match[2]); err == nil && n < len(value) {
+				value = value[:n]
+			}
+			return string(value)
+		})
+		base = strings.ToLower(strings.Join(strings.Fields(base), ""))
+		for i := 1; i <= maxPatternIDCount; i++ {
+			id := base
+			if i > 1 {
+				id += strconv.Itoa(i)
+			}
+			result, cErr := users.Search(Filterf(idExistsFilter, attr, id), WithAttributes(attr))
+			if cErr != nil {
+				return "", cErr
+			}
+			if len(result.Users) == 0 {
+				return id, nil
+			}
+		}
+		return "", WrapError(errors.InternalServerError(fmt.Sprintf(patternIDExhaustedMsg, attr, pattern, maxPatternIDCount)))
+	})
+}
+
+// EmployeeNumberIDGenerator returns an IDGenerator which derives the id from the employee number of the user
+// assigned by the HR system, formatted according to the format, e.g. "C%05s" generates "C00042" for the employee
+// number 42.
+func EmployeeNumberIDGenerator(format string) IDGenerator {
+	return IDGeneratorFunc(func(users UsersReader, attr string, user User) (string, *Error) {
+		if strings.TrimSpace(user.EmployeeNumber) == "" {
+			return "", invalidParameterError(employeeNumberAttr, fmt.Sprintf(missingEmployeeNumMsg, attr))
+		}
+		return fmt.Sprintf(format, strings.TrimSpace(user.EmployeeNumber)), nil
+	})
+}
+
+// GenerateIDs returns the user with the uid and the altUid generated by the IDGenerators set on the client, see
+// WithUidGenerator and WithAltUidGenerator, if they are not set. Users.Create generates the ids the same way, so the
+// method can be used to know the uid of a user before it is created.
+// The method returns an error:
+//   - if a generator fails
+//   - if a generator returns an empty id
+func (um *usersManager) GenerateIDs(user User) (User, *Error) {
+	generators := um.Client.idGenerators
+	if user.Uid == "" && generators.uid != nil {
+		uid, cErr := um.generateID(generators.uid, userIdAttr, user)
+		if cErr != nil {
+			return user, cErr
+		}
+		user.Uid = uid
+	}
+	if user.AltUid == "" && generators.altUid != nil {
+		altUid, cErr := um.generateID(generators.altUid, alternateUserIdAttr, user)
+		if cErr != nil {
+			return user, cErr
+		}
+		user.AltUid = altUid
+	}
+	return user, nil
+}
+
+// generateID generates the value of the attr of a new user using the generator.
+func (um *usersManager) generateID(generator IDGenerator, attr string, user User) (string, *Error) {
+	id, cErr := generator.GenerateID(um, attr, user)
+	if cErr != nil {
+		return "", cErr
+	}
+	if strings.TrimSpace(id) == "" {
+		return "", WrapError(errors.InternalServerError(fmt.Sprintf(emptyGeneratedIDMsg, attr)))
+	}
+	return id, nil
+}
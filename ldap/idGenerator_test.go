@@ -0,0 +1,146 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getUidsSearchResult(uids ...string) *ldap.SearchResult {
+	result := &ldap.SearchResult{}
+	for _, uid := range uids {
+		result.Entries = append(result.Entries, ldap.NewEntry("uid="+uid+",ou=users,o=company",
+			map[string][]string{userIdAttr: {uid}}))
+	}
+	return result
+}
+
+func TestSequenceIDGenerator(t *testing.T) {
+	t.Run("next number", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(uid=C*)", sr.Filter)
+				assert.Equal(t, []string{userIdAttr}, sr.Attributes)
+			}).
+			Return(getUidsSearchResult("C00001", "C00041", "CADMIN", "C00007"), nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		uid, cErr := SequenceIDGenerator("C", 5).GenerateID(client.Users.(UsersReader), userIdAttr, User{})
+		assert.Nil(t, cErr)
+		assert.Equal(t, "C00042", uid)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(getUidsSearchResult("C99"), nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		uid, cErr := SequenceIDGenerator("C", 2).GenerateID(client.Users.(UsersReader), userIdAttr, User{})
+		assert.Empty(t, uid)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	})
+}
+
+func TestPatternIDGenerator(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	var filters []string
+
+	ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+		Run(func(args mock.Arguments) {
+			filters = append(filters, args.Get(0).(*ldap.SearchRequest).Filter)
+		}).
+		Return(getUidsSearchResult("jvandoe"), nil).Once()
+	ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+		Run(func(args mock.Arguments) {
+			filters = append(filters, args.Get(0).(*ldap.SearchRequest).Filter)
+		}).
+		Return(getUidsSearchResult(), nil).Once()
+	ldapMock.On(methodNameClose).Return(nil)
+
+	uid, cErr := PatternIDGenerator("{cn:1}{sn}").GenerateID(client.Users.(UsersReader), userIdAttr, User{Cn: "John", Sn: "Van Doe"})
+	assert.Nil(t, cErr)
+	assert.Equal(t, "jvandoe2", uid)
+	assert.Equal(t, []string{"(uid=jvandoe)", "(uid=jvandoe2)"}, filters)
+}
+
+func TestEmployeeNumberIDGenerator(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		uid, cErr := EmployeeNumberIDGenerator("C%05s").GenerateID(nil, userIdAttr, User{EmployeeNumber: "42"})
+		assert.Nil(t, cErr)
+		assert.Equal(t, "C00042", uid)
+	})
+
+	t.Run("employee number not set", func(t *testing.T) {
+		uid, cErr := EmployeeNumberIDGenerator("C%05s").GenerateID(nil, userIdAttr, User{})
+		assert.Empty(t, uid)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(employeeNumberAttr).Code)
+	})
+}
+
+func TestUsersManager_GenerateIDs(t *testing.T) {
+	t.Run("generated", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting(),
+			WithUidGenerator(EmployeeNumberIDGenerator("C%05s")),
+			WithAltUidGenerator(IDGeneratorFunc(func(users UsersReader, attr string, user User) (string, *Error) {
+				return "alt-" + user.Uid, nil
+			})))
+
+		user, cErr := client.Users.(UserProvisioner).GenerateIDs(User{EmployeeNumber: "7"})
+		assert.Nil(t, cErr)
+		assert.Equal(t, "C00007", user.Uid)
+		assert.Equal(t, "alt-C00007", user.AltUid)
+	})
+
+	t.Run("ids set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting(), WithUidGenerator(EmployeeNumberIDGenerator("C%05s")))
+
+		user, cErr := client.Users.(UserProvisioner).GenerateIDs(testUser1)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testUser1, user)
+	})
+
+	t.Run("empty id", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting(),
+			WithUidGenerator(IDGeneratorFunc(func(users UsersReader, attr string, user User) (string, *Error) {
+				return "", nil
+			})))
+
+		_, cErr := client.Users.(UserProvisioner).GenerateIDs(User{})
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithUidGenerator(EmployeeNumberIDGenerator("C%05s")))
+		um := usersManager{Client: client}
+		user := testUser1
+		user.Uid = ""
+		user.EmployeeNumber = "1"
+		created := user
+		created.Uid = "C00001"
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, um.getAddRequest(created)).Return(nil)
+		ldapMock.On("PasswordModify", um.getPasswordModifyRequest(created.Uid, created.UserPassword,
+			created.UserPassword)).Return(nil, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.Create(user))
+	})
+}
@@ -0,0 +1,71 @@
+package ldap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	defaultLastLoginAttr = "authTimestamp"
+
+	generalizedTimeLayout = "20060102150405Z"
+
+	inactiveUsersSearchFilter = "(&%s(|(!(%s=*))(%s<=%s)))"
+)
+
+// InactiveUser represents a user entry which has not authenticated since a cutoff time.
+type InactiveUser struct {
+	User
+	// LastLoginTime is the time of the last successful authentication, nil if the user never authenticated.
+	LastLoginTime *time.Time `json:"lastLoginTime,omitempty"`
+}
+
+// Inactive retrieves the user entries from LDAP which have not authenticated since the cutoff time, including the
+// user entries which never authenticated.
+// The time of the last authentication is read from the LastLoginAttr set in the client Config.
+// The method returns an error:
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Inactive(since time.Time) ([]InactiveUser, *Error) {
+	lastLoginAttr := um.lastLoginAttr()
+	users := []InactiveUser{}
+	cErr := um.Client.doLDAPPagedSearch(um.getInactiveUsersSearchRequest(since), defaultPageSize,
+		func(result *ldap.SearchResult) *Error {
+			for i, user := range um.parseSearchResult(result) {
+				inactiveUser := InactiveUser{User: user}
+				lastLoginTime, ok := parseGeneralizedTime(result.Entries[i].GetAttributeValue(lastLoginAttr))
+				if ok {
+					if lastLoginTime.After(since) {
+						continue
+					}
+					inactiveUser.LastLoginTime = &lastLoginTime
+				}
+				users = append(users, inactiveUser)
+			}
+			return nil
+		})
+	if cErr != nil {
+		return nil, cErr
+	}
+	return users, nil
+}
+
+// lastLoginAttr returns the LastLoginAttr or the default last login attribute if the LastLoginAttr is not set.
+func (um *usersManager) lastLoginAttr() string {
+	if attr := um.Client.getConfig().LastLoginAttr; attr != "" {
+		return attr
+	}
+	return defaultLastLoginAttr
+}
+
+// getInactiveUsersSearchRequest returns a ldap search request to get the user entries which have not authenticated
+// since the cutoff time.
+func (um *usersManager) getInactiveUsersSearchRequest(since time.Time) *ldap.SearchRequest {
+	lastLoginAttr := um.lastLoginAttr()
+	sr := um.getUsersSearchRequest(fmt.Sprintf(inactiveUsersSearchFilter, um.Client.getConfig().userObjectFilter(), lastLoginAttr, lastLoginAttr,
+		since.UTC().Format(generalizedTimeLayout)))
+	sr.Attributes = append(sr.Attributes, lastLoginAttr)
+	return sr
+}
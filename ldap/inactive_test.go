@@ -0,0 +1,69 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsersManager_Inactive(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		config := testConfig
+		config.LastLoginAttr = "lastLoginTime"
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.MatchedBy(func(sr *ldap.SearchRequest) bool {
+			return sr.Filter == "(&(objectClass=inetOrgPerson)(|(!(lastLoginTime=*))(lastLoginTime<=20240101000000Z)))"
+		})).Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			ldap.NewEntry(um.getDN(testUser1.Uid), map[string][]string{
+				userIdAttr:      {testUser1.Uid},
+				"lastLoginTime": {"20231201120000Z"},
+			}),
+			ldap.NewEntry(um.getDN(testUser2.Uid), map[string][]string{
+				userIdAttr: {testUser2.Uid},
+			}),
+		}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.(UserLifecycleManager).Inactive(since)
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 2)
+		assert.Equal(t, testUser1.Uid, users[0].Uid)
+		assert.Equal(t, time.Date(2023, 12, 1, 12, 0, 0, 0, time.UTC), *users[0].LastLoginTime)
+		assert.Equal(t, testUser2.Uid, users[1].Uid)
+		assert.Nil(t, users[1].LastLoginTime)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.(UserLifecycleManager).Inactive(since)
+		assert.Nil(t, users)
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+	})
+}
+
+func TestUsersManager_getInactiveUsersSearchRequest(t *testing.T) {
+	um := usersManager{Client: NewClient(testConfig)}
+
+	sr := um.getInactiveUsersSearchRequest(time.Date(2024, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)))
+	assert.Equal(t, "(&(objectClass=inetOrgPerson)(|(!(authTimestamp=*))(authTimestamp<=20240101000000Z)))",
+		sr.Filter)
+	assert.Contains(t, sr.Attributes, defaultLastLoginAttr)
+	assert.NotContains(t, userAttributes, defaultLastLoginAttr)
+}
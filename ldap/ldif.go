@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	ldifVersion = "version: 1\n"
+
+	invalidLDIFErrMsg = "Invalid LDIF on line %d: %s"
+)
+
+// writeLDIFAttribute writes an attribute value line, values which are not safe strings are base64 encoded.
+func writeLDIFAttribute(w io.Writer, attr, value string) {
+	if isLDIFSafeString(value) {
+		_, _ = io.WriteString(w, attr+": "+value+"\n")
+	} else {
+		_, _ = io.WriteString(w, attr+":: "+base64.StdEncoding.EncodeToString([]byte(value))+"\n")
+	}
+}
+
+// writeLDIFEntry writes an entry as an LDIF record, preceded by the empty line which separates the records.
+func writeLDIFEntry(w io.Writer, entry *ldap.Entry) {
+	_, _ = io.WriteString(w, "\n")
+	writeLDIFAttribute(w, "dn", entry.DN)
+	for _, attribute := range entry.Attributes {
+		for _, value := range attribute.ByteValues {
+			writeLDIFAttribute(w, attribute.Name, string(value))
+		}
+	}
+}
+
+// isLDIFSafeString reports whether a value can be written to LDIF without encoding.
+func isLDIFSafeString(value string) bool {
+	if value == "" {
+		return true
+	}
+	if strings.ContainsAny(value[:1], " :<") || strings.HasSuffix(value, " ") {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] == 0 || value[i] == '\n' || value[i] == '\r' || value[i] > 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLDIF parses the LDIF content records defined in RFC 2849 into entries.
+// Comments, the version line and folded lines are supported, change records and URL values are not.
+func parseLDIF(r io.Reader) ([]*ldap.Entry, *Error) {
+	var entries []*ldap.Entry
+	var entry *ldap.Entry
+	values := make(map[string][]string)
+	var names []string
+
+	flush := func() {
+		if entry != nil {
+			for _, name := range names {
+				entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(name, values[name]))
+			}
+			entries = append(entries, entry)
+		}
+		entry, values, names = nil, make(map[string][]string), nil
+	}
+
+	lines, cErr := unfoldLDIFLines(r)
+	if cErr != nil {
+		return nil, cErr
+	}
+	for _, line := range lines {
+		if line.text == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line.text, "#") {
+			continue
+		}
+		name, value, cErr := parseLDIFLine(line)
+		if cErr != nil {
+			return nil, cErr
+		}
+		switch {
+		case entry == nil && strings.EqualFold(name, "version"):
+		case entry == nil && strings.EqualFold(name, "dn"):
+			entry = &ldap.Entry{DN: value}
+		case entry == nil:
+			return nil, WrapError(errors.BadRequestError(fmt.Sprintf(invalidLDIFErrMsg, line.number, "expected dn")))
+		case strings.EqualFold(name, "changetype"):
+			return nil, WrapError(errors.BadRequestError(fmt.Sprintf(invalidLDIFErrMsg, line.number,
+				"change records are not supported")))
+		default:
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+			}
+			values[name] = append(values[name], value)
+		}
+	}
+	flush()
+	return entries, nil
+}
+
+// ldifLine is a logical LDIF line with the number of the first physical line.
+type ldifLine struct {
+	number int
+	text   string
+}
+
+// unfoldLDIFLines reads the physical lines and joins the folded lines into logical lines.
+func unfoldLDIFLines(r io.Reader) ([]ldifLine, *Error) {
+	var lines []ldifLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(text, " ") && len(lines) > 0 && lines[len(lines)-1].text != "" {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, ldifLine{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, WrapError(errors.BadRequestError(fmt.Sprintf(invalidLDIFErrMsg, number, err)))
+	}
+	return lines, nil
+}
+
+// parseLDIFLine parses an attribute value line into the attribute name and the decoded value.
+func parseLDIFLine(line ldifLine) (string, string, *Error) {
+	name, value, ok := strings.Cut(line.text, ":")
+	if !ok || name == "" {
+		return "", "", WrapError(errors.BadRequestError(fmt.Sprintf(invalidLDIFErrMsg, line.number, "expected attribute")))
+	}
+	switch {
+	case strings.HasPrefix(value, ":"):
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+		if err != nil {
+			return "", "", WrapError(errors.BadRequestError(fmt.Sprintf(invalidLDIFErrMsg, line.number, err)))
+		}
+		return name, string(decoded), nil
+	case strings.HasPrefix(value, "<"):
+		return "", "", WrapError(errors.BadRequestError(fmt.Sprintf(invalidLDIFErrMsg, line.number,
+			"URL values are not supported")))
+	default:
+		return name, strings.TrimLeft(value, " "), nil
+	}
+}
@@ -0,0 +1,53 @@
+package ldap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLDIF(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		entries, cErr := parseLDIF(strings.NewReader(`version: 1
+# a comment
+
+dn: uid=C00001,ou=users,
+ o=company
+objectClass: inetOrgPerson
+objectClass: top
+cn:: Sm/Dq2w=
+description:  leading space
+
+dn: ou=users,o=company
+ou: users
+`))
+		assert.Nil(t, cErr)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "uid=C00001,ou=users,o=company", entries[0].DN)
+		assert.Equal(t, []string{"inetOrgPerson", "top"}, entries[0].GetAttributeValues(objectClassAttr))
+		assert.Equal(t, "Joël", entries[0].GetAttributeValue(CommonNameAttr))
+		assert.Equal(t, "leading space", entries[0].GetAttributeValue("description"))
+		assert.Equal(t, "users", entries[1].GetAttributeValue(OrganizationalUnitAttr))
+	})
+
+	t.Run("missing dn", func(t *testing.T) {
+		_, cErr := parseLDIF(strings.NewReader("cn: test\n"))
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("change record", func(t *testing.T) {
+		_, cErr := parseLDIF(strings.NewReader("dn: cn=test\nchangetype: delete\n"))
+		assert.Contains(t, cErr.Message, "line 2")
+	})
+}
+
+func TestIsLDIFSafeString(t *testing.T) {
+	assert.True(t, isLDIFSafeString("uid=C00001,ou=users,o=company"))
+	assert.False(t, isLDIFSafeString(" leading space"))
+	assert.False(t, isLDIFSafeString(":colon"))
+	assert.False(t, isLDIFSafeString("trailing space "))
+	assert.False(t, isLDIFSafeString("multi\nline"))
+	assert.False(t, isLDIFSafeString("Jürgen"))
+}
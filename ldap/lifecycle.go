@@ -0,0 +1,155 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	// ErrCodeClientClosed is the code of the error returned by the operations executed after the client was closed.
+	ErrCodeClientClosed = "CLIENT_CLOSED"
+
+	clientClosedErrMsg = "The LDAP client is closed"
+	closeAbortedErrMsg = "The LDAP client was closed before the in-flight operations completed: %v"
+)
+
+type (
+	// lifecycle tracks the in-flight LDAP operations of a client and whether the client is closed.
+	lifecycle struct {
+		mu       sync.Mutex
+		closed   bool
+		inflight int
+		drained  chan struct{}
+		closers  []func(ctx context.Context) *Error
+	}
+)
+
+// Close closes the client gracefully: new LDAP operations are rejected with an ErrCodeClientClosed error, the
+// in-flight operations are drained and the background work of the client, e.g. the pending webhook deliveries, is
+// completed. Close waits until the client is drained or the ctx is done, in which case an error is returned.
+// Close is safe to call more than once, e.g. to wait again after a previous call timed out.
+//
+// Only the single LDAP operations are drained: a method which executes several operations, e.g. Users.Deprovision,
+// fails with an ErrCodeClientClosed error if it starts an operation after the client was closed.
+func (c *Client) Close(ctx context.Context) *Error {
+	select {
+	case <-c.lifecycle.close():
+	case <-ctx.Done():
+		return WrapError(errors.InternalServerError(fmt.Sprintf(closeAbortedErrMsg, ctx.Err())))
+	}
+	for _, closer := range c.lifecycle.closersSnapshot() {
+		if cErr := closer(ctx); cErr != nil {
+			return cErr
+		}
+	}
+	return nil
+}
+
+// Close closes all the clients of the ClientSet, see Client.Close. The first error is returned after all the clients
+// were closed.
+func (cs *ClientSet) Close(ctx context.Context) *Error {
+	cs.mu.RLock()
+	clients := make([]*Client, 0, len(cs.clients))
+	for _, name := range cs.names() {
+		clients = append(clients, cs.clients[name])
+	}
+	cs.mu.RUnlock()
+
+	var firstErr *Error
+	for _, client := range clients {
+		if cErr := client.Close(ctx); cErr != nil && firstErr == nil {
+			firstErr = cErr
+		}
+	}
+	return firstErr
+}
+
+// onClose registers a function which is invoked by Close once the in-flight operations are drained, e.g. to wait
+// for the background work of the client to complete.
+func (c *Client) onClose(closer func(ctx context.Context) *Error) {
+	c.lifecycle.mu.Lock()
+	defer c.lifecycle.mu.Unlock()
+	c.lifecycle.closers = append(c.lifecycle.closers, closer)
+}
+
+// begin registers an in-flight operation. It returns false if the client is closed.
+func (l *lifecycle) begin() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return false
+	}
+	l.inflight++
+	return true
+}
+
+// end unregisters an in-flight operation.
+func (l *lifecycle) end() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+	if l.closed && l.inflight == 0 {
+		l.closeDrained()
+	}
+}
+
+// isClosed checks if the client is closed.
+func (l *lifecycle) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
+
+// close marks the client as closed and returns a channel which is closed once the in-flight operations are drained.
+func (l *lifecycle) close() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.closed {
+		l.closed = true
+		l.drained = make(chan struct{})
+		if l.inflight == 0 {
+			l.closeDrained()
+		}
+	}
+	return l.drained
+}
+
+// closeDrained closes the drained channel once. The caller must hold the lock.
+func (l *lifecycle) closeDrained() {
+	select {
+	case <-l.drained:
+	default:
+		close(l.drained)
+	}
+}
+
+// closersSnapshot returns the registered closers.
+func (l *lifecycle) closersSnapshot() []func(ctx context.Context) *Error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]func(ctx context.Context) *Error(nil), l.closers...)
+}
+
+// clientClosedError returns the error returned by the operations executed after the client was closed.
+func clientClosedError() *Error {
+	return WrapError(errors.New(ErrCodeClientClosed, http.StatusServiceUnavailable, clientClosedErrMsg))
+}
+
+// waitContext waits until wg is done or the ctx is done, in which case an error is returned.
+func waitContext(ctx context.Context, wg *sync.WaitGroup) *Error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return WrapError(errors.InternalServerError(fmt.Sprintf(closeAbortedErrMsg, ctx.Err())))
+	}
+}
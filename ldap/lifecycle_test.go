@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClient_Close(t *testing.T) {
+	t.Run("operations are rejected after close", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		assert.Nil(t, client.Close(context.Background()))
+		assert.Nil(t, client.Close(context.Background()))
+
+		users, cErr := client.Users.GetAll()
+		assert.Nil(t, users)
+		assert.Equal(t, ErrCodeClientClosed, cErr.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, cErr.Status)
+		assert.Equal(t, ErrCodeClientClosed, client.Ping().Code)
+	})
+
+	t.Run("in-flight operations are drained", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Run(func(mock.Arguments) {
+				close(started)
+				<-release
+			}).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		done := make(chan *Error)
+		go func() {
+			_, cErr := client.Users.GetAll()
+			done <- cErr
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		cErr := client.Close(ctx)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+
+		close(release)
+		assert.Nil(t, client.Close(context.Background()))
+		assert.Nil(t, <-done)
+	})
+
+	t.Run("closers", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+		var closed int
+		client.onClose(func(ctx context.Context) *Error {
+			closed++
+			return nil
+		})
+
+		assert.Nil(t, client.Close(context.Background()))
+		assert.Equal(t, 1, closed)
+	})
+
+	t.Run("closer error", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+		client.onClose(func(ctx context.Context) *Error {
+			return WrapError(errors.InternalServerError("closer failed"))
+		})
+
+		cErr := client.Close(context.Background())
+		assert.Equal(t, "closer failed", cErr.Message)
+	})
+}
+
+func TestClientSet_Close(t *testing.T) {
+	cs := NewClientSet(testClientSetConfig)
+
+	assert.Nil(t, cs.Close(context.Background()))
+	for _, name := range cs.Names() {
+		client, _ := cs.Get(name)
+		_, cErr := client.doLDAPSearch(ldap.NewSearchRequest("", ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0,
+			false, allEntriesFilter, nil, nil))
+		assert.Equal(t, ErrCodeClientClosed, cErr.Code)
+	}
+}
+
+func TestWaitContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	assert.NotNil(t, waitContext(ctx, &wg))
+	wg.Done()
+	assert.Nil(t, waitContext(context.Background(), &wg))
+}
@@ -0,0 +1,189 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// defaultMailAliasAttr is the attribute which holds the mail aliases of a user unless a MailAliasAttr is set in the
+	// client Config.
+	defaultMailAliasAttr = "mailAlternateAddress"
+	// proxyAddressesAttr is the Active Directory attribute which holds the addresses of a user prefixed with their
+	// type, e.g. "smtp:john.doe@company.com". The primary address is prefixed with the uppercase "SMTP:".
+	proxyAddressesAttr = "proxyAddresses"
+	smtpAddressPrefix  = "smtp:"
+
+	mailAliasParam = "alias"
+
+	mailAliasesSearchFilter = "(&%s(|(%s=%s)(%s=%s)))"
+	invalidMailAliasMsg     = "Invalid alias '%s'. The alias must be a valid email address"
+	mailAliasNotFoundMsg    = "The user with uid = '%s' does not have the alias '%s'"
+)
+
+// GetAliases retrieves the mail aliases of an existing user entry from LDAP.
+// param:
+//
+//	uid = user identifier
+//
+// The aliases are read from the MailAliasAttr set in the client Config, mailAlternateAddress by default. For the
+// proxyAddresses attribute of Active Directory the secondary smtp addresses are returned without their prefix.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetAliases(uid string) ([]string, *Error) {
+	values, cErr := um.getBinaryAttribute(uid, um.mailAliasAttr())
+	if cErr != nil {
+		return nil, cErr
+	}
+	aliases := []string{}
+	for _, value := range binaryToStrings(values) {
+		if alias, ok := um.parseMailAlias(value); ok {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases, nil
+}
+
+// AddMailAlias adds a mail alias to an existing user entry in LDAP.
+// param:
+//
+//	uid 	= user identifier
+//	alias 	= the email address the mails of the user are also routed for
+//
+// The directory is searched before the alias is added, the alias must not be the mail or an alias of another user.
+// Adding an alias which already is the mail or an alias of the user is a no-op.
+// The method returns an error:
+//   - if a validation fails
+//   - if the alias is not a valid email address
+//   - if the alias is already used by another user
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) AddMailAlias(uid, alias string) *Error {
+	if cErr := um.validateMailAlias(uid, alias); cErr != nil {
+		return cErr
+	}
+	aliasAttr := um.mailAliasAttr()
+	value := um.mailAliasValue(alias)
+	sr := um.getUsersSearchRequest(fmt.Sprintf(mailAliasesSearchFilter, um.Client.getConfig().userObjectFilter(),
+		mailAttr, ldap.EscapeFilter(alias), aliasAttr, ldap.EscapeFilter(value)))
+	result, cErr := um.Client.doLDAPSearch(sr)
+	if cErr != nil {
+		return cErr
+	}
+	hasAlias := false
+	for _, existing := range um.parseSearchResult(result) {
+		if !strings.EqualFold(existing.Uid, uid) {
+			return attributeNotUniqueError(aliasAttr, alias, existing.Uid)
+		}
+		hasAlias = true
+	}
+	if hasAlias {
+		return nil
+	}
+
+	mr := ldap.NewModifyRequest(um.getDN(uid), nil)
+	mr.Add(aliasAttr, []string{value})
+	if cErr := um.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return cErr
+	}
+	um.emit(EventUserUpdated, uid)
+	return nil
+}
+
+// RemoveMailAlias removes a mail alias from an existing user entry in LDAP.
+// param:
+//
+//	uid 	= user identifier
+//	alias 	= the alias to remove
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if the user does not have the alias
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) RemoveMailAlias(uid, alias string) *Error {
+	if cErr := um.validateMailAlias(uid, alias); cErr != nil {
+		return cErr
+	}
+	aliasAttr := um.mailAliasAttr()
+	values, cErr := um.getBinaryAttribute(uid, aliasAttr)
+	if cErr != nil {
+		return cErr
+	}
+	var existing []string
+	for _, value := range binaryToStrings(values) {
+		if parsed, ok := um.parseMailAlias(value); ok && strings.EqualFold(parsed, alias) {
+			existing = append(existing, value)
+		}
+	}
+	if len(existing) == 0 {
+		return WrapError(errors.NotFoundError(fmt.Sprintf(mailAliasNotFoundMsg, uid, alias)))
+	}
+
+	mr := ldap.NewModifyRequest(um.getDN(uid), nil)
+	mr.Delete(aliasAttr, existing)
+	if cErr := um.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return cErr
+	}
+	um.emit(EventUserUpdated, uid)
+	return nil
+}
+
+// validateMailAlias checks if the uid and the alias are set and the alias is a valid email address.
+func (um *usersManager) validateMailAlias(uid, alias string) *Error {
+	var missingParams []string
+	if strings.TrimSpace(uid) == "" {
+		missingParams = append(missingParams, userIdAttr)
+	}
+	if strings.TrimSpace(alias) == "" {
+		missingParams = append(missingParams, mailAliasParam)
+	}
+	if len(missingParams) > 0 {
+		return missingParametersError(missingParams)
+	}
+	if !isValidMail(alias) {
+		return invalidParameterError(mailAliasParam, fmt.Sprintf(invalidMailAliasMsg, alias))
+	}
+	return nil
+}
+
+// mailAliasAttr returns the MailAliasAttr set in the client Config or the default mail alias attribute.
+func (um *usersManager) mailAliasAttr() string {
+	if attr := um.Client.getConfig().MailAliasAttr; attr != "" {
+		return attr
+	}
+	return defaultMailAliasAttr
+}
+
+// mailAliasValue returns the value of the mail alias attribute for the alias, which is prefixed with "smtp:" for the
+// proxyAddresses attribute.
+func (um *usersManager) mailAliasValue(alias string) string {
+	if strings.EqualFold(um.mailAliasAttr(), proxyAddressesAttr) {
+		return smtpAddressPrefix + alias
+	}
+	return alias
+}
+
+// parseMailAlias returns the alias of a value of the mail alias attribute and whether the value is an alias. For the
+// proxyAddresses attribute only the secondary smtp addresses are aliases.
+func (um *usersManager) parseMailAlias(value string) (string, bool) {
+	if !strings.EqualFold(um.mailAliasAttr(), proxyAddressesAttr) {
+		return value, true
+	}
+	return strings.CutPrefix(value, smtpAddressPrefix)
+}
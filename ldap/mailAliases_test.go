@@ -0,0 +1,176 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testMailAlias = "jdoe@company.com"
+
+func getMailAliasEntry(uid string, attr string, values ...string) *ldap.Entry {
+	return ldap.NewEntry("uid="+uid+",ou=users,o=company", map[string][]string{userIdAttr: {uid}, attr: values})
+}
+
+func TestUsersManager_GetAliases(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				assert.Equal(t, []string{defaultMailAliasAttr}, args.Get(0).(*ldap.SearchRequest).Attributes)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getMailAliasEntry(testUser1.Uid, defaultMailAliasAttr, testMailAlias, "john@company.com"),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		aliases, cErr := client.Users.(UserProfileManager).GetAliases(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{testMailAlias, "john@company.com"}, aliases)
+	})
+
+	t.Run("proxy addresses", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.MailAliasAttr = proxyAddressesAttr
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getMailAliasEntry(testUser1.Uid, proxyAddressesAttr, "SMTP:john.doe@company.com",
+					"smtp:"+testMailAlias, "X500:/o=company/cn=jdoe"),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		aliases, cErr := client.Users.(UserProfileManager).GetAliases(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{testMailAlias}, aliases)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		aliases, cErr := client.Users.(UserProfileManager).GetAliases(testUser1.Uid)
+		assert.Nil(t, aliases)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+}
+
+func TestUsersManager_AddMailAlias(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.MailAliasAttr = proxyAddressesAttr
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest((&usersManager{Client: client}).getDN(testUser1.Uid), nil)
+		mr.Add(proxyAddressesAttr, []string{"smtp:" + testMailAlias})
+		var events []Event
+		client.On(EventUserUpdated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				assert.Contains(t, args.Get(0).(*ldap.SearchRequest).Filter,
+					"(|(mail=jdoe@company.com)(proxyAddresses=smtp:jdoe@company.com))")
+			}).
+			Return(&ldap.SearchResult{}, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserProfileManager).AddMailAlias(testUser1.Uid, testMailAlias))
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("alias of the user", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getMailAliasEntry(testUser1.Uid, defaultMailAliasAttr, testMailAlias),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserProfileManager).AddMailAlias(testUser1.Uid, testMailAlias))
+	})
+
+	t.Run("alias used by another user", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getMailAliasEntry("C00002", defaultMailAliasAttr, testMailAlias),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProfileManager).AddMailAlias(testUser1.Uid, testMailAlias)
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+		assert.Equal(t, FieldErrCodeNotUnique, cErr.Validation.Field(defaultMailAliasAttr).Code)
+	})
+
+	t.Run("invalid alias", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.Users.(UserProfileManager).AddMailAlias(testUser1.Uid, "John Doe <jdoe@company.com>")
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(mailAliasParam).Code)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.Users.(UserProfileManager).AddMailAlias("", "")
+		assert.Equal(t, missingParametersError([]string{userIdAttr, mailAliasParam}), cErr)
+	})
+}
+
+func TestUsersManager_RemoveMailAlias(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest((&usersManager{Client: client}).getDN(testUser1.Uid), nil)
+		mr.Delete(defaultMailAliasAttr, []string{"JDoe@company.com"})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getMailAliasEntry(testUser1.Uid, defaultMailAliasAttr, "JDoe@company.com", "john@company.com"),
+			}}, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserProfileManager).RemoveMailAlias(testUser1.Uid, testMailAlias))
+	})
+
+	t.Run("alias not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getMailAliasEntry(testUser1.Uid, defaultMailAliasAttr, "john@company.com"),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProfileManager).RemoveMailAlias(testUser1.Uid, testMailAlias)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(mailAliasNotFoundMsg, testUser1.Uid, testMailAlias), cErr.Message)
+	})
+}
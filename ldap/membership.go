@@ -0,0 +1,153 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+// membershipCacheKeyPrefix is the prefix of the keys of the cached membership checks.
+const membershipCacheKeyPrefix = "ldap:membership:"
+
+type (
+	// CompareRequest represents an LDAP compare operation which checks whether the entry with the DN holds the value
+	// for the attribute.
+	CompareRequest struct {
+		DN        string
+		Attribute string
+		Value     string
+	}
+
+	// membershipCache caches the results of the membership checks.
+	membershipCache struct {
+		store CacheStore
+		ttl   time.Duration
+	}
+)
+
+// WithMembershipCache caches the results of Groups.IsMemberOf and Groups.IsMemberOfAny in the store for the ttl.
+// The ttl is meant to be short, e.g. a few seconds, so that request-time authorization checks do not query LDAP for
+// every request. The cached results are invalidated when members are added to or removed from the group using the
+// same client. Changes made outside the client become visible after the ttl.
+func WithMembershipCache(store CacheStore, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.membershipCache = &membershipCache{store: store, ttl: ttl}
+	}
+}
+
+// IsMemberOf checks if a user is a member of a group.
+// params:
+//
+//	uid = user identifier
+//	cn = name of the group
+//	ou = organizational unit under which the group exists
+//
+// The membership is checked with an LDAP compare of the uniqueMember attribute of the group entry, so the members
+// of the group are not retrieved. This makes the method suitable for request-time authorization checks, see
+// WithMembershipCache to cache the results.
+// The method returns an error:
+//   - if any validation fails
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) IsMemberOf(uid, cn, ou string) (bool, *Error) {
+	if cErr := gm.validateMembership(uid, cn, ou); cErr != nil {
+		return false, cErr
+	}
+	uniqueMember := gm.memberDn(uid)
+	groupDn := gm.getDN(cn, ou)
+	cache := gm.Client.membershipCache
+	if cache != nil {
+		if value, ok := cache.store.Get(membershipCacheKey(groupDn, uniqueMember)); ok {
+			if isMember, ok := value.(bool); ok {
+				return isMember, nil
+			}
+		}
+	}
+	isMember, cErr := gm.Client.doLDAPCompare(&CompareRequest{DN: groupDn, Attribute: uniqueMemberAttr, Value: uniqueMember})
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return false, WrapError(errors.NotFoundError(fmt.Sprintf(groupNotFoundMsg, cn, ou)))
+		}
+		return false, cErr
+	}
+	if cache != nil {
+		cache.store.Set(membershipCacheKey(groupDn, uniqueMember), isMember, cache.ttl)
+	}
+	return isMember, nil
+}
+
+// IsMemberOfAny checks if a user is a member of at least one of the groups.
+// params:
+//
+//	uid = user identifier
+//	groups = the groups identified by their cn and organizational unit
+//
+// The groups are checked in order using IsMemberOf until a group is found the user is a member of.
+// The method returns an error:
+//   - if any validation fails
+//   - if a group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (gm *groupsManager) IsMemberOfAny(uid string, groups []GroupMembership) (bool, *Error) {
+	for _, group := range groups {
+		isMember, cErr := gm.IsMemberOf(uid, group.Cn, group.Ou)
+		if cErr != nil {
+			return false, cErr
+		}
+		if isMember {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// invalidateMemberships removes the cached membership checks of the members of the group.
+func (gm *groupsManager) invalidateMemberships(cn, ou string, memberIds []string) {
+	cache := gm.Client.membershipCache
+	if cache == nil {
+		return
+	}
+	groupDn := gm.getDN(cn, ou)
+	for _, memberId := range memberIds {
+		cache.store.Delete(membershipCacheKey(groupDn, gm.memberDn(memberId)))
+	}
+}
+
+// validateMembership checks if required information is provided for a membership check.
+// The organizational unit is not validated, as a group in an unknown organizational unit is not found.
+func (gm *groupsManager) validateMembership(uid, cn, ou string) *Error {
+	var missingParams []string
+
+	if strings.TrimSpace(uid) == "" {
+		missingParams = append(missingParams, userIdAttr)
+	}
+	if strings.TrimSpace(cn) == "" {
+		missingParams = append(missingParams, CommonNameAttr)
+	}
+	if strings.TrimSpace(ou) == "" {
+		missingParams = append(missingParams, OrganizationalUnitAttr)
+	}
+	if len(missingParams) > 0 {
+		return missingParametersError(missingParams)
+	}
+	return nil
+}
+
+// doLDAPCompare checks whether an entry in LDAP holds the value for the attribute.
+func (c *Client) doLDAPCompare(cr *CompareRequest, opts ...RequestOption) (bool, *Error) {
+	result, cErr := c.doLDAPOperation(OperationCompare, cr, opts...)
+	if cErr != nil {
+		return false, cErr
+	}
+	isEqual, _ := result.(bool)
+	return isEqual, nil
+}
+
+// membershipCacheKey returns the key of a cached membership check.
+func membershipCacheKey(groupDn, uniqueMember string) string {
+	return membershipCacheKeyPrefix + strings.ToLower(groupDn) + ":" + strings.ToLower(uniqueMember)
+}
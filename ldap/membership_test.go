@@ -0,0 +1,199 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const methodNameCompare = "Compare"
+
+func TestGroupsManager_IsMemberOf(t *testing.T) {
+	gm := groupsManager{Client: NewClient(testConfig)}
+	groupDn := gm.getDN(testGroupCn1, testOrganizationUnit1)
+	uniqueMember := gm.getUniqueMemberDn(testUser1.Uid)
+
+	t.Run("member", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameCompare, groupDn, uniqueMemberAttr, uniqueMember).Return(true, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOf("c00001", testGroupCn1, testOrganizationUnit1)
+		assert.Nil(t, cErr)
+		assert.True(t, isMember)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameCompare, groupDn, uniqueMemberAttr, uniqueMember).Return(false, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOf(testUser1.Uid, testGroupCn1, testOrganizationUnit1)
+		assert.Nil(t, cErr)
+		assert.False(t, isMember)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameCompare, groupDn, uniqueMemberAttr, uniqueMember).Return(false, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOf(testUser1.Uid, testGroupCn1, testOrganizationUnit1)
+		assert.False(t, isMember)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(groupNotFoundMsg, testGroupCn1, testOrganizationUnit1), cErr.Message)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOf(" ", "", testOrganizationUnit1)
+		assert.False(t, isMember)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{userIdAttr, CommonNameAttr}), cErr.Message)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		store := NewMemoryCacheStore()
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithMembershipCache(store, time.Minute))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil).Once()
+		ldapMock.On(methodNameCompare, groupDn, uniqueMemberAttr, uniqueMember).Return(true, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil).Once()
+
+		for i := 0; i < 2; i++ {
+			isMember, cErr := client.Groups.(GroupsReader).IsMemberOf(testUser1.Uid, testGroupCn1, testOrganizationUnit1)
+			assert.Nil(t, cErr)
+			assert.True(t, isMember)
+		}
+	})
+
+	t.Run("cache expired", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		store := NewMemoryCacheStore()
+		now := time.Now()
+		store.now = func() time.Time { return now }
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithMembershipCache(store, time.Second))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil).Twice()
+		ldapMock.On(methodNameCompare, groupDn, uniqueMemberAttr, uniqueMember).Return(false, nil).Once()
+		ldapMock.On(methodNameCompare, groupDn, uniqueMemberAttr, uniqueMember).Return(true, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil).Twice()
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOf(testUser1.Uid, testGroupCn1, testOrganizationUnit1)
+		assert.Nil(t, cErr)
+		assert.False(t, isMember)
+
+		now = now.Add(time.Second)
+		isMember, cErr = client.Groups.(GroupsReader).IsMemberOf(testUser1.Uid, testGroupCn1, testOrganizationUnit1)
+		assert.Nil(t, cErr)
+		assert.True(t, isMember)
+	})
+
+	t.Run("cache invalidated by AddMembers", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		store := NewMemoryCacheStore()
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation(), WithMembershipCache(store, time.Minute))
+		store.Set(membershipCacheKey(groupDn, uniqueMember), false, time.Minute)
+		searchRequest := gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Add(uniqueMemberAttr, []string{uniqueMember})
+		mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+		searchResult := &ldap.SearchResult{Entries: []*ldap.Entry{getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1,
+			[]string{gm.getUniqueMemberDn(testUser2.Uid)})}}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequest).Return(searchResult, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameCompare, groupDn, uniqueMemberAttr, uniqueMember).Return(true, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Groups.AddMembers(testGroupCn1, testOrganizationUnit1, []string{testUser1.Uid})
+		assert.Nil(t, cErr)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOf(testUser1.Uid, testGroupCn1, testOrganizationUnit1)
+		assert.Nil(t, cErr)
+		assert.True(t, isMember)
+	})
+}
+
+func TestGroupsManager_IsMemberOfAny(t *testing.T) {
+	gm := groupsManager{Client: NewClient(testConfig)}
+	uniqueMember := gm.getUniqueMemberDn(testUser1.Uid)
+	groups := []GroupMembership{
+		{Cn: testGroupCn1, Ou: testOrganizationUnit1},
+		{Cn: testGroupCn2, Ou: testOrganizationUnit1},
+	}
+
+	t.Run("member of the second group", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameCompare, gm.getDN(testGroupCn1, testOrganizationUnit1), uniqueMemberAttr, uniqueMember).
+			Return(false, nil)
+		ldapMock.On(methodNameCompare, gm.getDN(testGroupCn2, testOrganizationUnit1), uniqueMemberAttr, uniqueMember).
+			Return(true, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOfAny(testUser1.Uid, groups)
+		assert.Nil(t, cErr)
+		assert.True(t, isMember)
+	})
+
+	t.Run("member of the first group", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameCompare, gm.getDN(testGroupCn1, testOrganizationUnit1), uniqueMemberAttr, uniqueMember).
+			Return(true, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOfAny(testUser1.Uid, groups)
+		assert.Nil(t, cErr)
+		assert.True(t, isMember)
+	})
+
+	t.Run("no groups", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOfAny(testUser1.Uid, nil)
+		assert.Nil(t, cErr)
+		assert.False(t, isMember)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameCompare, gm.getDN(testGroupCn1, testOrganizationUnit1), uniqueMemberAttr, uniqueMember).
+			Return(false, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		isMember, cErr := client.Groups.(GroupsReader).IsMemberOfAny(testUser1.Uid, groups)
+		assert.False(t, isMember)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
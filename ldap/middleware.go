@@ -0,0 +1,76 @@
+package ldap
+
+import (
+	"time"
+)
+
+const (
+	OperationSearch         = "search"
+	OperationAdd            = "add"
+	OperationDelete         = "delete"
+	OperationModify         = "modify"
+	OperationModifyDN       = "modifyDN"
+	OperationPasswordModify = "passwordModify"
+	OperationPagedSearch    = "pagedSearch"
+	OperationBatch          = "batch"
+	OperationCompare        = "compare"
+
+	unsupportedOperationErrMsg = "Unsupported LDAP operation '%s' with request type %T"
+)
+
+type (
+	// OperationRequest represents an LDAP operation that is executed by the client.
+	// Request holds the go-ldap request of the operation, e.g. *ldap.SearchRequest for OperationSearch,
+	// *ldap.AddRequest for OperationAdd, *ldap.DelRequest for OperationDelete, *ldap.ModifyRequest for
+	// OperationModify, *ldap.ModifyDNRequest for OperationModifyDN, *ldap.PasswordModifyRequest for
+	// OperationPasswordModify, *PagedSearchRequest for OperationPagedSearch, *BatchRequest for OperationBatch and
+	// *CompareRequest for OperationCompare.
+	OperationRequest struct {
+		Name    string
+		Request any
+		// Timeout overrides the RequestTimeout set in the client Config for the operation if set, see WithTimeout.
+		Timeout time.Duration
+		// MaxResults overrides the MaxResults set in the client Config for a search or paged search if set, see
+		// WithMaxResults.
+		MaxResults int
+		// DerefAliases overrides the DerefAliases set in the client Config for a search or paged search if set, see
+		// WithDerefAliases.
+		DerefAliases *int
+	}
+
+	// Operation executes an OperationRequest and returns the go-ldap result of the operation, e.g.
+	// *ldap.SearchResult for OperationSearch, *ldap.PasswordModifyResult for OperationPasswordModify and bool for
+	// OperationCompare.
+	Operation func(req *OperationRequest) (any, *Error)
+
+	// Middleware wraps an Operation to add behaviour before and/or after the operation is executed.
+	// A Middleware can modify the request, short-circuit the operation by returning an error without calling
+	// next, or inspect the result and the error returned by next.
+	Middleware func(next Operation) Operation
+)
+
+// WithMiddleware registers one or more Middleware which wrap every LDAP operation executed by the client.
+// Middleware are invoked in the order in which they are registered, the first Middleware being the outermost.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// String returns the name and the trace of the request, e.g. for logging the requests in a Middleware. The values
+// of the attributes, including the passwords of a password modify, are not part of it, see RequestTrace.
+func (r *OperationRequest) String() string {
+	trace, ok := newRequestTrace(r.Name, r.Request)
+	if !ok {
+		return r.Name
+	}
+	return r.Name + " " + trace.String()
+}
+
+// chain wraps the Operation with the Middleware registered on the client.
+func (c *Client) chain(op Operation) Operation {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		op = c.middleware[i](op)
+	}
+	return op
+}
@@ -0,0 +1,101 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	noop := func(next Operation) Operation { return next }
+	client := NewClient(testConfig, WithMiddleware(noop), WithMiddleware(noop, noop))
+	assert.Len(t, client.middleware, 3)
+}
+
+func TestClient_chain(t *testing.T) {
+	t.Run("middleware order", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var calls []string
+		record := func(name string) Middleware {
+			return func(next Operation) Operation {
+				return func(req *OperationRequest) (any, *Error) {
+					calls = append(calls, name+":before")
+					result, cErr := next(req)
+					calls = append(calls, name+":after")
+					return result, cErr
+				}
+			}
+		}
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithMiddleware(record("first"), record("second")))
+		dr := ldap.NewDelRequest(testConfig.UserBaseDN, nil)
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, dr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.doLDAPDelete(dr)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{"first:before", "second:before", "second:after", "first:after"}, calls)
+	})
+
+	t.Run("request mutation", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		mutate := func(next Operation) Operation {
+			return func(req *OperationRequest) (any, *Error) {
+				if sr, ok := req.Request.(*ldap.SearchRequest); ok {
+					sr.SizeLimit = 10
+				}
+				return next(req)
+			}
+		}
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithMiddleware(mutate))
+		um := usersManager{Client: client}
+		sr := um.getUsersSearchRequest(userSearchFilter)
+		expected := um.getUsersSearchRequest(userSearchFilter)
+		expected.SizeLimit = 10
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, expected).Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.doLDAPSearch(sr)
+		assert.Nil(t, cErr)
+		assert.Len(t, result.Entries, 1)
+	})
+
+	t.Run("short-circuit", func(t *testing.T) {
+		deny := func(next Operation) Operation {
+			return func(req *OperationRequest) (any, *Error) {
+				if req.Name == OperationDelete {
+					return nil, WrapError(errors.ForbiddenError("deletes are not allowed"))
+				}
+				return next(req)
+			}
+		}
+		client := NewClient(testConfig, UnitTesting(), WithMiddleware(deny))
+
+		cErr := client.doLDAPDelete(ldap.NewDelRequest(testConfig.UserBaseDN, nil))
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+		assert.Equal(t, "deletes are not allowed", cErr.Message)
+	})
+}
+
+func TestClient_execute(t *testing.T) {
+	t.Run("unsupported request", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.execute(&OperationRequest{Name: "compare", Request: "test"})
+		assert.Equal(t, errors.ErrCodeInternalServerError, cErr.Code)
+		assert.Equal(t, "Unsupported LDAP operation 'compare' with request type string", cErr.Message)
+	})
+}
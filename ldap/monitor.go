@@ -0,0 +1,206 @@
+package ldap
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// MonitorBaseDN is the dn of the subtree of the OpenLDAP monitor backend.
+	MonitorBaseDN = "cn=Monitor"
+
+	monitorCounterAttr     = "monitorCounter"
+	monitorOpInitiatedAttr = "monitorOpInitiated"
+	monitorOpCompletedAttr = "monitorOpCompleted"
+	monitoredInfoAttr      = "monitoredInfo"
+
+	monitorConnections = "connections"
+	monitorOperations  = "operations"
+	monitorThreads     = "threads"
+	monitorWaiters     = "waiters"
+
+	monitorNotFoundMsg = "The monitor backend is not enabled on the LDAP server or not readable by the bind user"
+)
+
+var monitorAttributes = []string{
+	monitorCounterAttr,
+	monitorOpInitiatedAttr,
+	monitorOpCompletedAttr,
+	monitoredInfoAttr,
+}
+
+type (
+	// MonitorManager describes the interface which needs to be implemented for reading the metrics of the LDAP
+	// server published by the OpenLDAP monitor backend.
+	MonitorManager interface {
+		Stats() (*MonitorStats, *Error)
+	}
+
+	// monitorManager implements the MonitorManager interface.
+	monitorManager struct {
+		Client *Client
+	}
+
+	// MonitorStats represents the metrics of the LDAP server read from the cn=Monitor subtree.
+	MonitorStats struct {
+		Connections ConnectionStats `json:"connections"`
+		// Operations are the totals of all the operations.
+		Operations OperationStats `json:"operations"`
+		// OperationsByType are the operations per type, e.g. "bind", "search" or "modify".
+		OperationsByType map[string]OperationStats `json:"operationsByType"`
+		Threads          ThreadStats               `json:"threads"`
+		Waiters          WaiterStats               `json:"waiters"`
+	}
+
+	// ConnectionStats represents the connection metrics of the LDAP server.
+	ConnectionStats struct {
+		// Total is the number of connections accepted since the server started.
+		Total int64 `json:"total"`
+		// Current is the number of open connections.
+		Current            int64 `json:"current"`
+		MaxFileDescriptors int64 `json:"maxFileDescriptors"`
+	}
+
+	// OperationStats represents the number of initiated and completed operations of the LDAP server.
+	OperationStats struct {
+		Initiated int64 `json:"initiated"`
+		Completed int64 `json:"completed"`
+	}
+
+	// ThreadStats represents the state of the thread pool of the LDAP server.
+	ThreadStats struct {
+		Max        int64  `json:"max"`
+		MaxPending int64  `json:"maxPending"`
+		Open       int64  `json:"open"`
+		Starting   int64  `json:"starting"`
+		Active     int64  `json:"active"`
+		Pending    int64  `json:"pending"`
+		Backload   int64  `json:"backload"`
+		State      string `json:"state"`
+	}
+
+	// WaiterStats represents the number of connections waiting to read or to write.
+	WaiterStats struct {
+		Read  int64 `json:"read"`
+		Write int64 `json:"write"`
+	}
+)
+
+// WithMonitorManager overrides the default MonitorManager.
+func WithMonitorManager(mm MonitorManager) ClientOption {
+	return func(c *Client) {
+		c.Monitor = mm
+	}
+}
+
+// Stats reads the connections, operations, threads and waiters from the cn=Monitor subtree of an OpenLDAP server,
+// e.g. to export them as metrics. The monitor backend must be enabled and readable by the bind user.
+// The metrics which are not published by the server are zero.
+// The method returns an error:
+//   - if the monitor backend is not enabled or not readable
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (mm *monitorManager) Stats() (*MonitorStats, *Error) {
+	result, cErr := mm.Client.doLDAPSearch(mm.getSearchRequest())
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(monitorNotFoundMsg))
+		}
+		return nil, cErr
+	}
+	return mm.parseSearchResult(result), nil
+}
+
+// getSearchRequest returns a ldap search request to get the entries of the cn=Monitor subtree.
+func (mm *monitorManager) getSearchRequest() *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		MonitorBaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		allEntriesFilter,
+		monitorAttributes,
+		nil,
+	)
+}
+
+// parseSearchResult parses the entries of the cn=Monitor subtree. Every metric is an entry named after the metric
+// within the container of the metric, e.g. cn=Current,cn=Connections,cn=Monitor.
+func (mm *monitorManager) parseSearchResult(result *ldap.SearchResult) *MonitorStats {
+	stats := &MonitorStats{OperationsByType: map[string]OperationStats{}}
+	for _, entry := range result.Entries {
+		parsedDN, err := ldap.ParseDN(entry.DN)
+		if err != nil || len(parsedDN.RDNs) < 2 || len(parsedDN.RDNs) > 3 {
+			continue
+		}
+		name := strings.ToLower(parsedDN.RDNs[0].Attributes[0].Value)
+		if len(parsedDN.RDNs) == 2 {
+			if name == monitorOperations {
+				stats.Operations = parseOperationStats(entry)
+			}
+			continue
+		}
+		switch strings.ToLower(parsedDN.RDNs[1].Attributes[0].Value) {
+		case monitorConnections:
+			counter := monitorCounter(entry)
+			switch name {
+			case "total":
+				stats.Connections.Total = counter
+			case "current":
+				stats.Connections.Current = counter
+			case "max file descriptors":
+				stats.Connections.MaxFileDescriptors = counter
+			}
+		case monitorOperations:
+			stats.OperationsByType[name] = parseOperationStats(entry)
+		case monitorThreads:
+			info := entry.GetAttributeValue(monitoredInfoAttr)
+			value, _ := strconv.ParseInt(info, 10, 64)
+			switch name {
+			case "max":
+				stats.Threads.Max = value
+			case "max pending":
+				stats.Threads.MaxPending = value
+			case "open":
+				stats.Threads.Open = value
+			case "starting":
+				stats.Threads.Starting = value
+			case "active":
+				stats.Threads.Active = value
+			case "pending":
+				stats.Threads.Pending = value
+			case "backload":
+				stats.Threads.Backload = value
+			case "state":
+				stats.Threads.State = info
+			}
+		case monitorWaiters:
+			switch name {
+			case "read":
+				stats.Waiters.Read = monitorCounter(entry)
+			case "write":
+				stats.Waiters.Write = monitorCounter(entry)
+			}
+		}
+	}
+	return stats
+}
+
+// parseOperationStats returns the initiated and completed operations of a monitor entry.
+func parseOperationStats(entry *ldap.Entry) OperationStats {
+	initiated, _ := strconv.ParseInt(entry.GetAttributeValue(monitorOpInitiatedAttr), 10, 64)
+	completed, _ := strconv.ParseInt(entry.GetAttributeValue(monitorOpCompletedAttr), 10, 64)
+	return OperationStats{Initiated: initiated, Completed: completed}
+}
+
+// monitorCounter returns the counter of a monitor entry or 0 if the entry does not have a counter.
+func monitorCounter(entry *ldap.Entry) int64 {
+	counter, _ := strconv.ParseInt(entry.GetAttributeValue(monitorCounterAttr), 10, 64)
+	return counter
+}
@@ -0,0 +1,93 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// getMonitorLDAPEntry returns a cn=Monitor entry with the value of the attribute.
+func getMonitorLDAPEntry(dn, attr, value string) *ldap.Entry {
+	return ldap.NewEntry(dn, map[string][]string{attr: {value}})
+}
+
+func TestWithMonitorManager(t *testing.T) {
+	mm := &monitorManager{}
+	client := NewClient(testConfig, WithMonitorManager(mm))
+	assert.Equal(t, mm, client.Monitor)
+}
+
+func TestMonitorManager_Stats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mm := monitorManager{Client: client}
+		operations := ldap.NewEntry("cn=Operations,cn=Monitor", map[string][]string{
+			monitorOpInitiatedAttr: {"120"}, monitorOpCompletedAttr: {"118"},
+		})
+		search := ldap.NewEntry("cn=Search,cn=Operations,cn=Monitor", map[string][]string{
+			monitorOpInitiatedAttr: {"100"}, monitorOpCompletedAttr: {"99"},
+		})
+		result := &ldap.SearchResult{Entries: []*ldap.Entry{
+			ldap.NewEntry("cn=Monitor", nil),
+			ldap.NewEntry("cn=Connections,cn=Monitor", nil),
+			getMonitorLDAPEntry("cn=Total,cn=Connections,cn=Monitor", monitorCounterAttr, "1001"),
+			getMonitorLDAPEntry("cn=Current,cn=Connections,cn=Monitor", monitorCounterAttr, "12"),
+			getMonitorLDAPEntry("cn=Max File Descriptors,cn=Connections,cn=Monitor", monitorCounterAttr, "1024"),
+			ldap.NewEntry("cn=Connection 1000,cn=Connections,cn=Monitor", nil),
+			operations,
+			search,
+			getMonitorLDAPEntry("cn=Max,cn=Threads,cn=Monitor", monitoredInfoAttr, "16"),
+			getMonitorLDAPEntry("cn=Active,cn=Threads,cn=Monitor", monitoredInfoAttr, "2"),
+			getMonitorLDAPEntry("cn=State,cn=Threads,cn=Monitor", monitoredInfoAttr, "running"),
+			getMonitorLDAPEntry("cn=Read,cn=Waiters,cn=Monitor", monitorCounterAttr, "7"),
+			getMonitorLDAPEntry("cn=Write,cn=Waiters,cn=Monitor", monitorCounterAttr, "1"),
+			getMonitorLDAPEntry("cn=Database 1,cn=Databases,cn=Monitor", monitoredInfoAttr, "mdb"),
+		}}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mm.getSearchRequest()).Return(result, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		stats, cErr := client.Monitor.Stats()
+		assert.Nil(t, cErr)
+		assert.Equal(t, &MonitorStats{
+			Connections:      ConnectionStats{Total: 1001, Current: 12, MaxFileDescriptors: 1024},
+			Operations:       OperationStats{Initiated: 120, Completed: 118},
+			OperationsByType: map[string]OperationStats{"search": {Initiated: 100, Completed: 99}},
+			Threads:          ThreadStats{Max: 16, Active: 2, State: "running"},
+			Waiters:          WaiterStats{Read: 7, Write: 1},
+		}, stats)
+	})
+
+	t.Run("monitor backend not enabled", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mm := monitorManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mm.getSearchRequest()).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		stats, cErr := client.Monitor.Stats()
+		assert.Nil(t, stats)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, monitorNotFoundMsg, cErr.Message)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mm := monitorManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mm.getSearchRequest()).Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Monitor.Stats()
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+}
@@ -0,0 +1,307 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	nisNetgroupTripleAttr = "nisNetgroupTriple"
+	memberNisNetgroupAttr = "memberNisNetgroup"
+
+	// netgroupObjectClassFilter matches the netgroup entries.
+	netgroupObjectClassFilter = "(objectClass=nisNetgroup)"
+	// netgroupFilterTemplate combines the filter matching the netgroup entries with an attribute value assertion.
+	netgroupFilterTemplate = "(&(%s=%s)" + netgroupObjectClassFilter + ")"
+
+	netgroupBaseDNParam = "netgroupBaseDN"
+
+	netgroupNotFoundMsg         = "Netgroup with cn = '%s' was not found"
+	netgroupAlreadyExistsMsg    = "Netgroup with cn = '%s' already exists"
+	invalidNetgroupTripleErrMsg = "'%s' is not a valid netgroup triple, expected (host,user,domain)"
+)
+
+var defaultObjectClassesNetgroup = []string{
+	"nisNetgroup",
+	"top",
+}
+
+type (
+	// NetgroupsManager describes the interface which needs to be implemented for managing the NIS netgroup entries
+	// within the NetgroupBaseDN set in the client Config.
+	NetgroupsManager interface {
+		GetAll(opts ...RequestOption) ([]Netgroup, *Error)
+		Get(cn string) (*Netgroup, *Error)
+		Create(netgroup Netgroup) *Error
+		Update(netgroup Netgroup) *Error
+		Delete(cn string) *Error
+	}
+
+	// netgroupsManager implements the NetgroupsManager interface.
+	netgroupsManager struct {
+		Client *Client
+	}
+
+	// Netgroup represents a nisNetgroup entry in LDAP.
+	Netgroup struct {
+		Dn string `json:"dn"`
+		Cn string `json:"cn"`
+		// Triples are the (host,user,domain) triples of the netgroup.
+		Triples []NetgroupTriple `json:"triples,omitempty"`
+		// Members are the cns of the netgroups which are nested in the netgroup.
+		Members     []string `json:"members,omitempty"`
+		Description string   `json:"description,omitempty"`
+	}
+
+	// NetgroupTriple represents a (host,user,domain) triple of a netgroup. An empty field is a wildcard and a field
+	// set to "-" matches no value.
+	NetgroupTriple struct {
+		Host   string `json:"host"`
+		User   string `json:"user"`
+		Domain string `json:"domain"`
+	}
+)
+
+// WithNetgroupsManager overrides the default NetgroupsManager.
+func WithNetgroupsManager(nm NetgroupsManager) ClientOption {
+	return func(c *Client) {
+		c.Netgroups = nm
+	}
+}
+
+// ParseNetgroupTriple parses a nisNetgroupTriple value, e.g. "(web01,-,company.com)".
+// The function returns an error if the value is not a triple.
+func ParseNetgroupTriple(value string) (NetgroupTriple, *Error) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "(") || !strings.HasSuffix(trimmed, ")") {
+		return NetgroupTriple{}, invalidParameterError(nisNetgroupTripleAttr,
+			fmt.Sprintf(invalidNetgroupTripleErrMsg, value))
+	}
+	fields := strings.Split(trimmed[1:len(trimmed)-1], ",")
+	if len(fields) != 3 {
+		return NetgroupTriple{}, invalidParameterError(nisNetgroupTripleAttr,
+			fmt.Sprintf(invalidNetgroupTripleErrMsg, value))
+	}
+	return NetgroupTriple{
+		Host:   strings.TrimSpace(fields[0]),
+		User:   strings.TrimSpace(fields[1]),
+		Domain: strings.TrimSpace(fields[2]),
+	}, nil
+}
+
+// String returns the triple as a nisNetgroupTriple value, e.g. "(web01,-,company.com)".
+func (t NetgroupTriple) String() string {
+	return fmt.Sprintf("(%s,%s,%s)", t.Host, t.User, t.Domain)
+}
+
+// GetAll retrieves all the netgroup entries from LDAP. The triples which cannot be parsed are skipped.
+// The method returns an error:
+//   - if the NetgroupBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (nm *netgroupsManager) GetAll(opts ...RequestOption) ([]Netgroup, *Error) {
+	return nm.search(netgroupObjectClassFilter, opts...)
+}
+
+// Get retrieves a netgroup entry from LDAP.
+// params:
+//
+//	cn = the name of the netgroup
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the NetgroupBaseDN is not set in the client Config
+//   - if the netgroup is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (nm *netgroupsManager) Get(cn string) (*Netgroup, *Error) {
+	if strings.TrimSpace(cn) == "" {
+		return nil, missingParametersError([]string{CommonNameAttr})
+	}
+	netgroups, cErr := nm.search(fmt.Sprintf(netgroupFilterTemplate, CommonNameAttr, ldap.EscapeFilter(cn)))
+	if cErr != nil {
+		return nil, cErr
+	}
+	if len(netgroups) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(netgroupNotFoundMsg, cn)))
+	}
+	return &netgroups[0], nil
+}
+
+// Create creates a new netgroup entry in LDAP.
+// params:
+//
+//	netgroup = the netgroup, the Cn is mandatory
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the NetgroupBaseDN is not set in the client Config
+//   - if the netgroup already exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (nm *netgroupsManager) Create(netgroup Netgroup) *Error {
+	netgroupBaseDN, cErr := nm.netgroupBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(netgroup.Cn) == "" {
+		return missingParametersError([]string{CommonNameAttr})
+	}
+	ar := ldap.NewAddRequest(nm.getDN(netgroupBaseDN, netgroup.Cn), nil)
+	ar.Attribute(objectClassAttr, defaultObjectClassesNetgroup)
+	ar.Attribute(CommonNameAttr, []string{netgroup.Cn})
+	if triples := netgroup.tripleValues(); len(triples) > 0 {
+		ar.Attribute(nisNetgroupTripleAttr, triples)
+	}
+	if len(netgroup.Members) > 0 {
+		ar.Attribute(memberNisNetgroupAttr, netgroup.Members)
+	}
+	if netgroup.Description != "" {
+		ar.Attribute(descriptionAttr, []string{netgroup.Description})
+	}
+	if cErr := nm.Client.doLDAPAdd(ar); cErr != nil {
+		if cErr.Status == http.StatusBadRequest {
+			return WrapError(errors.ConflictError(fmt.Sprintf(netgroupAlreadyExistsMsg, netgroup.Cn)))
+		}
+		return cErr
+	}
+	nm.emit(EventNetgroupCreated, netgroupBaseDN, netgroup.Cn)
+	return nil
+}
+
+// Update replaces the triples, the members and the description of an existing netgroup entry in LDAP. The
+// attributes which are not set are removed.
+// params:
+//
+//	netgroup = the netgroup, the Cn is mandatory
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the NetgroupBaseDN is not set in the client Config
+//   - if the netgroup is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (nm *netgroupsManager) Update(netgroup Netgroup) *Error {
+	netgroupBaseDN, cErr := nm.netgroupBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(netgroup.Cn) == "" {
+		return missingParametersError([]string{CommonNameAttr})
+	}
+	mr := ldap.NewModifyRequest(nm.getDN(netgroupBaseDN, netgroup.Cn), nil)
+	mr.Replace(nisNetgroupTripleAttr, netgroup.tripleValues())
+	mr.Replace(memberNisNetgroupAttr, append([]string{}, netgroup.Members...))
+	mr.Replace(descriptionAttr, nonEmptyValues(netgroup.Description))
+	if cErr := nm.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(netgroupNotFoundMsg, netgroup.Cn)))
+		}
+		return cErr
+	}
+	nm.emit(EventNetgroupUpdated, netgroupBaseDN, netgroup.Cn)
+	return nil
+}
+
+// Delete deletes an existing netgroup entry from LDAP.
+// params:
+//
+//	cn = the name of the netgroup
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the NetgroupBaseDN is not set in the client Config
+//   - if the netgroup is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (nm *netgroupsManager) Delete(cn string) *Error {
+	netgroupBaseDN, cErr := nm.netgroupBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(cn) == "" {
+		return missingParametersError([]string{CommonNameAttr})
+	}
+	if cErr := nm.Client.doLDAPDelete(ldap.NewDelRequest(nm.getDN(netgroupBaseDN, cn), nil)); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(netgroupNotFoundMsg, cn)))
+		}
+		return cErr
+	}
+	nm.emit(EventNetgroupDeleted, netgroupBaseDN, cn)
+	return nil
+}
+
+// search retrieves the netgroup entries within the NetgroupBaseDN which match the search filter.
+func (nm *netgroupsManager) search(searchFilter string, opts ...RequestOption) ([]Netgroup, *Error) {
+	netgroupBaseDN, cErr := nm.netgroupBaseDN()
+	if cErr != nil {
+		return nil, cErr
+	}
+	sr := ldap.NewSearchRequest(
+		netgroupBaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		searchFilter,
+		[]string{CommonNameAttr, nisNetgroupTripleAttr, memberNisNetgroupAttr, descriptionAttr},
+		nil,
+	)
+	netgroups := []Netgroup{}
+	cErr = nm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			netgroup := Netgroup{
+				Dn:          entry.DN,
+				Cn:          entry.GetAttributeValue(CommonNameAttr),
+				Members:     entry.GetAttributeValues(memberNisNetgroupAttr),
+				Description: entry.GetAttributeValue(descriptionAttr),
+			}
+			for _, value := range entry.GetAttributeValues(nisNetgroupTripleAttr) {
+				if triple, cErr := ParseNetgroupTriple(value); cErr == nil {
+					netgroup.Triples = append(netgroup.Triples, triple)
+				}
+			}
+			netgroups = append(netgroups, netgroup)
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return netgroups, nil
+}
+
+// tripleValues returns the triples of the netgroup as nisNetgroupTriple values.
+func (n Netgroup) tripleValues() []string {
+	values := make([]string, 0, len(n.Triples))
+	for _, triple := range n.Triples {
+		values = append(values, triple.String())
+	}
+	return values
+}
+
+// emit emits an event of the event type for the netgroup entry.
+func (nm *netgroupsManager) emit(eventType EventType, netgroupBaseDN, cn string) {
+	nm.Client.emit(Event{Type: eventType, Dn: nm.getDN(netgroupBaseDN, cn), Cn: cn})
+}
+
+// getDN returns the dn of the netgroup entry.
+func (nm *netgroupsManager) getDN(netgroupBaseDN, cn string) string {
+	return dn.Join(dn.RDN(CommonNameAttr, cn), netgroupBaseDN)
+}
+
+// netgroupBaseDN returns the NetgroupBaseDN set in the client Config or an error if it is not set.
+func (nm *netgroupsManager) netgroupBaseDN() (string, *Error) {
+	netgroupBaseDN := nm.Client.getConfig().NetgroupBaseDN
+	if netgroupBaseDN == "" {
+		return "", missingParametersError([]string{netgroupBaseDNParam})
+	}
+	return netgroupBaseDN, nil
+}
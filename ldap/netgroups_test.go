@@ -0,0 +1,231 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testNetgroupConfig = func() Config {
+		config := testConfig
+		config.NetgroupBaseDN = "ou=netgroup,o=company"
+		return config
+	}()
+
+	testNetgroup = Netgroup{
+		Dn: "cn=webservers,ou=netgroup,o=company",
+		Cn: "webservers",
+		Triples: []NetgroupTriple{
+			{Host: "web01", User: "-", Domain: "company.com"},
+			{Host: "web02", User: "-", Domain: "company.com"},
+		},
+		Members:     []string{"appservers"},
+		Description: "Web servers",
+	}
+
+	getNetgroupSearchResult = &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry(testNetgroup.Dn, map[string][]string{
+				CommonNameAttr:        {testNetgroup.Cn},
+				nisNetgroupTripleAttr: {"(web01,-,company.com)", "( web02 , - , company.com )", "invalid"},
+				memberNisNetgroupAttr: testNetgroup.Members,
+				descriptionAttr:       {testNetgroup.Description},
+			}),
+		},
+	}
+)
+
+func TestParseNetgroupTriple(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		triple, cErr := ParseNetgroupTriple("(web01,,company.com)")
+		assert.Nil(t, cErr)
+		assert.Equal(t, NetgroupTriple{Host: "web01", Domain: "company.com"}, triple)
+		assert.Equal(t, "(web01,,company.com)", triple.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, value := range []string{"", "web01,-,company.com", "(web01,-)", "(web01,-,company.com,x)"} {
+			_, cErr := ParseNetgroupTriple(value)
+			assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(nisNetgroupTripleAttr).Code, value)
+		}
+	})
+}
+
+func TestNetgroupsManager_GetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, testNetgroupConfig.NetgroupBaseDN, sr.BaseDN)
+				assert.Equal(t, netgroupObjectClassFilter, sr.Filter)
+			}).
+			Return(getNetgroupSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		netgroups, cErr := client.Netgroups.GetAll()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []Netgroup{testNetgroup}, netgroups)
+	})
+
+	t.Run("netgroup base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		netgroups, cErr := client.Netgroups.GetAll()
+		assert.Nil(t, netgroups)
+		assert.Equal(t, missingParametersError([]string{netgroupBaseDNParam}), cErr)
+	})
+}
+
+func TestNetgroupsManager_Get(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(cn=webservers)(objectClass=nisNetgroup))", sr.Filter)
+			}).
+			Return(getNetgroupSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		netgroup, cErr := client.Netgroups.Get(testNetgroup.Cn)
+		assert.Nil(t, cErr)
+		assert.Equal(t, &testNetgroup, netgroup)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(&ldap.SearchResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		netgroup, cErr := client.Netgroups.Get(testNetgroup.Cn)
+		assert.Nil(t, netgroup)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testNetgroupConfig, UnitTesting())
+
+		netgroup, cErr := client.Netgroups.Get(" ")
+		assert.Nil(t, netgroup)
+		assert.Equal(t, missingParametersError([]string{CommonNameAttr}), cErr)
+	})
+}
+
+func TestNetgroupsManager_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest(testNetgroup.Dn, nil)
+		ar.Attribute(objectClassAttr, defaultObjectClassesNetgroup)
+		ar.Attribute(CommonNameAttr, []string{testNetgroup.Cn})
+		ar.Attribute(nisNetgroupTripleAttr, []string{"(web01,-,company.com)", "(web02,-,company.com)"})
+		ar.Attribute(memberNisNetgroupAttr, testNetgroup.Members)
+		ar.Attribute(descriptionAttr, []string{testNetgroup.Description})
+		var events []Event
+		client.On(EventNetgroupCreated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Netgroups.Create(testNetgroup))
+		assert.Len(t, events, 1)
+		assert.Equal(t, testNetgroup.Dn, events[0].Dn)
+		assert.Equal(t, testNetgroup.Cn, events[0].Cn)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).Return(ldapEntryAlreadyExistsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Netgroups.Create(testNetgroup)
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testNetgroupConfig, UnitTesting())
+
+		cErr := client.Netgroups.Create(Netgroup{})
+		assert.Equal(t, missingParametersError([]string{CommonNameAttr}), cErr)
+	})
+}
+
+func TestNetgroupsManager_Update(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest(testNetgroup.Dn, nil)
+		mr.Replace(nisNetgroupTripleAttr, []string{"(web03,-,company.com)"})
+		mr.Replace(memberNisNetgroupAttr, []string{})
+		mr.Replace(descriptionAttr, []string{})
+		var events []EventType
+		client.On(EventNetgroupUpdated, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Netgroups.Update(Netgroup{Cn: testNetgroup.Cn,
+			Triples: []NetgroupTriple{{Host: "web03", User: "-", Domain: "company.com"}}}))
+		assert.Equal(t, []EventType{EventNetgroupUpdated}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Netgroups.Update(testNetgroup)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestNetgroupsManager_Delete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var events []EventType
+		client.On(EventNetgroupDeleted, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(testNetgroup.Dn, nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Netgroups.Delete(testNetgroup.Cn))
+		assert.Equal(t, []EventType{EventNetgroupDeleted}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testNetgroupConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, mock.AnythingOfType("*ldap.DelRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Netgroups.Delete(testNetgroup.Cn)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
@@ -0,0 +1,62 @@
+package ldap
+
+import (
+	"time"
+)
+
+type (
+	// OperationMetadata describes how an LDAP operation was executed by the client, e.g. to find out why an operation
+	// is slow in a setup with several LDAP servers.
+	OperationMetadata struct {
+		// Operation is the name of the operation, e.g. OperationSearch.
+		Operation string `json:"operation"`
+		// Duration is the wall time of the operation, including the connect and the bind.
+		Duration time.Duration `json:"duration"`
+		// Server is the LDAP URL of the server the operation was executed on, e.g. the first reachable server of the
+		// SRVDomain. Empty if the operation failed before a server was chosen.
+		Server string `json:"server,omitempty"`
+		// ConnectionReused is true if the operation was executed on an existing connection instead of a connection
+		// dialed for the operation, i.e. an idle connection of the pool set with WithConnectionPool or the connection
+		// set with WithLDAPClient for unit testing.
+		ConnectionReused bool `json:"connectionReused"`
+		// Retries is the number of retries performed to connect: the servers of the SRVDomain which could not be
+		// reached before the Server and the bind retried with refreshed credentials.
+		Retries int `json:"retries"`
+		// Error is the error of the operation, if any.
+		Error *Error `json:"error,omitempty"`
+	}
+
+	// OperationObserver is invoked with the metadata of every LDAP operation executed by the client.
+	OperationObserver func(metadata OperationMetadata)
+
+	// connectionStats collects how the connection of an operation was established.
+	connectionStats struct {
+		server  string
+		reused  bool
+		retries int
+	}
+)
+
+// WithOperationObserver sets the OperationObserver invoked with the metadata of every LDAP operation executed by the
+// client once the operation completed, including the operations which failed and the operations of the health
+// checks. A paged search and a batch are observed as a single operation.
+func WithOperationObserver(observer OperationObserver) ClientOption {
+	return func(c *Client) {
+		c.operationObserver = observer
+	}
+}
+
+// observeOperation invokes the OperationObserver set on the client, if any, with the metadata of the operation.
+func (c *Client) observeOperation(operation string, start time.Time, stats *connectionStats, cErr *Error) {
+	if c.operationObserver == nil {
+		return
+	}
+	c.operationObserver(OperationMetadata{
+		Operation:        operation,
+		Duration:         time.Since(start),
+		Server:           stats.server,
+		ConnectionReused: stats.reused,
+		Retries:          stats.retries,
+		Error:            cErr,
+	})
+}
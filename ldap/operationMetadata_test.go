@@ -0,0 +1,114 @@
+package ldap
+
+import (
+	err "errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithOperationObserver(t *testing.T) {
+	t.Run("reused connection", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var observed []OperationMetadata
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithOperationObserver(func(metadata OperationMetadata) { observed = append(observed, metadata) }))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.Get(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Len(t, observed, 1)
+		assert.Equal(t, OperationSearch, observed[0].Operation)
+		assert.Equal(t, "ldaps://ldap.company.com:636", observed[0].Server)
+		assert.True(t, observed[0].ConnectionReused)
+		assert.Zero(t, observed[0].Retries)
+		assert.Positive(t, observed[0].Duration)
+		assert.Nil(t, observed[0].Error)
+	})
+
+	t.Run("rebind", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var observed []OperationMetadata
+		provider := func() (string, string, error) {
+			return testConfig.BindUser, testRotatedBindPassword, nil
+		}
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), WithCredentialsProvider(provider), UnitTesting(),
+			WithOperationObserver(func(metadata OperationMetadata) { observed = append(observed, metadata) }))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testRotatedBindPassword).
+			Return(ldapInvalidCredentialsErr).Once()
+		ldapMock.On(methodNameBind, testConfig.BindUser, testRotatedBindPassword).Return(nil).Once()
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest("uid=C00001,ou=users,o=company", nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.Delete(testUser1.Uid))
+		assert.Len(t, observed, 1)
+		assert.Equal(t, OperationDelete, observed[0].Operation)
+		assert.Equal(t, 1, observed[0].Retries)
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		var addresses []string
+		var observed []OperationMetadata
+		dialer := DialerFunc(func(network, address string) (net.Conn, error) {
+			addresses = append(addresses, address)
+			if len(addresses) == 1 {
+				return nil, err.New("connection refused")
+			}
+			server, conn := net.Pipe()
+			server.Close()
+			return conn, nil
+		})
+		client := NewClient(testSRVConfig, WithDialer(dialer), WithLookupSRV(testLookupSRV),
+			WithOperationObserver(func(metadata OperationMetadata) { observed = append(observed, metadata) }))
+
+		// the connection is established with the second server, the bind fails on the closed transport
+		_, cErr := client.Users.Get(testUser1.Uid)
+		assert.NotNil(t, cErr)
+		assert.Len(t, observed, 1)
+		assert.Equal(t, "ldap://"+addresses[1], observed[0].Server)
+		assert.False(t, observed[0].ConnectionReused)
+		assert.Equal(t, 1, observed[0].Retries)
+		assert.Equal(t, cErr, observed[0].Error)
+	})
+
+	t.Run("health checks", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var observed []string
+		client := NewClient(testHealthProbeConfig, WithLDAPClient(ldapMock), UnitTesting(), WithCapabilityProbing(),
+			WithOperationObserver(func(metadata OperationMetadata) { observed = append(observed, metadata.Operation) }))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil)
+		ldapMock.On(methodNameModify, isHealthProbeRequest).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		report, cErr := client.HealthReport()
+		assert.Nil(t, cErr)
+		assert.True(t, *report.Writable)
+		assert.Equal(t, []string{OperationSearch, OperationSearch, OperationModify}, observed)
+	})
+
+	t.Run("refused before connecting", func(t *testing.T) {
+		var observed []OperationMetadata
+		config := testConfig
+		config.ProtectedDNs = []string{"uid=C00001,ou=users,o=company"}
+		client := NewClient(config, UnitTesting(),
+			WithOperationObserver(func(metadata OperationMetadata) { observed = append(observed, metadata) }))
+
+		cErr := client.Users.(UserLifecycleManager).HardDelete(testUser1.Uid)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+		assert.Len(t, observed, 1)
+		assert.Empty(t, observed[0].Server)
+		assert.Equal(t, cErr, observed[0].Error)
+	})
+}
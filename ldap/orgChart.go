@@ -0,0 +1,193 @@
+package ldap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	dotWriteErrMsg    = "Writing the DOT graph failed: %v"
+	orgChartCycleMsg  = "The managers of the user with uid = '%s' form a cycle, the user is added as a root of the chart"
+	orgChartGraphName = "orgchart"
+)
+
+type (
+	// OrgChart represents the reporting tree of the users, built from the manager of the users.
+	OrgChart struct {
+		// Roots are the users at the top of the chart. For the full chart these are the users without a manager or
+		// whose manager is not found, for a subtree it is the user the subtree is rooted at.
+		Roots []*OrgChartNode `json:"roots"`
+	}
+
+	// OrgChartNode represents a user and its direct reports in an OrgChart.
+	OrgChartNode struct {
+		Uid         string `json:"uid"`
+		Dn          string `json:"dn"`
+		Cn          string `json:"cn,omitempty"`
+		DisplayName string `json:"displayName,omitempty"`
+		// Title is only set if the title is listed in the UserContactAttributes set in the client Config.
+		Title   string          `json:"title,omitempty"`
+		Reports []*OrgChartNode `json:"reports,omitempty"`
+	}
+)
+
+// OrgChart builds the reporting tree of the users from the manager of the user entries in LDAP.
+// params:
+//
+//	uid 	= user identifier of the root of the subtree, if empty the chart of all the users is built
+//	opts 	= options applied to the search request, e.g. WithTimeout
+//
+// The user entries are retrieved page by page with only the attributes needed for the chart, so large organizations
+// are not retrieved in a single response. The users and the reports are ordered by uid. Users whose managers form a
+// cycle are added as roots of the full chart, breaking the cycle, and a warning is logged.
+// The chart can be serialized with OrgChart.WriteJSON or OrgChart.WriteDOT.
+// The method returns an error:
+//   - if the user of the subtree is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) OrgChart(uid string, opts ...RequestOption) (*OrgChart, *Error) {
+	sr := um.getUsersSearchRequest(um.Client.getConfig().usersFilter())
+	sr.Attributes = um.orgChartAttributes()
+	nodes := map[string]*OrgChartNode{}
+	managers := map[string]string{}
+	var keys []string
+	cErr := um.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, e := range result.Entries {
+			key := dnKey(e.DN)
+			nodes[key] = &OrgChartNode{
+				Uid:         e.GetAttributeValue(userIdAttr),
+				Dn:          e.DN,
+				Cn:          e.GetAttributeValue(CommonNameAttr),
+				DisplayName: e.GetAttributeValue(displayNameAttr),
+				Title:       e.GetAttributeValue(titleAttr),
+			}
+			if manager := e.GetAttributeValue(managerAttr); manager != "" {
+				managers[key] = dnKey(manager)
+			}
+			keys = append(keys, key)
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return nodes[keys[i]].Uid < nodes[keys[j]].Uid
+	})
+	reports := map[string][]string{}
+	for _, key := range keys {
+		if manager, ok := managers[key]; ok && manager != key && nodes[manager] != nil {
+			reports[manager] = append(reports[manager], key)
+		}
+	}
+
+	visited := map[string]bool{}
+	chart := &OrgChart{Roots: []*OrgChartNode{}}
+	if uid != "" {
+		root := dnKey(um.getDN(uid))
+		if nodes[root] == nil {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		chart.Roots = append(chart.Roots, buildOrgChartNode(root, nodes, reports, visited))
+		return chart, nil
+	}
+	for _, key := range keys {
+		if manager, ok := managers[key]; !ok || manager == key || nodes[manager] == nil {
+			chart.Roots = append(chart.Roots, buildOrgChartNode(key, nodes, reports, visited))
+		}
+	}
+	for _, key := range keys {
+		if !visited[key] {
+			logger.Warnf(orgChartCycleMsg, nodes[key].Uid)
+			chart.Roots = append(chart.Roots, buildOrgChartNode(key, nodes, reports, visited))
+		}
+	}
+	return chart, nil
+}
+
+// WriteJSON writes the chart to w as a JSON object with the nested reports of the roots.
+// The method returns an error if writing to w fails.
+func (oc *OrgChart) WriteJSON(w io.Writer) *Error {
+	if err := json.NewEncoder(w).Encode(oc); err != nil {
+		return WrapError(errors.InternalServerErrorf(jsonWriteErrMsg, err))
+	}
+	return nil
+}
+
+// WriteDOT writes the chart to w as a directed graph in the DOT language of Graphviz, with an edge from every manager
+// to each of its direct reports, e.g. to render the chart with "dot -Tsvg".
+// The method returns an error if writing to w fails.
+func (oc *OrgChart) WriteDOT(w io.Writer) *Error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "digraph %s {\n", orgChartGraphName)
+	var writeNode func(node *OrgChartNode)
+	writeNode = func(node *OrgChartNode) {
+		fmt.Fprintf(b, "\t%s [label=%s];\n", dotQuote(node.Uid), dotQuote(node.label()))
+		for _, report := range node.Reports {
+			fmt.Fprintf(b, "\t%s -> %s;\n", dotQuote(node.Uid), dotQuote(report.Uid))
+		}
+		for _, report := range node.Reports {
+			writeNode(report)
+		}
+	}
+	for _, root := range oc.Roots {
+		writeNode(root)
+	}
+	b.WriteString("}\n")
+	if err := b.Flush(); err != nil {
+		return WrapError(errors.InternalServerErrorf(dotWriteErrMsg, err))
+	}
+	return nil
+}
+
+// label returns the label of the node in a DOT graph, the display name, the common name or the uid of the user
+// followed by the title if it is set.
+func (n *OrgChartNode) label() string {
+	label := n.Uid
+	if n.DisplayName != "" {
+		label = n.DisplayName
+	} else if n.Cn != "" {
+		label = n.Cn
+	}
+	if n.Title != "" {
+		label += "\n" + n.Title
+	}
+	return label
+}
+
+// orgChartAttributes returns the attributes retrieved to build an OrgChart.
+func (um *usersManager) orgChartAttributes() []string {
+	attrs := []string{userIdAttr, CommonNameAttr, displayNameAttr, managerAttr}
+	if slice.EntryExists(um.Client.getConfig().contactAttributes(), titleAttr) {
+		attrs = append(attrs, titleAttr)
+	}
+	return attrs
+}
+
+// buildOrgChartNode returns the node of the user with the key and its reports, skipping the users which are already
+// visited so a cycle does not recurse endlessly.
+func buildOrgChartNode(key string, nodes map[string]*OrgChartNode, reports map[string][]string,
+	visited map[string]bool) *OrgChartNode {
+	visited[key] = true
+	node := nodes[key]
+	for _, report := range reports[key] {
+		if !visited[report] {
+			node.Reports = append(node.Reports, buildOrgChartNode(report, nodes, reports, visited))
+		}
+	}
+	return node
+}
+
+// dotQuote returns the value as a quoted string of the DOT language.
+func dotQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
@@ -0,0 +1,144 @@
+package ldap
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getOrgChartPages(um *usersManager) (*ldap.SearchResult, *ldap.SearchResult) {
+	firstPage := &ldap.SearchResult{Entries: []*ldap.Entry{
+		getManagedUserEntry("C00001", um.getDN("C00002")),
+		getManagedUserEntry("C00002", um.getDN("C00003")),
+		getManagedUserEntry("C00005", um.getDN("C00006")),
+	}}
+	control := ldap.NewControlPaging(0)
+	control.SetCookie([]byte("page-2"))
+	firstPage.Controls = []ldap.Control{control}
+	secondPage := &ldap.SearchResult{Entries: []*ldap.Entry{
+		getManagedUserEntry("C00003", ""),
+		getManagedUserEntry("C00004", "UID=C00003, OU=users, O=company"),
+		getManagedUserEntry("C00006", um.getDN("C00005")),
+	}}
+	return firstPage, secondPage
+}
+
+func TestUsersManager_OrgChart(t *testing.T) {
+	t.Run("full chart", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		firstPage, secondPage := getOrgChartPages(&usersManager{Client: client})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				assert.Equal(t, []string{userIdAttr, CommonNameAttr, displayNameAttr, managerAttr},
+					args.Get(0).(*ldap.SearchRequest).Attributes)
+			}).
+			Return(firstPage, nil).Once()
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(secondPage, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chart, cErr := client.Users.(UserHierarchyManager).OrgChart("")
+		assert.Nil(t, cErr)
+		assert.Len(t, chart.Roots, 2)
+		assert.Equal(t, "C00003", chart.Roots[0].Uid)
+		assert.Len(t, chart.Roots[0].Reports, 2)
+		assert.Equal(t, "C00002", chart.Roots[0].Reports[0].Uid)
+		assert.Equal(t, "C00001", chart.Roots[0].Reports[0].Reports[0].Uid)
+		assert.Equal(t, "C00004", chart.Roots[0].Reports[1].Uid)
+		assert.Equal(t, "C00005", chart.Roots[1].Uid)
+		assert.Equal(t, "C00006", chart.Roots[1].Reports[0].Uid)
+		assert.Empty(t, chart.Roots[1].Reports[0].Reports)
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.UserContactAttributes = []string{titleAttr}
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		firstPage, secondPage := getOrgChartPages(&usersManager{Client: client})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				assert.Contains(t, args.Get(0).(*ldap.SearchRequest).Attributes, titleAttr)
+			}).
+			Return(firstPage, nil).Once()
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(secondPage, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chart, cErr := client.Users.(UserHierarchyManager).OrgChart("C00002")
+		assert.Nil(t, cErr)
+		assert.Len(t, chart.Roots, 1)
+		assert.Equal(t, "C00002", chart.Roots[0].Uid)
+		assert.Equal(t, "C00001", chart.Roots[0].Reports[0].Uid)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(&ldap.SearchResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chart, cErr := client.Users.(UserHierarchyManager).OrgChart(testUser1.Uid)
+		assert.Nil(t, chart)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		chart, cErr := client.Users.(UserHierarchyManager).OrgChart("")
+		assert.Nil(t, chart)
+		assert.NotNil(t, cErr)
+	})
+}
+
+func TestOrgChart_WriteJSON(t *testing.T) {
+	chart := &OrgChart{Roots: []*OrgChartNode{
+		{Uid: "C00002", Dn: "uid=C00002,ou=users,o=company", Reports: []*OrgChartNode{
+			{Uid: "C00001", Dn: "uid=C00001,ou=users,o=company", Cn: "John"},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	assert.Nil(t, chart.WriteJSON(&buf))
+	assert.Equal(t, `{"roots":[{"uid":"C00002","dn":"uid=C00002,ou=users,o=company","reports":[`+
+		`{"uid":"C00001","dn":"uid=C00001,ou=users,o=company","cn":"John"}]}]}
+`, buf.String())
+}
+
+func TestOrgChart_WriteDOT(t *testing.T) {
+	chart := &OrgChart{Roots: []*OrgChartNode{
+		{Uid: "C00003", DisplayName: `Jane "JD" Doe`, Title: "CEO", Reports: []*OrgChartNode{
+			{Uid: "C00002", Cn: "Jane", Reports: []*OrgChartNode{{Uid: "C00001"}}},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	assert.Nil(t, chart.WriteDOT(&buf))
+	assert.Equal(t, `digraph orgchart {
+	"C00003" [label="Jane \"JD\" Doe\nCEO"];
+	"C00003" -> "C00002";
+	"C00002" [label="Jane"];
+	"C00002" -> "C00001";
+	"C00001" [label="C00001"];
+}
+`, buf.String())
+}
@@ -1,29 +1,73 @@
 package ldap
 
 import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
 	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/atselvan/ldap-go-lib/dn"
 	"github.com/go-ldap/ldap/v3"
 )
 
+const (
+	// OuPathSeparator separates the names of nested organizational units in an organizational unit path,
+	// e.g. "department-1/team-a" addresses the organizational unit team-a within the organizational unit
+	// department-1.
+	OuPathSeparator = "/"
+
+	orgUnitNotFoundMsg                  = "Organizational unit with ou = '%s' was not found"
+	invalidOrganizationalUnitErrMsg     = "Invalid organizational unit '%s'. Valid values are %v"
+	invalidOrganizationalUnitPathErrMsg = "Invalid organizational unit path '%s'. The organizational unit does not exist"
+)
+
 type (
 	// OrganizationalUnitsManager describes the interface which needs to be implemented for performing operations on
 	// LDAP organizational units.
+	// The nested organizational units are managed with the optional interface OrganizationalUnitsTreeManager, which
+	// is implemented by the OrganizationalUnitsManager of the client.
 	OrganizationalUnitsManager interface {
-		GetAll() ([]string, *errors.Error)
+		GetAll() ([]string, *Error)
+	}
+
+	// OrganizationalUnitsTreeManager describes the operations on the nested organizational units.
+	OrganizationalUnitsTreeManager interface {
+		GetTree() ([]*OrganizationalUnit, *Error)
+		Get(ouPath string) (*OrganizationalUnit, *Error)
+		Exists(ou string) (bool, *Error)
+		Validate(ou string) *Error
+		Delete(ou string, recursive bool) *Error
 	}
 
 	// organizationalUnitsManager implements the operations to be performed on an LDAP organizational unit.
 	organizationalUnitsManager struct {
 		Client *Client
+		// baseDN overrides the GroupBaseDN set in the client Config.
+		baseDN string
+	}
+
+	// OrganizationalUnit represents an LDAP organizational unit including its nested organizational units.
+	OrganizationalUnit struct {
+		Name     string                `json:"name"`
+		Dn       string                `json:"dn"`
+		Path     string                `json:"path"`
+		Children []*OrganizationalUnit `json:"children,omitempty"`
 	}
 )
 
 // GetAll gets all the organizations unit entries from LDAP using GroupBaseDN as the root dn.
+// The result is served from the cache if the cache is enabled using WithOrganizationalUnitsCache.
 // The method returns an error:
 //   - if a validation fails
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (oum *organizationalUnitsManager) GetAll() ([]string, *errors.Error) {
+func (oum *organizationalUnitsManager) GetAll() ([]string, *Error) {
+	if organizationalUnits, ok := oum.Client.orgUnitsCache.get(oum.groupBaseDN()); ok {
+		return organizationalUnits, nil
+	}
+
 	sr := oum.getSearchRequest()
 
 	result, cErr := oum.Client.doLDAPSearch(sr)
@@ -31,13 +75,244 @@ func (oum *organizationalUnitsManager) GetAll() ([]string, *errors.Error) {
 		return nil, cErr
 	}
 
-	return oum.parseSearchResult(result), nil
+	organizationalUnits := oum.parseSearchResult(result)
+	oum.Client.orgUnitsCache.set(oum.groupBaseDN(), organizationalUnits)
+	return organizationalUnits, nil
+}
+
+// GetTree gets all the organizational unit entries below the GroupBaseDN from LDAP including the nested
+// organizational units. The top-level organizational units are returned with their nested organizational units
+// set as children.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (oum *organizationalUnitsManager) GetTree() ([]*OrganizationalUnit, *Error) {
+	result, cErr := oum.Client.doLDAPSearch(oum.getTreeSearchRequest(oum.groupBaseDN()))
+	if cErr != nil {
+		return nil, cErr
+	}
+	return oum.parseTree(result), nil
+}
+
+// Get gets a single organizational unit entry from LDAP including its nested organizational units.
+// params:
+//
+//	ouPath: path of the organizational unit, the names of nested organizational units are separated by
+//	        OuPathSeparator, e.g. "department-1/team-a"
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the organizational unit is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (oum *organizationalUnitsManager) Get(ouPath string) (*OrganizationalUnit, *Error) {
+	if strings.TrimSpace(ouPath) == "" {
+		return nil, missingParametersError([]string{OrganizationalUnitAttr})
+	}
+	result, cErr := oum.Client.doLDAPSearch(oum.getTreeSearchRequest(oum.getDN(ouPath)))
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(orgUnitNotFoundMsg, ouPath)))
+		}
+		return nil, cErr
+	}
+	for _, ou := range oum.parseTree(result) {
+		if found := ou.find(splitOuPath(ouPath)); found != nil {
+			return found, nil
+		}
+	}
+	return nil, WrapError(errors.NotFoundError(fmt.Sprintf(orgUnitNotFoundMsg, ouPath)))
+}
+
+// Exists checks if an organizational unit entry exists in LDAP.
+// params:
+//
+//	ou: name or path of the organizational unit
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (oum *organizationalUnitsManager) Exists(ou string) (bool, *Error) {
+	if cErr := oum.Validate(ou); cErr != nil {
+		if cErr.Status == http.StatusBadRequest && strings.TrimSpace(ou) != "" {
+			return false, nil
+		}
+		return false, cErr
+	}
+	return true, nil
+}
+
+// Validate checks if the organizational unit is valid, which is the validation performed on the organizational unit
+// of a group before the group is created, retrieved, modified or deleted.
+// params:
+//
+//	ou: name or path of the organizational unit
+//
+// The method returns an error:
+//   - if the organizational unit is empty
+//   - if the organizational unit does not exist
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (oum *organizationalUnitsManager) Validate(ou string) *Error {
+	if strings.TrimSpace(ou) == "" {
+		return missingParametersError([]string{OrganizationalUnitAttr})
+	}
+	if strings.Contains(ou, OuPathSeparator) {
+		return oum.validatePath(ou)
+	}
+	organizationalUnits, cErr := oum.GetAll()
+	if cErr != nil {
+		return cErr
+	}
+	if !slice.EntryExists(organizationalUnits, ou) {
+		return invalidParameterError(OrganizationalUnitAttr, fmt.Sprintf(invalidOrganizationalUnitErrMsg, ou,
+			organizationalUnits))
+	}
+	return nil
+}
+
+// validatePath checks if the nested organizational unit addressed by the organizational unit path exists.
+func (oum *organizationalUnitsManager) validatePath(ouPath string) *Error {
+	if _, cErr := oum.Get(ouPath); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return invalidParameterError(OrganizationalUnitAttr,
+				fmt.Sprintf(invalidOrganizationalUnitPathErrMsg, ouPath))
+		}
+		return cErr
+	}
+	return nil
+}
+
+// Delete an existing organizational unit entry from LDAP.
+// params:
+//
+//	ou: name or path of the organizational unit
+//	recursive: if true, all the groups and other entries within the organizational unit are deleted as well
+//
+// An organizational unit which is not empty can only be deleted recursively.
+// The method returns an error:
+//   - if a validation fails
+//   - if the organizational unit is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (oum *organizationalUnitsManager) Delete(ou string, recursive bool) *Error {
+	if strings.TrimSpace(ou) == "" {
+		return missingParametersError([]string{OrganizationalUnitAttr})
+	}
+	defer oum.Client.orgUnitsCache.invalidate()
+	var cErr *Error
+	if recursive {
+		cErr = oum.Client.Entries.DeleteSubtree(oum.getDN(ou))
+	} else {
+		cErr = oum.Client.doLDAPDelete(ldap.NewDelRequest(oum.getDN(ou), nil))
+	}
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(orgUnitNotFoundMsg, ou)))
+		}
+		return cErr
+	}
+	oum.Client.emit(Event{Type: EventOrganizationalUnitDeleted, Dn: oum.getDN(ou), Ou: ou})
+	return nil
+}
+
+// groupBaseDN returns the base dn of the organizational unit entries managed by the organizationalUnitsManager.
+func (oum *organizationalUnitsManager) groupBaseDN() string {
+	if oum.baseDN != "" {
+		return oum.baseDN
+	}
+	return oum.Client.getConfig().GroupBaseDN
+}
+
+// getDN returns the formatted LDAP organizational unit domain name.
+func (oum *organizationalUnitsManager) getDN(ouPath string) string {
+	return dn.Join(ouPathRDNs(ouPath), oum.groupBaseDN())
+}
+
+// getTreeSearchRequest returns a ldap search request to get all organization units within the baseDN.
+func (oum *organizationalUnitsManager) getTreeSearchRequest(baseDN string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		orgUnitSearchFilter,
+		[]string{OrganizationalUnitAttr},
+		nil,
+	)
+}
+
+// parseTree parses the ldap search result and returns the organization units as a tree.
+// Organizational units of which the parent is not part of the search result are returned as roots of the tree.
+func (oum *organizationalUnitsManager) parseTree(result *ldap.SearchResult) []*OrganizationalUnit {
+	var organizationalUnits []*OrganizationalUnit
+	for _, entry := range result.Entries {
+		ouPath, cErr := dn.ExtractOUPath(entry.DN, oum.groupBaseDN())
+		if cErr != nil || len(ouPath) == 0 {
+			continue
+		}
+		organizationalUnits = append(organizationalUnits, &OrganizationalUnit{
+			Name: ouPath[len(ouPath)-1],
+			Dn:   entry.DN,
+			Path: strings.Join(ouPath, OuPathSeparator),
+		})
+	}
+	sort.SliceStable(organizationalUnits, func(i, j int) bool {
+		return strings.Count(organizationalUnits[i].Path, OuPathSeparator) <
+			strings.Count(organizationalUnits[j].Path, OuPathSeparator)
+	})
+
+	var roots []*OrganizationalUnit
+	byPath := make(map[string]*OrganizationalUnit, len(organizationalUnits))
+	for _, ou := range organizationalUnits {
+		byPath[ou.Path] = ou
+		parentPath := strings.TrimSuffix(strings.TrimSuffix(ou.Path, ou.Name), OuPathSeparator)
+		if parent, ok := byPath[parentPath]; ok && parentPath != "" {
+			parent.Children = append(parent.Children, ou)
+		} else {
+			roots = append(roots, ou)
+		}
+	}
+	return roots
+}
+
+// find returns the organizational unit in the tree with the path, or nil if the path is not part of the tree.
+func (ou *OrganizationalUnit) find(path []string) *OrganizationalUnit {
+	if ou.Path == strings.Join(path, OuPathSeparator) {
+		return ou
+	}
+	for _, child := range ou.Children {
+		if found := child.find(path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// splitOuPath splits an organizational unit path into the names of the organizational units.
+func splitOuPath(ouPath string) []string {
+	return strings.Split(strings.Trim(ouPath, OuPathSeparator), OuPathSeparator)
+}
+
+// ouPathRDNs returns the relative domain names of an organizational unit path, starting with the organizational
+// unit the path points to.
+func ouPathRDNs(ouPath string) string {
+	names := splitOuPath(ouPath)
+	rdns := make([]string, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		rdns = append(rdns, dn.RDN(OrganizationalUnitAttr, names[i]))
+	}
+	return dn.Join(rdns...)
 }
 
 // getSearchRequest returns a ldap search request to get all organization units.
 func (oum *organizationalUnitsManager) getSearchRequest() *ldap.SearchRequest {
 	return ldap.NewSearchRequest(
-		oum.Client.Config.GroupBaseDN,
+		oum.groupBaseDN(),
 		ldap.ScopeSingleLevel,
 		ldap.NeverDerefAliases,
 		0,
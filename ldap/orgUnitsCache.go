@@ -0,0 +1,88 @@
+package ldap
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// orgUnitsCache caches the organizational units listed by OrganizationalUnits.GetAll per base dn, so validating
+	// the organizational unit of a group does not require a search for every group operation.
+	orgUnitsCache struct {
+		mu      sync.Mutex
+		ttl     time.Duration
+		entries map[string]orgUnitsCacheEntry
+		now     func() time.Time
+	}
+
+	// orgUnitsCacheEntry is a cached list of organizational units.
+	orgUnitsCacheEntry struct {
+		organizationalUnits []string
+		expiresAt           time.Time
+	}
+)
+
+// WithOrganizationalUnitsCache caches the list of organizational units returned by OrganizationalUnits.GetAll,
+// which is also used for validating the organizational unit of group operations, for the ttl.
+// Use Client.InvalidateOrganizationalUnitsCache to discard the cached list after organizational units were changed
+// outside the client.
+func WithOrganizationalUnitsCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.orgUnitsCache = &orgUnitsCache{
+			ttl:     ttl,
+			entries: make(map[string]orgUnitsCacheEntry),
+			now:     time.Now,
+		}
+	}
+}
+
+// WithoutOrganizationalUnitValidation disables checking if the organizational unit exists before a group is
+// retrieved, created, deleted or updated. Operations on a group in an organizational unit which does not exist
+// fail with the error returned by the LDAP server instead.
+func WithoutOrganizationalUnitValidation() ClientOption {
+	return func(c *Client) {
+		c.skipOrgUnitValidation = true
+	}
+}
+
+// InvalidateOrganizationalUnitsCache discards the cached lists of organizational units.
+func (c *Client) InvalidateOrganizationalUnitsCache() {
+	c.orgUnitsCache.invalidate()
+}
+
+// get returns a copy of the cached organizational units within the baseDN if they are cached and not expired.
+func (oc *orgUnitsCache) get(baseDN string) ([]string, bool) {
+	if oc == nil {
+		return nil, false
+	}
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	entry, ok := oc.entries[baseDN]
+	if !ok || !oc.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return append([]string(nil), entry.organizationalUnits...), true
+}
+
+// set caches the organizational units within the baseDN.
+func (oc *orgUnitsCache) set(baseDN string, organizationalUnits []string) {
+	if oc == nil {
+		return
+	}
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.entries[baseDN] = orgUnitsCacheEntry{
+		organizationalUnits: append([]string(nil), organizationalUnits...),
+		expiresAt:           oc.now().Add(oc.ttl),
+	}
+}
+
+// invalidate discards all the cached organizational units.
+func (oc *orgUnitsCache) invalidate() {
+	if oc == nil {
+		return
+	}
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.entries = make(map[string]orgUnitsCacheEntry)
+}
@@ -0,0 +1,95 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithOrganizationalUnitsCache(t *testing.T) {
+	t.Run("cached organizational units", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithOrganizationalUnitsCache(time.Minute))
+		oum := organizationalUnitsManager{Client: client}
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil).Once()
+		ldapMock.On(methodNameDelete, gm.getDeleteRequest(testGroupCn1, testOrganizationUnit1)).Return(nil).Twice()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Groups.Delete(testGroupCn1, testOrganizationUnit1))
+		assert.Nil(t, client.Groups.Delete(testGroupCn1, testOrganizationUnit1))
+	})
+
+	t.Run("expired and invalidated", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithOrganizationalUnitsCache(time.Minute))
+		now := time.Now()
+		client.orgUnitsCache.now = func() time.Time { return now }
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil).Times(3)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		organizationalUnits, cErr := client.OrganizationalUnits.GetAll()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{testOrganizationUnit1, testOrganizationUnit2}, organizationalUnits)
+
+		// the cached list is a copy which can not be modified by the caller
+		organizationalUnits[0] = "modified"
+		organizationalUnits, _ = client.OrganizationalUnits.GetAll()
+		assert.Equal(t, testOrganizationUnit1, organizationalUnits[0])
+
+		now = now.Add(time.Minute)
+		_, cErr = client.OrganizationalUnits.GetAll()
+		assert.Nil(t, cErr)
+
+		client.InvalidateOrganizationalUnitsCache()
+		_, cErr = client.OrganizationalUnits.GetAll()
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("invalidated by delete", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithOrganizationalUnitsCache(time.Minute))
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil).Twice()
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(oum.getDN(testOrganizationUnit2), nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.OrganizationalUnits.GetAll()
+		assert.Nil(t, cErr)
+		assert.Nil(t, client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Delete(testOrganizationUnit2, false))
+		_, cErr = client.OrganizationalUnits.GetAll()
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("cache disabled", func(t *testing.T) {
+		client := NewClient(testConfig)
+		assert.Nil(t, client.orgUnitsCache)
+		client.InvalidateOrganizationalUnitsCache()
+	})
+}
+
+func TestWithoutOrganizationalUnitValidation(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+	gm := groupsManager{Client: client}
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameDelete, gm.getDeleteRequest(testGroupCn1, testOrganizationUnit1)).Return(nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	cErr := client.Groups.Delete(testGroupCn1, testOrganizationUnit1)
+	assert.Nil(t, cErr)
+}
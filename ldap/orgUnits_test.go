@@ -77,3 +77,242 @@ func getOrganizationUnitLDAPEntry(ou string) *ldap.Entry {
 		Attributes: attributes,
 	}
 }
+
+func TestOrganizationalUnitsManager_Delete(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		client := NewClient(testConfig)
+		cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Delete("", false)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(
+			errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{OrganizationalUnitAttr},
+		), cErr.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(oum.getDN(testOrganizationUnit1), nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Delete(testOrganizationUnit1, false)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+		dr := ldap.NewDelRequest(oum.getDN(testOrganizationUnit1), []ldap.Control{ldap.NewControlSubtreeDelete()})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, dr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Delete(testOrganizationUnit1, true)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("organizational unit not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(oum.getDN(testOrganizationUnit1), nil)).
+			Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Delete(testOrganizationUnit1, false)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(orgUnitNotFoundMsg, testOrganizationUnit1), cErr.Message)
+	})
+}
+
+func TestOrganizationalUnitsManager_GetTree(t *testing.T) {
+	getTreeSearchResult := &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry(testConfig.GroupBaseDN, nil),
+			ldap.NewEntry("ou=team-a,ou=department-1,"+testConfig.GroupBaseDN, nil),
+			ldap.NewEntry("ou=department-1,"+testConfig.GroupBaseDN, nil),
+			ldap.NewEntry("ou=department-2,"+testConfig.GroupBaseDN, nil),
+			ldap.NewEntry("ou=team-b,ou=department-1,"+testConfig.GroupBaseDN, nil),
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getTreeSearchRequest(testConfig.GroupBaseDN)).
+			Return(getTreeSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		tree, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).GetTree()
+		assert.Nil(t, cErr)
+		assert.Len(t, tree, 2)
+		assert.Equal(t, "department-1", tree[0].Name)
+		assert.Equal(t, "ou=department-1,"+testConfig.GroupBaseDN, tree[0].Dn)
+		assert.Len(t, tree[0].Children, 2)
+		assert.Equal(t, "department-1/team-a", tree[0].Children[0].Path)
+		assert.Equal(t, "team-b", tree[0].Children[1].Name)
+		assert.Equal(t, "department-2", tree[1].Path)
+		assert.Empty(t, tree[1].Children)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getTreeSearchRequest(testConfig.GroupBaseDN)).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		tree, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).GetTree()
+		assert.Nil(t, tree)
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+	})
+}
+
+func TestOrganizationalUnitsManager_Get(t *testing.T) {
+	ouPath := "department-1/team-a"
+	ouDN := "ou=team-a,ou=department-1," + testConfig.GroupBaseDN
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+		assert.Equal(t, ouDN, oum.getDN(ouPath))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getTreeSearchRequest(ouDN)).Return(&ldap.SearchResult{
+			Entries: []*ldap.Entry{
+				ldap.NewEntry(ouDN, nil),
+				ldap.NewEntry("ou=squad-1,"+ouDN, nil),
+			},
+		}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		ou, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Get(ouPath)
+		assert.Nil(t, cErr)
+		assert.Equal(t, "team-a", ou.Name)
+		assert.Equal(t, ouPath, ou.Path)
+		assert.Len(t, ou.Children, 1)
+		assert.Equal(t, "department-1/team-a/squad-1", ou.Children[0].Path)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		client := NewClient(testConfig)
+		ou, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Get(" ")
+		assert.Nil(t, ou)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("organizational unit not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getTreeSearchRequest(ouDN)).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		ou, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Get(ouPath)
+		assert.Nil(t, ou)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(orgUnitNotFoundMsg, ouPath), cErr.Message)
+	})
+}
+
+func TestOrganizationalUnitsManager_Validate(t *testing.T) {
+	t.Run("missing organizational unit", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Validate(" ")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{OrganizationalUnitAttr}), cErr.Message)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Validate(testOrganizationUnit1))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Validate("unknown")
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(invalidOrganizationalUnitErrMsg, "unknown",
+			[]string{testOrganizationUnit1, testOrganizationUnit2}), cErr.Message)
+	})
+}
+
+func TestOrganizationalUnitsManager_Exists(t *testing.T) {
+	t.Run("exists", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(getOrganizationUnitsSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		exists, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Exists(testOrganizationUnit2)
+		assert.Nil(t, cErr)
+		assert.True(t, exists)
+	})
+
+	t.Run("path does not exist", func(t *testing.T) {
+		ouPath := "department-1/team-a"
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getTreeSearchRequest(oum.getDN(ouPath))).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		exists, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Exists(ouPath)
+		assert.Nil(t, cErr)
+		assert.False(t, exists)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		oum := organizationalUnitsManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, oum.getSearchRequest()).Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		exists, cErr := client.OrganizationalUnits.(OrganizationalUnitsTreeManager).Exists(testOrganizationUnit1)
+		assert.False(t, exists)
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+	})
+}
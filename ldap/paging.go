@@ -0,0 +1,82 @@
+package ldap
+
+import (
+	"github.com/go-ldap/ldap/v3"
+)
+
+// defaultPageSize is the number of entries requested per page by paged searches.
+const defaultPageSize = 500
+
+type (
+	// PagedSearchRequest represents a search which retrieves the entries page by page using the simple paged results
+	// control. All the pages are retrieved using the same connection.
+	PagedSearchRequest struct {
+		SearchRequest *ldap.SearchRequest
+		PageSize      uint32
+		// HandlePage is invoked with the entries of every page as soon as the page is received. If HandlePage
+		// returns an error the search is abandoned and the error is returned.
+		HandlePage func(result *ldap.SearchResult) *Error
+	}
+)
+
+// doLDAPPagedSearch searches for entries in LDAP page by page and invokes handlePage for every page.
+func (c *Client) doLDAPPagedSearch(sr *ldap.SearchRequest, pageSize uint32,
+	handlePage func(result *ldap.SearchResult) *Error, opts ...RequestOption) *Error {
+	psr := &PagedSearchRequest{SearchRequest: sr, PageSize: pageSize, HandlePage: handlePage}
+	_, cErr := c.doLDAPOperation(OperationPagedSearch, psr, opts...)
+	return cErr
+}
+
+// executePagedSearch retrieves the pages of a paged search using the connection.
+// All the entries are retrieved as a single page if the LDAP server does not support the simple paged results control.
+// The search is abandoned as soon as more than maxResults entries are received, unless maxResults is zero.
+// The error returned by the LDAP server, if any, is returned as well for the circuit breaker.
+func (c *Client) executePagedSearch(conn ldap.Client, psr *PagedSearchRequest, maxResults int) (*Error,
+	error) {
+	sr := psr.SearchRequest
+	c.applyTimeLimit(sr)
+	if !c.supportsControl(ldap.ControlTypePaging) {
+		applySizeLimit(sr, maxResults)
+		c.traceRequest(OperationPagedSearch, sr)
+		result, err := conn.Search(sr)
+		if result != nil {
+			if cErr := checkResultLimit(maxResults, len(result.Entries)); cErr != nil {
+				return cErr, err
+			}
+		}
+		if err != nil {
+			return c.handleLdapError(err), err
+		}
+		return psr.HandlePage(result), nil
+	}
+	count := 0
+	paging := ldap.NewControlPaging(psr.PageSize)
+	sr.Controls = append(sr.Controls, paging)
+	for {
+		c.traceRequest(OperationPagedSearch, sr)
+		result, err := conn.Search(sr)
+		if err != nil {
+			return c.handleLdapError(err), err
+		}
+		count += len(result.Entries)
+		if cErr := checkResultLimit(maxResults, count); cErr != nil {
+			c.abandonPagedSearch(conn, sr, paging)
+			return cErr, nil
+		}
+		if cErr := psr.HandlePage(result); cErr != nil {
+			c.abandonPagedSearch(conn, sr, paging)
+			return cErr, nil
+		}
+		control, ok := ldap.FindControl(result.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+		if !ok || len(control.Cookie) == 0 {
+			return nil, nil
+		}
+		paging.SetCookie(control.Cookie)
+	}
+}
+
+// abandonPagedSearch tells the LDAP server that no more pages will be requested by requesting a page size of zero.
+func (c *Client) abandonPagedSearch(conn ldap.Client, sr *ldap.SearchRequest, paging *ldap.ControlPaging) {
+	paging.PagingSize = 0
+	_, _ = conn.Search(sr)
+}
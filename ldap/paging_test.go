@@ -0,0 +1,104 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getPagedSearchResult(cookie string, dns ...string) *ldap.SearchResult {
+	result := &ldap.SearchResult{}
+	for _, dn := range dns {
+		result.Entries = append(result.Entries, ldap.NewEntry(dn, nil))
+	}
+	if cookie != "" {
+		control := ldap.NewControlPaging(0)
+		control.SetCookie([]byte(cookie))
+		result.Controls = []ldap.Control{control}
+	}
+	return result
+}
+
+func TestClient_doLDAPPagedSearch(t *testing.T) {
+	t.Run("all pages", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var cookies []string
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				paging := ldap.FindControl(sr.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+				assert.Equal(t, uint32(2), paging.PagingSize)
+				cookies = append(cookies, string(paging.Cookie))
+			}).
+			Return(getPagedSearchResult("page-2", "uid=1", "uid=2"), nil).Once()
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				paging := ldap.FindControl(sr.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+				cookies = append(cookies, string(paging.Cookie))
+			}).
+			Return(getPagedSearchResult("", "uid=3"), nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var dns []string
+		cErr := client.doLDAPPagedSearch(ldap.NewSearchRequest(testConfig.UserBaseDN, ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases, 0, 0, false, userSearchFilter, nil, nil), 2,
+			func(result *ldap.SearchResult) *Error {
+				for _, entry := range result.Entries {
+					dns = append(dns, entry.DN)
+				}
+				return nil
+			})
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{"uid=1", "uid=2", "uid=3"}, dns)
+		assert.Equal(t, []string{"", "page-2"}, cookies)
+	})
+
+	t.Run("handler error abandons the search", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var pageSizes []uint32
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				paging := ldap.FindControl(sr.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+				pageSizes = append(pageSizes, paging.PagingSize)
+			}).
+			Return(getPagedSearchResult("page-2", "uid=1"), nil).Twice()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.doLDAPPagedSearch(ldap.NewSearchRequest(testConfig.UserBaseDN, ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases, 0, 0, false, userSearchFilter, nil, nil), 1,
+			func(result *ldap.SearchResult) *Error {
+				return WrapError(errors.InternalServerError("stop"))
+			})
+		assert.Equal(t, "stop", cErr.Message)
+		assert.Equal(t, []uint32{1, 0}, pageSizes)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.doLDAPPagedSearch(ldap.NewSearchRequest(testConfig.UserBaseDN, ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases, 0, 0, false, userSearchFilter, nil, nil), 1,
+			func(result *ldap.SearchResult) *Error {
+				return nil
+			})
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+	})
+}
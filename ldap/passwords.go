@@ -0,0 +1,242 @@
+package ldap
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	pwdChangedTimeAttr       = "pwdChangedTime"
+	pwdAccountLockedTimeAttr = "pwdAccountLockedTime"
+	pwdPolicySubentryAttr    = "pwdPolicySubentry"
+	pwdMaxAgeAttr            = "pwdMaxAge"
+	pwdLockoutDurationAttr   = "pwdLockoutDuration"
+
+	// permanentlyLockedTime is the pwdAccountLockedTime of an account which is locked until an administrator
+	// unlocks it.
+	permanentlyLockedTime = "000001010000Z"
+
+	passwordPolicyNotFoundMsg = "Password policy with dn = '%s' was not found"
+
+	handlerParam = "handler"
+
+	expiringPasswordsSearchFilter = "(&%s(%s=%s)(%s=*))"
+)
+
+type (
+	// PasswordStatus represents the status of the password of a user entry based on the effective password policy.
+	PasswordStatus struct {
+		Uid string `json:"uid"`
+		// PolicyDn is the dn of the effective password policy, empty if no password policy applies to the user.
+		PolicyDn string `json:"policyDn,omitempty"`
+		// ChangedTime is the time of the last password change, nil if it is not known.
+		ChangedTime *time.Time `json:"changedTime,omitempty"`
+		// ExpiresAt is the time the password expires, nil if the password does not expire.
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+		// DaysRemaining is the number of days until the password expires, rounded up. It is negative if the password
+		// is expired and zero if the password does not expire.
+		DaysRemaining int  `json:"daysRemaining"`
+		Expired       bool `json:"expired"`
+		Locked        bool `json:"locked"`
+		// LockedTime is the time the account was locked, nil if the account is not locked.
+		LockedTime *time.Time `json:"lockedTime,omitempty"`
+	}
+
+	// passwordPolicy represents the attributes of a password policy entry used to compute a PasswordStatus.
+	passwordPolicy struct {
+		maxAge          time.Duration
+		lockoutDuration time.Duration
+	}
+)
+
+// GetPasswordStatus retrieves the status of the password of an existing user entry from LDAP.
+// param:
+//
+//	uid = user identifier
+//
+// The effective password policy is the pwdPolicySubentry of the user or the PasswordPolicyDN set in the
+// client Config.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user or the password policy is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetPasswordStatus(uid string) (*PasswordStatus, *Error) {
+	if cErr := um.validateUid(uid); cErr != nil {
+		return nil, cErr
+	}
+	result, cErr := um.Client.doLDAPSearch(um.getPasswordStatusSearchRequest(uid))
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return nil, cErr
+	}
+	if len(result.Entries) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+	}
+	entry := result.Entries[0]
+
+	policyDN := entry.GetAttributeValue(pwdPolicySubentryAttr)
+	if policyDN == "" {
+		policyDN = um.Client.getConfig().PasswordPolicyDN
+	}
+	var policy passwordPolicy
+	if policyDN != "" {
+		if policy, cErr = um.getPasswordPolicy(policyDN); cErr != nil {
+			return nil, cErr
+		}
+	}
+	return um.parsePasswordStatus(uid, policyDN, policy, entry, time.Now()), nil
+}
+
+// ExpiringPasswords scans the active user entries in LDAP and invokes the handler with the status of every password
+// which is not expired yet and expires within the given duration.
+// The user entries are retrieved page by page and the handler is invoked as soon as a page is received, so the
+// memory usage does not grow with the number of users. If the handler returns an error the scan is stopped and the
+// error is returned.
+// The method returns an error:
+//   - if a validation fails
+//   - if a password policy is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) ExpiringPasswords(within time.Duration, handler func(status PasswordStatus) *Error) *Error {
+	if handler == nil {
+		return missingParametersError([]string{handlerParam})
+	}
+	now := time.Now()
+	defaultPolicyDN := um.Client.getConfig().PasswordPolicyDN
+	policies := make(map[string]passwordPolicy)
+	return um.Client.doLDAPPagedSearch(um.getExpiringPasswordsSearchRequest(), defaultPageSize,
+		func(result *ldap.SearchResult) *Error {
+			for _, entry := range result.Entries {
+				policyDN := entry.GetAttributeValue(pwdPolicySubentryAttr)
+				if policyDN == "" {
+					policyDN = defaultPolicyDN
+				}
+				if policyDN == "" {
+					continue
+				}
+				policy, ok := policies[policyDN]
+				if !ok {
+					var cErr *Error
+					if policy, cErr = um.getPasswordPolicy(policyDN); cErr != nil {
+						return cErr
+					}
+					policies[policyDN] = policy
+				}
+				status := um.parsePasswordStatus(entry.GetAttributeValue(userIdAttr), policyDN, policy, entry, now)
+				if status.ExpiresAt == nil || status.Expired || status.ExpiresAt.After(now.Add(within)) {
+					continue
+				}
+				if cErr := handler(*status); cErr != nil {
+					return cErr
+				}
+			}
+			return nil
+		})
+}
+
+// getPasswordPolicy retrieves the password policy entry from LDAP.
+func (um *usersManager) getPasswordPolicy(policyDN string) (passwordPolicy, *Error) {
+	result, cErr := um.Client.doLDAPSearch(um.getPasswordPolicySearchRequest(policyDN))
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return passwordPolicy{}, WrapError(errors.NotFoundError(fmt.Sprintf(passwordPolicyNotFoundMsg, policyDN)))
+		}
+		return passwordPolicy{}, cErr
+	}
+	if len(result.Entries) == 0 {
+		return passwordPolicy{}, WrapError(errors.NotFoundError(fmt.Sprintf(passwordPolicyNotFoundMsg, policyDN)))
+	}
+	entry := result.Entries[0]
+	return passwordPolicy{
+		maxAge:          parseSeconds(entry.GetAttributeValue(pwdMaxAgeAttr)),
+		lockoutDuration: parseSeconds(entry.GetAttributeValue(pwdLockoutDurationAttr)),
+	}, nil
+}
+
+// parsePasswordStatus computes the PasswordStatus of a user entry at the time now.
+func (um *usersManager) parsePasswordStatus(uid, policyDN string, policy passwordPolicy, entry *ldap.Entry,
+	now time.Time) *PasswordStatus {
+	status := &PasswordStatus{Uid: uid, PolicyDn: policyDN}
+	if changedTime, ok := parseGeneralizedTime(entry.GetAttributeValue(pwdChangedTimeAttr)); ok {
+		status.ChangedTime = &changedTime
+		if policy.maxAge > 0 {
+			expiresAt := changedTime.Add(policy.maxAge)
+			status.ExpiresAt = &expiresAt
+			status.DaysRemaining = int(math.Ceil(expiresAt.Sub(now).Hours() / 24))
+			status.Expired = !now.Before(expiresAt)
+		}
+	}
+	lockedTimeValue := entry.GetAttributeValue(pwdAccountLockedTimeAttr)
+	if lockedTimeValue == permanentlyLockedTime {
+		status.Locked = true
+	} else if lockedTime, ok := parseGeneralizedTime(lockedTimeValue); ok {
+		status.LockedTime = &lockedTime
+		status.Locked = policy.lockoutDuration == 0 || now.Before(lockedTime.Add(policy.lockoutDuration))
+		if !status.Locked {
+			status.LockedTime = nil
+		}
+	}
+	return status
+}
+
+// getPasswordStatusSearchRequest returns a ldap search request to get the password policy state of a user entry.
+func (um *usersManager) getPasswordStatusSearchRequest(uid string) *ldap.SearchRequest {
+	sr := um.getUserSearchRequest(um.getDN(uid))
+	sr.Attributes = []string{pwdChangedTimeAttr, pwdAccountLockedTimeAttr, pwdPolicySubentryAttr}
+	return sr
+}
+
+// getExpiringPasswordsSearchRequest returns a ldap search request to get the password policy state of all the active
+// user entries which have a pwdChangedTime.
+func (um *usersManager) getExpiringPasswordsSearchRequest() *ldap.SearchRequest {
+	sr := um.getUsersSearchRequest(fmt.Sprintf(expiringPasswordsSearchFilter, um.Client.getConfig().userObjectFilter(), statusAttr, UserStatusActive,
+		pwdChangedTimeAttr))
+	sr.Attributes = []string{userIdAttr, pwdChangedTimeAttr, pwdAccountLockedTimeAttr, pwdPolicySubentryAttr}
+	return sr
+}
+
+// getPasswordPolicySearchRequest returns a ldap search request to get a password policy entry.
+func (um *usersManager) getPasswordPolicySearchRequest(policyDN string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		policyDN,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		allEntriesFilter,
+		[]string{pwdMaxAgeAttr, pwdLockoutDurationAttr},
+		nil,
+	)
+}
+
+// parseGeneralizedTime parses an LDAP generalized time value.
+func parseGeneralizedTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := ber.ParseGeneralizedTime([]byte(value))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+// parseSeconds parses a number of seconds, as used by the password policy attributes, into a duration.
+func parseSeconds(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
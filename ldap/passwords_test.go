@@ -0,0 +1,185 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPasswordPolicyDN = "cn=default,ou=policies,o=company"
+
+func TestUsersManager_GetPasswordStatus(t *testing.T) {
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		status, cErr := client.Users.(UserPasswordsManager).GetPasswordStatus("")
+		assert.Nil(t, status)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("policy subentry", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		policyDN := "cn=strict,ou=policies,o=company"
+		changedTime := time.Now().UTC().Add(-80 * 24 * time.Hour)
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getPasswordStatusSearchRequest(testUser1.Uid)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(um.getDN(testUser1.Uid), map[string][]string{
+				pwdChangedTimeAttr:    {changedTime.Format(generalizedTimeLayout)},
+				pwdPolicySubentryAttr: {policyDN},
+			})}}, nil)
+		ldapMock.On(methodNameSearch, um.getPasswordPolicySearchRequest(policyDN)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(policyDN, map[string][]string{
+				pwdMaxAgeAttr: {"7776000"},
+			})}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		status, cErr := client.Users.(UserPasswordsManager).GetPasswordStatus(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, policyDN, status.PolicyDn)
+		assert.Equal(t, 10, status.DaysRemaining)
+		assert.False(t, status.Expired)
+		assert.False(t, status.Locked)
+	})
+
+	t.Run("default policy not found", func(t *testing.T) {
+		config := testConfig
+		config.PasswordPolicyDN = testPasswordPolicyDN
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getPasswordStatusSearchRequest(testUser1.Uid)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(um.getDN(testUser1.Uid), nil)}}, nil)
+		ldapMock.On(methodNameSearch, um.getPasswordPolicySearchRequest(testPasswordPolicyDN)).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		status, cErr := client.Users.(UserPasswordsManager).GetPasswordStatus(testUser1.Uid)
+		assert.Nil(t, status)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(passwordPolicyNotFoundMsg, testPasswordPolicyDN), cErr.Message)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getPasswordStatusSearchRequest(testUser1.Uid)).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.(UserPasswordsManager).GetPasswordStatus(testUser1.Uid)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+}
+
+func TestUsersManager_parsePasswordStatus(t *testing.T) {
+	um := usersManager{Client: NewClient(testConfig)}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := passwordPolicy{maxAge: 30 * 24 * time.Hour, lockoutDuration: time.Hour}
+
+	t.Run("expired", func(t *testing.T) {
+		entry := ldap.NewEntry("", map[string][]string{pwdChangedTimeAttr: {"20240430120000Z"}})
+
+		status := um.parsePasswordStatus(testUser1.Uid, testPasswordPolicyDN, policy, entry, now)
+		assert.Equal(t, time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC), *status.ExpiresAt)
+		assert.Equal(t, -2, status.DaysRemaining)
+		assert.True(t, status.Expired)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		entry := ldap.NewEntry("", map[string][]string{pwdChangedTimeAttr: {"20240430120000Z"}})
+
+		status := um.parsePasswordStatus(testUser1.Uid, "", passwordPolicy{}, entry, now)
+		assert.NotNil(t, status.ChangedTime)
+		assert.Nil(t, status.ExpiresAt)
+		assert.False(t, status.Expired)
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		entry := ldap.NewEntry("", map[string][]string{pwdAccountLockedTimeAttr: {"20240601113000Z"}})
+
+		status := um.parsePasswordStatus(testUser1.Uid, testPasswordPolicyDN, policy, entry, now)
+		assert.True(t, status.Locked)
+		assert.Equal(t, time.Date(2024, 6, 1, 11, 30, 0, 0, time.UTC), *status.LockedTime)
+	})
+
+	t.Run("lockout expired", func(t *testing.T) {
+		entry := ldap.NewEntry("", map[string][]string{pwdAccountLockedTimeAttr: {"20240601100000Z"}})
+
+		status := um.parsePasswordStatus(testUser1.Uid, testPasswordPolicyDN, policy, entry, now)
+		assert.False(t, status.Locked)
+		assert.Nil(t, status.LockedTime)
+	})
+
+	t.Run("permanently locked", func(t *testing.T) {
+		entry := ldap.NewEntry("", map[string][]string{pwdAccountLockedTimeAttr: {permanentlyLockedTime}})
+
+		status := um.parsePasswordStatus(testUser1.Uid, testPasswordPolicyDN, policy, entry, now)
+		assert.True(t, status.Locked)
+	})
+}
+
+func TestUsersManager_ExpiringPasswords(t *testing.T) {
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UserPasswordsManager).ExpiringPasswords(time.Hour, nil)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		config := testConfig
+		config.PasswordPolicyDN = testPasswordPolicyDN
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		now := time.Now().UTC()
+		getEntry := func(uid string, changedTime time.Time) *ldap.Entry {
+			return ldap.NewEntry(um.getDN(uid), map[string][]string{
+				userIdAttr:         {uid},
+				pwdChangedTimeAttr: {changedTime.Format(generalizedTimeLayout)},
+			})
+		}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.MatchedBy(func(sr *ldap.SearchRequest) bool {
+			return sr.BaseDN == testConfig.UserBaseDN
+		})).Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			// expires in 5 days
+			getEntry(testUser1.Uid, now.Add(-25*24*time.Hour)),
+			// expires in 20 days
+			getEntry(testUser2.Uid, now.Add(-10*24*time.Hour)),
+			// expired
+			getEntry(testUser3.Uid, now.Add(-40*24*time.Hour)),
+		}}, nil).Once()
+		ldapMock.On(methodNameSearch, um.getPasswordPolicySearchRequest(testPasswordPolicyDN)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(testPasswordPolicyDN, map[string][]string{
+				pwdMaxAgeAttr: {"2592000"},
+			})}}, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var statuses []PasswordStatus
+		cErr := client.Users.(UserPasswordsManager).ExpiringPasswords(7*24*time.Hour, func(status PasswordStatus) *Error {
+			statuses = append(statuses, status)
+			return nil
+		})
+		assert.Nil(t, cErr)
+		assert.Len(t, statuses, 1)
+		assert.Equal(t, testUser1.Uid, statuses[0].Uid)
+		assert.Equal(t, 5, statuses[0].DaysRemaining)
+	})
+}
@@ -0,0 +1,70 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ErrCodePolicyDenied is the code of the error returned by a write which is denied by the PolicyFunc of the
+	// client.
+	ErrCodePolicyDenied = "POLICY_DENIED"
+
+	policyDeniedErrMsg = "The LDAP %s of '%s' is denied by the policy: %v"
+)
+
+// PolicyFunc decides if the client is allowed to execute a write operation on an entry. The op is the name of the
+// operation, one of OperationAdd, OperationDelete, OperationModify, OperationModifyDN and OperationPasswordModify,
+// the dn is the dn of the entry and the attrs are the names of the attributes added or modified, userPassword for a
+// password modify and none for a delete or a modify dn. A non-nil error denies the operation.
+type PolicyFunc func(op, dn string, attrs []string) error
+
+// WithPolicy sets the PolicyFunc invoked before every write operation executed by the client, including every
+// request of a batch, e.g. to allow a service to only modify the groups under ou=self-service. A denied operation is
+// not sent to the LDAP server and fails with an ErrCodePolicyDenied error with the http.StatusForbidden status.
+func WithPolicy(policy PolicyFunc) ClientOption {
+	return func(c *Client) {
+		c.policy = policy
+	}
+}
+
+// checkPolicy invokes the PolicyFunc set on the client, if any, if the request is a write request. The requests of a
+// batch are checked when the batch is executed.
+func (c *Client) checkPolicy(request any) *Error {
+	operation := writeOperation(request)
+	if c.policy == nil || operation == "" {
+		return nil
+	}
+	trace, _ := newRequestTrace(operation, request)
+	attrs := trace.Attributes
+	if operation == OperationPasswordModify {
+		attrs = []string{userPasswordAttr}
+	}
+	if err := c.policy(operation, trace.Dn, attrs); err != nil {
+		return WrapError(errors.New(ErrCodePolicyDenied, http.StatusForbidden,
+			fmt.Sprintf(policyDeniedErrMsg, operation, trace.Dn, err)))
+	}
+	return nil
+}
+
+// writeOperation returns the name of the write operation of a go-ldap request, or an empty string if the request is
+// not a write request.
+func writeOperation(request any) string {
+	switch request.(type) {
+	case *ldap.AddRequest:
+		return OperationAdd
+	case *ldap.DelRequest:
+		return OperationDelete
+	case *ldap.ModifyRequest:
+		return OperationModify
+	case *ldap.ModifyDNRequest:
+		return OperationModifyDN
+	case *ldap.PasswordModifyRequest:
+		return OperationPasswordModify
+	default:
+		return ""
+	}
+}
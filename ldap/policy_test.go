@@ -0,0 +1,77 @@
+package ldap
+
+import (
+	err "errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// selfServicePolicy only allows writes of the entries under ou=self-service.
+func selfServicePolicy(op, dn string, attrs []string) error {
+	if !strings.HasSuffix(strings.ToLower(dn), ",ou=self-service,ou=projects,o=company") {
+		return err.New("only the groups under ou=self-service may be modified")
+	}
+	return nil
+}
+
+func TestWithPolicy(t *testing.T) {
+	t.Run("allowed", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var calls [][]any
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithPolicy(func(op, dn string, attrs []string) error {
+				calls = append(calls, []any{op, dn, attrs})
+				return nil
+			}))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).
+			Return(&ldap.PasswordModifyResult{}, nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(&ldap.SearchResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, ""))
+		_, cErr := client.Users.SetNewPassword(testUser1.Uid, "N3w-Secret!")
+		assert.Nil(t, cErr)
+		_, cErr = client.Users.Filter(userIdAttr, "C0*")
+		assert.Nil(t, cErr)
+		assert.Equal(t, [][]any{
+			{OperationModify, "uid=C00001,ou=users,o=company", []string{managerAttr}},
+			{OperationPasswordModify, "uid=C00001,ou=users,o=company", []string{userPasswordAttr}},
+		}, calls)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithPolicy(selfServicePolicy))
+
+		cErr := client.doLDAPDelete(ldap.NewDelRequest("cn=admins,ou=nexus,ou=projects,o=company", nil))
+		assert.Equal(t, ErrCodePolicyDenied, cErr.Code)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+		assert.Equal(t, "The LDAP delete of 'cn=admins,ou=nexus,ou=projects,o=company' is denied by the policy: "+
+			"only the groups under ou=self-service may be modified", cErr.Message)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithPolicy(selfServicePolicy))
+		allowed := ldap.NewModifyRequest("cn=wiki-editors,ou=self-service,ou=projects,o=company", nil)
+		denied := ldap.NewModifyRequest("cn=admins,ou=nexus,ou=projects,o=company", nil)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, allowed).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		results, cErr := client.doLDAPBatch([]any{allowed, denied})
+		assert.Nil(t, cErr)
+		assert.Nil(t, results[0])
+		assert.Equal(t, ErrCodePolicyDenied, results[1].Code)
+	})
+}
@@ -0,0 +1,221 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// DefaultKeepAliveInterval is the interval the idle connections of the pool are kept alive at if no interval is
+	// set with WithConnectionPool.
+	DefaultKeepAliveInterval = time.Minute
+
+	keepAliveFailedMsg = "The idle LDAP connection failed the keep-alive and was replaced: %v"
+)
+
+type (
+	// connectionPool keeps the idle connections of a client bound with the bind credentials of the client, so the
+	// LDAP operations are executed on an existing connection instead of opening a new one.
+	connectionPool struct {
+		mu     sync.Mutex
+		size   int
+		idle   []pooledConnection
+		closed bool
+		stop   chan struct{}
+		done   chan struct{}
+	}
+
+	// pooledConnection is an idle connection of the pool and the key of the server and the credentials it is
+	// bound with.
+	pooledConnection struct {
+		conn ldap.Client
+		key  poolKey
+	}
+
+	// poolKey identifies the server and the bind credentials a connection was opened with, so a connection is not
+	// reused once the Config or the credentials changed.
+	poolKey struct {
+		url          string
+		protocol     string
+		hostname     string
+		port         string
+		srvDomain    string
+		bindUser     string
+		bindPassword string
+	}
+)
+
+// WithConnectionPool keeps up to size idle connections bound with the bind credentials of the client and executes
+// the LDAP operations on them instead of opening, binding and closing a connection for every operation. The idle
+// connections are kept alive by reading the RootDSE entry every keepAlive interval, so they are not dropped by an
+// idle timeout of the server, a firewall or a load balancer, and a connection which fails the keep-alive or was closed
+// by the server is closed and replaced by a new connection when the next operation is executed. keepAlive defaults
+// to DefaultKeepAliveInterval if not greater than 0.
+// The keep-alive reads are not seen by the middleware and the OperationObserver. A connection on which an operation
+// failed with a network error is not reused. The connections are closed and the keep-alive is stopped by Close.
+// Without a pool, the default, every operation opens its own connection.
+func WithConnectionPool(size int, keepAlive time.Duration) ClientOption {
+	return func(c *Client) {
+		if size <= 0 {
+			return
+		}
+		if keepAlive <= 0 {
+			keepAlive = DefaultKeepAliveInterval
+		}
+		c.pool = newConnectionPool(size)
+		go c.pool.keepAlive(keepAlive)
+		c.onClose(c.pool.close)
+	}
+}
+
+// newConnectionPool returns a connectionPool which keeps up to size idle connections.
+func newConnectionPool(size int) *connectionPool {
+	return &connectionPool{size: size, stop: make(chan struct{}), done: make(chan struct{})}
+}
+
+// newPoolKey returns the poolKey of the connections opened with the config.
+func newPoolKey(config Config) poolKey {
+	return poolKey{
+		url:          config.URL,
+		protocol:     config.Protocol,
+		hostname:     config.Hostname,
+		port:         config.Port,
+		srvDomain:    config.SRVDomain,
+		bindUser:     config.BindUser,
+		bindPassword: config.BindPassword,
+	}
+}
+
+// get returns an idle connection opened with the key, or nil if there is none. The idle connections opened with
+// another key and the connections closed by the server are closed and removed from the pool.
+func (p *connectionPool) get(key poolKey) ldap.Client {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.idle) > 0 {
+		pc := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if pc.key == key && !pc.conn.IsClosing() {
+			return pc.conn
+		}
+		pc.conn.Close()
+	}
+	return nil
+}
+
+// put returns a connection opened with the key to the pool. The connection is closed instead if the pool is closed
+// or full.
+func (p *connectionPool) put(conn ldap.Client, key poolKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed || len(p.idle) >= p.size {
+		conn.Close()
+		return
+	}
+	p.idle = append(p.idle, pooledConnection{conn: conn, key: key})
+}
+
+// keepAlive pings the idle connections every interval until the pool is closed.
+func (p *connectionPool) keepAlive(interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.ping()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// ping reads the RootDSE entry on every idle connection. The connections which fail the read are closed and removed
+// from the pool, so the next operation opens a new connection instead. The connections are taken out of the pool
+// while they are pinged, so they are not used by an operation at the same time.
+func (p *connectionPool) ping() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, pc := range idle {
+		pc.conn.SetTimeout(HealthCheckTimeout)
+		if _, err := pc.conn.Search(keepAliveSearchRequest()); err != nil {
+			logger.Debug(fmt.Sprintf(keepAliveFailedMsg, err))
+			pc.conn.Close()
+			continue
+		}
+		p.put(pc.conn, pc.key)
+	}
+}
+
+// close stops the keep-alive and closes the idle connections. It is invoked by Client.Close.
+func (p *connectionPool) close(ctx context.Context) *Error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.stop)
+	}
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, pc := range idle {
+		pc.conn.Close()
+	}
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return WrapError(errors.InternalServerError(fmt.Sprintf(closeAbortedErrMsg, ctx.Err())))
+	}
+}
+
+// keepAliveSearchRequest returns a ldap search request which reads the RootDSE entry without any attribute.
+func keepAliveSearchRequest() *ldap.SearchRequest {
+	return ldap.NewSearchRequest("", ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0,
+		int(HealthCheckTimeout.Seconds()), false, rootDSESearchFilter, []string{noAttributes}, nil)
+}
+
+// openConnection returns an idle connection of the pool, if any, or opens a new connection with the ldap server.
+// The returned function must be invoked with the error of the operation once the operation completed, it returns the
+// connection to the pool or closes it if the client has no pool or the connection failed with a network error.
+func (c *Client) openConnection(stats *connectionStats) (ldap.Client, func(err error), *Error) {
+	config := c.getConfig()
+	conn := c.pool.get(newPoolKey(config))
+	if conn != nil {
+		if cErr := c.breaker.allow(); cErr != nil {
+			c.pool.put(conn, newPoolKey(config))
+			return nil, nil, cErr
+		}
+		if cnf, cErr := config.withURL(); cErr == nil {
+			stats.server = cnf.server()
+		}
+		stats.reused = true
+		conn.SetTimeout(config.requestTimeout())
+	} else {
+		var cErr *Error
+		if conn, cErr = c.connectWithStats(stats); cErr != nil {
+			return nil, nil, cErr
+		}
+		// the credentials may have been loaded or refreshed by the bind
+		config = c.getConfig()
+	}
+	release := func(err error) {
+		if c.pool == nil || ldap.IsErrorAnyOf(err, networkErrorResultCodes...) {
+			conn.Close()
+			return
+		}
+		c.pool.put(conn, newPoolKey(config))
+	}
+	return conn, release, nil
+}
@@ -0,0 +1,125 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var methodNameIsClosing = "IsClosing"
+
+func TestWithConnectionPool(t *testing.T) {
+	t.Run("idle connection is reused", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var observed []OperationMetadata
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithConnectionPool(1, time.Hour),
+			WithOperationObserver(func(metadata OperationMetadata) { observed = append(observed, metadata) }))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Once()
+		ldapMock.On(methodNameSetTimeout, mock.Anything).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil).Twice()
+		ldapMock.On(methodNameIsClosing).Return(false).Once()
+
+		assert.Nil(t, client.Ping())
+		assert.Nil(t, client.Ping())
+		assert.Len(t, observed, 2)
+		assert.True(t, observed[1].ConnectionReused)
+		ldapMock.AssertNotCalled(t, methodNameClose)
+
+		ldapMock.On(methodNameClose).Return(nil).Once()
+		assert.Nil(t, client.Close(context.Background()))
+	})
+
+	t.Run("connection closed by the server is replaced", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithConnectionPool(1, time.Hour))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Twice()
+		ldapMock.On(methodNameSetTimeout, mock.Anything).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil).Twice()
+		ldapMock.On(methodNameIsClosing).Return(true).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Ping())
+		assert.Nil(t, client.Ping())
+		ldapMock.AssertNumberOfCalls(t, methodNameClose, 1)
+	})
+
+	t.Run("connection failed with a network error is not reused", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithConnectionPool(1, time.Hour))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Twice()
+		ldapMock.On(methodNameSetTimeout, mock.Anything).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(nil, ldapNetworkErr).Once()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil).Once()
+
+		assert.NotNil(t, client.Ping())
+		assert.Nil(t, client.Ping())
+	})
+
+	t.Run("changed credentials", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithConnectionPool(1, time.Hour))
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Once()
+		ldapMock.On(methodNameBind, testConfig.BindUser, "newPassword").Return(nil).Once()
+		ldapMock.On(methodNameSetTimeout, mock.Anything).Return()
+		ldapMock.On(methodNameSearch, client.getRootDSESearchRequest()).Return(getRootDSESearchResult, nil).Twice()
+		ldapMock.On(methodNameClose).Return(nil).Once()
+
+		assert.Nil(t, client.Ping())
+		client.SetBindCredentials(testConfig.BindUser, "newPassword")
+		assert.Nil(t, client.Ping())
+	})
+}
+
+func TestConnectionPool_ping(t *testing.T) {
+	alive, dead := mocks.NewClient(t), mocks.NewClient(t)
+	pool := newConnectionPool(2)
+	key := newPoolKey(testConfig)
+	pool.put(alive, key)
+	pool.put(dead, key)
+
+	alive.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+	alive.On(methodNameSearch, keepAliveSearchRequest()).Return(getRootDSESearchResult, nil)
+	dead.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+	dead.On(methodNameSearch, keepAliveSearchRequest()).Return(nil, ldapNetworkErr)
+	dead.On(methodNameClose).Return(nil)
+
+	pool.ping()
+	assert.Equal(t, []pooledConnection{{conn: alive, key: key}}, pool.idle)
+}
+
+func TestConnectionPool_keepAlive(t *testing.T) {
+	conn := mocks.NewClient(t)
+	pool := newConnectionPool(1)
+	pool.put(conn, newPoolKey(testConfig))
+	pinged := make(chan struct{}, 1)
+
+	conn.On(methodNameSetTimeout, HealthCheckTimeout).Return()
+	conn.On(methodNameSearch, keepAliveSearchRequest()).
+		Run(func(mock.Arguments) {
+			select {
+			case pinged <- struct{}{}:
+			default:
+			}
+		}).
+		Return(getRootDSESearchResult, nil)
+	conn.On(methodNameClose).Return(nil)
+
+	go pool.keepAlive(time.Millisecond)
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Fatal("the idle connection was not kept alive")
+	}
+	assert.Nil(t, pool.close(context.Background()))
+	pool.put(conn, newPoolKey(testConfig))
+	conn.AssertNumberOfCalls(t, methodNameClose, 2)
+}
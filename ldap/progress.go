@@ -0,0 +1,82 @@
+package ldap
+
+import (
+	"sync"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	// ErrCodeCancelled is the code of the error reported for the items of a bulk job which were skipped because the
+	// job was cancelled.
+	ErrCodeCancelled = "CANCELLED"
+	// statusClientClosedRequest is the non-standard status used for requests which were cancelled by the client.
+	statusClientClosedRequest = 499
+
+	cancelledErrMsg = "The job was cancelled before the item was processed"
+)
+
+type (
+	// Progress reports the progress of a long-running bulk job.
+	Progress struct {
+		// Processed is the number of items processed so far, including the failed items.
+		Processed int `json:"processed"`
+		// Total is the number of items of the job, or 0 if the number of items is not known upfront, e.g. when
+		// the items are read from a stream.
+		Total int `json:"total"`
+		// Failed is the number of items which failed so far.
+		Failed int `json:"failed"`
+	}
+
+	// ProgressFunc is invoked every time items of a bulk job have been processed. Returning false cancels the job
+	// gracefully: the items being processed are completed and the remaining items are skipped.
+	// A ProgressFunc is never invoked concurrently.
+	ProgressFunc func(progress Progress) bool
+
+	// progressTracker counts the processed items of a bulk job and reports the progress to a ProgressFunc.
+	progressTracker struct {
+		mu        sync.Mutex
+		fn        ProgressFunc
+		progress  Progress
+		cancelled bool
+	}
+)
+
+// newProgressTracker returns a progressTracker for a job with total items which reports the progress to fn.
+// The fn is optional.
+func newProgressTracker(fn ProgressFunc, total int) *progressTracker {
+	return &progressTracker{fn: fn, progress: Progress{Total: total}}
+}
+
+// done records processed and failed items, reports the progress and returns whether the job may continue.
+func (t *progressTracker) done(processed, failed int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress.Processed += processed
+	t.progress.Failed += failed
+	if t.fn != nil && !t.cancelled && !t.fn(t.progress) {
+		t.cancelled = true
+	}
+	return !t.cancelled
+}
+
+// doneItem records a processed item which failed if cErr is not nil, reports the progress and returns whether the
+// job may continue.
+func (t *progressTracker) doneItem(cErr *Error) bool {
+	if cErr != nil {
+		return t.done(1, 1)
+	}
+	return t.done(1, 0)
+}
+
+// isCancelled returns whether the job was cancelled by the ProgressFunc.
+func (t *progressTracker) isCancelled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cancelled
+}
+
+// cancelledError returns the error reported for an item which was skipped because the job was cancelled.
+func cancelledError() *Error {
+	return WrapError(errors.New(ErrCodeCancelled, statusClientClosedRequest, cancelledErrMsg))
+}
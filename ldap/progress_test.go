@@ -0,0 +1,66 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressTracker(t *testing.T) {
+	t.Run("reports the progress", func(t *testing.T) {
+		var reported []Progress
+		tracker := newProgressTracker(func(progress Progress) bool {
+			reported = append(reported, progress)
+			return true
+		}, 3)
+
+		assert.True(t, tracker.doneItem(nil))
+		assert.True(t, tracker.doneItem(WrapError(errors.BadRequestError("failed"))))
+		assert.True(t, tracker.done(1, 0))
+		assert.False(t, tracker.isCancelled())
+		assert.Equal(t, []Progress{
+			{Processed: 1, Total: 3},
+			{Processed: 2, Total: 3, Failed: 1},
+			{Processed: 3, Total: 3, Failed: 1},
+		}, reported)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		calls := 0
+		tracker := newProgressTracker(func(progress Progress) bool {
+			calls++
+			return false
+		}, 3)
+
+		assert.False(t, tracker.done(1, 0))
+		assert.False(t, tracker.done(1, 0))
+		assert.True(t, tracker.isCancelled())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("without progress func", func(t *testing.T) {
+		tracker := newProgressTracker(nil, 0)
+
+		assert.True(t, tracker.done(2, 1))
+		assert.False(t, tracker.isCancelled())
+	})
+}
+
+func TestBulkRunner_Run_Progress(t *testing.T) {
+	runner := NewBulkRunner(1)
+	runner.Progress = func(progress Progress) bool {
+		return progress.Processed < 2
+	}
+	executed := 0
+
+	errs := runner.Run(4, func(i int) *Error {
+		executed++
+		return nil
+	})
+	assert.Equal(t, 2, executed)
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.Equal(t, ErrCodeCancelled, errs[2].Code)
+	assert.Equal(t, statusClientClosedRequest, errs[3].Status)
+}
@@ -0,0 +1,125 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const protectedDNErrMsg = "The entry '%s' is protected, the LDAP %s is refused"
+
+// isProtectedDN checks if the dn matches one of the ProtectedDNs set in the client Config, ignoring the case and
+// insignificant spaces.
+func (cnf Config) isProtectedDN(entryDN string) bool {
+	key := dnKey(entryDN)
+	for _, pattern := range cnf.ProtectedDNs {
+		if matchWildcard(dnKey(pattern), key) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard checks if the value matches the pattern, in which * matches any sequence of characters.
+func matchWildcard(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// hasProtectedSubtree checks if the dn or any dn below it matches one of the ProtectedDNs set in the client Config.
+// A pattern matches below the dn if it has more RDNs than the dn and its last RDNs match the dn.
+func (cnf Config) hasProtectedSubtree(entryDN string) bool {
+	key := dnKey(entryDN)
+	rdns := strings.Count(key, ",") + 1
+	for _, pattern := range cnf.ProtectedDNs {
+		pattern = dnKey(pattern)
+		if matchWildcard(pattern, key) {
+			return true
+		}
+		parts := strings.Split(pattern, ",")
+		if len(parts) > rdns && matchWildcard(strings.Join(parts[len(parts)-rdns:], ","), key) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkProtected refuses the delete, the modify, the modify dn and the password modify of the entries which match
+// the ProtectedDNs set in the client Config. The requests of a batch are checked when the batch is executed.
+// Requests which affect the entries below their dn are refused if a protected entry may be among them:
+//   - a delete with the subtree delete control
+//   - a modify dn, which also moves the entries below the entry, or which would move the entry to a protected dn
+func (c *Client) checkProtected(request any) *Error {
+	operation := writeOperation(request)
+	if operation == "" || operation == OperationAdd {
+		return nil
+	}
+	config := c.getConfig()
+	if len(config.ProtectedDNs) == 0 {
+		return nil
+	}
+	trace, _ := newRequestTrace(operation, request)
+	protected := config.isProtectedDN(trace.Dn)
+	switch r := request.(type) {
+	case *ldap.DelRequest:
+		protected = protected || (hasSubtreeDeleteControl(r.Controls) && config.hasProtectedSubtree(r.DN))
+	case *ldap.ModifyDNRequest:
+		protected = protected || config.hasProtectedSubtree(r.DN) || config.isProtectedDN(modifiedDN(r))
+	}
+	if protected {
+		return protectedError(trace.Dn, operation)
+	}
+	return nil
+}
+
+// checkProtectedSubtree refuses the operation if the dn or any dn below it may match one of the ProtectedDNs set in
+// the client Config, e.g. before the entries of a subtree are deleted one by one.
+func (c *Client) checkProtectedSubtree(entryDN, operation string) *Error {
+	if c.getConfig().hasProtectedSubtree(entryDN) {
+		return protectedError(entryDN, operation)
+	}
+	return nil
+}
+
+// protectedError returns the error of an operation refused because of a protected entry.
+func protectedError(entryDN, operation string) *Error {
+	return WrapError(errors.ForbiddenError(fmt.Sprintf(protectedDNErrMsg, strings.TrimSpace(entryDN), operation)))
+}
+
+// hasSubtreeDeleteControl checks if the controls contain the subtree delete control.
+func hasSubtreeDeleteControl(controls []ldap.Control) bool {
+	for _, control := range controls {
+		if control.GetControlType() == ldap.ControlTypeSubtreeDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// modifiedDN returns the dn of the entry after the modify dn request is executed.
+func modifiedDN(mdr *ldap.ModifyDNRequest) string {
+	parent := mdr.NewSuperior
+	if parent == "" {
+		if parsedDN, err := ldap.ParseDN(mdr.DN); err == nil && len(parsedDN.RDNs) > 1 {
+			parent = (&ldap.DN{RDNs: parsedDN.RDNs[1:]}).String()
+		}
+	}
+	return dn.Join(mdr.NewRDN, parent)
+}
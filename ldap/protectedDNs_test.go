@@ -0,0 +1,123 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getProtectedConfig() Config {
+	config := testConfig
+	config.ProtectedDNs = []string{"uid=breakglass-*, ou=users, o=company", "OU=Users,O=Company"}
+	return config
+}
+
+func TestConfig_isProtectedDN(t *testing.T) {
+	config := getProtectedConfig()
+
+	assert.True(t, config.isProtectedDN("ou=users,o=company"))
+	assert.True(t, config.isProtectedDN("uid=breakglass-01,ou=users,o=company"))
+	assert.True(t, config.isProtectedDN("UID=BREAKGLASS-02, OU=users, O=company"))
+	assert.False(t, config.isProtectedDN("uid=C00001,ou=users,o=company"))
+	assert.False(t, config.isProtectedDN("uid=breakglass-01,ou=archive,o=company"))
+}
+
+func TestConfig_hasProtectedSubtree(t *testing.T) {
+	config := getProtectedConfig()
+
+	assert.True(t, config.hasProtectedSubtree("o=company"))
+	assert.True(t, config.hasProtectedSubtree("ou=users,o=company"))
+	assert.True(t, config.hasProtectedSubtree("uid=breakglass-01,ou=users,o=company"))
+	assert.False(t, config.hasProtectedSubtree("ou=archive,o=company"))
+	assert.False(t, config.hasProtectedSubtree("o=other"))
+
+	config.ProtectedDNs = []string{"uid=breakglass-*,ou=users,o=company"}
+	assert.True(t, config.hasProtectedSubtree("ou=users,o=company"))
+	assert.False(t, config.hasProtectedSubtree("uid=C00001,ou=users,o=company"))
+}
+
+func TestMatchWildcard(t *testing.T) {
+	assert.True(t, matchWildcard("*", "anything"))
+	assert.True(t, matchWildcard("cn=*,ou=*,o=company", "cn=admins,ou=nexus,o=company"))
+	assert.True(t, matchWildcard("a*b*b", "abb"))
+	assert.False(t, matchWildcard("a*b*b", "ab"))
+	assert.False(t, matchWildcard("cn=admins", "cn=admins2"))
+}
+
+func TestClient_checkProtected(t *testing.T) {
+	t.Run("refused", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getProtectedConfig(), WithLDAPClient(ldapMock), UnitTesting())
+
+		cErr := client.doLDAPDelete(ldap.NewDelRequest("uid=breakglass-01,ou=users,o=company", nil))
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+		assert.Equal(t, "The entry 'uid=breakglass-01,ou=users,o=company' is protected, the LDAP delete is refused",
+			cErr.Message)
+
+		_, cErr = client.Users.SetNewPassword("breakglass-01", "N3w-Secret!")
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+
+		cErr = client.doLDAPModifyDN(ldap.NewModifyDNRequest("ou=users,o=company", "ou=people", true, ""))
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+
+		cErr = client.doLDAPModifyDN(ldap.NewModifyDNRequest("o=company", "o=corp", true, ""))
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+
+		cErr = client.doLDAPModifyDN(ldap.NewModifyDNRequest("uid=C00001,ou=archive,o=company", "uid=breakglass-09",
+			true, "ou=users,o=company"))
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+		results, cErr := client.doLDAPBatch([]any{ldap.NewModifyRequest("uid=breakglass-01,ou=users,o=company", nil)})
+		assert.Nil(t, cErr)
+		assert.Equal(t, http.StatusForbidden, results[0].Status)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getProtectedConfig(), WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest("uid=breakglass-03,ou=users,o=company", nil)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.doLDAPAdd(ar))
+		assert.Nil(t, client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, ""))
+	})
+}
+
+func TestClient_checkProtected_subtreeDelete(t *testing.T) {
+	t.Run("subtree delete control", func(t *testing.T) {
+		client := NewClient(getProtectedConfig(), UnitTesting())
+
+		cErr := client.Entries.DeleteSubtree("o=company")
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+
+	t.Run("depth-first fallback", func(t *testing.T) {
+		client := NewClient(getProtectedConfig(), UnitTesting())
+		em := entriesManager{Client: client}
+
+		cErr := em.deleteSubtreeEntries("o=company")
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getProtectedConfig(), WithLDAPClient(ldapMock), UnitTesting())
+		dr := ldap.NewDelRequest("ou=archive,o=company", []ldap.Control{ldap.NewControlSubtreeDelete()})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, dr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Entries.DeleteSubtree("ou=archive,o=company"))
+	})
+}
@@ -0,0 +1,79 @@
+package ldap
+
+import (
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/logger"
+)
+
+const (
+	rollbackMembershipsMsg = "Rolling back the user '%s' failed, the user could not be removed from the group " +
+		"with cn = '%s' and ou = '%s': %s"
+	rollbackUserMsg = "Rolling back the user '%s' failed, the user entry could not be deleted: %s"
+)
+
+// CreateInGroups creates a new user entry in LDAP and adds the user to the groups.
+// params:
+//
+//	user = the user to create
+//	groups = the groups the user is added to, e.g. a default "all-employees" group
+//
+// If the user cannot be added to one of the groups, the user is removed from the groups it was already added to and
+// the user entry is deleted, so that no half-provisioned account is left behind.
+// The method returns an error:
+//   - if a validation fails
+//   - if the mail or the employee number is already used by another user and the uniqueness check is enabled
+//   - if a group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) CreateInGroups(user User, groups []GroupMembership) *Error {
+	return um.createInGroups(user, nil, groups)
+}
+
+// createInGroups creates a new user entry with the objectClasses and adds the user to the groups. The user entry is
+// rolled back if the user cannot be added to a group.
+func (um *usersManager) createInGroups(user User, objectClasses []string, groups []GroupMembership) *Error {
+	if cErr := validateGroupMemberships(groups); cErr != nil {
+		return cErr
+	}
+	if cErr := um.create(user, objectClasses); cErr != nil {
+		return cErr
+	}
+	for i, group := range groups {
+		if cErr := um.Client.Groups.AddMembers(group.Cn, group.Ou, []string{user.Uid}); cErr != nil {
+			um.rollbackCreate(user.Uid, groups[:i])
+			return cErr
+		}
+	}
+	return nil
+}
+
+// rollbackCreate removes the user from the groups and deletes the user entry. Failures are logged as the error which
+// caused the rollback is returned to the caller.
+func (um *usersManager) rollbackCreate(uid string, groups []GroupMembership) {
+	for _, group := range groups {
+		if cErr := um.Client.Groups.RemoveMembers(group.Cn, group.Ou, []string{uid}); cErr != nil {
+			logger.Errorf(rollbackMembershipsMsg, uid, group.Cn, group.Ou, cErr.Message)
+		}
+	}
+	if cErr := um.HardDelete(uid); cErr != nil {
+		logger.Errorf(rollbackUserMsg, uid, cErr.Message)
+	}
+}
+
+// validateGroupMemberships checks if the cn and the ou of every group are set.
+func validateGroupMemberships(groups []GroupMembership) *Error {
+	for _, group := range groups {
+		var missingParams []string
+		if strings.TrimSpace(group.Cn) == "" {
+			missingParams = append(missingParams, CommonNameAttr)
+		}
+		if strings.TrimSpace(group.Ou) == "" {
+			missingParams = append(missingParams, OrganizationalUnitAttr)
+		}
+		if len(missingParams) > 0 {
+			return missingParametersError(missingParams)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersManager_CreateInGroups(t *testing.T) {
+	groups := []GroupMembership{
+		{Cn: testGroupCn1, Ou: testOrganizationUnit1},
+		{Cn: testGroupCn2, Ou: testOrganizationUnit1},
+	}
+
+	t.Run("rollback", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+		um := usersManager{Client: client}
+		gm := groupsManager{Client: client}
+		addMemberRequest := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		addMemberRequest.Add(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser3.Uid)})
+		addMemberRequest.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+		removeMemberRequest := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		removeMemberRequest.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser3.Uid)})
+		user := testUser3
+		user.UserPassword = "somePassword"
+		var events []EventType
+		for _, eventType := range eventTypes {
+			client.On(eventType, func(event Event) { events = append(events, event.Type) })
+		}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, um.getAddRequest(user)).Return(nil)
+		ldapMock.On("PasswordModify",
+			um.getPasswordModifyRequest(user.Uid, user.UserPassword, user.UserPassword)).Return(nil, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult2, nil).Once()
+		ldapMock.On(methodNameModify, addMemberRequest).Return(nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn2, testOrganizationUnit1, groupSearchFilter)).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1,
+				[]string{testUniqueMembers2[0], gm.getUniqueMemberDn(testUser3.Uid)})}}, nil).Once()
+		ldapMock.On(methodNameModify, removeMemberRequest).Return(nil)
+		ldapMock.On(methodNameDelete, um.getDeleteRequest(user.Uid)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProvisioner).CreateInGroups(user, groups)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, []EventType{
+			EventUserCreated, EventGroupMembersAdded, EventGroupMembersRemoved, EventUserDeleted,
+		}, events)
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UserProvisioner).CreateInGroups(User{}, groups)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("invalid group", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UserProvisioner).CreateInGroups(testUser1, []GroupMembership{{Cn: testGroupCn1}})
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(OrganizationalUnitAttr).Code)
+	})
+}
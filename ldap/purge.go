@@ -0,0 +1,133 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	modifyTimestampAttr = "modifyTimestamp"
+	olderThanParam      = "olderThan"
+
+	deletedUsersSearchFilter = "(&%s(%s=%s)(%s<=%s))"
+	invalidRetentionMsg      = "Invalid retention period '%s'. The retention period must be greater than 0"
+)
+
+type (
+	// PurgeReport reports the user entries removed by PurgeDeleted.
+	PurgeReport struct {
+		// DryRun is true if the user entries were only reported and not removed.
+		DryRun bool `json:"dryRun"`
+		// Cutoff is the time before which the user entries were last modified.
+		Cutoff time.Time `json:"cutoff"`
+		// Purged is the number of user entries which were removed, or would be removed on a dry run.
+		Purged int `json:"purged"`
+		// Failed is the number of user entries which could not be removed.
+		Failed int          `json:"failed"`
+		Users  []PurgedUser `json:"users"`
+	}
+
+	// PurgedUser reports a user entry removed by PurgeDeleted.
+	PurgedUser struct {
+		Uid string `json:"uid"`
+		// ModifyTimestamp is the time the user entry was last modified, i.e. marked as Deleted.
+		ModifyTimestamp time.Time `json:"modifyTimestamp"`
+		// Groups are the groups the user was removed from, or would be removed from on a dry run.
+		Groups []GroupMembership `json:"groups"`
+		// Error is set if the user entry could not be removed.
+		Error *Error `json:"error,omitempty"`
+	}
+)
+
+// PurgeDeleted removes the user entries with the status Deleted which have not been modified within the retention
+// period, together with their group memberships.
+// params:
+//
+//	olderThan = the retention period, user entries modified within the retention period are kept
+//	dryRun = if true the user entries are only reported and not removed
+//
+// The user entries are searched in the ArchiveBaseDN set in the client Config, into which soft-deleted users are
+// moved, if set, and in the user base dn. The modifyTimestamp of a user entry is the time the user was marked as
+// Deleted or moved into the ArchiveBaseDN, as long as the entry is not modified afterwards. A user entry which cannot
+// be removed is reported with the error and the other user entries are still removed.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) PurgeDeleted(olderThan time.Duration, dryRun bool) (*PurgeReport, *Error) {
+	if olderThan <= 0 {
+		return nil, invalidParameterError(olderThanParam, fmt.Sprintf(invalidRetentionMsg, olderThan))
+	}
+	report := &PurgeReport{DryRun: dryRun, Cutoff: time.Now().UTC().Add(-olderThan).Truncate(time.Second),
+		Users: []PurgedUser{}}
+	// the manager of the base dn every user entry was found in, which removes the user entry
+	var managers []*usersManager
+	found := map[string]bool{}
+	for _, manager := range um.deletedUsersManagers() {
+		cErr := um.Client.doLDAPPagedSearch(manager.getDeletedUsersSearchRequest(report.Cutoff), defaultPageSize,
+			func(result *ldap.SearchResult) *Error {
+				for i, user := range manager.parseSearchResult(result) {
+					entryDN := strings.ToLower(result.Entries[i].DN)
+					modifyTimestamp, ok := parseGeneralizedTime(result.Entries[i].GetAttributeValue(modifyTimestampAttr))
+					if !ok || modifyTimestamp.After(report.Cutoff) || found[entryDN] {
+						continue
+					}
+					found[entryDN] = true
+					report.Users = append(report.Users, PurgedUser{Uid: user.Uid, ModifyTimestamp: modifyTimestamp})
+					managers = append(managers, manager)
+				}
+				return nil
+			})
+		if cErr != nil {
+			return nil, cErr
+		}
+	}
+	for i := range report.Users {
+		if managers[i].purge(&report.Users[i], dryRun) {
+			report.Purged++
+		} else {
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// purge removes the user from all the groups and deletes the user entry, unless dryRun is set. The method returns
+// false if the user entry could not be removed.
+func (um *usersManager) purge(user *PurgedUser, dryRun bool) bool {
+	if dryRun {
+		user.Groups, user.Error = um.memberGroups(user.Uid)
+		return user.Error == nil
+	}
+	if user.Groups, user.Error = um.removeFromAllGroups(user.Uid); user.Error != nil {
+		return false
+	}
+	user.Error = um.HardDelete(user.Uid)
+	return user.Error == nil
+}
+
+// deletedUsersManagers returns the managers of the base dns searched for the user entries with the status Deleted:
+// the ArchiveBaseDN set in the client Config, if set, followed by the user base dn. The ArchiveBaseDN is searched
+// first, so a user entry found in both base dns, e.g. if the ArchiveBaseDN is within the user base dn, is removed
+// from the ArchiveBaseDN.
+func (um *usersManager) deletedUsersManagers() []*usersManager {
+	var managers []*usersManager
+	archiveBaseDN := um.Client.getConfig().ArchiveBaseDN
+	if archiveBaseDN != "" && !dn.EqualFold(archiveBaseDN, um.userBaseDN()) {
+		managers = append(managers, &usersManager{Client: um.Client, baseDN: archiveBaseDN})
+	}
+	return append(managers, um)
+}
+
+// getDeletedUsersSearchRequest returns a ldap search request to get the user entries with the status Deleted which
+// have not been modified since the cutoff time.
+func (um *usersManager) getDeletedUsersSearchRequest(cutoff time.Time) *ldap.SearchRequest {
+	sr := um.getUsersSearchRequest(fmt.Sprintf(deletedUsersSearchFilter, um.Client.getConfig().userObjectFilter(), statusAttr, UserStatusDeleted,
+		modifyTimestampAttr, cutoff.UTC().Format(generalizedTimeLayout)))
+	sr.Attributes = append(sr.Attributes, modifyTimestampAttr)
+	return sr
+}
@@ -0,0 +1,190 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsersManager_PurgeDeleted(t *testing.T) {
+	setup := func(t *testing.T) (*mocks.Client, *Client) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		um := usersManager{Client: client}
+		gm := groupsManager{Client: client}
+		group := &ldap.SearchResult{Entries: []*ldap.Entry{getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1,
+			[]string{gm.getUniqueMemberDn(testUser1.Uid), gm.getUniqueMemberDn(testUser2.Uid)})}}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.MatchedBy(func(sr *ldap.SearchRequest) bool {
+			return strings.HasPrefix(sr.Filter, "(&(objectClass=inetOrgPerson)(status=Deleted)(modifyTimestamp<=")
+		})).Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			ldap.NewEntry(um.getDN(testUser2.Uid), map[string][]string{
+				userIdAttr:          {testUser2.Uid},
+				statusAttr:          {UserStatusDeleted},
+				modifyTimestampAttr: {"20230101120000Z"},
+			}),
+			ldap.NewEntry(um.getDN(testUser1.Uid), map[string][]string{
+				userIdAttr:          {testUser1.Uid},
+				statusAttr:          {UserStatusDeleted},
+				modifyTimestampAttr: {time.Now().UTC().Format(generalizedTimeLayout)},
+			}),
+		}}, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", fmt.Sprintf("(&%s(%s=%s))", groupSearchFilter,
+			uniqueMemberAttr, ldap.EscapeFilter(gm.getUniqueMemberDn(testUser2.Uid))))).Return(group, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+		return ldapMock, client
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock, client := setup(t)
+		um := usersManager{Client: client}
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser2.Uid)})
+
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1,
+				[]string{gm.getUniqueMemberDn(testUser1.Uid), gm.getUniqueMemberDn(testUser2.Uid)})}}, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameDelete, um.getDeleteRequest(testUser2.Uid)).Return(nil)
+
+		report, cErr := client.Users.(UserLifecycleManager).PurgeDeleted(30*24*time.Hour, false)
+		assert.Nil(t, cErr)
+		assert.False(t, report.DryRun)
+		assert.Equal(t, 1, report.Purged)
+		assert.Equal(t, 0, report.Failed)
+		assert.Equal(t, []PurgedUser{{
+			Uid:             testUser2.Uid,
+			ModifyTimestamp: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			Groups:          []GroupMembership{{Cn: testGroupCn1, Ou: testOrganizationUnit1}},
+		}}, report.Users)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		_, client := setup(t)
+
+		report, cErr := client.Users.(UserLifecycleManager).PurgeDeleted(30*24*time.Hour, true)
+		assert.Nil(t, cErr)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 1, report.Purged)
+		assert.Equal(t, []GroupMembership{{Cn: testGroupCn1, Ou: testOrganizationUnit1}}, report.Users[0].Groups)
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		ldapMock, client := setup(t)
+		um := usersManager{Client: client}
+		gm := groupsManager{Client: client}
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser2.Uid)})
+
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1,
+				[]string{gm.getUniqueMemberDn(testUser1.Uid), gm.getUniqueMemberDn(testUser2.Uid)})}}, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameDelete, um.getDeleteRequest(testUser2.Uid)).Return(ldapInsufficientRightsErr)
+
+		report, cErr := client.Users.(UserLifecycleManager).PurgeDeleted(30*24*time.Hour, false)
+		assert.Nil(t, cErr)
+		assert.Equal(t, 0, report.Purged)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, report.Users[0].Error.Code)
+	})
+
+	t.Run("soft-deleted user", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getSoftDeleteConfig(), WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation())
+		um := usersManager{Client: client}
+		archived := usersManager{Client: client, baseDN: testArchiveBaseDN}
+		gm := groupsManager{Client: client}
+		status := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		status.Replace(statusAttr, []string{UserStatusDeleted})
+		member := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		member.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser1.Uid)})
+		group := &ldap.SearchResult{Entries: []*ldap.Entry{getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1,
+			[]string{gm.getUniqueMemberDn(testUser1.Uid), gm.getUniqueMemberDn(testUser2.Uid)})}}
+		deletedUsersSearch := func(baseDN string) any {
+			return mock.MatchedBy(func(sr *ldap.SearchRequest) bool {
+				return sr.BaseDN == baseDN && strings.Contains(sr.Filter, "(status=Deleted)")
+			})
+		}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+		// soft delete
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil).Once()
+		ldapMock.On(methodNameModify, status).Return(nil).Once()
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).
+			Return(&ldap.PasswordModifyResult{}, nil).Once()
+		ldapMock.On(methodNameModifyDN, ldap.NewModifyDNRequest(um.getDN(testUser1.Uid), "uid="+testUser1.Uid, true,
+			testArchiveBaseDN)).Return(nil).Once()
+		// purge
+		ldapMock.On(methodNameSearch, deletedUsersSearch(testArchiveBaseDN)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry(archived.getDN(testUser1.Uid), map[string][]string{
+					userIdAttr:          {testUser1.Uid},
+					statusAttr:          {UserStatusDeleted},
+					modifyTimestampAttr: {"20230101120000Z"},
+				}),
+			}}, nil).Once()
+		ldapMock.On(methodNameSearch, deletedUsersSearch(testConfig.UserBaseDN)).
+			Return(&ldap.SearchResult{}, nil).Once()
+		ldapMock.On(methodNameSearch, gm.getSearchRequest("", "", fmt.Sprintf("(&%s(%s=%s))", groupSearchFilter,
+			uniqueMemberAttr, ldap.EscapeFilter(gm.getUniqueMemberDn(testUser1.Uid))))).Return(group, nil).Once()
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(group, nil).Once()
+		ldapMock.On(methodNameModify, member).Return(nil).Once()
+		ldapMock.On(methodNameDelete, archived.getDeleteRequest(testUser1.Uid)).Return(nil).Once()
+
+		assert.Nil(t, client.Users.Delete(testUser1.Uid))
+		report, cErr := client.Users.(UserLifecycleManager).PurgeDeleted(30*24*time.Hour, false)
+		assert.Nil(t, cErr)
+		assert.Equal(t, 1, report.Purged)
+		assert.Equal(t, 0, report.Failed)
+		assert.Equal(t, []PurgedUser{{
+			Uid:             testUser1.Uid,
+			ModifyTimestamp: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			Groups:          []GroupMembership{{Cn: testGroupCn1, Ou: testOrganizationUnit1}},
+		}}, report.Users)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		report, cErr := client.Users.(UserLifecycleManager).PurgeDeleted(time.Hour, false)
+		assert.Nil(t, report)
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+	})
+
+	t.Run("invalid retention period", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		report, cErr := client.Users.(UserLifecycleManager).PurgeDeleted(0, false)
+		assert.Nil(t, report)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(olderThanParam).Code)
+	})
+}
+
+func TestUsersManager_getDeletedUsersSearchRequest(t *testing.T) {
+	um := usersManager{Client: NewClient(testConfig)}
+
+	sr := um.getDeletedUsersSearchRequest(time.Date(2024, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)))
+	assert.Equal(t, "(&(objectClass=inetOrgPerson)(status=Deleted)(modifyTimestamp<=20240101000000Z))", sr.Filter)
+	assert.Contains(t, sr.Attributes, modifyTimestampAttr)
+}
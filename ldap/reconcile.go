@@ -0,0 +1,256 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/slice"
+)
+
+const (
+	UserChangeCreate      UserChangeAction = "create"
+	UserChangeUpdate      UserChangeAction = "update"
+	UserChangeMarkDeleted UserChangeAction = "markDeleted"
+
+	// defaultReconcileBatchSize is the number of modify requests sent using the same connection by Reconcile.
+	defaultReconcileBatchSize = 100
+
+	desiredParam      = "desired"
+	attributesParam   = "attributes"
+	duplicateUidMsg   = "Duplicate uid '%s'"
+	invalidAttrMsg    = "Invalid attribute '%s'. Valid attributes are %v"
+	missingDesiredUid = "A desired user does not have a uid"
+)
+
+type (
+	// ReconcileOptions configure a Reconcile.
+	ReconcileOptions struct {
+		// DryRun computes and validates the changes without changing LDAP.
+		DryRun bool
+		// BatchSize is the number of updates sent to LDAP using the same connection. Defaults to 100.
+		BatchSize int
+		// Attributes are the attributes which are compared and updated. Defaults to all the user attributes.
+		// The uid is always used to match the users and the password of existing users is never updated.
+		Attributes []string
+		// Protected are the uids of the accounts which are never changed, e.g. technical or break-glass accounts.
+		Protected []string
+		// Progress is invoked every time changes have been applied, or validated in a dry-run. Optional.
+		Progress ProgressFunc
+	}
+
+	// UserChangeAction is the type of change made to a user by Reconcile.
+	UserChangeAction string
+
+	// UserChange represents a change made to a user by Reconcile.
+	UserChange struct {
+		Action UserChangeAction `json:"action"`
+		Uid    string           `json:"uid"`
+		// Attributes are the attributes which are updated.
+		Attributes []string `json:"attributes,omitempty"`
+		// Changes are the old and the new values of the updated attributes.
+		Changes ChangeSet `json:"changes,omitempty"`
+		// Error is set if the change failed the validation or could not be applied.
+		Error *Error `json:"error,omitempty"`
+	}
+
+	// ReconcileResult holds the changes of a Reconcile.
+	ReconcileResult struct {
+		// Changes are the changes which are applied, or in a dry-run the changes which would be applied.
+		Changes []UserChange `json:"changes"`
+		// Protected are the changes which are not applied because the account is protected.
+		Protected []UserChange `json:"protected,omitempty"`
+		// Cancelled is true if the reconcile was cancelled by the Progress func, the changes which were not applied
+		// have an ErrCodeCancelled error.
+		Cancelled bool `json:"cancelled,omitempty"`
+	}
+
+	// reconcileUpdate is a pending change of Reconcile.
+	reconcileUpdate struct {
+		// change is the index of the UserChange in the ReconcileResult.
+		change int
+		// user is the desired user entry, for an update the existing user entry with the changed attributes.
+		user User
+	}
+)
+
+// Reconcile compares the desired user entries, e.g. from an HR feed, with the user entries in LDAP and applies the
+// changes required to match the desired state:
+//   - users which do not exist are created, the desired user must contain all the attributes required by Create
+//   - the differing attributes of existing users are updated
+//   - users which are not desired are marked as Deleted
+//
+// params:
+//
+//	desired = the desired user entries
+//	opts = the options of the reconcile, e.g. the dry-run and the protected accounts
+//
+// A change which fails does not stop the reconcile, the error of every change is reported in the ReconcileResult.
+// The new users and the batches of updates are applied concurrently by at most BulkConcurrency workers, see the
+// client Config.
+// The method returns an error:
+//   - if a desired user does not have a uid or the uid is not unique
+//   - if an attribute is not valid
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Reconcile(desired []User, opts ReconcileOptions) (*ReconcileResult, *Error) {
+	validAttrs := um.attributes()
+	attrs := opts.Attributes
+	if len(attrs) == 0 {
+		attrs = validAttrs
+	}
+	for _, attr := range attrs {
+		if !slice.EntryExists(validAttrs, attr) {
+			return nil, invalidParameterError(attributesParam, fmt.Sprintf(invalidAttrMsg, attr, validAttrs))
+		}
+	}
+	desiredUsers := make(map[string]bool, len(desired))
+	for _, user := range desired {
+		if user.Uid == "" {
+			return nil, invalidParameterError(desiredParam, missingDesiredUid)
+		}
+		if desiredUsers[strings.ToLower(user.Uid)] {
+			return nil, invalidParameterError(desiredParam, fmt.Sprintf(duplicateUidMsg, user.Uid))
+		}
+		desiredUsers[strings.ToLower(user.Uid)] = true
+	}
+	protected := make(map[string]bool, len(opts.Protected))
+	for _, uid := range opts.Protected {
+		protected[strings.ToLower(uid)] = true
+	}
+
+	users, cErr := um.getAll()
+	if cErr != nil {
+		return nil, cErr
+	}
+	existingUsers := make(map[string]User, len(users))
+	for _, user := range users {
+		existingUsers[strings.ToLower(user.Uid)] = user
+	}
+
+	result := &ReconcileResult{Changes: []UserChange{}}
+	var pending []reconcileUpdate
+	addChange := func(change UserChange, user User) {
+		if protected[strings.ToLower(change.Uid)] {
+			result.Protected = append(result.Protected, change)
+			return
+		}
+		result.Changes = append(result.Changes, change)
+		pending = append(pending, reconcileUpdate{change: len(result.Changes) - 1, user: user})
+	}
+	for _, user := range desired {
+		existing, ok := existingUsers[strings.ToLower(user.Uid)]
+		if !ok {
+			addChange(UserChange{Action: UserChangeCreate, Uid: user.Uid}, user)
+			continue
+		}
+		if changed := changedUserAttributes(existing, user, attrs); len(changed) > 0 {
+			updated := mergeUserAttributes(existing, user, changed)
+			addChange(UserChange{Action: UserChangeUpdate, Uid: existing.Uid, Attributes: changed,
+				Changes: userChangeSet(existing, updated, changed)}, updated)
+		}
+	}
+	for _, user := range users {
+		if !desiredUsers[strings.ToLower(user.Uid)] && user.Status != UserStatusDeleted {
+			deleted := user
+			deleted.Status = UserStatusDeleted
+			addChange(UserChange{Action: UserChangeMarkDeleted, Uid: user.Uid, Attributes: []string{statusAttr},
+				Changes: userChangeSet(user, deleted, []string{statusAttr})}, deleted)
+		}
+	}
+
+	var creates, updates []reconcileUpdate
+	for _, update := range pending {
+		if result.Changes[update.change].Action == UserChangeCreate {
+			creates = append(creates, update)
+		} else {
+			updates = append(updates, update)
+		}
+	}
+	tracker := newProgressTracker(opts.Progress, len(creates)+len(updates))
+	errs := um.Client.bulkRunner().run(len(creates), func(i int) *Error {
+		cErr := um.reconcileCreate(creates[i].user, opts.DryRun)
+		tracker.doneItem(cErr)
+		return cErr
+	}, tracker)
+	for i, create := range creates {
+		result.Changes[create.change].Error = errs[i]
+	}
+	cErr = um.applyReconcileUpdates(result, updates, opts, tracker)
+	result.Cancelled = tracker.isCancelled()
+	return result, cErr
+}
+
+// reconcileCreate creates the desired user or only validates the user in a dry-run.
+func (um *usersManager) reconcileCreate(user User, dryRun bool) *Error {
+	if !dryRun {
+		return um.Create(user)
+	}
+	if cErr := um.validateUser(user); cErr != nil {
+		return cErr
+	}
+	return um.validateUniqueness(user)
+}
+
+// applyReconcileUpdates validates the updates and sends the valid updates to LDAP in batches.
+func (um *usersManager) applyReconcileUpdates(result *ReconcileResult, updates []reconcileUpdate,
+	opts ReconcileOptions, tracker *progressTracker) *Error {
+	var valid []reconcileUpdate
+	for _, update := range updates {
+		change := &result.Changes[update.change]
+		if change.Error = um.validateUpdate(update.user); change.Error == nil {
+			valid = append(valid, update)
+		} else {
+			tracker.doneItem(change.Error)
+		}
+	}
+	if opts.DryRun {
+		tracker.done(len(valid), 0)
+		return nil
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReconcileBatchSize
+	}
+	var batches [][]reconcileUpdate
+	for start := 0; start < len(valid); start += batchSize {
+		batches = append(batches, valid[start:min(start+batchSize, len(valid))])
+	}
+	errs := um.Client.bulkRunner().run(len(batches), func(b int) *Error {
+		batch := batches[b]
+		requests := make([]any, 0, len(batch))
+		for _, update := range batch {
+			change := result.Changes[update.change]
+			requests = append(requests, um.getUpdateRequest(change.Uid, update.user, change.Attributes))
+		}
+		errs, cErr := um.Client.doLDAPBatch(requests)
+		if cErr != nil {
+			tracker.done(len(batch), len(batch))
+			return cErr
+		}
+		failed := 0
+		for i, update := range batch {
+			change := &result.Changes[update.change]
+			if change.Error = errs[i]; change.Error == nil {
+				um.emitChanges(EventUserUpdated, change.Uid, change.Changes)
+			} else {
+				failed++
+			}
+		}
+		tracker.done(len(batch), failed)
+		return nil
+	}, tracker)
+	var batchErr *Error
+	for b, cErr := range errs {
+		switch {
+		case cErr == nil:
+		case cErr.Code == ErrCodeCancelled:
+			for _, update := range batches[b] {
+				result.Changes[update.change].Error = cErr
+			}
+		case batchErr == nil:
+			batchErr = cErr
+		}
+	}
+	return batchErr
+}
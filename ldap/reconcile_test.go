@@ -0,0 +1,150 @@
+package ldap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersManager_Reconcile(t *testing.T) {
+	newUser := User{
+		Uid:          "C00099",
+		AltUid:       "max.doe",
+		Cn:           "Max",
+		Sn:           "Doe",
+		DisplayName:  "Max Doe",
+		Mail:         "max.doe@company.com",
+		UserPassword: "somePassword",
+		Status:       UserStatusActive,
+	}
+	updatedUser := testUser1
+	updatedUser.Mail = "john.doe@example.com"
+	desired := []User{updatedUser, newUser}
+	opts := ReconcileOptions{Protected: []string{"abc_builder"}}
+
+	t.Run("dry run", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		dryRunOpts := opts
+		dryRunOpts.DryRun = true
+		result, cErr := client.Users.(UserProvisioner).Reconcile(desired, dryRunOpts)
+		assert.Nil(t, cErr)
+		markDeleted := ChangeSet{{Attribute: statusAttr, Old: []string{UserStatusActive},
+			New: []string{UserStatusDeleted}}}
+		assert.Equal(t, []UserChange{
+			{Action: UserChangeUpdate, Uid: testUser1.Uid, Attributes: []string{mailAttr},
+				Changes: ChangeSet{{Attribute: mailAttr, Old: []string{testUser1.Mail}, New: []string{updatedUser.Mail}}}},
+			{Action: UserChangeCreate, Uid: newUser.Uid},
+			{Action: UserChangeMarkDeleted, Uid: testUser4.Uid, Attributes: []string{statusAttr}, Changes: markDeleted},
+		}, result.Changes)
+		assert.Equal(t, []UserChange{
+			{Action: UserChangeMarkDeleted, Uid: testUser3.Uid, Attributes: []string{statusAttr}, Changes: markDeleted},
+		}, result.Protected)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		updateRequest := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		updateRequest.Replace(mailAttr, []string{updatedUser.Mail})
+		markDeletedRequest := ldap.NewModifyRequest(um.getDN(testUser4.Uid), nil)
+		markDeletedRequest.Replace(statusAttr, []string{UserStatusDeleted})
+		var events []Event
+		client.On(EventUserUpdated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameAdd, um.getAddRequest(newUser)).Return(nil)
+		ldapMock.On("PasswordModify",
+			um.getPasswordModifyRequest(newUser.Uid, newUser.UserPassword, newUser.UserPassword)).Return(nil, nil)
+		ldapMock.On(methodNameModify, updateRequest).Return(nil)
+		ldapMock.On(methodNameModify, markDeletedRequest).Return(ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		batchOpts := opts
+		batchOpts.BatchSize = 1
+		result, cErr := client.Users.(UserProvisioner).Reconcile(desired, batchOpts)
+		assert.Nil(t, cErr)
+		assert.Len(t, result.Changes, 3)
+		assert.Nil(t, result.Changes[0].Error)
+		assert.Nil(t, result.Changes[1].Error)
+		assert.NotNil(t, result.Changes[2].Error)
+		assert.Len(t, events, 1)
+		assert.Equal(t, testUser1.Uid, events[0].Uid)
+	})
+
+	t.Run("selected attributes", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProvisioner).Reconcile([]User{updatedUser, testUser2, testUser3, testUser4},
+			ReconcileOptions{Attributes: []string{CommonNameAttr}})
+		assert.Nil(t, cErr)
+		assert.Empty(t, result.Changes)
+	})
+
+	t.Run("invalid update", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser1
+		user.Status = "invalid"
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProvisioner).Reconcile([]User{user, testUser2, testUser3, testUser4}, ReconcileOptions{})
+		assert.Nil(t, cErr)
+		assert.Len(t, result.Changes, 1)
+		assert.Equal(t, errors.ErrCodeBadRequest, result.Changes[0].Error.Code)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		_, cErr := client.Users.(UserProvisioner).Reconcile([]User{{}}, ReconcileOptions{})
+		assert.Equal(t, missingDesiredUid, cErr.Message)
+
+		_, cErr = client.Users.(UserProvisioner).Reconcile([]User{testUser1, {Uid: "c00001"}}, ReconcileOptions{})
+		assert.Equal(t, fmt.Sprintf(duplicateUidMsg, "c00001"), cErr.Message)
+
+		_, cErr = client.Users.(UserProvisioner).Reconcile(desired, ReconcileOptions{Attributes: []string{userPasswordAttr}})
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(attributesParam).Code)
+	})
+
+	t.Run("get all error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProvisioner).Reconcile(desired, opts)
+		assert.Nil(t, result)
+		assert.NotNil(t, cErr)
+	})
+}
@@ -0,0 +1,104 @@
+package ldap
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// RedactedValue replaces the secrets in the messages logged and the errors returned by the client.
+const RedactedValue = "[REDACTED]"
+
+// secretAttributes are the attributes whose values are secrets, which are never logged, returned in error messages
+// or read from the accesslog.
+var secretAttributes = []string{
+	userPasswordAttr,
+	"unicodePwd",
+	"sambaNTPassword",
+	"sambaLMPassword",
+	"authPassword",
+}
+
+// redactor replaces secrets in messages with the RedactedValue.
+type redactor []string
+
+// newRedactor returns a redactor for the non-empty secrets. Longer secrets are replaced first, so a secret which
+// contains another secret is replaced as a whole.
+func newRedactor(secrets ...string) redactor {
+	r := redactor{}
+	for _, secret := range secrets {
+		if secret != "" {
+			r = append(r, secret)
+		}
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		return len(r[i]) > len(r[j])
+	})
+	return r
+}
+
+// redact returns the message with every secret replaced by the RedactedValue.
+func (r redactor) redact(message string) string {
+	for _, secret := range r {
+		message = strings.ReplaceAll(message, secret, RedactedValue)
+	}
+	return message
+}
+
+// redactor returns the redactor of the BindPassword set in the client Config and the secrets.
+func (c *Client) redactor(secrets ...string) redactor {
+	return newRedactor(append(secrets, c.getConfig().BindPassword)...)
+}
+
+// isSecretAttribute checks if the attribute is a secret attribute, ignoring the case and the attribute options,
+// e.g. "userPassword;binary".
+func isSecretAttribute(attr string) bool {
+	attr, _, _ = strings.Cut(attr, ";")
+	for _, secret := range secretAttributes {
+		if strings.EqualFold(attr, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedValues returns the values of the attribute, or a RedactedValue per value if the attribute is a secret
+// attribute.
+func redactedValues(attr string, values []string) []string {
+	if !isSecretAttribute(attr) {
+		return values
+	}
+	redacted := make([]string, len(values))
+	for i := range values {
+		redacted[i] = RedactedValue
+	}
+	return redacted
+}
+
+// requestSecrets returns the secrets sent with a go-ldap request, the passwords of a password modify and the values
+// of the secret attributes of an add, a modify or a compare.
+func requestSecrets(request any) []string {
+	var secrets []string
+	switch r := request.(type) {
+	case *ldap.PasswordModifyRequest:
+		secrets = append(secrets, r.OldPassword, r.NewPassword)
+	case *ldap.AddRequest:
+		for _, attribute := range r.Attributes {
+			if isSecretAttribute(attribute.Type) {
+				secrets = append(secrets, attribute.Vals...)
+			}
+		}
+	case *ldap.ModifyRequest:
+		for _, change := range r.Changes {
+			if isSecretAttribute(change.Modification.Type) {
+				secrets = append(secrets, change.Modification.Vals...)
+			}
+		}
+	case *CompareRequest:
+		if isSecretAttribute(r.Attribute) {
+			secrets = append(secrets, r.Value)
+		}
+	}
+	return secrets
+}
@@ -0,0 +1,97 @@
+package ldap
+
+import (
+	err "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// captureLogs returns the messages logged at debug level or above while f runs.
+func captureLogs(t *testing.T, f func()) string {
+	path := filepath.Join(t.TempDir(), "ldap.log")
+	logger.SetLogger(logger.WithLogLevel(logger.LevelDebug), logger.WithOutputPaths([]string{path}))
+	defer logger.SetLogger(logger.WithLogLevel(logger.LevelInfo), logger.WithOutputPaths([]string{"stdout"}))
+	f()
+	logs, readErr := os.ReadFile(path)
+	assert.Nil(t, readErr)
+	return string(logs)
+}
+
+func TestRedaction(t *testing.T) {
+	t.Run("password modify", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		serverErr := ldap.NewError(ldap.LDAPResultOther, err.New("password 'N3w-Secret!' of 'somePassword' rejected"))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).Return(nil, serverErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var cErrMessage string
+		var details *ErrorDetails
+		logs := captureLogs(t, func() {
+			_, cErr := client.Users.SetNewPassword(testUser1.Uid, "N3w-Secret!")
+			cErrMessage = cErr.Message
+			details = cErr.Details
+		})
+		assert.NotContains(t, cErrMessage, "N3w-Secret!")
+		assert.NotContains(t, cErrMessage, "somePassword")
+		assert.Contains(t, cErrMessage, RedactedValue)
+		assert.Equal(t, "password '[REDACTED]' of '[REDACTED]' rejected", details.DiagnosticMessage)
+		assert.NotContains(t, logs, "N3w-Secret!")
+		assert.NotContains(t, logs, "somePassword")
+		assert.Contains(t, logs, RedactedValue)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		serverErr := ldap.NewError(ldap.LDAPResultConstraintViolation, err.New("userPassword: s3cr3t-pass is too weak"))
+		ar := ldap.NewAddRequest("uid=C00001,ou=users,o=company", nil)
+		ar.Attribute(userPasswordAttr, []string{"s3cr3t-pass"})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(serverErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var cErrMessage string
+		logs := captureLogs(t, func() {
+			cErrMessage = client.doLDAPAdd(ar).Message
+		})
+		assert.Equal(t, "LDAP Result Code 19 \"Constraint Violation\": userPassword: [REDACTED] is too weak", cErrMessage)
+		assert.NotContains(t, logs, "s3cr3t-pass")
+	})
+
+	t.Run("operation request", func(t *testing.T) {
+		pmr := ldap.NewPasswordModifyRequest("uid=C00001,ou=users,o=company", "0ld-Secret", "N3w-Secret")
+		req := &OperationRequest{Name: OperationPasswordModify, Request: pmr}
+
+		assert.Equal(t, "passwordModify dn='uid=C00001,ou=users,o=company'", req.String())
+	})
+}
+
+func TestRequestSecrets(t *testing.T) {
+	mr := ldap.NewModifyRequest("uid=C00001,ou=users,o=company", nil)
+	mr.Replace(mailAttr, []string{"john.doe@company.com"})
+	mr.Replace("unicodePwd;binary", []string{"secret"})
+
+	assert.Equal(t, []string{"secret"}, requestSecrets(mr))
+	assert.Equal(t, []string{"old", "new"}, requestSecrets(ldap.NewPasswordModifyRequest("", "old", "new")))
+	assert.Equal(t, []string{"secret"}, requestSecrets(&CompareRequest{Attribute: userPasswordAttr, Value: "secret"}))
+	assert.Empty(t, requestSecrets(&CompareRequest{Attribute: uniqueMemberAttr, Value: "uid=C00001"}))
+}
+
+func TestRedactor(t *testing.T) {
+	r := newRedactor("", "pass", "password")
+
+	assert.Equal(t, "[REDACTED] and [REDACTED]", r.redact("password and pass"))
+	assert.Equal(t, []string{RedactedValue, RedactedValue}, redactedValues("userpassword", []string{"a", "b"}))
+	assert.Equal(t, []string{"a"}, redactedValues(mailAttr, []string{"a"}))
+}
@@ -0,0 +1,184 @@
+package ldap
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	contextCSNAttr = "contextCSN"
+
+	// csnTimeLayout is the layout of the timestamp of a change sequence number, e.g.
+	// 20240101120000.123456Z#000000#001#000000.
+	csnTimeLayout = "20060102150405.000000Z"
+	csnSeparator  = "#"
+)
+
+type (
+	// ReplicationReport represents the replication state of the replicas of a directory.
+	ReplicationReport struct {
+		// InSync is true if all the replicas could be read and hold the newest change of every provider.
+		InSync bool `json:"inSync"`
+		// MaxLag is the largest Lag of the replicas.
+		MaxLag   time.Duration   `json:"maxLag"`
+		Replicas []ReplicaStatus `json:"replicas"`
+	}
+
+	// ReplicaStatus represents the replication state of a replica.
+	ReplicaStatus struct {
+		Name string `json:"name"`
+		// ContextCSNs are the contextCSN values of the suffix entry of the replica, one per provider.
+		ContextCSNs []string `json:"contextCSNs,omitempty"`
+		// InSync is true if the replica holds the newest change of every provider.
+		InSync bool `json:"inSync"`
+		// Lag is the time between the newest change of a provider and the newest change of the same provider the
+		// replica holds, for the provider with the largest difference.
+		Lag time.Duration `json:"lag"`
+		// Missing are the server ids of the providers of which the replica holds no change.
+		Missing []string `json:"missing,omitempty"`
+		// Error is set if the contextCSN of the replica could not be read.
+		Error *Error `json:"error,omitempty"`
+	}
+
+	// changeSequenceNumber is a parsed contextCSN value.
+	changeSequenceNumber struct {
+		value string
+		time  time.Time
+	}
+)
+
+// CheckReplication compares the contextCSN values of the suffix entry, the BaseDN set in the Config of the clients,
+// read from every replica and reports the replication lag and the divergence of the replicas.
+// params:
+//
+//	replicas = the clients of the replicas keyed by a name, e.g. the hostname
+//
+// The replicas are read concurrently. A replica which cannot be read is reported with the error and is not in sync.
+// The contextCSN holds the change sequence number of the newest change of every provider, so a replica is in sync if
+// it holds the newest change sequence number of every provider found on any of the replicas.
+func CheckReplication(replicas map[string]*Client) *ReplicationReport {
+	report := &ReplicationReport{InSync: true, Replicas: make([]ReplicaStatus, len(replicas))}
+	names := make([]string, 0, len(replicas))
+	for name := range replicas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	csns := make([]map[string]changeSequenceNumber, len(names))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, name := range names {
+		report.Replicas[i].Name = name
+		wg.Add(1)
+		go func(i int, client *Client) {
+			defer wg.Done()
+			values, cErr := client.ContextCSN()
+			mu.Lock()
+			defer mu.Unlock()
+			report.Replicas[i].ContextCSNs = values
+			report.Replicas[i].Error = cErr
+			csns[i] = parseContextCSNs(values)
+		}(i, replicas[name])
+	}
+	wg.Wait()
+
+	newest := map[string]changeSequenceNumber{}
+	for i := range names {
+		for serverId, csn := range csns[i] {
+			if current, ok := newest[serverId]; !ok || csn.value > current.value {
+				newest[serverId] = csn
+			}
+		}
+	}
+	for i := range report.Replicas {
+		replica := &report.Replicas[i]
+		replica.InSync = replica.Error == nil
+		for serverId, newestCSN := range newest {
+			csn, ok := csns[i][serverId]
+			switch {
+			case !ok:
+				replica.Missing = append(replica.Missing, serverId)
+				replica.InSync = false
+			case csn.value != newestCSN.value:
+				replica.Lag = max(replica.Lag, newestCSN.time.Sub(csn.time))
+				replica.InSync = false
+			}
+		}
+		sort.Strings(replica.Missing)
+		report.MaxLag = max(report.MaxLag, replica.Lag)
+		report.InSync = report.InSync && replica.InSync
+	}
+	return report
+}
+
+// CheckReplication compares the contextCSN values read from the replicas with the names, or from all the profiles if
+// no names are given, see CheckReplication.
+// The method returns an error if a profile does not exist.
+func (cs *ClientSet) CheckReplication(names ...string) (*ReplicationReport, *Error) {
+	if len(names) == 0 {
+		names = cs.Names()
+	}
+	replicas := make(map[string]*Client, len(names))
+	for _, name := range names {
+		client, cErr := cs.Get(name)
+		if cErr != nil {
+			return nil, cErr
+		}
+		replicas[name] = client
+	}
+	return CheckReplication(replicas), nil
+}
+
+// ContextCSN reads the contextCSN values of the suffix entry, the BaseDN set in the client Config. Every value is
+// the change sequence number of the newest change of a provider.
+// The method returns an error:
+//   - if the suffix entry is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (c *Client) ContextCSN() ([]string, *Error) {
+	result, cErr := c.doLDAPSearch(c.getContextCSNSearchRequest())
+	if cErr != nil {
+		return nil, cErr
+	}
+	if len(result.Entries) == 0 {
+		return nil, nil
+	}
+	return result.Entries[0].GetAttributeValues(contextCSNAttr), nil
+}
+
+// getContextCSNSearchRequest returns a ldap search request to read the contextCSN of the suffix entry.
+func (c *Client) getContextCSNSearchRequest() *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		c.getConfig().BaseDN,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		allEntriesFilter,
+		[]string{contextCSNAttr},
+		nil,
+	)
+}
+
+// parseContextCSNs parses the contextCSN values keyed by the server id of the provider. The values which cannot be
+// parsed are ignored.
+func parseContextCSNs(values []string) map[string]changeSequenceNumber {
+	csns := make(map[string]changeSequenceNumber, len(values))
+	for _, value := range values {
+		parts := strings.Split(value, csnSeparator)
+		if len(parts) != 4 {
+			continue
+		}
+		csnTime, err := time.Parse(csnTimeLayout, parts[0])
+		if err != nil {
+			continue
+		}
+		csns[parts[2]] = changeSequenceNumber{value: value, time: csnTime}
+	}
+	return csns
+}
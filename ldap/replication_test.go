@@ -0,0 +1,121 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCSNProvider1 = "20261016120000.000000Z#000000#001#000000"
+	testCSNProvider2 = "20261016110000.000000Z#000000#002#000000"
+	testCSNLagging   = "20261016115950.000000Z#000000#001#000000"
+)
+
+// getReplicaClient returns a client of a replica which returns the contextCSN values.
+func getReplicaClient(t *testing.T, values []string, err error) *Client {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	var result *ldap.SearchResult
+	if err == nil {
+		result = &ldap.SearchResult{Entries: []*ldap.Entry{
+			ldap.NewEntry(testConfig.BaseDN, map[string][]string{contextCSNAttr: values}),
+		}}
+	}
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, client.getContextCSNSearchRequest()).Return(result, err)
+	ldapMock.On(methodNameClose).Return(nil)
+	return client
+}
+
+func TestClient_ContextCSN(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client := getReplicaClient(t, []string{testCSNProvider1, testCSNProvider2}, nil)
+
+		values, cErr := client.ContextCSN()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []string{testCSNProvider1, testCSNProvider2}, values)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := getReplicaClient(t, nil, ldapInsufficientRightsErr)
+
+		values, cErr := client.ContextCSN()
+		assert.Nil(t, values)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+}
+
+func TestCheckReplication(t *testing.T) {
+	t.Run("in sync", func(t *testing.T) {
+		report := CheckReplication(map[string]*Client{
+			"ldap1": getReplicaClient(t, []string{testCSNProvider1, testCSNProvider2}, nil),
+			"ldap2": getReplicaClient(t, []string{testCSNProvider2, testCSNProvider1}, nil),
+		})
+		assert.True(t, report.InSync)
+		assert.Equal(t, time.Duration(0), report.MaxLag)
+		assert.Equal(t, "ldap1", report.Replicas[0].Name)
+		assert.True(t, report.Replicas[0].InSync)
+		assert.True(t, report.Replicas[1].InSync)
+	})
+
+	t.Run("lagging and diverged replicas", func(t *testing.T) {
+		report := CheckReplication(map[string]*Client{
+			"ldap1": getReplicaClient(t, []string{testCSNProvider1, testCSNProvider2}, nil),
+			"ldap2": getReplicaClient(t, []string{testCSNLagging, testCSNProvider2}, nil),
+			"ldap3": getReplicaClient(t, []string{testCSNProvider1}, nil),
+		})
+		assert.False(t, report.InSync)
+		assert.Equal(t, 10*time.Second, report.MaxLag)
+		assert.True(t, report.Replicas[0].InSync)
+		assert.False(t, report.Replicas[1].InSync)
+		assert.Equal(t, 10*time.Second, report.Replicas[1].Lag)
+		assert.Empty(t, report.Replicas[1].Missing)
+		assert.False(t, report.Replicas[2].InSync)
+		assert.Equal(t, []string{"002"}, report.Replicas[2].Missing)
+	})
+
+	t.Run("unreachable replica", func(t *testing.T) {
+		report := CheckReplication(map[string]*Client{
+			"ldap1": getReplicaClient(t, []string{testCSNProvider1}, nil),
+			"ldap2": getReplicaClient(t, nil, ldapNetworkErr),
+		})
+		assert.False(t, report.InSync)
+		assert.True(t, report.Replicas[0].InSync)
+		assert.False(t, report.Replicas[1].InSync)
+		assert.NotNil(t, report.Replicas[1].Error)
+		assert.Equal(t, []string{"001"}, report.Replicas[1].Missing)
+	})
+}
+
+func TestClientSet_CheckReplication(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cs := NewClientSet(ClientSetConfig{})
+		cs.Add("ldap1", getReplicaClient(t, []string{testCSNProvider1}, nil))
+		cs.Add("ldap2", getReplicaClient(t, []string{testCSNProvider1}, nil))
+
+		report, cErr := cs.CheckReplication()
+		assert.Nil(t, cErr)
+		assert.True(t, report.InSync)
+		assert.Len(t, report.Replicas, 2)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		cs := NewClientSet(ClientSetConfig{})
+
+		report, cErr := cs.CheckReplication("unknown")
+		assert.Nil(t, report)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestParseContextCSNs(t *testing.T) {
+	csns := parseContextCSNs([]string{testCSNProvider1, "invalid", "2026#000000#003#000000"})
+	assert.Len(t, csns, 1)
+	assert.Equal(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), csns["001"].time)
+}
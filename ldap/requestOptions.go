@@ -0,0 +1,75 @@
+package ldap
+
+import (
+	"math"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// WithScope sets the scope of an LDAP search or paged search request, one of ldap.ScopeBaseObject,
+// ldap.ScopeSingleLevel or ldap.ScopeWholeSubtree, e.g. ldap.ScopeSingleLevel to only retrieve the groups directly
+// within an organizational unit instead of the whole subtree. Other requests are left unchanged.
+func WithScope(scope int) RequestOption {
+	return func(req *OperationRequest) {
+		if sr := searchRequestOf(req); sr != nil {
+			sr.Scope = scope
+		}
+	}
+}
+
+// WithTimeout overrides the RequestTimeout set in the client Config for a single LDAP operation, e.g. to fail fast
+// on a latency sensitive path. The timeout is also sent to the server as the time limit of search requests.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(req *OperationRequest) {
+		req.Timeout = timeout
+		if sr := searchRequestOf(req); sr != nil && timeout > 0 {
+			sr.TimeLimit = int(math.Ceil(timeout.Seconds()))
+		}
+	}
+}
+
+// WithDerefAliases sets the alias dereferencing policy of an LDAP search or paged search request, one of
+// ldap.NeverDerefAliases, ldap.DerefInSearching, ldap.DerefFindingBaseObj or ldap.DerefAlways, overriding the
+// DerefAliases set in the client Config, e.g. ldap.NeverDerefAliases to read an alias entry itself.
+// Other requests are left unchanged.
+func WithDerefAliases(derefAliases int) RequestOption {
+	return func(req *OperationRequest) {
+		if sr := searchRequestOf(req); sr != nil {
+			sr.DerefAliases = derefAliases
+			req.DerefAliases = &derefAliases
+		}
+	}
+}
+
+// WithAttributes sets the attributes retrieved by an LDAP search or paged search request, e.g. to only retrieve the
+// mail of a user with UsersReader.GetWithOptions. The fields of the entries which are not retrieved are left empty.
+// Other requests are left unchanged.
+func WithAttributes(attributes ...string) RequestOption {
+	return func(req *OperationRequest) {
+		if sr := searchRequestOf(req); sr != nil {
+			sr.Attributes = attributes
+		}
+	}
+}
+
+// WithBaseDN sets the base dn of an LDAP search or paged search request, e.g. to only search the users within a
+// sub-container of the UserBaseDN. Other requests are left unchanged.
+func WithBaseDN(baseDN string) RequestOption {
+	return func(req *OperationRequest) {
+		if sr := searchRequestOf(req); sr != nil {
+			sr.BaseDN = baseDN
+		}
+	}
+}
+
+// searchRequestOf returns the search request of an LDAP search or paged search request or nil for other requests.
+func searchRequestOf(req *OperationRequest) *ldap.SearchRequest {
+	switch r := req.Request.(type) {
+	case *ldap.SearchRequest:
+		return r
+	case *PagedSearchRequest:
+		return r.SearchRequest
+	}
+	return nil
+}
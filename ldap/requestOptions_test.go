@@ -0,0 +1,128 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithScope(t *testing.T) {
+	t.Run("search request", func(t *testing.T) {
+		req := &OperationRequest{Request: &ldap.SearchRequest{Scope: ldap.ScopeWholeSubtree}}
+		WithScope(ldap.ScopeSingleLevel)(req)
+		assert.Equal(t, ldap.ScopeSingleLevel, req.Request.(*ldap.SearchRequest).Scope)
+	})
+
+	t.Run("paged search request", func(t *testing.T) {
+		req := &OperationRequest{Request: &PagedSearchRequest{SearchRequest: &ldap.SearchRequest{}}}
+		WithScope(ldap.ScopeBaseObject)(req)
+		assert.Equal(t, ldap.ScopeBaseObject, req.Request.(*PagedSearchRequest).SearchRequest.Scope)
+	})
+
+	t.Run("other request", func(t *testing.T) {
+		dr := ldap.NewDelRequest(testConfig.UserBaseDN, nil)
+		WithScope(ldap.ScopeSingleLevel)(&OperationRequest{Request: dr})
+		assert.Equal(t, ldap.NewDelRequest(testConfig.UserBaseDN, nil), dr)
+	})
+}
+
+func TestWithDerefAliases(t *testing.T) {
+	t.Run("search request", func(t *testing.T) {
+		req := &OperationRequest{Request: &ldap.SearchRequest{DerefAliases: ldap.DerefAlways}}
+		WithDerefAliases(ldap.NeverDerefAliases)(req)
+		assert.Equal(t, ldap.NeverDerefAliases, req.Request.(*ldap.SearchRequest).DerefAliases)
+		assert.Equal(t, ldap.NeverDerefAliases, *req.DerefAliases)
+	})
+
+	t.Run("other request", func(t *testing.T) {
+		req := &OperationRequest{Request: ldap.NewDelRequest(testConfig.UserBaseDN, nil)}
+		WithDerefAliases(ldap.DerefAlways)(req)
+		assert.Equal(t, ldap.NewDelRequest(testConfig.UserBaseDN, nil), req.Request)
+		assert.Nil(t, req.DerefAliases)
+	})
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("search request", func(t *testing.T) {
+		req := &OperationRequest{Request: &ldap.SearchRequest{}}
+		WithTimeout(1500 * time.Millisecond)(req)
+		assert.Equal(t, 1500*time.Millisecond, req.Timeout)
+		assert.Equal(t, 2, req.Request.(*ldap.SearchRequest).TimeLimit)
+	})
+
+	t.Run("modify request", func(t *testing.T) {
+		req := &OperationRequest{Request: ldap.NewModifyRequest(testConfig.UserBaseDN, nil)}
+		WithTimeout(time.Second)(req)
+		assert.Equal(t, time.Second, req.Timeout)
+	})
+
+	t.Run("connection timeout", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		expected := um.getUserSearchRequest(um.getDN(testUser1.Uid))
+		expected.TimeLimit = 2
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSetTimeout, 2*time.Second).Return()
+		ldapMock.On(methodNameSearch, expected).Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		user, cErr := client.Users.(UsersReader).GetWithOptions(testUser1.Uid, WithTimeout(2*time.Second))
+		assert.Nil(t, cErr)
+		assert.Equal(t, testUser1.Uid, user.Uid)
+	})
+}
+
+func TestWithAttributes(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	um := usersManager{Client: client}
+	expected := um.getUserSearchRequest(um.getDN(testUser1.Uid))
+	expected.Attributes = []string{mailAttr}
+	result := &ldap.SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry(um.getDN(testUser1.Uid), map[string][]string{mailAttr: {testUser1.Mail}}),
+	}}
+
+	ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, expected).Return(result, nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	user, cErr := client.Users.(UsersReader).GetWithOptions(testUser1.Uid, WithAttributes(mailAttr))
+	assert.Nil(t, cErr)
+	assert.Equal(t, testUser1.Mail, user.Mail)
+	assert.Empty(t, user.DisplayName)
+}
+
+func TestWithBaseDN(t *testing.T) {
+	t.Run("search request", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		expected := um.getUsersSearchRequest(userSearchFilter)
+		expected.BaseDN = "ou=contractors," + testConfig.UserBaseDN
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, expected).Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.(UsersReader).GetAllWithOptions(WithBaseDN("ou=contractors," + testConfig.UserBaseDN))
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 4)
+	})
+
+	t.Run("paged search request", func(t *testing.T) {
+		req := &OperationRequest{Request: &PagedSearchRequest{SearchRequest: &ldap.SearchRequest{}}}
+		WithBaseDN(testConfig.GroupBaseDN)(req)
+		assert.Equal(t, testConfig.GroupBaseDN, req.Request.(*PagedSearchRequest).SearchRequest.BaseDN)
+	})
+
+	t.Run("other request", func(t *testing.T) {
+		dr := ldap.NewDelRequest(testConfig.UserBaseDN, nil)
+		WithBaseDN(testConfig.GroupBaseDN)(&OperationRequest{Request: dr})
+		assert.Equal(t, testConfig.UserBaseDN, dr.DN)
+	})
+}
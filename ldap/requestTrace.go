@@ -0,0 +1,155 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const requestTraceMsg = "LDAP %s request: %s"
+
+type (
+	// RequestTrace describes an LDAP request built by the client, as it is sent to the LDAP server. The values of the
+	// attributes are never part of a trace, so passwords and other secrets are not exposed.
+	RequestTrace struct {
+		// Operation is the name of the operation, e.g. OperationSearch. The pages of a paged search are traced as
+		// OperationPagedSearch and the requests of a batch as the operation of the request.
+		Operation string `json:"operation"`
+		// Dn is the base dn of a search, the user identity of a password modify or the dn of the entry otherwise.
+		Dn string `json:"dn"`
+		// Scope is the scope of a search, e.g. "Whole Subtree".
+		Scope     string `json:"scope,omitempty"`
+		Filter    string `json:"filter,omitempty"`
+		SizeLimit int    `json:"sizeLimit,omitempty"`
+		TimeLimit int    `json:"timeLimit,omitempty"`
+		// Attributes are the attributes retrieved by a search, the attributes of the entry added, the attributes
+		// changed by a modify or the attribute compared.
+		Attributes []string `json:"attributes,omitempty"`
+		// Controls are the descriptions of the controls sent with the request, e.g. "Paging".
+		Controls []string `json:"controls,omitempty"`
+		// NewRDN and NewSuperior are the new relative dn and the new parent of the entry of a modify dn.
+		NewRDN      string `json:"newRDN,omitempty"`
+		NewSuperior string `json:"newSuperior,omitempty"`
+	}
+
+	// RequestTracer is invoked with the trace of every LDAP request before it is sent to the LDAP server.
+	RequestTracer func(trace RequestTrace)
+)
+
+// WithRequestTracer sets the RequestTracer invoked with the trace of every LDAP request built by the client before
+// it is sent to the LDAP server, e.g. to find out why a search does not return the expected entries. The tracer is
+// invoked for every page of a paged search and every request of a batch. See also DebugRequests in the client Config
+// to log the requests instead.
+func WithRequestTracer(tracer RequestTracer) ClientOption {
+	return func(c *Client) {
+		c.requestTracer = tracer
+	}
+}
+
+// String returns the trace as a single line, e.g. to log it.
+func (t RequestTrace) String() string {
+	parts := []string{fmt.Sprintf("dn='%s'", t.Dn)}
+	if t.Scope != "" {
+		parts = append(parts, fmt.Sprintf("scope='%s'", t.Scope))
+	}
+	if t.Filter != "" {
+		parts = append(parts, fmt.Sprintf("filter='%s'", t.Filter))
+	}
+	if t.SizeLimit > 0 {
+		parts = append(parts, fmt.Sprintf("sizeLimit=%d", t.SizeLimit))
+	}
+	if t.TimeLimit > 0 {
+		parts = append(parts, fmt.Sprintf("timeLimit=%d", t.TimeLimit))
+	}
+	if len(t.Attributes) > 0 {
+		parts = append(parts, fmt.Sprintf("attributes=%v", t.Attributes))
+	}
+	if len(t.Controls) > 0 {
+		parts = append(parts, fmt.Sprintf("controls=%v", t.Controls))
+	}
+	if t.NewRDN != "" {
+		parts = append(parts, fmt.Sprintf("newRDN='%s'", t.NewRDN))
+	}
+	if t.NewSuperior != "" {
+		parts = append(parts, fmt.Sprintf("newSuperior='%s'", t.NewSuperior))
+	}
+	return strings.Join(parts, " ")
+}
+
+// traceRequest logs the request if DebugRequests is set in the client Config and invokes the RequestTracer set on
+// the client, if any.
+func (c *Client) traceRequest(operation string, request any) {
+	debug := c.getConfig().DebugRequests
+	if !debug && c.requestTracer == nil {
+		return
+	}
+	trace, ok := newRequestTrace(operation, request)
+	if !ok {
+		return
+	}
+	if debug {
+		logger.Debugf(requestTraceMsg, operation, trace)
+	}
+	if c.requestTracer != nil {
+		c.requestTracer(trace)
+	}
+}
+
+// newRequestTrace returns the trace of a go-ldap request and whether the request can be traced. The paged searches
+// and the batches are not traced as a whole, their requests are traced when they are sent.
+func newRequestTrace(operation string, request any) (RequestTrace, bool) {
+	trace := RequestTrace{Operation: operation}
+	switch r := request.(type) {
+	case *ldap.SearchRequest:
+		trace.Dn = r.BaseDN
+		trace.Scope = ldap.ScopeMap[r.Scope]
+		trace.Filter = r.Filter
+		trace.SizeLimit = r.SizeLimit
+		trace.TimeLimit = r.TimeLimit
+		trace.Attributes = r.Attributes
+		trace.Controls = controlDescriptions(r.Controls)
+	case *ldap.AddRequest:
+		trace.Dn = r.DN
+		for _, attribute := range r.Attributes {
+			trace.Attributes = append(trace.Attributes, attribute.Type)
+		}
+		trace.Controls = controlDescriptions(r.Controls)
+	case *ldap.DelRequest:
+		trace.Dn = r.DN
+		trace.Controls = controlDescriptions(r.Controls)
+	case *ldap.ModifyRequest:
+		trace.Dn = r.DN
+		for _, change := range r.Changes {
+			trace.Attributes = append(trace.Attributes, change.Modification.Type)
+		}
+		trace.Controls = controlDescriptions(r.Controls)
+	case *ldap.ModifyDNRequest:
+		trace.Dn = r.DN
+		trace.NewRDN = r.NewRDN
+		trace.NewSuperior = r.NewSuperior
+		trace.Controls = controlDescriptions(r.Controls)
+	case *ldap.PasswordModifyRequest:
+		trace.Dn = r.UserIdentity
+	case *CompareRequest:
+		trace.Dn = r.DN
+		trace.Attributes = []string{r.Attribute}
+	default:
+		return trace, false
+	}
+	return trace, true
+}
+
+// controlDescriptions returns the descriptions of the controls, or their OIDs if they are unknown.
+func controlDescriptions(controls []ldap.Control) []string {
+	var descriptions []string
+	for _, control := range controls {
+		description, ok := ldap.ControlTypeMap[control.GetControlType()]
+		if !ok || description == "" {
+			description = control.GetControlType()
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
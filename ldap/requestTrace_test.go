@@ -0,0 +1,121 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithRequestTracer(t *testing.T) {
+	t.Run("search", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var traces []RequestTrace
+		config := testConfig
+		config.DebugRequests = true
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting(),
+			WithRequestTracer(func(trace RequestTrace) { traces = append(traces, trace) }))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(&ldap.SearchResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.(UsersReader).FilterWithOptions(userIdAttr, "C0*", WithMaxResults(10))
+		assert.Nil(t, cErr)
+		assert.Len(t, traces, 1)
+		assert.Equal(t, OperationSearch, traces[0].Operation)
+		assert.Equal(t, testConfig.UserBaseDN, traces[0].Dn)
+		assert.Equal(t, "Whole Subtree", traces[0].Scope)
+		assert.Contains(t, traces[0].Filter, "(uid=C0*)")
+		assert.Equal(t, 11, traces[0].SizeLimit)
+		assert.Contains(t, traces[0].Attributes, userIdAttr)
+	})
+
+	t.Run("paged search", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var traces []RequestTrace
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithRequestTracer(func(trace RequestTrace) { traces = append(traces, trace) }))
+		firstPage := &ldap.SearchResult{}
+		control := ldap.NewControlPaging(0)
+		control.SetCookie([]byte("page-2"))
+		firstPage.Controls = []ldap.Control{control}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(firstPage, nil).Once()
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{}, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.(UserLifecycleManager).Inactive(testTombstonesSince)
+		assert.Nil(t, cErr)
+		assert.Len(t, traces, 2)
+		assert.Equal(t, OperationPagedSearch, traces[1].Operation)
+		assert.Equal(t, []string{"Paging"}, traces[1].Controls)
+	})
+
+	t.Run("modify", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		var traces []RequestTrace
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithRequestTracer(func(trace RequestTrace) { traces = append(traces, trace) }))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserHierarchyManager).SetManager(testUser1.Uid, ""))
+		assert.Equal(t, []RequestTrace{{
+			Operation:  OperationModify,
+			Dn:         "uid=C00001,ou=users,o=company",
+			Attributes: []string{managerAttr},
+		}}, traces)
+	})
+}
+
+func TestNewRequestTrace(t *testing.T) {
+	t.Run("add", func(t *testing.T) {
+		ar := ldap.NewAddRequest("uid=C00001,ou=users,o=company", nil)
+		ar.Attribute(userIdAttr, []string{"C00001"})
+		ar.Attribute(userPasswordAttr, []string{"secret"})
+
+		trace, ok := newRequestTrace(OperationAdd, ar)
+		assert.True(t, ok)
+		assert.Equal(t, []string{userIdAttr, userPasswordAttr}, trace.Attributes)
+		assert.NotContains(t, trace.String(), "secret")
+	})
+
+	t.Run("modify dn", func(t *testing.T) {
+		mdr := ldap.NewModifyDNRequest("uid=C00001,ou=users,o=company", "uid=C00001", true, "ou=archive,o=company")
+
+		trace, ok := newRequestTrace(OperationModifyDN, mdr)
+		assert.True(t, ok)
+		assert.Equal(t, "dn='uid=C00001,ou=users,o=company' newRDN='uid=C00001' newSuperior='ou=archive,o=company'",
+			trace.String())
+	})
+
+	t.Run("password modify", func(t *testing.T) {
+		pmr := ldap.NewPasswordModifyRequest("uid=C00001,ou=users,o=company", "old", "new")
+
+		trace, ok := newRequestTrace(OperationPasswordModify, pmr)
+		assert.True(t, ok)
+		assert.Equal(t, "dn='uid=C00001,ou=users,o=company'", trace.String())
+	})
+
+	t.Run("search", func(t *testing.T) {
+		sr := ldap.NewSearchRequest("ou=users,o=company", ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+			"(objectClass=*)", []string{userIdAttr}, []ldap.Control{ldap.NewControlManageDsaIT(false)})
+
+		trace, ok := newRequestTrace(OperationSearch, sr)
+		assert.True(t, ok)
+		assert.Equal(t, "dn='ou=users,o=company' scope='Base Object' filter='(objectClass=*)' attributes=[uid] "+
+			"controls=[Manage DSA IT]", trace.String())
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		_, ok := newRequestTrace(OperationBatch, &BatchRequest{})
+		assert.False(t, ok)
+	})
+}
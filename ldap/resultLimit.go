@@ -0,0 +1,76 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ErrCodeTooManyResults is the code of the error returned by a search which matches more entries than the
+	// MaxResults set in the client Config, see Error.TooManyResults.
+	ErrCodeTooManyResults = "LDAP_TOO_MANY_RESULTS"
+
+	tooManyResultsErrMsg = "The search matched more than %d entries, narrow the search filter"
+)
+
+type (
+	// TooManyResultsError holds the details of an error returned by a search which matches more entries than the
+	// MaxResults set in the client Config. The details are set as the TooManyResults of the Error.
+	TooManyResultsError struct {
+		// MaxResults is the maximum number of entries the search was allowed to return.
+		MaxResults int `json:"maxResults"`
+		// Count is the number of entries received before the search was stopped, which is more than MaxResults.
+		// The search may match even more entries.
+		Count int `json:"count"`
+	}
+)
+
+// WithMaxResults overrides the MaxResults set in the client Config for a single LDAP search or paged search, e.g.
+// to export all the entries of a large directory. A negative value removes the limit.
+// Other requests are left unchanged.
+func WithMaxResults(maxResults int) RequestOption {
+	return func(req *OperationRequest) {
+		req.MaxResults = maxResults
+	}
+}
+
+// Error returns the message of the error returned by the search, so the details can be retrieved from an Error with
+// errors.As.
+func (e *TooManyResultsError) Error() string {
+	return fmt.Sprintf(tooManyResultsErrMsg, e.MaxResults)
+}
+
+// maxResults returns the maximum number of entries the search of the request is allowed to return or zero if the
+// number of entries is not limited.
+func (c *Client) maxResults(req *OperationRequest) int {
+	maxResults := c.getConfig().MaxResults
+	if req.MaxResults != 0 {
+		maxResults = req.MaxResults
+	}
+	if maxResults < 0 {
+		return 0
+	}
+	return maxResults
+}
+
+// applySizeLimit sets the size limit of a search request to one entry more than maxResults, so the LDAP server stops
+// the search as soon as the limit is exceeded, unless a lower size limit is already set on the request.
+func applySizeLimit(sr *ldap.SearchRequest, maxResults int) {
+	if maxResults > 0 && (sr.SizeLimit == 0 || sr.SizeLimit > maxResults) {
+		sr.SizeLimit = maxResults + 1
+	}
+}
+
+// checkResultLimit returns an error if the count of entries exceeds maxResults.
+func checkResultLimit(maxResults, count int) *Error {
+	if maxResults <= 0 || count <= maxResults {
+		return nil
+	}
+	cErr := WrapError(errors.New(ErrCodeTooManyResults, http.StatusUnprocessableEntity,
+		fmt.Sprintf(tooManyResultsErrMsg, maxResults)))
+	cErr.TooManyResults = &TooManyResultsError{MaxResults: maxResults, Count: count}
+	return cErr
+}
@@ -0,0 +1,111 @@
+package ldap
+
+import (
+	err "errors"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClient_MaxResults(t *testing.T) {
+	testMaxResultsConfig := testConfig
+	testMaxResultsConfig.MaxResults = 1
+	twoUsersResult := &ldap.SearchResult{Entries: []*ldap.Entry{
+		getUserLDAPEntry(testUser1),
+		getUserLDAPEntry(testUser3),
+	}}
+
+	t.Run("search exceeds the limit", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testMaxResultsConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				assert.Equal(t, 2, args.Get(0).(*ldap.SearchRequest).SizeLimit)
+			}).
+			Return(twoUsersResult, ldap.NewError(ldap.LDAPResultSizeLimitExceeded, nil))
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.GetAll()
+		assert.Nil(t, users)
+		assert.Equal(t, ErrCodeTooManyResults, cErr.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, cErr.Status)
+		assert.Equal(t, &TooManyResultsError{MaxResults: 1, Count: 2}, cErr.TooManyResults)
+	})
+
+	t.Run("search within the limit", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testMaxResultsConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: twoUsersResult.Entries[:1]}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.GetAll()
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 1)
+	})
+
+	t.Run("limit removed for a single search", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testMaxResultsConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				assert.Equal(t, 0, args.Get(0).(*ldap.SearchRequest).SizeLimit)
+			}).
+			Return(twoUsersResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.(UsersReader).GetAllWithOptions(WithMaxResults(-1))
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 2)
+	})
+
+	t.Run("paged search exceeds the limit", func(t *testing.T) {
+		config := testConfig
+		config.MaxResults = 2
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		var pageSizes []uint32
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				paging := ldap.FindControl(sr.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+				pageSizes = append(pageSizes, paging.PagingSize)
+			}).
+			Return(getPagedSearchResult("page", "uid=1", "uid=2"), nil).Times(3)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var pages int
+		cErr := client.doLDAPPagedSearch(ldap.NewSearchRequest(testConfig.UserBaseDN, ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases, 0, 0, false, userSearchFilter, nil, nil), 2,
+			func(result *ldap.SearchResult) *Error {
+				pages++
+				return nil
+			})
+		assert.Equal(t, ErrCodeTooManyResults, cErr.Code)
+		assert.Equal(t, 4, cErr.TooManyResults.Count)
+		assert.Equal(t, 1, pages)
+		assert.Equal(t, []uint32{2, 2, 0}, pageSizes)
+	})
+}
+
+func TestTooManyResultsError(t *testing.T) {
+	var tooManyResultsErr *TooManyResultsError
+	assert.True(t, err.As(checkResultLimit(2, 3), &tooManyResultsErr))
+	assert.Equal(t, &TooManyResultsError{MaxResults: 2, Count: 3}, tooManyResultsErr)
+	assert.Equal(t, "The search matched more than 2 entries, narrow the search filter", tooManyResultsErr.Error())
+
+	assert.Nil(t, WrapError(errors.BadRequestError("invalid")).TooManyResults)
+}
@@ -0,0 +1,60 @@
+package ldap
+
+import (
+	"github.com/go-ldap/ldap/v3"
+)
+
+// searchFilterParam is the name of the search filter parameter used in validation errors.
+const searchFilterParam = "searchFilter"
+
+type (
+	// ResultControls holds the response controls and the referrals returned by the LDAP server with the result of
+	// a search, e.g. the paging cookie, the password policy response or the server side sorting result.
+	ResultControls struct {
+		Controls  []ldap.Control
+		Referrals []string
+	}
+
+	// UsersResult represents the user entries found by a search together with the ResultControls of the search.
+	UsersResult struct {
+		Users []User
+		ResultControls
+	}
+
+	// GroupsResult represents the group entries found by a search together with the ResultControls of the search.
+	GroupsResult struct {
+		Groups []Group
+		ResultControls
+	}
+)
+
+// newResultControls returns the ResultControls of an LDAP search result.
+func newResultControls(result *ldap.SearchResult) ResultControls {
+	return ResultControls{Controls: result.Controls, Referrals: result.Referrals}
+}
+
+// Control returns the response control with the controlType or nil if the server did not return the control.
+func (rc ResultControls) Control(controlType string) ldap.Control {
+	return ldap.FindControl(rc.Controls, controlType)
+}
+
+// PagingCookie returns the cookie of the simple paged results control, which is used to request the next page.
+// An empty cookie is returned if the control is missing or if there are no more pages.
+func (rc ResultControls) PagingCookie() []byte {
+	if control, ok := rc.Control(ldap.ControlTypePaging).(*ldap.ControlPaging); ok {
+		return control.Cookie
+	}
+	return nil
+}
+
+// PasswordPolicy returns the password policy response control or nil if the server did not return the control.
+func (rc ResultControls) PasswordPolicy() *ldap.ControlBeheraPasswordPolicy {
+	control, _ := rc.Control(ldap.ControlTypeBeheraPasswordPolicy).(*ldap.ControlBeheraPasswordPolicy)
+	return control
+}
+
+// SortResult returns the server side sorting result control or nil if the server did not return the control.
+func (rc ResultControls) SortResult() *ldap.ControlServerSideSortingResult {
+	control, _ := rc.Control(ldap.ControlTypeServerSideSortingResult).(*ldap.ControlServerSideSortingResult)
+	return control
+}
@@ -0,0 +1,35 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultControls(t *testing.T) {
+	t.Run("controls", func(t *testing.T) {
+		paging := ldap.NewControlPaging(0)
+		paging.SetCookie([]byte("cookie"))
+		passwordPolicy := &ldap.ControlBeheraPasswordPolicy{Expire: 3600, Grace: -1}
+		sortResult := &ldap.ControlServerSideSortingResult{Result: ldap.ControlServerSideSortingCodeSuccess}
+		rc := newResultControls(&ldap.SearchResult{
+			Controls:  []ldap.Control{paging, passwordPolicy, sortResult},
+			Referrals: []string{"ldap://ldap2.company.com/o=company"},
+		})
+
+		assert.Equal(t, paging, rc.Control(ldap.ControlTypePaging))
+		assert.Equal(t, []byte("cookie"), rc.PagingCookie())
+		assert.Equal(t, passwordPolicy, rc.PasswordPolicy())
+		assert.Equal(t, sortResult, rc.SortResult())
+		assert.Equal(t, []string{"ldap://ldap2.company.com/o=company"}, rc.Referrals)
+	})
+
+	t.Run("no controls", func(t *testing.T) {
+		rc := newResultControls(&ldap.SearchResult{})
+		assert.Nil(t, rc.Control(ldap.ControlTypePaging))
+		assert.Nil(t, rc.PagingCookie())
+		assert.Nil(t, rc.PasswordPolicy())
+		assert.Nil(t, rc.SortResult())
+	})
+}
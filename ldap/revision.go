@@ -0,0 +1,31 @@
+package ldap
+
+import (
+	"fmt"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	// defaultRevisionAttr is the operational attribute which identifies the revision of an entry by default.
+	defaultRevisionAttr = modifyTimestampAttr
+
+	revisionConflictMsg = "The entry '%s' was modified since revision '%s', the current revision is '%s'"
+)
+
+// revisionAttr returns the RevisionAttr or the default revision attribute if the RevisionAttr is not set.
+func (c *Client) revisionAttr() string {
+	if attr := c.getConfig().RevisionAttr; attr != "" {
+		return attr
+	}
+	return defaultRevisionAttr
+}
+
+// checkRevision returns a conflict error if the expected revision of the entry with the dn is set and differs from
+// the current revision of the entry.
+func checkRevision(dn, expected, current string) *Error {
+	if expected == "" || expected == current {
+		return nil
+	}
+	return WrapError(errors.ConflictError(fmt.Sprintf(revisionConflictMsg, dn, expected, current)))
+}
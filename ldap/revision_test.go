@@ -0,0 +1,30 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRevision = "20261016120000Z"
+
+func TestClient_revisionAttr(t *testing.T) {
+	assert.Equal(t, modifyTimestampAttr, NewClient(testConfig).revisionAttr())
+
+	config := testConfig
+	config.RevisionAttr = "entryCSN"
+	assert.Equal(t, "entryCSN", NewClient(config).revisionAttr())
+}
+
+func TestCheckRevision(t *testing.T) {
+	groupDn := "cn=group1,ou=groups,o=company"
+
+	assert.Nil(t, checkRevision(groupDn, "", testRevision))
+	assert.Nil(t, checkRevision(groupDn, testRevision, testRevision))
+
+	cErr := checkRevision(groupDn, "20261016110000Z", testRevision)
+	assert.Equal(t, http.StatusConflict, cErr.Status)
+	assert.Equal(t, fmt.Sprintf(revisionConflictMsg, groupDn, "20261016110000Z", testRevision), cErr.Message)
+}
@@ -0,0 +1,175 @@
+package ldap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atselvan/ldap-go-lib/dn"
+)
+
+const (
+	roleParam      = "role"
+	unknownRoleMsg = "Unknown role '%s'. Valid roles are %v"
+)
+
+type (
+	// RolesManager describes the interface that needs to be implemented for managing the logical roles of users,
+	// e.g. "nexus-admin", which are backed by LDAP groups.
+	RolesManager interface {
+		HasRole(uid, role string) (bool, *Error)
+		GrantRole(uid, role string) *Error
+		RevokeRole(uid, role string) *Error
+		Roles(uid string) ([]string, *Error)
+	}
+
+	// rolesManager implements RolesManager.
+	rolesManager struct {
+		Client *Client
+	}
+
+	// Role maps a logical role to the LDAP groups which grant the role.
+	Role struct {
+		Name   string            `json:"name" yaml:"name"`
+		Groups []GroupMembership `json:"groups" yaml:"groups"`
+	}
+)
+
+// WithRolesManager overrides the default RolesManager.
+func WithRolesManager(rm RolesManager) ClientOption {
+	return func(c *Client) {
+		c.Roles = rm
+	}
+}
+
+// HasRole checks if a user has a role, i.e. if the user is a member of all the groups of the role.
+// params:
+//
+//	uid = user identifier
+//	role = the name of the role set in the RoleMappings of the client Config
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the role is unknown
+//   - if a group of the role is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (rm *rolesManager) HasRole(uid, role string) (bool, *Error) {
+	r, cErr := rm.getRole(uid, role)
+	if cErr != nil {
+		return false, cErr
+	}
+	return rm.hasRole(uid, r)
+}
+
+// GrantRole grants a role to a user by adding the user to all the groups of the role.
+// params:
+//
+//	uid = user identifier
+//	role = the name of the role set in the RoleMappings of the client Config
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the role is unknown
+//   - if a group of the role is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (rm *rolesManager) GrantRole(uid, role string) *Error {
+	r, cErr := rm.getRole(uid, role)
+	if cErr != nil {
+		return cErr
+	}
+	for _, group := range r.Groups {
+		if cErr := rm.Client.Groups.AddMembers(group.Cn, group.Ou, []string{uid}); cErr != nil {
+			return cErr
+		}
+	}
+	return nil
+}
+
+// RevokeRole revokes a role from a user by removing the user from all the groups of the role.
+// params:
+//
+//	uid = user identifier
+//	role = the name of the role set in the RoleMappings of the client Config
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the role is unknown
+//   - if a group of the role is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (rm *rolesManager) RevokeRole(uid, role string) *Error {
+	r, cErr := rm.getRole(uid, role)
+	if cErr != nil {
+		return cErr
+	}
+	for _, group := range r.Groups {
+		if cErr := rm.Client.Groups.RemoveMembers(group.Cn, group.Ou, []string{uid}); cErr != nil {
+			return cErr
+		}
+	}
+	return nil
+}
+
+// Roles returns the sorted names of the roles the user has.
+// params:
+//
+//	uid = user identifier
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if a group of a role is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (rm *rolesManager) Roles(uid string) ([]string, *Error) {
+	if strings.TrimSpace(uid) == "" {
+		return nil, missingParametersError([]string{userIdAttr})
+	}
+	roles := []string{}
+	for _, role := range rm.Client.getConfig().RoleMappings {
+		hasRole, cErr := rm.hasRole(uid, role)
+		if cErr != nil {
+			return nil, cErr
+		}
+		if hasRole {
+			roles = append(roles, role.Name)
+		}
+	}
+	sort.Strings(roles)
+	return roles, nil
+}
+
+// hasRole checks if the user is a member of all the groups of the role.
+func (rm *rolesManager) hasRole(uid string, role Role) (bool, *Error) {
+	member := dn.Join(dn.RDN(userIdAttr, rm.Client.canonicalMemberId(uid)), rm.Client.getConfig().UserBaseDN)
+	for _, group := range role.Groups {
+		groups, cErr := rm.Client.Groups.Get(group.Cn, group.Ou)
+		if cErr != nil {
+			return false, cErr
+		}
+		if len(groups) == 0 {
+			return false, nil
+		}
+		if _, ok := findMember(groups[0].Members, member); !ok {
+			return false, nil
+		}
+	}
+	return len(role.Groups) > 0, nil
+}
+
+// getRole validates the uid and returns the role set in the RoleMappings of the client Config.
+func (rm *rolesManager) getRole(uid, role string) (Role, *Error) {
+	if strings.TrimSpace(uid) == "" {
+		return Role{}, missingParametersError([]string{userIdAttr})
+	}
+	roles := rm.Client.getConfig().RoleMappings
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r.Name == role {
+			return r, nil
+		}
+		names = append(names, r.Name)
+	}
+	return Role{}, invalidParameterError(roleParam, fmt.Sprintf(unknownRoleMsg, role, names))
+}
@@ -0,0 +1,158 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRoleMappings = []Role{
+	{
+		Name: "nexus-admin",
+		Groups: []GroupMembership{
+			{Cn: testGroupCn1, Ou: testOrganizationUnit1},
+			{Cn: testGroupCn2, Ou: testOrganizationUnit1},
+		},
+	},
+	{
+		Name:   "nexus-reader",
+		Groups: []GroupMembership{{Cn: testGroupCn2, Ou: testOrganizationUnit1}},
+	},
+}
+
+// newRolesTestClient returns a client with the test role mappings which uses the go-ldap Client mock.
+func newRolesTestClient(t *testing.T) (*mocks.Client, *Client) {
+	config := testConfig
+	config.RoleMappings = testRoleMappings
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting(), WithoutOrganizationalUnitValidation())
+	ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+	ldapMock.On(methodNameClose).Return(nil)
+	return ldapMock, client
+}
+
+func TestWithRolesManager(t *testing.T) {
+	rm := &rolesManager{}
+	client := NewClient(testConfig, WithRolesManager(rm))
+	assert.Equal(t, rm, client.Roles)
+}
+
+func TestRolesManager_HasRole(t *testing.T) {
+	t.Run("has role", func(t *testing.T) {
+		ldapMock, client := newRolesTestClient(t)
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult2, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn2, testOrganizationUnit1, groupSearchFilter)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getGroupLDAPEntry(testGroupCn2, testOrganizationUnit1, testUniqueMembers2),
+			}}, nil)
+
+		hasRole, cErr := client.Roles.HasRole("c00001", "nexus-admin")
+		assert.Nil(t, cErr)
+		assert.True(t, hasRole)
+	})
+
+	t.Run("not a member of all the groups", func(t *testing.T) {
+		ldapMock, client := newRolesTestClient(t)
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, []string{gm.getUniqueMemberDn(testUser3.Uid)}),
+			}}, nil)
+
+		hasRole, cErr := client.Roles.HasRole(testUser1.Uid, "nexus-admin")
+		assert.Nil(t, cErr)
+		assert.False(t, hasRole)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		ldapMock, client := newRolesTestClient(t)
+		gm := groupsManager{Client: client}
+
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn2, testOrganizationUnit1, groupSearchFilter)).
+			Return(nil, ldapNoSuchObjectErr)
+
+		hasRole, cErr := client.Roles.HasRole(testUser1.Uid, "nexus-reader")
+		assert.False(t, hasRole)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		config := testConfig
+		config.RoleMappings = testRoleMappings
+		client := NewClient(config)
+
+		hasRole, cErr := client.Roles.HasRole(testUser1.Uid, "jira-admin")
+		assert.False(t, hasRole)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(unknownRoleMsg, "jira-admin", []string{"nexus-admin", "nexus-reader"}),
+			cErr.Message)
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		config := testConfig
+		config.RoleMappings = testRoleMappings
+		client := NewClient(config)
+
+		_, cErr := client.Roles.HasRole("", "nexus-admin")
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(userIdAttr).Code)
+	})
+}
+
+func TestRolesManager_GrantRole(t *testing.T) {
+	ldapMock, client := newRolesTestClient(t)
+	gm := groupsManager{Client: client}
+	mr := gm.getModifyRequest(testGroupCn2, testOrganizationUnit1)
+	mr.Add(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser3.Uid)})
+	mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+
+	ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn2, testOrganizationUnit1, groupSearchFilter)).
+		Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			getGroupLDAPEntry(testGroupCn2, testOrganizationUnit1, testUniqueMembers2),
+		}}, nil)
+	ldapMock.On(methodNameModify, mr).Return(nil)
+
+	assert.Nil(t, client.Roles.GrantRole(testUser3.Uid, "nexus-reader"))
+}
+
+func TestRolesManager_RevokeRole(t *testing.T) {
+	ldapMock, client := newRolesTestClient(t)
+	gm := groupsManager{Client: client}
+	mr := gm.getModifyRequest(testGroupCn2, testOrganizationUnit1)
+	mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(testUser1.Uid)})
+	mr.Add(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+
+	ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn2, testOrganizationUnit1, groupSearchFilter)).
+		Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			getGroupLDAPEntry(testGroupCn2, testOrganizationUnit1, testUniqueMembers2),
+		}}, nil)
+	ldapMock.On(methodNameModify, mr).Return(nil)
+
+	assert.Nil(t, client.Roles.RevokeRole(testUser1.Uid, "nexus-reader"))
+}
+
+func TestRolesManager_Roles(t *testing.T) {
+	ldapMock, client := newRolesTestClient(t)
+	gm := groupsManager{Client: client}
+
+	ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+		Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			getGroupLDAPEntry(testGroupCn1, testOrganizationUnit1, []string{gm.getUniqueMemberDn(testUser3.Uid)}),
+		}}, nil)
+	ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn2, testOrganizationUnit1, groupSearchFilter)).
+		Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+			getGroupLDAPEntry(testGroupCn2, testOrganizationUnit1, testUniqueMembers2),
+		}}, nil)
+
+	roles, cErr := client.Roles.Roles(testUser1.Uid)
+	assert.Nil(t, cErr)
+	assert.Equal(t, []string{"nexus-reader"}, roles)
+}
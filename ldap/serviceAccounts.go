@@ -0,0 +1,273 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// defaultServiceAccountOwnerAttr is the attribute which holds the owner of a service account unless a
+	// ServiceAccountOwnerAttr is set in the client Config.
+	defaultServiceAccountOwnerAttr = "manager"
+
+	notServiceAccountErrMsg  = "Invalid uid '%s'. The uid of a service account must not be the uid of a personal account"
+	serviceAccountOwnerParam = "owner"
+)
+
+type (
+	// ServiceAccountsManager describes the interface which needs to be implemented for managing the service accounts,
+	// the NPA and the builder accounts, with their owner and the time their password was last rotated.
+	ServiceAccountsManager interface {
+		GetAll(opts ...RequestOption) ([]ServiceAccount, *Error)
+		Get(uid string) (*ServiceAccount, *Error)
+		Create(account ServiceAccount) *Error
+		SetOwner(uid, owner string) *Error
+		RotatePassword(uid string) (string, *Error)
+	}
+
+	// serviceAccountsManager implements the ServiceAccountsManager interface.
+	serviceAccountsManager struct {
+		Client *Client
+	}
+
+	// ServiceAccount represents a non-personal user entry in LDAP.
+	ServiceAccount struct {
+		User
+		// Type is the type of the account derived from the uid, UserTypeNPA or UserTypeBuilder.
+		Type string `json:"type"`
+		// Owner is the dn of the user who is accountable for the service account. When an account is created the
+		// owner can also be set to the uid of a user within the UserBaseDN.
+		Owner string `json:"owner"`
+		// LastRotation is the time the password of the account was last rotated, nil if it is unknown.
+		LastRotation *time.Time `json:"lastRotation,omitempty"`
+	}
+)
+
+// WithServiceAccountsManager overrides the default ServiceAccountsManager.
+func WithServiceAccountsManager(sam ServiceAccountsManager) ClientOption {
+	return func(c *Client) {
+		c.ServiceAccounts = sam
+	}
+}
+
+// GetAll retrieves all the service accounts from LDAP, i.e. the user entries which are not personal accounts.
+// params:
+//
+//	opts = options applied to the search request, e.g. WithBaseDN or WithTimeout
+//
+// The method returns an error:
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sam *serviceAccountsManager) GetAll(opts ...RequestOption) ([]ServiceAccount, *Error) {
+	um := sam.users()
+	sr := um.getUsersSearchRequest(sam.Client.getConfig().usersFilter())
+	sr.Attributes = append(sr.Attributes, sam.ownerAttr(), sam.rotationAttr())
+	result, cErr := sam.Client.doLDAPSearch(sr, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return sam.parseSearchResult(result), nil
+}
+
+// Get retrieves a single service account from LDAP.
+// params:
+//
+//	uid = the uid of the service account
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the uid is the uid of a personal account
+//   - if the service account is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sam *serviceAccountsManager) Get(uid string) (*ServiceAccount, *Error) {
+	if cErr := sam.validateUid(uid); cErr != nil {
+		return nil, cErr
+	}
+	um := sam.users()
+	sr := um.getUserSearchRequest(um.getDN(uid))
+	sr.Attributes = append(sr.Attributes, sam.ownerAttr(), sam.rotationAttr())
+	result, cErr := sam.Client.doLDAPSearch(sr)
+	if cErr != nil && cErr.Status != http.StatusNotFound {
+		return nil, cErr
+	}
+	var accounts []ServiceAccount
+	if cErr == nil {
+		accounts = sam.parseSearchResult(result)
+	}
+	if len(accounts) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+	}
+	return &accounts[0], nil
+}
+
+// Create creates a new service account in LDAP.
+// params:
+//
+//	account = the service account, the attributes of a user and the Owner are mandatory
+//
+// The uid must not be the uid of a personal account and must match the pattern of its type if the UidPolicy set in
+// the client Config has one. The rotation time is set if a custom ServiceAccountRotationAttr is set in the client
+// Config.
+// The method returns an error:
+//   - if a validation fails
+//   - if the uid violates the UidPolicy set in the client Config
+//   - if the service account already exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sam *serviceAccountsManager) Create(account ServiceAccount) *Error {
+	if cErr := sam.validateUid(account.Uid); cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(account.Owner) == "" {
+		return missingParametersError([]string{serviceAccountOwnerParam})
+	}
+	attributes := []ldap.Attribute{{Type: sam.ownerAttr(), Vals: []string{sam.ownerDn(account.Owner)}}}
+	if rotationAttr := sam.rotationAttr(); rotationAttr != pwdChangedTimeAttr {
+		attributes = append(attributes, ldap.Attribute{Type: rotationAttr,
+			Vals: []string{time.Now().UTC().Format(generalizedTimeLayout)}})
+	}
+	return sam.users().create(account.User, nil, attributes...)
+}
+
+// SetOwner replaces the owner of an existing service account in LDAP.
+// params:
+//
+//	uid = the uid of the service account
+//	owner = the dn or the uid of the user who is accountable for the service account
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the uid is the uid of a personal account
+//   - if the service account is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sam *serviceAccountsManager) SetOwner(uid, owner string) *Error {
+	if cErr := sam.validateUid(uid); cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(owner) == "" {
+		return missingParametersError([]string{serviceAccountOwnerParam})
+	}
+	um := sam.users()
+	mr := ldap.NewModifyRequest(um.getDN(uid), nil)
+	mr.Replace(sam.ownerAttr(), []string{sam.ownerDn(owner)})
+	if cErr := sam.modify(uid, mr); cErr != nil {
+		return cErr
+	}
+	um.emit(EventUserUpdated, uid)
+	return nil
+}
+
+// RotatePassword replaces the password of an existing service account with a password generated by the LDAP server
+// and returns the new password.
+// params:
+//
+//	uid = the uid of the service account
+//
+// The rotation time is updated if a custom ServiceAccountRotationAttr is set in the client Config, otherwise the
+// LDAP server maintains the pwdChangedTime of the account.
+// The method returns an error:
+//   - if a validation fails
+//   - if the uid is the uid of a personal account
+//   - if the service account is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sam *serviceAccountsManager) RotatePassword(uid string) (string, *Error) {
+	if cErr := sam.validateUid(uid); cErr != nil {
+		return "", cErr
+	}
+	um := sam.users()
+	password, cErr := um.SetNewPassword(uid, "")
+	if cErr != nil {
+		return "", cErr
+	}
+	if rotationAttr := sam.rotationAttr(); rotationAttr != pwdChangedTimeAttr {
+		mr := ldap.NewModifyRequest(um.getDN(uid), nil)
+		mr.Replace(rotationAttr, []string{time.Now().UTC().Format(generalizedTimeLayout)})
+		if cErr := sam.modify(uid, mr); cErr != nil {
+			return "", cErr
+		}
+	}
+	return password, nil
+}
+
+// validateUid checks if the uid is set and is not the uid of a personal account.
+func (sam *serviceAccountsManager) validateUid(uid string) *Error {
+	if cErr := sam.users().validateUid(uid); cErr != nil {
+		return cErr
+	}
+	userType, cErr := userTypeOf(uid)
+	if cErr != nil {
+		return cErr
+	}
+	if userType == UserTypePersonal {
+		return invalidParameterError(userIdAttr, fmt.Sprintf(notServiceAccountErrMsg, uid))
+	}
+	return nil
+}
+
+// modify executes the modify request of a service account.
+func (sam *serviceAccountsManager) modify(uid string, mr *ldap.ModifyRequest) *Error {
+	if cErr := sam.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return cErr
+	}
+	return nil
+}
+
+// parseSearchResult parses the result of the LDAP user search query and keeps the service accounts.
+func (sam *serviceAccountsManager) parseSearchResult(result *ldap.SearchResult) []ServiceAccount {
+	users := sam.users().parseSearchResult(result)
+	accounts := []ServiceAccount{}
+	for i, entry := range result.Entries {
+		userType, cErr := userTypeOf(users[i].Uid)
+		if cErr != nil || userType == UserTypePersonal {
+			continue
+		}
+		account := ServiceAccount{
+			User:  users[i],
+			Type:  userType,
+			Owner: entry.GetAttributeValue(sam.ownerAttr()),
+		}
+		if rotation, ok := parseGeneralizedTime(entry.GetAttributeValue(sam.rotationAttr())); ok {
+			account.LastRotation = &rotation
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
+
+// ownerDn returns the dn of the owner, which is used as is if it is a dn or is the uid of a user within the
+// UserBaseDN otherwise.
+func (sam *serviceAccountsManager) ownerDn(owner string) string {
+	return (&groupsManager{Client: sam.Client}).memberDn(owner)
+}
+
+// ownerAttr returns the ServiceAccountOwnerAttr set in the client Config or the default owner attribute.
+func (sam *serviceAccountsManager) ownerAttr() string {
+	if attr := sam.Client.getConfig().ServiceAccountOwnerAttr; attr != "" {
+		return attr
+	}
+	return defaultServiceAccountOwnerAttr
+}
+
+// rotationAttr returns the ServiceAccountRotationAttr set in the client Config or pwdChangedTime.
+func (sam *serviceAccountsManager) rotationAttr() string {
+	if attr := sam.Client.getConfig().ServiceAccountRotationAttr; attr != "" {
+		return attr
+	}
+	return pwdChangedTimeAttr
+}
+
+// users returns the usersManager the service accounts are managed with.
+func (sam *serviceAccountsManager) users() *usersManager {
+	return &usersManager{Client: sam.Client}
+}
@@ -0,0 +1,260 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testServiceAccountOwner = "uid=C00001,ou=users,o=company"
+
+func getServiceAccountLDAPEntry(user User, rotation string) *ldap.Entry {
+	entry := getUserLDAPEntry(user)
+	entry.Attributes = append(entry.Attributes,
+		ldap.NewEntryAttribute(defaultServiceAccountOwnerAttr, []string{testServiceAccountOwner}),
+		ldap.NewEntryAttribute(pwdChangedTimeAttr, []string{rotation}))
+	return entry
+}
+
+func TestServiceAccountsManager_GetAll(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+	um := usersManager{Client: client}
+	sr := um.getUsersSearchRequest(userSearchFilter)
+	sr.Attributes = append(sr.Attributes, defaultServiceAccountOwnerAttr, pwdChangedTimeAttr)
+
+	ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+	ldapMock.On(methodNameSearch, sr).Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+		getServiceAccountLDAPEntry(testUser1, ""),
+		getServiceAccountLDAPEntry(testUser3, "20240101120000Z"),
+		getServiceAccountLDAPEntry(testUser4, ""),
+	}}, nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	accounts, cErr := client.ServiceAccounts.GetAll()
+	assert.Nil(t, cErr)
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, testUser3.Uid, accounts[0].Uid)
+	assert.Equal(t, UserTypeBuilder, accounts[0].Type)
+	assert.Equal(t, testServiceAccountOwner, accounts[0].Owner)
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), *accounts[0].LastRotation)
+	assert.Equal(t, testUser4.Uid, accounts[1].Uid)
+	assert.Equal(t, UserTypeNPA, accounts[1].Type)
+	assert.Nil(t, accounts[1].LastRotation)
+}
+
+func TestServiceAccountsManager_Get(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{getServiceAccountLDAPEntry(testUser4, "")}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		account, cErr := client.ServiceAccounts.Get(testUser4.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testUser4.Uid, account.Uid)
+		assert.Equal(t, testServiceAccountOwner, account.Owner)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		account, cErr := client.ServiceAccounts.Get(testUser4.Uid)
+		assert.Nil(t, account)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("personal account", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		account, cErr := client.ServiceAccounts.Get(testUser1.Uid)
+		assert.Nil(t, account)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userIdAttr).Code)
+	})
+}
+
+func TestServiceAccountsManager_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser4
+		user.UserPassword = "secret"
+		ar := um.getAddRequest(user)
+		ar.Attribute(defaultServiceAccountOwnerAttr, []string{testServiceAccountOwner})
+		pmr := um.getPasswordModifyRequest(user.Uid, user.UserPassword, user.UserPassword)
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On("PasswordModify", pmr).Return(nil, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.ServiceAccounts.Create(ServiceAccount{User: user, Owner: testUser1.Uid})
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("success: custom rotation attribute", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.ServiceAccountOwnerAttr = "owner"
+		config.ServiceAccountRotationAttr = "lastRotation"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).
+			Run(func(args mock.Arguments) {
+				ar := args.Get(0).(*ldap.AddRequest)
+				attributes := ar.Attributes[len(ar.Attributes)-2:]
+				assert.Equal(t, "owner", attributes[0].Type)
+				assert.Equal(t, []string{testServiceAccountOwner}, attributes[0].Vals)
+				assert.Equal(t, "lastRotation", attributes[1].Type)
+				_, ok := parseGeneralizedTime(attributes[1].Vals[0])
+				assert.True(t, ok)
+			}).
+			Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).Return(nil, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		user := testUser3
+		user.UserPassword = "secret"
+		cErr := client.ServiceAccounts.Create(ServiceAccount{User: user, Owner: testServiceAccountOwner})
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("missing owner", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.ServiceAccounts.Create(ServiceAccount{User: testUser4})
+		assert.Equal(t, missingParametersError([]string{serviceAccountOwnerParam}), cErr)
+	})
+
+	t.Run("personal account", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.ServiceAccounts.Create(ServiceAccount{User: testUser1, Owner: testServiceAccountOwner})
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userIdAttr).Code)
+	})
+
+	t.Run("uid policy", func(t *testing.T) {
+		config := testConfig
+		config.UidPolicy = UidPolicy{Patterns: map[string]string{UserTypeNPA: "^svc-"}}
+		client := NewClient(config, UnitTesting())
+		user := testUser4
+		user.UserPassword = "secret"
+
+		cErr := client.ServiceAccounts.Create(ServiceAccount{User: user, Owner: testServiceAccountOwner})
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userIdAttr).Code)
+	})
+}
+
+func TestServiceAccountsManager_SetOwner(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser4.Uid), nil)
+		mr.Replace(defaultServiceAccountOwnerAttr, []string{testServiceAccountOwner})
+		var events []EventType
+		client.On(EventUserUpdated, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.ServiceAccounts.SetOwner(testUser4.Uid, testServiceAccountOwner))
+		assert.Equal(t, []EventType{EventUserUpdated}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.ServiceAccounts.SetOwner(testUser4.Uid, testUser1.Uid)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("missing owner", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		cErr := client.ServiceAccounts.SetOwner(testUser4.Uid, " ")
+		assert.Equal(t, missingParametersError([]string{serviceAccountOwnerParam}), cErr)
+	})
+}
+
+func TestServiceAccountsManager_RotatePassword(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On("PasswordModify", um.getPasswordModifyRequest(testUser4.Uid, "", "")).
+			Return(&ldap.PasswordModifyResult{GeneratedPassword: "generated"}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		password, cErr := client.ServiceAccounts.RotatePassword(testUser4.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, "generated", password)
+	})
+
+	t.Run("success: custom rotation attribute", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.ServiceAccountRotationAttr = "lastRotation"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).
+			Return(&ldap.PasswordModifyResult{GeneratedPassword: "generated"}, nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).
+			Run(func(args mock.Arguments) {
+				mr := args.Get(0).(*ldap.ModifyRequest)
+				assert.Equal(t, "lastRotation", mr.Changes[0].Modification.Type)
+			}).
+			Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		password, cErr := client.ServiceAccounts.RotatePassword(testUser3.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, "generated", password)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		password, cErr := client.ServiceAccounts.RotatePassword(testUser4.Uid)
+		assert.Empty(t, password)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("personal account", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		password, cErr := client.ServiceAccounts.RotatePassword(testUser1.Uid)
+		assert.Empty(t, password)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userIdAttr).Code)
+	})
+}
@@ -0,0 +1,103 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/atselvan/go-utils/utils/logger"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const searchCollapsedMsg = "The result of the search was shared with %d identical searches in flight"
+
+type (
+	// searchFlight collapses identical search requests which are in flight at the same time into a single LDAP
+	// search, see WithSearchDeduplication.
+	searchFlight struct {
+		mu    sync.Mutex
+		calls map[string]*searchCall
+	}
+
+	// searchCall represents a search request in flight and the callers waiting for its result.
+	searchCall struct {
+		done   chan struct{}
+		result *ldap.SearchResult
+		cErr   *Error
+		// waiters is the number of identical searches waiting for the result, which is logged once the search
+		// completed.
+		waiters int
+	}
+)
+
+// WithSearchDeduplication enables the collapsing of identical search requests in flight: while a search is executed
+// every identical search request, i.e. with the same base dn, scope, alias dereferencing policy, filter, attributes,
+// limits including the MaxResults, controls and timeout, waits for it and receives its result instead of sending
+// another request to LDAP, e.g. when many requests resolve the same group at the same time. Completed searches are
+// not cached, see WithCache for caching the results.
+// Every caller receives its own copy of the search result, the entries of the result are shared.
+// Paged searches are not collapsed.
+func WithSearchDeduplication() ClientOption {
+	return func(c *Client) {
+		c.searchFlight = &searchFlight{calls: make(map[string]*searchCall)}
+	}
+}
+
+// do executes the search unless an identical search is in flight, in which case it waits for the result of the
+// search in flight.
+func (f *searchFlight) do(key string, search func() (any, *Error)) (any, *Error) {
+	f.mu.Lock()
+	if call, ok := f.calls[key]; ok {
+		call.waiters++
+		f.mu.Unlock()
+		<-call.done
+		return copySearchResult(call.result), call.cErr
+	}
+	call := &searchCall{done: make(chan struct{})}
+	f.calls[key] = call
+	f.mu.Unlock()
+
+	result, cErr := search()
+	call.result, _ = result.(*ldap.SearchResult)
+	call.cErr = cErr
+
+	f.mu.Lock()
+	delete(f.calls, key)
+	waiters := call.waiters
+	f.mu.Unlock()
+	close(call.done)
+	if waiters > 0 {
+		logger.Debug(fmt.Sprintf(searchCollapsedMsg, waiters))
+	}
+	return copySearchResult(call.result), cErr
+}
+
+// searchKey returns the key which identifies identical search requests. The key is built before the client applies
+// the MaxResults and the DerefAliases to the search request, so the effective values are part of the key.
+func (c *Client) searchKey(req *OperationRequest, sr *ldap.SearchRequest) string {
+	controls := make([]string, 0, len(sr.Controls))
+	for _, control := range sr.Controls {
+		controls = append(controls, control.String())
+	}
+	return strings.Join([]string{
+		sr.BaseDN,
+		fmt.Sprint(sr.Scope, c.derefAliases(req, sr), sr.SizeLimit, sr.TimeLimit, sr.TypesOnly, req.Timeout,
+			c.maxResults(req)),
+		sr.Filter,
+		strings.Join(sr.Attributes, ","),
+		strings.Join(controls, ","),
+	}, "\x00")
+}
+
+// copySearchResult returns a copy of the search result, so the callers sharing a result can e.g. sort the entries
+// without affecting each other.
+func copySearchResult(result *ldap.SearchResult) *ldap.SearchResult {
+	if result == nil {
+		return nil
+	}
+	return &ldap.SearchResult{
+		Entries:   append([]*ldap.Entry(nil), result.Entries...),
+		Referrals: append([]string(nil), result.Referrals...),
+		Controls:  append([]ldap.Control(nil), result.Controls...),
+	}
+}
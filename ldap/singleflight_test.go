@@ -0,0 +1,155 @@
+package ldap
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithSearchDeduplication(t *testing.T) {
+	t.Run("identical searches in flight", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithSearchDeduplication())
+		um := usersManager{Client: client}
+		started, release := make(chan struct{}), make(chan struct{})
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil).Once()
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Run(func(_ mock.Arguments) {
+				close(started)
+				<-release
+			}).
+			Return(getUserSearchResult, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil).Once()
+
+		const callers = 5
+		users := make([]*User, callers)
+		logs := captureLogs(t, func() {
+			var wg sync.WaitGroup
+			wg.Add(callers)
+			go func() {
+				defer wg.Done()
+				users[0], _ = client.Users.Get(testUser1.Uid)
+			}()
+			<-started
+			for i := 1; i < callers; i++ {
+				go func(i int) {
+					defer wg.Done()
+					users[i], _ = client.Users.Get(testUser1.Uid)
+				}(i)
+			}
+			assert.Eventually(t, func() bool {
+				client.searchFlight.mu.Lock()
+				defer client.searchFlight.mu.Unlock()
+				for _, call := range client.searchFlight.calls {
+					return call.waiters == callers-1
+				}
+				return false
+			}, time.Second, time.Millisecond)
+			close(release)
+			wg.Wait()
+		})
+
+		for _, user := range users {
+			assert.Equal(t, testUser1.Uid, user.Uid)
+		}
+		assert.Empty(t, client.searchFlight.calls)
+		assert.Contains(t, logs, fmt.Sprintf(searchCollapsedMsg, callers-1))
+	})
+
+	t.Run("different searches", func(t *testing.T) {
+		client := NewClient(testConfig)
+		sr := ldap.NewSearchRequest("ou=users,o=company", ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0,
+			false, "(uid=C00001)", []string{"uid"}, nil)
+		other := *sr
+		other.Filter = "(uid=C00002)"
+		req := &OperationRequest{Name: OperationSearch, Request: sr}
+
+		assert.Equal(t, client.searchKey(req, sr), client.searchKey(req, sr))
+		assert.NotEqual(t, client.searchKey(req, sr), client.searchKey(req, &other))
+
+		other = *sr
+		other.Controls = []ldap.Control{ldap.NewControlManageDsaIT(true)}
+		assert.NotEqual(t, client.searchKey(req, sr), client.searchKey(req, &other))
+	})
+
+	t.Run("different request options", func(t *testing.T) {
+		config := testConfig
+		config.DerefAliases = ldap.DerefAlways
+		client := NewClient(config)
+		sr := ldap.NewSearchRequest("ou=users,o=company", ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0,
+			false, "(uid=C00001)", []string{"uid"}, nil)
+		req := &OperationRequest{Name: OperationSearch, Request: sr}
+
+		other := *sr
+		otherReq := &OperationRequest{Name: OperationSearch, Request: &other}
+		WithDerefAliases(ldap.NeverDerefAliases)(otherReq)
+		assert.NotEqual(t, client.searchKey(req, sr), client.searchKey(otherReq, &other))
+
+		other = *sr
+		otherReq = &OperationRequest{Name: OperationSearch, Request: &other}
+		WithMaxResults(1)(otherReq)
+		assert.NotEqual(t, client.searchKey(req, sr), client.searchKey(otherReq, &other))
+	})
+
+	t.Run("concurrent searches with different max results", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(), WithSearchDeduplication())
+		um := usersManager{Client: client}
+		limited := um.getUsersSearchRequest(userSearchFilter)
+		limited.SizeLimit = 2
+		// every search waits until both searches are sent, which only happens if they are not collapsed
+		var sent sync.WaitGroup
+		sent.Add(2)
+		bothSent := make(chan struct{})
+		go func() {
+			sent.Wait()
+			close(bothSent)
+		}()
+		waitForBoth := func(_ mock.Arguments) {
+			sent.Done()
+			select {
+			case <-bothSent:
+			case <-time.After(time.Second):
+			}
+		}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, limited).Run(waitForBoth).Return(&getUsersSearchResult, nil).Once()
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).Run(waitForBoth).
+			Return(&getUsersSearchResult, nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		var limitedErr, unlimitedErr *Error
+		var unlimitedUsers []User
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, limitedErr = client.Users.(UsersReader).GetAllWithOptions(WithMaxResults(1))
+		}()
+		go func() {
+			defer wg.Done()
+			unlimitedUsers, unlimitedErr = client.Users.GetAll()
+		}()
+		wg.Wait()
+
+		assert.Equal(t, ErrCodeTooManyResults, limitedErr.Code)
+		assert.Nil(t, unlimitedErr)
+		assert.Len(t, unlimitedUsers, 4)
+	})
+}
+
+func TestCopySearchResult(t *testing.T) {
+	result := copySearchResult(getUserSearchResult)
+	assert.Equal(t, getUserSearchResult, result)
+	result.Entries[0] = nil
+	assert.NotNil(t, getUserSearchResult.Entries[0])
+	assert.Nil(t, copySearchResult(nil))
+}
@@ -0,0 +1,44 @@
+package ldap
+
+import (
+	"github.com/go-ldap/ldap/v3"
+)
+
+// softDelete deletes a user following the soft-delete convention:
+//   - the status of the user is set to Deleted
+//   - the password of the user is replaced with a random password, which is not returned
+//   - the user entry is moved to the ArchiveBaseDN set in the client Config
+//
+// The steps are executed in the order above, so a soft delete which fails can be retried. The group memberships of
+// the user are not changed and the user can be restored with Unarchive.
+func (um *usersManager) softDelete(uid string) *Error {
+	archiveBaseDN, cErr := um.archiveBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := um.validateUid(uid); cErr != nil {
+		return cErr
+	}
+	user, cErr := um.get(uid)
+	if cErr != nil {
+		return cErr
+	}
+
+	mr := ldap.NewModifyRequest(um.getDN(user.Uid), nil)
+	mr.Replace(statusAttr, []string{UserStatusDeleted})
+	if cErr := um.Client.doLDAPModify(mr); cErr != nil {
+		return cErr
+	}
+	password, cErr := scrambledPassword()
+	if cErr != nil {
+		return cErr
+	}
+	if _, cErr := um.SetNewPassword(user.Uid, password); cErr != nil {
+		return cErr
+	}
+	if cErr := um.move(user.Uid, &usersManager{Client: um.Client, baseDN: archiveBaseDN}); cErr != nil {
+		return cErr
+	}
+	um.emit(EventUserDeleted, user.Uid)
+	return nil
+}
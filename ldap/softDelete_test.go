@@ -0,0 +1,78 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getSoftDeleteConfig() Config {
+	config := testConfig
+	config.SoftDelete = true
+	config.ArchiveBaseDN = testArchiveBaseDN
+	return config
+}
+
+func TestUsersManager_softDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getSoftDeleteConfig(), WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		var events []Event
+		client.On(EventUserDeleted, func(event Event) { events = append(events, event) })
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(statusAttr, []string{UserStatusDeleted})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil).Once()
+		ldapMock.On("PasswordModify", mock.MatchedBy(func(pmr *ldap.PasswordModifyRequest) bool {
+			return pmr.UserIdentity == um.getDN(testUser1.Uid) && len(pmr.NewPassword) > 32
+		})).Return(&ldap.PasswordModifyResult{}, nil).Once()
+		ldapMock.On(methodNameModifyDN, ldap.NewModifyDNRequest(um.getDN(testUser1.Uid), "uid="+testUser1.Uid, true,
+			testArchiveBaseDN)).Return(nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.Delete(testUser1.Uid))
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("archive base dn not set", func(t *testing.T) {
+		config := getSoftDeleteConfig()
+		config.ArchiveBaseDN = ""
+		client := NewClient(config, UnitTesting())
+
+		cErr := client.Users.Delete(testUser1.Uid)
+		assert.Equal(t, missingParametersError([]string{archiveBaseDNParam}), cErr)
+	})
+
+	t.Run("status update fails", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(getSoftDeleteConfig(), WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.NotNil(t, client.Users.Delete(testUser1.Uid))
+	})
+}
+
+func TestUsersManager_HardDelete(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := NewClient(getSoftDeleteConfig(), WithLDAPClient(ldapMock), UnitTesting())
+	um := usersManager{Client: client}
+
+	ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+	ldapMock.On(methodNameDelete, um.getDeleteRequest(testUser1.Uid)).Return(nil)
+	ldapMock.On(methodNameClose).Return(nil)
+
+	assert.Nil(t, client.Users.(UserLifecycleManager).HardDelete(testUser1.Uid))
+}
@@ -0,0 +1,341 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	sudoUserAttr      = "sudoUser"
+	sudoHostAttr      = "sudoHost"
+	sudoCommandAttr   = "sudoCommand"
+	sudoRunAsUserAttr = "sudoRunAsUser"
+	sudoOptionAttr    = "sudoOption"
+
+	// SudoDefaultsCn is the cn of the sudoRole entry which holds the global sudoOptions. Unlike the other sudoRole
+	// entries it does not need users, hosts and commands.
+	SudoDefaultsCn = "defaults"
+
+	// sudoRoleObjectClassFilter matches the sudoRole entries.
+	sudoRoleObjectClassFilter = "(objectClass=sudoRole)"
+	// sudoRoleFilterTemplate combines the filter matching the sudoRole entries with an attribute value assertion.
+	sudoRoleFilterTemplate = "(&(%s=%s)" + sudoRoleObjectClassFilter + ")"
+
+	sudoersBaseDNParam = "sudoersBaseDN"
+
+	sudoRoleNotFoundMsg      = "Sudo role with cn = '%s' was not found"
+	sudoRoleAlreadyExistsMsg = "Sudo role with cn = '%s' already exists"
+)
+
+var (
+	defaultObjectClassesSudoRole = []string{
+		"sudoRole",
+		"top",
+	}
+
+	sudoRoleAttributes = []string{
+		CommonNameAttr,
+		sudoUserAttr,
+		sudoHostAttr,
+		sudoCommandAttr,
+		sudoRunAsUserAttr,
+		sudoOptionAttr,
+		descriptionAttr,
+	}
+)
+
+type (
+	// SudoersManager describes the interface which needs to be implemented for managing the sudoRole entries within
+	// the SudoersBaseDN set in the client Config, which are consumed by the sudoers LDAP backend of sudo and SSSD.
+	SudoersManager interface {
+		GetAll(opts ...RequestOption) ([]SudoRole, *Error)
+		Get(cn string) (*SudoRole, *Error)
+		GetForUser(user string) ([]SudoRole, *Error)
+		Create(role SudoRole) *Error
+		Update(role SudoRole) *Error
+		Delete(cn string) *Error
+	}
+
+	// sudoersManager implements the SudoersManager interface.
+	sudoersManager struct {
+		Client *Client
+	}
+
+	// SudoRole represents a sudoRole entry in LDAP, a rule which allows users to run commands on hosts.
+	SudoRole struct {
+		Dn string `json:"dn"`
+		Cn string `json:"cn"`
+		// Users are the users the rule applies to: user names, %groups, +netgroups or ALL.
+		Users []string `json:"users"`
+		// Hosts are the hosts the rule applies to: host names, IP addresses, networks, +netgroups or ALL.
+		Hosts []string `json:"hosts"`
+		// Commands are the commands the users may run, e.g. "/usr/bin/systemctl restart nginx" or ALL. A command
+		// prefixed with '!' is denied.
+		Commands []string `json:"commands"`
+		// RunAsUsers are the users the commands may be run as. Defaults to root if not set.
+		RunAsUsers []string `json:"runAsUsers,omitempty"`
+		// Options are the sudoers options of the rule, e.g. "!authenticate".
+		Options     []string `json:"options,omitempty"`
+		Description string   `json:"description,omitempty"`
+	}
+)
+
+// WithSudoersManager overrides the default SudoersManager.
+func WithSudoersManager(sm SudoersManager) ClientOption {
+	return func(c *Client) {
+		c.Sudoers = sm
+	}
+}
+
+// GetAll retrieves all the sudoRole entries from LDAP.
+// The method returns an error:
+//   - if the SudoersBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sm *sudoersManager) GetAll(opts ...RequestOption) ([]SudoRole, *Error) {
+	return sm.search(sudoRoleObjectClassFilter, opts...)
+}
+
+// Get retrieves a sudoRole entry from LDAP.
+// params:
+//
+//	cn = the name of the sudo role
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the SudoersBaseDN is not set in the client Config
+//   - if the sudo role is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sm *sudoersManager) Get(cn string) (*SudoRole, *Error) {
+	if strings.TrimSpace(cn) == "" {
+		return nil, missingParametersError([]string{CommonNameAttr})
+	}
+	roles, cErr := sm.search(fmt.Sprintf(sudoRoleFilterTemplate, CommonNameAttr, ldap.EscapeFilter(cn)))
+	if cErr != nil {
+		return nil, cErr
+	}
+	if len(roles) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(sudoRoleNotFoundMsg, cn)))
+	}
+	return &roles[0], nil
+}
+
+// GetForUser retrieves the sudoRole entries from LDAP which list the user in their sudoUser attribute.
+// params:
+//
+//	user = the user name, %group or +netgroup as it is listed in the sudoUser attribute
+//
+// The rules which apply to the user through the groups of the user or ALL are not included.
+// The method returns an error:
+//   - if a validation fails
+//   - if the SudoersBaseDN is not set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sm *sudoersManager) GetForUser(user string) ([]SudoRole, *Error) {
+	if strings.TrimSpace(user) == "" {
+		return nil, missingParametersError([]string{sudoUserAttr})
+	}
+	return sm.search(fmt.Sprintf(sudoRoleFilterTemplate, sudoUserAttr, ldap.EscapeFilter(user)))
+}
+
+// Create creates a new sudoRole entry in LDAP.
+// params:
+//
+//	role = the sudo role, the Cn, the Users, the Hosts and the Commands are mandatory unless the Cn is SudoDefaultsCn
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the SudoersBaseDN is not set in the client Config
+//   - if the sudo role already exists
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sm *sudoersManager) Create(role SudoRole) *Error {
+	sudoersBaseDN, cErr := sm.sudoersBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := validateSudoRole(role); cErr != nil {
+		return cErr
+	}
+	ar := ldap.NewAddRequest(sm.getDN(sudoersBaseDN, role.Cn), nil)
+	ar.Attribute(objectClassAttr, defaultObjectClassesSudoRole)
+	ar.Attribute(CommonNameAttr, []string{role.Cn})
+	attributes := role.attributes()
+	for _, attr := range sudoRoleAttributes[1:] {
+		if values := attributes[attr]; len(values) > 0 {
+			ar.Attribute(attr, values)
+		}
+	}
+	if cErr := sm.Client.doLDAPAdd(ar); cErr != nil {
+		if cErr.Status == http.StatusBadRequest {
+			return WrapError(errors.ConflictError(fmt.Sprintf(sudoRoleAlreadyExistsMsg, role.Cn)))
+		}
+		return cErr
+	}
+	sm.emit(EventSudoRoleCreated, sudoersBaseDN, role.Cn)
+	return nil
+}
+
+// Update replaces the users, the hosts, the commands, the run as users, the options and the description of an
+// existing sudoRole entry in LDAP. The attributes which are not set are removed.
+// params:
+//
+//	role = the sudo role, the Cn, the Users, the Hosts and the Commands are mandatory unless the Cn is SudoDefaultsCn
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the SudoersBaseDN is not set in the client Config
+//   - if the sudo role is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sm *sudoersManager) Update(role SudoRole) *Error {
+	sudoersBaseDN, cErr := sm.sudoersBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := validateSudoRole(role); cErr != nil {
+		return cErr
+	}
+	mr := ldap.NewModifyRequest(sm.getDN(sudoersBaseDN, role.Cn), nil)
+	attributes := role.attributes()
+	for _, attr := range sudoRoleAttributes[1:] {
+		mr.Replace(attr, append([]string{}, attributes[attr]...))
+	}
+	if cErr := sm.Client.doLDAPModify(mr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(sudoRoleNotFoundMsg, role.Cn)))
+		}
+		return cErr
+	}
+	sm.emit(EventSudoRoleUpdated, sudoersBaseDN, role.Cn)
+	return nil
+}
+
+// Delete deletes an existing sudoRole entry from LDAP.
+// params:
+//
+//	cn = the name of the sudo role
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if the SudoersBaseDN is not set in the client Config
+//   - if the sudo role is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (sm *sudoersManager) Delete(cn string) *Error {
+	sudoersBaseDN, cErr := sm.sudoersBaseDN()
+	if cErr != nil {
+		return cErr
+	}
+	if strings.TrimSpace(cn) == "" {
+		return missingParametersError([]string{CommonNameAttr})
+	}
+	if cErr := sm.Client.doLDAPDelete(ldap.NewDelRequest(sm.getDN(sudoersBaseDN, cn), nil)); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(sudoRoleNotFoundMsg, cn)))
+		}
+		return cErr
+	}
+	sm.emit(EventSudoRoleDeleted, sudoersBaseDN, cn)
+	return nil
+}
+
+// search retrieves the sudoRole entries within the SudoersBaseDN which match the search filter.
+func (sm *sudoersManager) search(searchFilter string, opts ...RequestOption) ([]SudoRole, *Error) {
+	sudoersBaseDN, cErr := sm.sudoersBaseDN()
+	if cErr != nil {
+		return nil, cErr
+	}
+	sr := ldap.NewSearchRequest(
+		sudoersBaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		searchFilter,
+		sudoRoleAttributes,
+		nil,
+	)
+	roles := []SudoRole{}
+	cErr = sm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			roles = append(roles, SudoRole{
+				Dn:          entry.DN,
+				Cn:          entry.GetAttributeValue(CommonNameAttr),
+				Users:       entry.GetAttributeValues(sudoUserAttr),
+				Hosts:       entry.GetAttributeValues(sudoHostAttr),
+				Commands:    entry.GetAttributeValues(sudoCommandAttr),
+				RunAsUsers:  entry.GetAttributeValues(sudoRunAsUserAttr),
+				Options:     entry.GetAttributeValues(sudoOptionAttr),
+				Description: entry.GetAttributeValue(descriptionAttr),
+			})
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return roles, nil
+}
+
+// attributes returns the values of the optional attributes of the sudo role keyed by the attribute.
+func (r SudoRole) attributes() map[string][]string {
+	return map[string][]string{
+		sudoUserAttr:      r.Users,
+		sudoHostAttr:      r.Hosts,
+		sudoCommandAttr:   r.Commands,
+		sudoRunAsUserAttr: r.RunAsUsers,
+		sudoOptionAttr:    r.Options,
+		descriptionAttr:   nonEmptyValues(r.Description),
+	}
+}
+
+// validateSudoRole checks if the mandatory fields of the sudo role are set.
+func validateSudoRole(role SudoRole) *Error {
+	if strings.TrimSpace(role.Cn) == "" {
+		return missingParametersError([]string{CommonNameAttr})
+	}
+	if strings.EqualFold(role.Cn, SudoDefaultsCn) {
+		return nil
+	}
+	var missingParams []string
+	if len(role.Users) == 0 {
+		missingParams = append(missingParams, sudoUserAttr)
+	}
+	if len(role.Hosts) == 0 {
+		missingParams = append(missingParams, sudoHostAttr)
+	}
+	if len(role.Commands) == 0 {
+		missingParams = append(missingParams, sudoCommandAttr)
+	}
+	if len(missingParams) > 0 {
+		return missingParametersError(missingParams)
+	}
+	return nil
+}
+
+// emit emits an event of the event type for the sudoRole entry.
+func (sm *sudoersManager) emit(eventType EventType, sudoersBaseDN, cn string) {
+	sm.Client.emit(Event{Type: eventType, Dn: sm.getDN(sudoersBaseDN, cn), Cn: cn})
+}
+
+// getDN returns the dn of the sudoRole entry.
+func (sm *sudoersManager) getDN(sudoersBaseDN, cn string) string {
+	return dn.Join(dn.RDN(CommonNameAttr, cn), sudoersBaseDN)
+}
+
+// sudoersBaseDN returns the SudoersBaseDN set in the client Config or an error if it is not set.
+func (sm *sudoersManager) sudoersBaseDN() (string, *Error) {
+	sudoersBaseDN := sm.Client.getConfig().SudoersBaseDN
+	if sudoersBaseDN == "" {
+		return "", missingParametersError([]string{sudoersBaseDNParam})
+	}
+	return sudoersBaseDN, nil
+}
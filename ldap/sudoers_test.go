@@ -0,0 +1,255 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testSudoersConfig = func() Config {
+		config := testConfig
+		config.SudoersBaseDN = "ou=SUDOers,o=company"
+		return config
+	}()
+
+	testSudoRole = SudoRole{
+		Dn:         "cn=web-admins,ou=SUDOers,o=company",
+		Cn:         "web-admins",
+		Users:      []string{"C00001", "%web-admins"},
+		Hosts:      []string{"web01", "web02"},
+		Commands:   []string{"/usr/bin/systemctl restart nginx"},
+		RunAsUsers: []string{"root"},
+		Options:    []string{"!authenticate"},
+	}
+
+	getSudoRoleSearchResult = &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			ldap.NewEntry(testSudoRole.Dn, map[string][]string{
+				CommonNameAttr:    {testSudoRole.Cn},
+				sudoUserAttr:      testSudoRole.Users,
+				sudoHostAttr:      testSudoRole.Hosts,
+				sudoCommandAttr:   testSudoRole.Commands,
+				sudoRunAsUserAttr: testSudoRole.RunAsUsers,
+				sudoOptionAttr:    testSudoRole.Options,
+			}),
+		},
+	}
+)
+
+func TestSudoersManager_GetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, testSudoersConfig.SudoersBaseDN, sr.BaseDN)
+				assert.Equal(t, sudoRoleObjectClassFilter, sr.Filter)
+			}).
+			Return(getSudoRoleSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		roles, cErr := client.Sudoers.GetAll()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []SudoRole{testSudoRole}, roles)
+	})
+
+	t.Run("sudoers base dn not set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		roles, cErr := client.Sudoers.GetAll()
+		assert.Nil(t, roles)
+		assert.Equal(t, missingParametersError([]string{sudoersBaseDNParam}), cErr)
+	})
+}
+
+func TestSudoersManager_Get(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(cn=web-admins)(objectClass=sudoRole))", sr.Filter)
+			}).
+			Return(getSudoRoleSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		role, cErr := client.Sudoers.Get(testSudoRole.Cn)
+		assert.Nil(t, cErr)
+		assert.Equal(t, &testSudoRole, role)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(&ldap.SearchResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		role, cErr := client.Sudoers.Get(testSudoRole.Cn)
+		assert.Nil(t, role)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestSudoersManager_GetForUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(sudoUser=%web-admins)(objectClass=sudoRole))", sr.Filter)
+			}).
+			Return(getSudoRoleSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		roles, cErr := client.Sudoers.GetForUser("%web-admins")
+		assert.Nil(t, cErr)
+		assert.Equal(t, []SudoRole{testSudoRole}, roles)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testSudoersConfig, UnitTesting())
+
+		roles, cErr := client.Sudoers.GetForUser("")
+		assert.Nil(t, roles)
+		assert.Equal(t, missingParametersError([]string{sudoUserAttr}), cErr)
+	})
+}
+
+func TestSudoersManager_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest(testSudoRole.Dn, nil)
+		ar.Attribute(objectClassAttr, defaultObjectClassesSudoRole)
+		ar.Attribute(CommonNameAttr, []string{testSudoRole.Cn})
+		ar.Attribute(sudoUserAttr, testSudoRole.Users)
+		ar.Attribute(sudoHostAttr, testSudoRole.Hosts)
+		ar.Attribute(sudoCommandAttr, testSudoRole.Commands)
+		ar.Attribute(sudoRunAsUserAttr, testSudoRole.RunAsUsers)
+		ar.Attribute(sudoOptionAttr, testSudoRole.Options)
+		var events []Event
+		client.On(EventSudoRoleCreated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Sudoers.Create(testSudoRole))
+		assert.Len(t, events, 1)
+		assert.Equal(t, testSudoRole.Dn, events[0].Dn)
+	})
+
+	t.Run("success: defaults", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+		ar := ldap.NewAddRequest("cn=defaults,ou=SUDOers,o=company", nil)
+		ar.Attribute(objectClassAttr, defaultObjectClassesSudoRole)
+		ar.Attribute(CommonNameAttr, []string{SudoDefaultsCn})
+		ar.Attribute(sudoOptionAttr, []string{"env_reset"})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Sudoers.Create(SudoRole{Cn: SudoDefaultsCn, Options: []string{"env_reset"}}))
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, mock.AnythingOfType("*ldap.AddRequest")).Return(ldapEntryAlreadyExistsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Sudoers.Create(testSudoRole)
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testSudoersConfig, UnitTesting())
+
+		cErr := client.Sudoers.Create(SudoRole{Cn: "web-admins"})
+		assert.Equal(t, missingParametersError([]string{sudoUserAttr, sudoHostAttr, sudoCommandAttr}), cErr)
+	})
+}
+
+func TestSudoersManager_Update(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+		mr := ldap.NewModifyRequest(testSudoRole.Dn, nil)
+		mr.Replace(sudoUserAttr, []string{"C00002"})
+		mr.Replace(sudoHostAttr, []string{"ALL"})
+		mr.Replace(sudoCommandAttr, []string{"ALL"})
+		mr.Replace(sudoRunAsUserAttr, []string{})
+		mr.Replace(sudoOptionAttr, []string{})
+		mr.Replace(descriptionAttr, []string{"Admins"})
+		var events []EventType
+		client.On(EventSudoRoleUpdated, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Sudoers.Update(SudoRole{Cn: testSudoRole.Cn, Users: []string{"C00002"},
+			Hosts: []string{"ALL"}, Commands: []string{"ALL"}, Description: "Admins"}))
+		assert.Equal(t, []EventType{EventSudoRoleUpdated}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mock.AnythingOfType("*ldap.ModifyRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Sudoers.Update(testSudoRole)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestSudoersManager_Delete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+		var events []EventType
+		client.On(EventSudoRoleDeleted, func(event Event) { events = append(events, event.Type) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, ldap.NewDelRequest(testSudoRole.Dn, nil)).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Sudoers.Delete(testSudoRole.Cn))
+		assert.Equal(t, []EventType{EventSudoRoleDeleted}, events)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testSudoersConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameDelete, mock.AnythingOfType("*ldap.DelRequest")).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Sudoers.Delete(testSudoRole.Cn)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
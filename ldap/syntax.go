@@ -0,0 +1,68 @@
+package ldap
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"unicode/utf8"
+)
+
+const (
+	// MaxUidLength is the maximum number of characters of a uid when the syntax validation is enabled.
+	MaxUidLength = 64
+	// MaxDisplayNameLength is the maximum number of characters of a display name when the syntax validation is
+	// enabled.
+	MaxDisplayNameLength = 256
+
+	invalidMailErrMsg        = "Invalid mail '%s'. The mail must be an email address like 'john.doe@company.com'"
+	invalidUidErrMsg         = "Invalid uid '%s'. The uid must be 1 to %d characters long and may only contain letters, digits, '.', '_' and '-'"
+	displayNameTooLongErrMsg = "Invalid displayName. The displayName must not be longer than %d characters"
+)
+
+// uidRegex matches the characters allowed in a uid when the syntax validation is enabled.
+var uidRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// validateUserSyntax checks the syntax of the mail, the uid and the display name of a User if the syntax validation
+// is enabled in the client Config. All the invalid fields are reported at once.
+func (um *usersManager) validateUserSyntax(user User) *Error {
+	if !um.Client.getConfig().ValidateSyntax {
+		return nil
+	}
+	var fields []FieldError
+	if !isValidUid(user.Uid) {
+		fields = append(fields, FieldError{
+			Field:   userIdAttr,
+			Code:    FieldErrCodeInvalid,
+			Message: fmt.Sprintf(invalidUidErrMsg, user.Uid, MaxUidLength),
+		})
+	}
+	if !isValidMail(user.Mail) {
+		fields = append(fields, FieldError{
+			Field:   mailAttr,
+			Code:    FieldErrCodeInvalid,
+			Message: fmt.Sprintf(invalidMailErrMsg, user.Mail),
+		})
+	}
+	if utf8.RuneCountInString(user.DisplayName) > MaxDisplayNameLength {
+		fields = append(fields, FieldError{
+			Field:   displayNameAttr,
+			Code:    FieldErrCodeInvalid,
+			Message: fmt.Sprintf(displayNameTooLongErrMsg, MaxDisplayNameLength),
+		})
+	}
+	if len(fields) > 0 {
+		return invalidFieldsError(fields)
+	}
+	return nil
+}
+
+// isValidUid checks if the uid only contains the allowed characters and is not too long.
+func isValidUid(uid string) bool {
+	return len(uid) <= MaxUidLength && uidRegex.MatchString(uid)
+}
+
+// isValidMail checks if the mail is a plain RFC 5322 email address without a display name or angle brackets.
+func isValidMail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
@@ -0,0 +1,74 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersManager_validateUserSyntax(t *testing.T) {
+	invalidUser := testUser1
+	invalidUser.Uid = "C0000 1"
+	invalidUser.Mail = "John Doe <john.doe@company.com>"
+	invalidUser.DisplayName = strings.Repeat("a", MaxDisplayNameLength+1)
+
+	t.Run("disabled", func(t *testing.T) {
+		um := usersManager{Client: NewClient(testConfig)}
+		assert.Nil(t, um.validateUserSyntax(invalidUser))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		config := testConfig
+		config.ValidateSyntax = true
+		um := usersManager{Client: NewClient(config)}
+		for _, user := range []User{testUser1, testUser3, testUser4} {
+			assert.Nil(t, um.validateUserSyntax(user))
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		config := testConfig
+		config.ValidateSyntax = true
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		cErr := client.Users.Create(invalidUser)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, strings.Join([]string{
+			fmt.Sprintf(invalidUidErrMsg, invalidUser.Uid, MaxUidLength),
+			fmt.Sprintf(invalidMailErrMsg, invalidUser.Mail),
+			fmt.Sprintf(displayNameTooLongErrMsg, MaxDisplayNameLength),
+		}, invalidFieldsErrMsgSeparator), cErr.Message)
+
+		validationErr := cErr.Validation
+		assert.Len(t, validationErr.Fields, 3)
+		assert.Equal(t, FieldErrCodeInvalid, validationErr.Field(userIdAttr).Code)
+		assert.Equal(t, FieldErrCodeInvalid, validationErr.Field(mailAttr).Code)
+		assert.Equal(t, FieldErrCodeInvalid, validationErr.Field(displayNameAttr).Code)
+	})
+}
+
+func TestIsValidUid(t *testing.T) {
+	assert.True(t, isValidUid("C00001"))
+	assert.True(t, isValidUid("nxrm-ado-agent"))
+	assert.True(t, isValidUid("john.doe_1"))
+	assert.True(t, isValidUid(strings.Repeat("a", MaxUidLength)))
+	assert.False(t, isValidUid(strings.Repeat("a", MaxUidLength+1)))
+	assert.False(t, isValidUid("john doe"))
+	assert.False(t, isValidUid("john,doe"))
+	assert.False(t, isValidUid("jöhn"))
+	assert.False(t, isValidUid(""))
+}
+
+func TestIsValidMail(t *testing.T) {
+	assert.True(t, isValidMail("john.doe@company.com"))
+	assert.True(t, isValidMail("john+ldap@sub.company.com"))
+	assert.False(t, isValidMail("john.doe"))
+	assert.False(t, isValidMail("john.doe@"))
+	assert.False(t, isValidMail("John Doe <john.doe@company.com>"))
+	assert.False(t, isValidMail(" john.doe@company.com"))
+}
@@ -0,0 +1,133 @@
+package ldap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	templateParam          = "template"
+	unknownUserTemplateMsg = "Unknown user template '%s'. Valid templates are %v"
+)
+
+// templateAttributes are the attributes of a user for which a template can hold a default value.
+var templateAttributes = append(append([]string{}, userCSVColumns...), userContactAttributes...)
+
+type (
+	// UserTemplate describes how the user entries of a kind of account, e.g. "employee" or "builder-account", are
+	// created, so that all the accounts of the kind are consistent.
+	UserTemplate struct {
+		// Name identifies the template.
+		Name string
+		// ObjectClasses replace the default object classes of the user entry. Optional.
+		ObjectClasses []string
+		// Defaults holds the values of the attributes which are used when the attribute is not set on the user,
+		// e.g. the Status or the Sn of technical users.
+		Defaults User
+		// UidSuffix is appended to the uid unless the uid already ends with the suffix, e.g. "_BUILDER".
+		UidSuffix string
+		// Groups are the groups the user is added to after the user entry is created.
+		Groups []GroupMembership
+	}
+
+	// GroupMembership identifies a group by its cn and the name or path of its organizational unit.
+	GroupMembership struct {
+		Cn string `json:"cn" yaml:"cn"`
+		Ou string `json:"ou" yaml:"ou"`
+	}
+
+	// userTemplates holds the user templates registered on a client.
+	userTemplates struct {
+		mu        sync.RWMutex
+		templates map[string]UserTemplate
+	}
+)
+
+// WithUserTemplates registers the user templates on the client.
+func WithUserTemplates(templates ...UserTemplate) ClientOption {
+	return func(c *Client) {
+		for _, template := range templates {
+			c.RegisterUserTemplate(template)
+		}
+	}
+}
+
+// RegisterUserTemplate registers the user template on the client, a template registered before with the same name
+// is replaced.
+func (c *Client) RegisterUserTemplate(template UserTemplate) {
+	c.userTemplates.add(template)
+}
+
+// UserTemplate returns the user template registered with the name and whether the template was found.
+func (c *Client) UserTemplate(name string) (UserTemplate, bool) {
+	return c.userTemplates.get(name)
+}
+
+// Apply returns the user with the defaults and the naming conventions of the template applied.
+func (t UserTemplate) Apply(user User) User {
+	for _, attr := range templateAttributes {
+		if userAttributeValue(user, attr) == "" {
+			setUserAttributeValue(&user, attr, userAttributeValue(t.Defaults, attr))
+		}
+	}
+	if t.UidSuffix != "" && user.Uid != "" && !strings.HasSuffix(user.Uid, t.UidSuffix) {
+		user.Uid += t.UidSuffix
+	}
+	return user
+}
+
+// CreateFromTemplate creates a new user entry in LDAP using a user template registered on the client.
+// params:
+//
+//	templateName = the name of the template
+//	user = the attributes of the user, the attributes which are not set are taken from the template
+//
+// The defaults and the naming conventions of the template are applied to the user, the user entry is created with
+// the object classes of the template and the user is added to the groups of the template. If the user cannot be
+// added to one of the groups, the user entry is rolled back like by CreateInGroups.
+// The method returns an error:
+//   - if the template is not registered
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+//   - if a group of the template is not found
+func (um *usersManager) CreateFromTemplate(templateName string, user User) *Error {
+	template, ok := um.Client.UserTemplate(templateName)
+	if !ok {
+		return invalidParameterError(templateParam,
+			fmt.Sprintf(unknownUserTemplateMsg, templateName, um.Client.userTemplates.names()))
+	}
+	return um.createInGroups(template.Apply(user), template.ObjectClasses, template.Groups)
+}
+
+// add registers the template.
+func (ut *userTemplates) add(template UserTemplate) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	if ut.templates == nil {
+		ut.templates = make(map[string]UserTemplate)
+	}
+	ut.templates[template.Name] = template
+}
+
+// get returns the template registered with the name.
+func (ut *userTemplates) get(name string) (UserTemplate, bool) {
+	ut.mu.RLock()
+	defer ut.mu.RUnlock()
+	template, ok := ut.templates[name]
+	return template, ok
+}
+
+// names returns the sorted names of the registered templates.
+func (ut *userTemplates) names() []string {
+	ut.mu.RLock()
+	defer ut.mu.RUnlock()
+	names := make([]string, 0, len(ut.templates))
+	for name := range ut.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,100 @@
+package ldap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var testBuilderTemplate = UserTemplate{
+	Name:          "builder-account",
+	ObjectClasses: []string{"inetOrgPerson", "top", "userExtras"},
+	Defaults: User{
+		Sn:     "Technical User",
+		Status: UserStatusActive,
+	},
+	UidSuffix: "_BUILDER",
+	Groups:    []GroupMembership{{Cn: testGroupCn1, Ou: testOrganizationUnit1}},
+}
+
+func TestClient_RegisterUserTemplate(t *testing.T) {
+	client := NewClient(testConfig, WithUserTemplates(testBuilderTemplate))
+
+	template, ok := client.UserTemplate(testBuilderTemplate.Name)
+	assert.True(t, ok)
+	assert.Equal(t, testBuilderTemplate, template)
+
+	_, ok = client.UserTemplate("unknown")
+	assert.False(t, ok)
+
+	client.RegisterUserTemplate(UserTemplate{Name: testBuilderTemplate.Name})
+	template, _ = client.UserTemplate(testBuilderTemplate.Name)
+	assert.Equal(t, "", template.UidSuffix)
+}
+
+func TestUserTemplate_Apply(t *testing.T) {
+	user := testBuilderTemplate.Apply(User{Uid: "ABC", Sn: "Builder"})
+	assert.Equal(t, "ABC_BUILDER", user.Uid)
+	assert.Equal(t, "Builder", user.Sn)
+	assert.Equal(t, UserStatusActive, user.Status)
+
+	user = testBuilderTemplate.Apply(User{Uid: "ABC_BUILDER"})
+	assert.Equal(t, "ABC_BUILDER", user.Uid)
+	assert.Equal(t, "Technical User", user.Sn)
+}
+
+func TestUsersManager_CreateFromTemplate(t *testing.T) {
+	user := User{
+		Uid:          "ABC",
+		AltUid:       "ABC_BUILDER",
+		Cn:           "ABC",
+		DisplayName:  "ABC Technical User",
+		Mail:         "abc@company.com",
+		UserPassword: "somePassword",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting(),
+			WithoutOrganizationalUnitValidation(), WithUserTemplates(testBuilderTemplate))
+		um := usersManager{Client: client}
+		gm := groupsManager{Client: client}
+		created := testBuilderTemplate.Apply(user)
+		ar := um.getAddRequest(created)
+		ar.Attributes[0].Vals = testBuilderTemplate.ObjectClasses
+		mr := gm.getModifyRequest(testGroupCn1, testOrganizationUnit1)
+		mr.Add(uniqueMemberAttr, []string{gm.getUniqueMemberDn(created.Uid)})
+		mr.Delete(uniqueMemberAttr, []string{gm.getUniqueMemberDn(noSuchUserGroupMemberCn)})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameAdd, ar).Return(nil)
+		ldapMock.On("PasswordModify",
+			um.getPasswordModifyRequest(created.Uid, created.UserPassword, created.UserPassword)).Return(nil, nil)
+		ldapMock.On(methodNameSearch, gm.getSearchRequest(testGroupCn1, testOrganizationUnit1, groupSearchFilter)).
+			Return(getGroupSearchResult2, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserProvisioner).CreateFromTemplate(testBuilderTemplate.Name, user))
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		client := NewClient(testConfig, WithUserTemplates(testBuilderTemplate))
+
+		cErr := client.Users.(UserProvisioner).CreateFromTemplate("employee", user)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(unknownUserTemplateMsg, "employee", []string{testBuilderTemplate.Name}),
+			cErr.Message)
+	})
+
+	t.Run("validate user", func(t *testing.T) {
+		client := NewClient(testConfig, WithUserTemplates(testBuilderTemplate))
+
+		cErr := client.Users.(UserProvisioner).CreateFromTemplate(testBuilderTemplate.Name, User{Uid: "ABC"})
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Nil(t, cErr.Validation.Field(familyNameAttr))
+	})
+}
@@ -0,0 +1,183 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	tlsMinVersionParam   = "tlsMinVersion"
+	tlsCipherSuitesParam = "tlsCipherSuites"
+
+	invalidTLSVersionMsg     = "Invalid TLS version '%s'. Valid versions are %v"
+	invalidTLSCipherSuiteMsg = "Invalid cipher suite '%s'. Valid cipher suites are %v"
+	fipsTLSVersionMsg        = "The TLS version '%s' is not approved in FIPS mode. Valid versions are %v"
+	fipsTLSCipherSuiteMsg    = "The cipher suite '%s' is not approved in FIPS mode. Valid cipher suites are %v"
+	fipsPasswordSchemeMsg    = "The password hash scheme '%s' is not approved in FIPS mode. Valid schemes are %v"
+)
+
+var (
+	// tlsVersionNames are the valid values of the TLSMinVersion set in the client Config.
+	tlsVersionNames = []string{"1.0", "1.1", "1.2", "1.3"}
+	tlsVersions     = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+
+	// fipsTLSVersions are the TLS versions approved by NIST SP 800-52.
+	fipsTLSVersions = []string{"1.2", "1.3"}
+	// fipsTLSCipherSuites are the TLS 1.2 cipher suites approved by NIST SP 800-52, which are used in FIPS mode if
+	// no TLSCipherSuites are set in the client Config.
+	fipsTLSCipherSuites = []string{
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	}
+	// fipsTLSCurves are the NIST curves used for the key exchange in FIPS mode.
+	fipsTLSCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+	// fipsPasswordSchemes are the password hash schemes approved in FIPS mode. The schemes based on MD5, SHA-1 and
+	// crypt, e.g. {MD5}, {SSHA} and {CRYPT}, are rejected.
+	fipsPasswordSchemes = []string{
+		"SHA256", "SSHA256", "SHA384", "SSHA384", "SHA512", "SSHA512",
+		"PBKDF2-SHA256", "PBKDF2-SHA384", "PBKDF2-SHA512",
+	}
+	// passwordSchemeRegex matches the scheme prefix of a hashed userPassword value, e.g. {SSHA512}.
+	passwordSchemeRegex = regexp.MustCompile(`^\{([A-Za-z0-9.+-]+)}`)
+)
+
+// tlsCipherSuiteNames returns the names of the secure cipher suites implemented by crypto/tls, which are the valid
+// TLSCipherSuites of the client Config.
+func tlsCipherSuiteNames() []string {
+	var names []string
+	for _, suite := range tls.CipherSuites() {
+		names = append(names, suite.Name)
+	}
+	return names
+}
+
+// tlsCipherSuiteID returns the id of the secure cipher suite with the name and whether the cipher suite exists.
+func tlsCipherSuiteID(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
+
+// validateTLS checks if the TLSMinVersion and the TLSCipherSuites set in the client Config are valid and, in FIPS
+// mode, approved.
+func (cnf Config) validateTLS() *Error {
+	if cnf.TLSMinVersion != "" {
+		if _, ok := tlsVersions[cnf.TLSMinVersion]; !ok {
+			return invalidParameterError(tlsMinVersionParam,
+				fmt.Sprintf(invalidTLSVersionMsg, cnf.TLSMinVersion, tlsVersionNames))
+		}
+		if cnf.FIPSMode && !slice.EntryExists(fipsTLSVersions, cnf.TLSMinVersion) {
+			return invalidParameterError(tlsMinVersionParam,
+				fmt.Sprintf(fipsTLSVersionMsg, cnf.TLSMinVersion, fipsTLSVersions))
+		}
+	}
+	for _, name := range cnf.TLSCipherSuites {
+		if _, ok := tlsCipherSuiteID(name); !ok {
+			return invalidParameterError(tlsCipherSuitesParam,
+				fmt.Sprintf(invalidTLSCipherSuiteMsg, name, tlsCipherSuiteNames()))
+		}
+		if cnf.FIPSMode && !slice.EntryExists(fipsTLSCipherSuites, name) {
+			return invalidParameterError(tlsCipherSuitesParam,
+				fmt.Sprintf(fipsTLSCipherSuiteMsg, name, fipsTLSCipherSuites))
+		}
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration of the connections with the LDAP server using the ldaps protocol, based
+// on the validated client Config. In FIPS mode the TLS version defaults to TLS 1.2, the cipher suites default to the
+// approved cipher suites and only the NIST curves are used for the key exchange.
+func (cnf Config) tlsConfig() *tls.Config {
+	tlsConfig := &tls.Config{
+		ServerName: cnf.host(),
+		MinVersion: tlsVersions[cnf.TLSMinVersion],
+	}
+	suites := cnf.TLSCipherSuites
+	if cnf.FIPSMode {
+		if tlsConfig.MinVersion == 0 {
+			tlsConfig.MinVersion = tls.VersionTLS12
+		}
+		if len(suites) == 0 {
+			suites = fipsTLSCipherSuites
+		}
+		tlsConfig.CurvePreferences = fipsTLSCurves
+	}
+	for _, name := range suites {
+		id, _ := tlsCipherSuiteID(name)
+		tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, id)
+	}
+	return tlsConfig
+}
+
+// checkPasswordSchemes checks, in FIPS mode, that the passwords set by a request, or by the requests of a batch, are
+// not hashed with a scheme which is not approved. Clear text passwords are accepted, they are hashed by the LDAP
+// server according to its password policy.
+func (c *Client) checkPasswordSchemes(request any) *Error {
+	if !c.getConfig().FIPSMode {
+		return nil
+	}
+	requests := []any{request}
+	if batch, ok := request.(*BatchRequest); ok {
+		requests = batch.Requests
+	}
+	for _, r := range requests {
+		for _, password := range newPasswords(r) {
+			match := passwordSchemeRegex.FindStringSubmatch(password)
+			if match == nil {
+				continue
+			}
+			scheme := match[1]
+			if !slice.EntryExists(fipsPasswordSchemes, strings.ToUpper(scheme)) {
+				return invalidParameterError(userPasswordAttr,
+					fmt.Sprintf(fipsPasswordSchemeMsg, scheme, fipsPasswordSchemes))
+			}
+		}
+	}
+	return nil
+}
+
+// newPasswords returns the userPassword values added or replaced by a go-ldap request and the new password of a
+// password modify.
+func newPasswords(request any) []string {
+	var passwords []string
+	switch r := request.(type) {
+	case *ldap.PasswordModifyRequest:
+		passwords = append(passwords, r.NewPassword)
+	case *ldap.AddRequest:
+		for _, attribute := range r.Attributes {
+			if isPasswordAttribute(attribute.Type) {
+				passwords = append(passwords, attribute.Vals...)
+			}
+		}
+	case *ldap.ModifyRequest:
+		for _, change := range r.Changes {
+			if change.Operation != ldap.DeleteAttribute && isPasswordAttribute(change.Modification.Type) {
+				passwords = append(passwords, change.Modification.Vals...)
+			}
+		}
+	}
+	return passwords
+}
+
+// isPasswordAttribute checks if the attribute is the userPassword attribute, ignoring the case and the attribute
+// options.
+func isPasswordAttribute(attr string) bool {
+	attr, _, _ = strings.Cut(attr, ";")
+	return strings.EqualFold(attr, userPasswordAttr)
+}
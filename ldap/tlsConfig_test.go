@@ -0,0 +1,146 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestConfig_validateTLS(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		config := testConfig
+		config.TLSMinVersion = "1.3"
+		config.TLSCipherSuites = []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}
+		assert.Nil(t, config.validateTLS())
+
+		config.FIPSMode = true
+		config.TLSMinVersion = "1.2"
+		config.TLSCipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}
+		assert.Nil(t, config.validateTLS())
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		config := testConfig
+		config.TLSMinVersion = "1.4"
+
+		_, cErr := NewClientE(config)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(tlsMinVersionParam).Code)
+	})
+
+	t.Run("invalid cipher suite", func(t *testing.T) {
+		config := testConfig
+		config.TLSCipherSuites = []string{"TLS_RSA_WITH_RC4_128_SHA"}
+
+		cErr := config.validateTLS()
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(tlsCipherSuitesParam).Code)
+	})
+
+	t.Run("fips mode", func(t *testing.T) {
+		config := testConfig
+		config.FIPSMode = true
+		config.TLSMinVersion = "1.1"
+
+		cErr := config.validateTLS()
+		assert.Contains(t, cErr.Validation.Field(tlsMinVersionParam).Message, "not approved in FIPS mode")
+
+		config.TLSMinVersion = ""
+		config.TLSCipherSuites = []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}
+		cErr = config.validateTLS()
+		assert.Contains(t, cErr.Validation.Field(tlsCipherSuitesParam).Message, "not approved in FIPS mode")
+	})
+}
+
+func TestConfig_tlsConfig(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		tlsConfig := testConfig.tlsConfig()
+		assert.Equal(t, "ldap.company.com", tlsConfig.ServerName)
+		assert.Zero(t, tlsConfig.MinVersion)
+		assert.Nil(t, tlsConfig.CipherSuites)
+		assert.Nil(t, tlsConfig.CurvePreferences)
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		config := testConfig
+		config.TLSMinVersion = "1.2"
+		config.TLSCipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}
+
+		tlsConfig := config.tlsConfig()
+		assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+		assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}, tlsConfig.CipherSuites)
+	})
+
+	t.Run("fips mode", func(t *testing.T) {
+		config := testConfig
+		config.FIPSMode = true
+
+		tlsConfig := config.tlsConfig()
+		assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+		assert.Equal(t, []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		}, tlsConfig.CipherSuites)
+		assert.Equal(t, []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}, tlsConfig.CurvePreferences)
+
+		config.TLSMinVersion = "1.3"
+		assert.Equal(t, uint16(tls.VersionTLS13), config.tlsConfig().MinVersion)
+	})
+}
+
+func TestClient_checkPasswordSchemes(t *testing.T) {
+	t.Run("non-approved scheme", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.FIPSMode = true
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		_, cErr := client.Users.SetNewPassword(testUser1.Uid, "{SSHA}c2VjcmV0")
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userPasswordAttr).Code)
+		assert.NotContains(t, cErr.Message, "c2VjcmV0")
+
+		mr := ldap.NewModifyRequest("uid=C00001,ou=users,o=company", nil)
+		mr.Replace("userPassword;binary", []string{"{crypt}$1$abc"})
+		cErr = client.doLDAPModify(mr)
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userPasswordAttr).Code)
+
+		ar := ldap.NewAddRequest("uid=C00001,ou=users,o=company", nil)
+		ar.Attribute(userPasswordAttr, []string{"{MD5}abc"})
+		_, cErr = client.doLDAPBatch([]any{ar})
+		assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userPasswordAttr).Code)
+	})
+
+	t.Run("approved scheme and clear text", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.FIPSMode = true
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).
+			Return(&ldap.PasswordModifyResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.SetNewPassword(testUser1.Uid, "{SSHA512}c2VjcmV0")
+		assert.Nil(t, cErr)
+		_, cErr = client.Users.SetNewPassword(testUser1.Uid, "N3w-Secret!")
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On("PasswordModify", mock.AnythingOfType("*ldap.PasswordModifyRequest")).
+			Return(&ldap.PasswordModifyResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := client.Users.SetNewPassword(testUser1.Uid, "{SSHA}c2VjcmV0")
+		assert.Nil(t, cErr)
+	})
+}
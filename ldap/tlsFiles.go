@@ -0,0 +1,176 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/logger"
+)
+
+const (
+	tlsCertFileParam = "tlsCertFile"
+	tlsKeyFileParam  = "tlsKeyFile"
+
+	defaultTLSReloadInterval = time.Minute
+
+	tlsKeyPairMissingMsg   = "The TLSCertFile and the TLSKeyFile must be set together"
+	tlsFileReadErrMsg      = "Unable to read the TLS file '%s': %v"
+	tlsCAFileInvalidErrMsg = "The TLS CA file '%s' does not contain any PEM encoded certificate"
+	tlsKeyPairErrMsg       = "Unable to load the TLS client certificate '%s' and key '%s': %v"
+	tlsFilesReloadedMsg    = "The TLS files %v changed and were reloaded"
+	tlsFilesReloadErrMsg   = "The TLS files %v changed but could not be reloaded, the previous certificates are used: %v"
+)
+
+type (
+	// tlsFiles loads the CA certificates and the client certificate of the connections using the ldaps protocol from
+	// the files set in the client Config. The files are checked for changes at most once per TLSReloadInterval when a
+	// connection is opened and reloaded if their modification time or size changed, so rotated certificates are used
+	// by long-running services without a restart.
+	tlsFiles struct {
+		mu          sync.Mutex
+		paths       []string
+		stamps      []tlsFileStamp
+		checked     time.Time
+		rootCAs     *x509.CertPool
+		certificate *tls.Certificate
+	}
+
+	// tlsFileStamp identifies the version of a file.
+	tlsFileStamp struct {
+		modTime time.Time
+		size    int64
+	}
+)
+
+// tlsFilePaths returns the paths of the TLS files set in the client Config.
+func (cnf Config) tlsFilePaths() []string {
+	return []string{cnf.TLSCAFile, cnf.TLSCertFile, cnf.TLSKeyFile}
+}
+
+// hasTLSFiles checks if any TLS file is set in the client Config.
+func (cnf Config) hasTLSFiles() bool {
+	return cnf.TLSCAFile != "" || cnf.TLSCertFile != "" || cnf.TLSKeyFile != ""
+}
+
+// tlsReloadInterval returns the interval the TLS files are checked for changes. Defaults to 1 minute.
+func (cnf Config) tlsReloadInterval() time.Duration {
+	if cnf.TLSReloadInterval <= 0 {
+		return defaultTLSReloadInterval
+	}
+	return cnf.TLSReloadInterval
+}
+
+// validateTLSFiles checks if the TLSCertFile and the TLSKeyFile set in the client Config are set together.
+func (cnf Config) validateTLSFiles() *Error {
+	if cnf.TLSCertFile != "" && cnf.TLSKeyFile == "" {
+		return invalidParameterError(tlsKeyFileParam, tlsKeyPairMissingMsg)
+	}
+	if cnf.TLSKeyFile != "" && cnf.TLSCertFile == "" {
+		return invalidParameterError(tlsCertFileParam, tlsKeyPairMissingMsg)
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration of the connections with the LDAP server based on the client Config,
+// including the certificates loaded from the TLS files. The method returns an error if the TLS files cannot be loaded.
+func (c *Client) tlsConfig(config Config) (*tls.Config, error) {
+	tlsConfig := config.tlsConfig()
+	if config.Protocol != ProtocolLdaps || !config.hasTLSFiles() {
+		return tlsConfig, nil
+	}
+	rootCAs, certificate, err := c.tlsFiles.load(config)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.RootCAs = rootCAs
+	if certificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*certificate}
+	}
+	return tlsConfig, nil
+}
+
+// load returns the CA certificates and the client certificate of the TLS files set in the client Config. The files
+// are read again if they changed since they were last loaded. If the changed files cannot be loaded, e.g. while the
+// certificate is written but not yet the key, a warning is logged and the certificates loaded before are returned.
+// The method returns an error if the files cannot be loaded and no certificates were loaded before.
+func (f *tlsFiles) load(config Config) (*x509.CertPool, *tls.Certificate, error) {
+	paths := config.tlsFilePaths()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	loaded := slices.Equal(paths, f.paths)
+	if loaded && time.Since(f.checked) < config.tlsReloadInterval() {
+		return f.rootCAs, f.certificate, nil
+	}
+	stamps, err := tlsFileStamps(paths)
+	if err == nil && loaded && slices.Equal(stamps, f.stamps) {
+		f.checked = time.Now()
+		return f.rootCAs, f.certificate, nil
+	}
+	var rootCAs *x509.CertPool
+	var certificate *tls.Certificate
+	if err == nil {
+		rootCAs, certificate, err = loadTLSFiles(config)
+	}
+	if err != nil {
+		if loaded {
+			f.checked = time.Now()
+			logger.Warnf(tlsFilesReloadErrMsg, paths, err)
+			return f.rootCAs, f.certificate, nil
+		}
+		return nil, nil, err
+	}
+	if loaded {
+		logger.Infof(tlsFilesReloadedMsg, paths)
+	}
+	f.paths, f.stamps, f.checked = paths, stamps, time.Now()
+	f.rootCAs, f.certificate = rootCAs, certificate
+	return rootCAs, certificate, nil
+}
+
+// tlsFileStamps returns the stamps of the files, the stamp of an empty path is empty.
+func tlsFileStamps(paths []string) ([]tlsFileStamp, error) {
+	stamps := make([]tlsFileStamp, len(paths))
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf(tlsFileReadErrMsg, path, err)
+		}
+		stamps[i] = tlsFileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
+
+// loadTLSFiles reads the CA certificates and the client certificate of the TLS files set in the client Config. The
+// CA certificates are nil if no TLSCAFile is set, the system roots are used then, and the client certificate is nil
+// if no TLSCertFile is set.
+func loadTLSFiles(config Config) (*x509.CertPool, *tls.Certificate, error) {
+	var rootCAs *x509.CertPool
+	if config.TLSCAFile != "" {
+		pem, err := os.ReadFile(config.TLSCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf(tlsFileReadErrMsg, config.TLSCAFile, err)
+		}
+		rootCAs = x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, nil, fmt.Errorf(tlsCAFileInvalidErrMsg, config.TLSCAFile)
+		}
+	}
+	var certificate *tls.Certificate
+	if config.TLSCertFile != "" {
+		keyPair, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf(tlsKeyPairErrMsg, config.TLSCertFile, config.TLSKeyFile, err)
+		}
+		certificate = &keyPair
+	}
+	return rootCAs, certificate, nil
+}
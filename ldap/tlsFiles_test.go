@@ -0,0 +1,145 @@
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCertificate writes a self-signed certificate with the common name and its private key as PEM files and
+// sets their modification time.
+func writeTestCertificate(t *testing.T, certFile, keyFile, commonName string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	assert.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	assert.Nil(t, os.Chtimes(certFile, modTime, modTime))
+	assert.Nil(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+// getTLSFilesConfig returns the test config with a CA file, a client certificate and a key in a temporary directory.
+func getTLSFilesConfig(t *testing.T) Config {
+	dir := t.TempDir()
+	config := testConfig
+	config.TLSCAFile = filepath.Join(dir, "ca.pem")
+	config.TLSCertFile = filepath.Join(dir, "client.pem")
+	config.TLSKeyFile = filepath.Join(dir, "client.key")
+	modTime := time.Now().Add(-time.Hour)
+	writeTestCertificate(t, config.TLSCAFile, filepath.Join(dir, "ca.key"), "CA 1", modTime)
+	writeTestCertificate(t, config.TLSCertFile, config.TLSKeyFile, "client 1", modTime)
+	return config
+}
+
+// leafCommonName returns the common name of the client certificate of the TLS configuration.
+func leafCommonName(t *testing.T, client *Client, config Config) string {
+	tlsConfig, err := client.tlsConfig(config)
+	assert.Nil(t, err)
+	leaf, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	assert.Nil(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestConfig_validateTLSFiles(t *testing.T) {
+	config := testConfig
+	config.TLSCertFile = "client.pem"
+	cErr := config.validateTLSFiles()
+	assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(tlsKeyFileParam).Code)
+
+	config.TLSCertFile, config.TLSKeyFile = "", "client.key"
+	cErr = config.validateTLSFiles()
+	assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(tlsCertFileParam).Code)
+}
+
+func TestClient_tlsConfig(t *testing.T) {
+	t.Run("load", func(t *testing.T) {
+		config := getTLSFilesConfig(t)
+		client := NewClient(config)
+
+		tlsConfig, err := client.tlsConfig(config)
+		assert.Nil(t, err)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.Len(t, tlsConfig.Certificates, 1)
+		assert.Equal(t, "ldap.company.com", tlsConfig.ServerName)
+	})
+
+	t.Run("ldap protocol", func(t *testing.T) {
+		config := testConfig
+		config.Protocol = ProtocolLdap
+		config.TLSCAFile = "/does/not/exist.pem"
+		client := NewClient(config)
+
+		tlsConfig, err := client.tlsConfig(config)
+		assert.Nil(t, err)
+		assert.Nil(t, tlsConfig.RootCAs)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		config := getTLSFilesConfig(t)
+		client := NewClient(config)
+		assert.Equal(t, "client 1", leafCommonName(t, client, config))
+
+		writeTestCertificate(t, config.TLSCertFile, config.TLSKeyFile, "client 2", time.Now())
+		assert.Equal(t, "client 1", leafCommonName(t, client, config))
+
+		config.TLSReloadInterval = time.Nanosecond
+		assert.Equal(t, "client 2", leafCommonName(t, client, config))
+	})
+
+	t.Run("reload error", func(t *testing.T) {
+		config := getTLSFilesConfig(t)
+		config.TLSReloadInterval = time.Nanosecond
+		client := NewClient(config)
+		assert.Equal(t, "client 1", leafCommonName(t, client, config))
+
+		assert.Nil(t, os.WriteFile(config.TLSCAFile, []byte("rotation in progress"), 0600))
+		logs := captureLogs(t, func() {
+			assert.Equal(t, "client 1", leafCommonName(t, client, config))
+		})
+		assert.Contains(t, logs, "could not be reloaded")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		config := getTLSFilesConfig(t)
+		config.TLSCAFile = filepath.Join(t.TempDir(), "missing.pem")
+		client := NewClient(config)
+
+		_, err := client.tlsConfig(config)
+		assert.ErrorContains(t, err, "missing.pem")
+
+		_, cErr := NewClientE(config)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("invalid ca file", func(t *testing.T) {
+		config := getTLSFilesConfig(t)
+		assert.Nil(t, os.WriteFile(config.TLSCAFile, []byte("not a certificate"), 0600))
+		client := NewClient(config)
+
+		_, err := client.tlsConfig(config)
+		assert.ErrorContains(t, err, "does not contain any PEM encoded certificate")
+	})
+}
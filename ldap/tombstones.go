@@ -0,0 +1,185 @@
+package ldap
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// TombstoneSourceActiveDirectory is the source of the deleted entries read from the Active Directory
+	// Deleted Objects container.
+	TombstoneSourceActiveDirectory = "activeDirectory"
+	// TombstoneSourceAccessLog is the source of the deleted entries read from the OpenLDAP accesslog database.
+	TombstoneSourceAccessLog = "accesslog"
+
+	lastKnownParentAttr   = "lastKnownParent"
+	lastKnownRDNAttr      = "msDS-LastKnownRDN"
+	whenChangedAttr       = "whenChanged"
+	reqDNAttr             = "reqDN"
+	reqStartAttr          = "reqStart"
+	reqAuthzIDAttr        = "reqAuthzID"
+	adDeletedRDNSuffix    = "\nDEL:"
+	adDeletedFilter       = "(&(isDeleted=TRUE)(whenChanged>=%s))"
+	accessLogDeleteFilter = "(&(objectClass=auditDelete)(reqResult=0)(reqStart>=%s))"
+
+	tombstonesFeature = "reading of deleted entries, set the DeletedObjectsDN or the AccessLogBaseDN in the client Config"
+)
+
+type (
+	// TombstonesManager describes the interface which needs to be implemented for reading the entries which were
+	// recently deleted from LDAP, e.g. to propagate the deletions to a system which is synchronised with LDAP.
+	TombstonesManager interface {
+		GetDeleted(since time.Time, opts ...RequestOption) ([]DeletedEntry, *Error)
+	}
+
+	// tombstonesManager implements the TombstonesManager interface.
+	tombstonesManager struct {
+		Client *Client
+	}
+
+	// DeletedEntry represents an entry which was deleted from LDAP.
+	DeletedEntry struct {
+		// Dn is the dn the entry had before it was deleted.
+		Dn        string    `json:"dn"`
+		DeletedAt time.Time `json:"deletedAt"`
+		// DeletedBy is the dn of the user who deleted the entry, only known for the entries read from the accesslog.
+		DeletedBy string `json:"deletedBy,omitempty"`
+		// ObjectClasses are the object classes of the entry, only known for the entries read from Active Directory.
+		ObjectClasses []string `json:"objectClasses,omitempty"`
+		// Source is where the deleted entry was read from, TombstoneSourceActiveDirectory or TombstoneSourceAccessLog.
+		Source string `json:"source"`
+	}
+)
+
+// WithTombstonesManager overrides the default TombstonesManager.
+func WithTombstonesManager(tm TombstonesManager) ClientOption {
+	return func(c *Client) {
+		c.Tombstones = tm
+	}
+}
+
+// GetDeleted retrieves the entries which were deleted from LDAP since the given time, ordered by the time they were
+// deleted.
+// params:
+//
+//	since = the time from which on the deleted entries are retrieved
+//
+// The deleted entries are read from the Active Directory Deleted Objects container if the DeletedObjectsDN is set in
+// the client Config, using the Show Deleted control, and from the OpenLDAP accesslog database if the AccessLogBaseDN
+// is set in the client Config. How long the deleted entries can be read depends on the tombstone lifetime of Active
+// Directory or the purge interval of the accesslog.
+// The method returns an error:
+//   - if neither the DeletedObjectsDN nor the AccessLogBaseDN is set in the client Config
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (tm *tombstonesManager) GetDeleted(since time.Time, opts ...RequestOption) ([]DeletedEntry, *Error) {
+	cnf := tm.Client.getConfig()
+	if cnf.DeletedObjectsDN == "" && cnf.AccessLogBaseDN == "" {
+		return nil, unsupportedFeatureError(tombstonesFeature)
+	}
+	deleted := []DeletedEntry{}
+	if cnf.DeletedObjectsDN != "" {
+		entries, cErr := tm.getActiveDirectoryDeleted(cnf.DeletedObjectsDN, since, opts...)
+		if cErr != nil {
+			return nil, cErr
+		}
+		deleted = append(deleted, entries...)
+	}
+	if cnf.AccessLogBaseDN != "" {
+		entries, cErr := tm.getAccessLogDeleted(cnf.AccessLogBaseDN, since, opts...)
+		if cErr != nil {
+			return nil, cErr
+		}
+		deleted = append(deleted, entries...)
+	}
+	sort.SliceStable(deleted, func(i, j int) bool {
+		return deleted[i].DeletedAt.Before(deleted[j].DeletedAt)
+	})
+	return deleted, nil
+}
+
+// getActiveDirectoryDeleted retrieves the tombstones of the Active Directory Deleted Objects container.
+func (tm *tombstonesManager) getActiveDirectoryDeleted(deletedObjectsDN string, since time.Time,
+	opts ...RequestOption) ([]DeletedEntry, *Error) {
+	var deleted []DeletedEntry
+	sr := ldap.NewSearchRequest(
+		deletedObjectsDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		Filterf(adDeletedFilter, since.UTC().Format(generalizedTimeLayout)),
+		[]string{objectClassAttr, lastKnownParentAttr, lastKnownRDNAttr, whenChangedAttr},
+		[]ldap.Control{&ldap.ControlMicrosoftShowDeleted{}},
+	)
+	cErr := tm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			deletedAt, _ := parseGeneralizedTime(entry.GetAttributeValue(whenChangedAttr))
+			deleted = append(deleted, DeletedEntry{
+				Dn:            activeDirectoryOriginalDN(entry),
+				DeletedAt:     deletedAt,
+				ObjectClasses: entry.GetAttributeValues(objectClassAttr),
+				Source:        TombstoneSourceActiveDirectory,
+			})
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return deleted, nil
+}
+
+// getAccessLogDeleted retrieves the successful delete operations recorded in the OpenLDAP accesslog database.
+func (tm *tombstonesManager) getAccessLogDeleted(accessLogBaseDN string, since time.Time,
+	opts ...RequestOption) ([]DeletedEntry, *Error) {
+	var deleted []DeletedEntry
+	sr := ldap.NewSearchRequest(
+		accessLogBaseDN,
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		Filterf(accessLogDeleteFilter, since.UTC().Format(generalizedTimeLayout)),
+		[]string{reqDNAttr, reqStartAttr, reqAuthzIDAttr},
+		nil,
+	)
+	cErr := tm.Client.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			deletedAt, _ := parseGeneralizedTime(entry.GetAttributeValue(reqStartAttr))
+			deleted = append(deleted, DeletedEntry{
+				Dn:        entry.GetAttributeValue(reqDNAttr),
+				DeletedAt: deletedAt,
+				DeletedBy: authzDN(entry.GetAttributeValue(reqAuthzIDAttr)),
+				Source:    TombstoneSourceAccessLog,
+			})
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return deleted, nil
+}
+
+// activeDirectoryOriginalDN returns the dn an Active Directory tombstone had before it was deleted, which is built
+// from its last known rdn and parent. The rdn of the tombstone without the deletion suffix is used if the
+// msDS-LastKnownRDN attribute is not available.
+func activeDirectoryOriginalDN(entry *ldap.Entry) string {
+	parsed, err := ldap.ParseDN(entry.DN)
+	if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+		return entry.DN
+	}
+	attr := parsed.RDNs[0].Attributes[0]
+	value := entry.GetAttributeValue(lastKnownRDNAttr)
+	if value == "" {
+		value, _, _ = strings.Cut(attr.Value, adDeletedRDNSuffix)
+	}
+	return dn.Join(dn.RDN(attr.Type, value), entry.GetAttributeValue(lastKnownParentAttr))
+}
@@ -0,0 +1,112 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testTombstonesConfig = func() Config {
+		config := testConfig
+		config.DeletedObjectsDN = "CN=Deleted Objects,DC=company,DC=com"
+		config.AccessLogBaseDN = "cn=accesslog"
+		return config
+	}()
+
+	testTombstonesSince = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func searchRequestWithBaseDN(baseDN string) any {
+	return mock.MatchedBy(func(sr *ldap.SearchRequest) bool { return sr.BaseDN == baseDN })
+}
+
+func TestTombstonesManager_GetDeleted(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testTombstonesConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(testTombstonesConfig.DeletedObjectsDN)).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(isDeleted=TRUE)(whenChanged>=20240101000000Z))", sr.Filter)
+				assert.NotNil(t, ldap.FindControl(sr.Controls, ldap.ControlTypeMicrosoftShowDeleted))
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("CN=John Doe\\0ADEL:0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0,CN=Deleted Objects,DC=company,DC=com",
+					map[string][]string{
+						objectClassAttr:     {"top", "person", "user"},
+						lastKnownParentAttr: {"OU=users,DC=company,DC=com"},
+						lastKnownRDNAttr:    {"John Doe"},
+						whenChangedAttr:     {"20240103120000.0Z"},
+					}),
+			}}, nil)
+		ldapMock.On(methodNameSearch, searchRequestWithBaseDN(testTombstonesConfig.AccessLogBaseDN)).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, "(&(objectClass=auditDelete)(reqResult=0)(reqStart>=20240101000000Z))", sr.Filter)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{
+				ldap.NewEntry("reqStart=20240102080000.000001Z,cn=accesslog", map[string][]string{
+					reqDNAttr:      {"uid=C00001,ou=users,o=company"},
+					reqStartAttr:   {"20240102080000.000001Z"},
+					reqAuthzIDAttr: {"dn:cn=root,o=company"},
+				}),
+			}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		deleted, cErr := client.Tombstones.GetDeleted(testTombstonesSince)
+		assert.Nil(t, cErr)
+		assert.Equal(t, []DeletedEntry{
+			{
+				Dn:        "uid=C00001,ou=users,o=company",
+				DeletedAt: time.Date(2024, 1, 2, 8, 0, 0, 1000, time.UTC),
+				DeletedBy: "cn=root,o=company",
+				Source:    TombstoneSourceAccessLog,
+			},
+			{
+				Dn:            "CN=John Doe,OU=users,DC=company,DC=com",
+				DeletedAt:     time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC),
+				ObjectClasses: []string{"top", "person", "user"},
+				Source:        TombstoneSourceActiveDirectory,
+			},
+		}, deleted)
+	})
+
+	t.Run("last known rdn not available", func(t *testing.T) {
+		entry := ldap.NewEntry("CN=John Doe\\0ADEL:0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0,CN=Deleted Objects,DC=company,DC=com",
+			map[string][]string{lastKnownParentAttr: {"OU=users,DC=company,DC=com"}})
+
+		assert.Equal(t, "CN=John Doe,OU=users,DC=company,DC=com", activeDirectoryOriginalDN(entry))
+	})
+
+	t.Run("no source set", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+
+		deleted, cErr := client.Tombstones.GetDeleted(testTombstonesSince)
+		assert.Nil(t, deleted)
+		assert.Equal(t, ErrCodeUnsupportedFeature, cErr.Code)
+		assert.Equal(t, http.StatusNotImplemented, cErr.Status)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.AccessLogBaseDN = "cn=accesslog"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		deleted, cErr := client.Tombstones.GetDeleted(testTombstonesSince)
+		assert.Nil(t, deleted)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
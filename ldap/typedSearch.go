@@ -0,0 +1,141 @@
+package ldap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	ldapTag   = "ldap"
+	dnTag     = "dn"
+	ignoreTag = "-"
+
+	typedSearchTypeErrMsg      = "The type %v cannot be used to search LDAP entries, expected a struct"
+	typedSearchUnmarshalErrMsg = "The entry with dn = '%s' could not be unmarshalled: %v"
+)
+
+// Search retrieves the entries from LDAP below the baseDN which match the filter and unmarshals them into values of
+// the struct type T, e.g. to query custom entry types like printers or hosts.
+// params:
+//
+//	c = the client used to search LDAP
+//	baseDN = the distinguished name of the entry the search starts from, the entry itself is included
+//	filter = the LDAP search filter, see Filterf to escape the values of the filter
+//
+// The fields of T are mapped to the attributes using the ldap struct tag, a field without a tag is mapped to the
+// attribute with the name of the field and a field with the tag `ldap:"-"` is ignored. The tag `ldap:"dn"` sets the
+// distinguished name of the entry. Only the mapped attributes are retrieved, unless overridden using WithAttributes.
+// The fields can be of type string, []string, int, int64, []byte, time.Time, *ldap.DN or []*ldap.DN.
+//
+//	type Printer struct {
+//		DN       string   `ldap:"dn"`
+//		Name     string   `ldap:"cn"`
+//		Location string   `ldap:"l"`
+//		Trays    int      `ldap:"printerNumberOfTrays"`
+//		Aliases  []string `ldap:"printerAliases"`
+//	}
+//
+//	printers, cErr := ldap.Search[Printer](client, "ou=printers,dc=example,dc=com",
+//		ldap.Filterf("(&(objectClass=printerService)(l=%s))", location))
+//
+// The entries are retrieved page by page.
+// The method returns an error:
+//   - if a validation fails
+//   - if T is not a struct
+//   - if an entry cannot be unmarshalled into T
+//   - if the baseDN is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func Search[T any](c *Client, baseDN, filter string, opts ...RequestOption) ([]T, *Error) {
+	var missingParams []string
+	if strings.TrimSpace(baseDN) == "" {
+		missingParams = append(missingParams, "dn")
+	}
+	if strings.TrimSpace(filter) == "" {
+		missingParams = append(missingParams, searchFilterParam)
+	}
+	if len(missingParams) > 0 {
+		return nil, missingParametersError(missingParams)
+	}
+	attributes, cErr := typedSearchAttributes(reflect.TypeOf((*T)(nil)).Elem())
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	var values []T
+	sr := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		attributes,
+		nil,
+	)
+	cErr = c.doLDAPPagedSearch(sr, defaultPageSize, func(result *ldap.SearchResult) *Error {
+		for _, entry := range result.Entries {
+			var value T
+			if err := entry.Unmarshal(&value); err != nil {
+				return WrapError(errors.InternalServerError(fmt.Sprintf(typedSearchUnmarshalErrMsg, entry.DN, err)))
+			}
+			values = append(values, value)
+		}
+		return nil
+	}, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return values, nil
+}
+
+// Filterf formats an LDAP search filter according to the format, escaping the string and fmt.Stringer args so that
+// special characters in the values, e.g. '*' or ')', are matched literally instead of changing the filter.
+// The other args, e.g. numbers, are formatted unchanged.
+//
+//	filter := ldap.Filterf("(&(objectClass=device)(cn=%s))", name)
+func Filterf(format string, args ...any) string {
+	escaped := make([]any, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			escaped[i] = ldap.EscapeFilter(v)
+		case fmt.Stringer:
+			escaped[i] = ldap.EscapeFilter(v.String())
+		default:
+			escaped[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, escaped...)
+}
+
+// typedSearchAttributes returns the attributes mapped to the fields of the struct type t.
+func typedSearchAttributes(t reflect.Type) ([]string, *Error) {
+	if t.Kind() != reflect.Struct {
+		return nil, WrapError(errors.BadRequestError(fmt.Sprintf(typedSearchTypeErrMsg, t)))
+	}
+	var attributes []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup(ldapTag); ok {
+			name, _, _ = strings.Cut(tag, ",")
+		}
+		if name == dnTag || name == ignoreTag {
+			continue
+		}
+		attributes = append(attributes, name)
+	}
+	if len(attributes) == 0 {
+		attributes = []string{noAttributes}
+	}
+	return attributes, nil
+}
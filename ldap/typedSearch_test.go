@@ -0,0 +1,139 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testPrinter struct {
+	DN       string   `ldap:"dn"`
+	Name     string   `ldap:"cn"`
+	Location string   `ldap:"l"`
+	Trays    int      `ldap:"printerNumberOfTrays"`
+	Aliases  []string `ldap:"printerAliases"`
+	Ignored  string   `ldap:"-"`
+}
+
+const testPrinterBaseDN = "ou=printers,dc=example,dc=com"
+
+func getPrinterSearchResult(cookie string) *ldap.SearchResult {
+	result := getPagedSearchResult(cookie)
+	result.Entries = []*ldap.Entry{
+		ldap.NewEntry("cn=printer1,"+testPrinterBaseDN, map[string][]string{
+			"cn":                   {"printer1"},
+			"l":                    {"floor 1"},
+			"printerNumberOfTrays": {"2"},
+			"printerAliases":       {"p1", "first"},
+		}),
+	}
+	return result
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("all pages", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, testPrinterBaseDN, sr.BaseDN)
+				assert.Equal(t, "(&(objectClass=printerService)(l=floor 1\\2a))", sr.Filter)
+				assert.Equal(t, []string{"cn", "l", "printerNumberOfTrays", "printerAliases"}, sr.Attributes)
+				assert.NotNil(t, ldap.FindControl(sr.Controls, ldap.ControlTypePaging))
+			}).
+			Return(getPrinterSearchResult("page-2"), nil).Once()
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(getPagedSearchResult("", "cn=printer2,"+testPrinterBaseDN), nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		printers, cErr := Search[testPrinter](client, testPrinterBaseDN,
+			Filterf("(&(objectClass=printerService)(l=%s))", "floor 1*"))
+		assert.Nil(t, cErr)
+		assert.Equal(t, []testPrinter{
+			{
+				DN:       "cn=printer1," + testPrinterBaseDN,
+				Name:     "printer1",
+				Location: "floor 1",
+				Trays:    2,
+				Aliases:  []string{"p1", "first"},
+			},
+			{DN: "cn=printer2," + testPrinterBaseDN},
+		}, printers)
+	})
+
+	t.Run("request options", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				sr := args.Get(0).(*ldap.SearchRequest)
+				assert.Equal(t, ldap.ScopeSingleLevel, sr.Scope)
+				assert.Equal(t, []string{"cn"}, sr.Attributes)
+			}).
+			Return(getPagedSearchResult(""), nil).Once()
+		ldapMock.On(methodNameClose).Return(nil)
+
+		printers, cErr := Search[testPrinter](client, testPrinterBaseDN, "(objectClass=printerService)",
+			WithScope(ldap.ScopeSingleLevel), WithAttributes("cn"))
+		assert.Nil(t, cErr)
+		assert.Empty(t, printers)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+		printers, cErr := Search[testPrinter](client, " ", "")
+		assert.Nil(t, printers)
+		assert.Equal(t, missingParametersError([]string{"dn", searchFilterParam}), cErr)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting())
+		values, cErr := Search[string](client, testPrinterBaseDN, "(objectClass=printerService)")
+		assert.Nil(t, values)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+	})
+
+	t.Run("unmarshal error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		result := getPagedSearchResult("")
+		result.Entries = []*ldap.Entry{ldap.NewEntry("cn=printer1,"+testPrinterBaseDN, map[string][]string{
+			"printerNumberOfTrays": {"two"},
+		})}
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(result, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		printers, cErr := Search[testPrinter](client, testPrinterBaseDN, "(objectClass=printerService)")
+		assert.Nil(t, printers)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	})
+
+	t.Run("base dn not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		ldapMock.On(methodNameBind, testConfig.BindUser, testConfig.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		printers, cErr := Search[testPrinter](client, testPrinterBaseDN, "(objectClass=printerService)")
+		assert.Nil(t, printers)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestFilterf(t *testing.T) {
+	assert.Equal(t, "(&(cn=a\\2a\\28b\\29)(printerNumberOfTrays=2))",
+		Filterf("(&(cn=%s)(printerNumberOfTrays=%d))", "a*(b)", 2))
+}
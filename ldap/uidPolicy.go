@@ -0,0 +1,102 @@
+package ldap
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/go-utils/utils/slice"
+)
+
+const (
+	blockedUidErrMsg        = "Invalid uid '%s'. The uid is reserved and cannot be used"
+	reservedUidPrefixErrMsg = "Invalid uid '%s'. The prefix '%s' is reserved and cannot be used"
+	uidPatternErrMsg        = "Invalid uid '%s'. The uid of a %s user must match the pattern '%s'"
+	invalidUidPatternErrMsg = "Invalid uid pattern '%s' of the user type '%s': %v"
+	invalidUidPolicyTypeMsg = "Invalid user type '%s' in the uid policy. Valid types are %v"
+)
+
+type (
+	// UidPolicy describes the naming conventions of the uids of new users. All the checks are optional.
+	UidPolicy struct {
+		// Patterns map a user type, UserTypePersonal, UserTypeNPA or UserTypeBuilder, to a regular expression the
+		// uids of the type must match. The type of a new user is derived from its uid like by Users.FilterByType.
+		Patterns map[string]string `json:"patterns" yaml:"patterns"`
+		// ReservedPrefixes are the prefixes the uids must not start with, e.g. "svc-". The prefixes are compared
+		// case-insensitively.
+		ReservedPrefixes []string `json:"reservedPrefixes" yaml:"reservedPrefixes"`
+		// Blocklist holds the uids which must not be used, e.g. "admin" or "root". The uids are compared
+		// case-insensitively.
+		Blocklist []string `json:"blocklist" yaml:"blocklist"`
+	}
+)
+
+// validateUidPolicy checks if the uid of a new user conforms to the UidPolicy set in the client Config.
+// The error explains which rule of the policy is violated.
+func (um *usersManager) validateUidPolicy(uid string) *Error {
+	policy := um.Client.getConfig().UidPolicy
+	for _, blocked := range policy.Blocklist {
+		if strings.EqualFold(uid, blocked) {
+			return invalidParameterError(userIdAttr, fmt.Sprintf(blockedUidErrMsg, uid))
+		}
+	}
+	for _, prefix := range policy.ReservedPrefixes {
+		if prefix != "" && strings.HasPrefix(strings.ToLower(uid), strings.ToLower(prefix)) {
+			return invalidParameterError(userIdAttr, fmt.Sprintf(reservedUidPrefixErrMsg, uid, prefix))
+		}
+	}
+	if len(policy.Patterns) == 0 {
+		return nil
+	}
+	for userType := range policy.Patterns {
+		if !slice.EntryExists(validUserTypes, userType) {
+			return WrapError(errors.InternalServerErrorf(invalidUidPolicyTypeMsg, userType, validUserTypes))
+		}
+	}
+	userType, cErr := userTypeOf(uid)
+	if cErr != nil {
+		return cErr
+	}
+	pattern, ok := policy.Patterns[userType]
+	if !ok || pattern == "" {
+		return nil
+	}
+	cRegex, err := regexp.Compile(pattern)
+	if err != nil {
+		return WrapError(errors.InternalServerErrorf(invalidUidPatternErrMsg, pattern, userType, err))
+	}
+	if !cRegex.MatchString(uid) {
+		return invalidParameterError(userIdAttr, fmt.Sprintf(uidPatternErrMsg, uid, userType, pattern))
+	}
+	return nil
+}
+
+// validate checks if the user types of the Patterns are valid and the Patterns compile.
+func (p UidPolicy) validate() *Error {
+	for userType, pattern := range p.Patterns {
+		if !slice.EntryExists(validUserTypes, userType) {
+			return WrapError(errors.BadRequestError(fmt.Sprintf(invalidUidPolicyTypeMsg, userType, validUserTypes)))
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return WrapError(errors.BadRequestError(fmt.Sprintf(invalidUidPatternErrMsg, pattern, userType, err)))
+		}
+	}
+	return nil
+}
+
+// userTypeOf returns the type of the user with the uid. Builder accounts have the BuilderAccountSuffix, personal
+// accounts match the PersonalUserTypeRegex and the other accounts are NPA accounts.
+func userTypeOf(uid string) (string, *Error) {
+	if strings.Contains(uid, BuilderAccountSuffix) {
+		return UserTypeBuilder, nil
+	}
+	cRegex, err := regexp.Compile(PersonalUserTypeRegex)
+	if err != nil {
+		return "", WrapError(errors.InternalServerError(err.Error()))
+	}
+	if cRegex.MatchString(uid) {
+		return UserTypePersonal, nil
+	}
+	return UserTypeNPA, nil
+}
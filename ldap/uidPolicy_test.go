@@ -0,0 +1,87 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// getUidPolicyConfig returns the test config with a uid policy.
+func getUidPolicyConfig() Config {
+	config := testConfig
+	config.UidPolicy = UidPolicy{
+		Patterns: map[string]string{
+			UserTypePersonal: "^C[0-9]{5}$",
+			UserTypeNPA:      "^npa-[a-z0-9-]+$",
+		},
+		ReservedPrefixes: []string{"svc-"},
+		Blocklist:        []string{"admin", "root"},
+	}
+	return config
+}
+
+func TestUsersManager_validateUidPolicy(t *testing.T) {
+	um := usersManager{Client: NewClient(getUidPolicyConfig())}
+
+	t.Run("valid uids", func(t *testing.T) {
+		assert.Nil(t, um.validateUidPolicy(testUser1.Uid))
+		assert.Nil(t, um.validateUidPolicy("npa-nexus"))
+		assert.Nil(t, um.validateUidPolicy("C00001"+BuilderAccountSuffix))
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		um := usersManager{Client: NewClient(testConfig)}
+
+		assert.Nil(t, um.validateUidPolicy("admin"))
+	})
+
+	t.Run("blocked uid", func(t *testing.T) {
+		cErr := um.validateUidPolicy("Admin")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(blockedUidErrMsg, "Admin"), cErr.Message)
+		assert.Equal(t, userIdAttr, cErr.Validation.Fields[0].Field)
+	})
+
+	t.Run("reserved prefix", func(t *testing.T) {
+		cErr := um.validateUidPolicy("SVC-nexus")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(reservedUidPrefixErrMsg, "SVC-nexus", "svc-"), cErr.Message)
+	})
+
+	t.Run("pattern of the user type", func(t *testing.T) {
+		cErr := um.validateUidPolicy("AB1234")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(uidPatternErrMsg, "AB1234", UserTypePersonal, "^C[0-9]{5}$"), cErr.Message)
+
+		cErr = um.validateUidPolicy("nexus_agent")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(uidPatternErrMsg, "nexus_agent", UserTypeNPA, "^npa-[a-z0-9-]+$"), cErr.Message)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		config := testConfig
+		config.UidPolicy.Patterns = map[string]string{"robot": ".*"}
+		um := usersManager{Client: NewClient(config)}
+
+		cErr := um.validateUidPolicy(testUser1.Uid)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+
+		config.UidPolicy.Patterns = map[string]string{UserTypePersonal: "["}
+		um = usersManager{Client: NewClient(config)}
+
+		cErr = um.validateUidPolicy(testUser1.Uid)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	})
+
+	t.Run("create rejects a blocked uid", func(t *testing.T) {
+		client := NewClient(getUidPolicyConfig())
+		user := testUser1
+		user.Uid = "root"
+
+		cErr := client.Users.Create(user)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(blockedUidErrMsg, "root"), cErr.Message)
+	})
+}
@@ -0,0 +1,74 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// FieldErrCodeNotUnique is the code of a FieldError of a field of which the value is already used by another
+	// entry.
+	FieldErrCodeNotUnique = "NOT_UNIQUE"
+
+	uniqueAttributesSearchFilter = "(&%s(|%s))"
+	attributeValueFilter         = "(%s=%s)"
+	attributeNotUniqueMsg        = "A user with %s = '%s' already exists with uid = '%s'"
+)
+
+// validateUniqueness checks that the mail and the employee number of a User are not used by another user entry, if
+// the checks are enabled in the client Config. LDAP does not enforce the uniqueness of these attributes, the check
+// is a best effort pre-check which does not prevent users which are created concurrently from sharing a value.
+// The method returns a conflict error naming the uid of the conflicting user entry.
+func (um *usersManager) validateUniqueness(user User) *Error {
+	config := um.Client.getConfig()
+	uniqueAttributes := map[string]string{}
+	if config.EnforceUniqueMail && user.Mail != "" {
+		uniqueAttributes[mailAttr] = user.Mail
+	}
+	if config.EnforceUniqueEmployeeNumber && user.EmployeeNumber != "" {
+		uniqueAttributes[employeeNumberAttr] = user.EmployeeNumber
+	}
+	if len(uniqueAttributes) == 0 {
+		return nil
+	}
+
+	var filters strings.Builder
+	for _, attr := range []string{mailAttr, employeeNumberAttr} {
+		if value, ok := uniqueAttributes[attr]; ok {
+			filters.WriteString(fmt.Sprintf(attributeValueFilter, attr, ldap.EscapeFilter(value)))
+		}
+	}
+	sr := um.getUsersSearchRequest(fmt.Sprintf(uniqueAttributesSearchFilter, um.Client.getConfig().userObjectFilter(),
+		filters.String()))
+	result, cErr := um.Client.doLDAPSearch(sr)
+	if cErr != nil {
+		return cErr
+	}
+
+	for _, existing := range um.parseSearchResult(result) {
+		if strings.EqualFold(existing.Uid, user.Uid) {
+			continue
+		}
+		if mail, ok := uniqueAttributes[mailAttr]; ok && strings.EqualFold(existing.Mail, mail) {
+			return attributeNotUniqueError(mailAttr, mail, existing.Uid)
+		}
+		if employeeNumber, ok := uniqueAttributes[employeeNumberAttr]; ok && existing.EmployeeNumber == employeeNumber {
+			return attributeNotUniqueError(employeeNumberAttr, employeeNumber, existing.Uid)
+		}
+	}
+	return nil
+}
+
+// attributeNotUniqueError returns a conflict error for an attribute value which is used by the user with the uid
+// together with a ValidationError for the attribute.
+func attributeNotUniqueError(attr, value, uid string) *Error {
+	message := fmt.Sprintf(attributeNotUniqueMsg, attr, value, uid)
+	cErr := WrapError(errors.ConflictError(message))
+	cErr.Validation = &ValidationError{
+		Fields: []FieldError{{Field: attr, Code: FieldErrCodeNotUnique, Message: message}},
+	}
+	return cErr
+}
@@ -0,0 +1,108 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsersManager_validateUniqueness(t *testing.T) {
+	uniqueConfig := testConfig
+	uniqueConfig.EnforceUniqueMail = true
+	uniqueConfig.EnforceUniqueEmployeeNumber = true
+
+	t.Run("disabled", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		um := usersManager{Client: NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())}
+		assert.Nil(t, um.validateUniqueness(testUser1))
+	})
+
+	t.Run("unique", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(uniqueConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		sr := um.getUsersSearchRequest(
+			"(&(objectClass=inetOrgPerson)(|(mail=john.doe@company.com)(employeeNumber=E100001)))")
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, um.validateUniqueness(testUser1))
+	})
+
+	t.Run("mail conflict", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(uniqueConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser1
+		user.Uid = "C00099"
+		user.Mail = "John.Doe@company.com"
+		user.EmployeeNumber = ""
+		sr := um.getUsersSearchRequest("(&(objectClass=inetOrgPerson)(|(mail=John.Doe@company.com)))")
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.Create(user)
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(attributeNotUniqueMsg, mailAttr, user.Mail, testUser1.Uid), cErr.Message)
+		assert.Equal(t, FieldErrCodeNotUnique, cErr.Validation.Field(mailAttr).Code)
+	})
+
+	t.Run("employee number conflict", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(uniqueConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser2
+		user.EmployeeNumber = testUser1.EmployeeNumber
+		sr := um.getUsersSearchRequest(
+			"(&(objectClass=inetOrgPerson)(|(mail=jane.doe@company.com)(employeeNumber=E100001)))")
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(getUserSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := um.validateUniqueness(user)
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(attributeNotUniqueMsg, employeeNumberAttr, user.EmployeeNumber, testUser1.Uid),
+			cErr.Message)
+	})
+
+	t.Run("escaped filter", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(uniqueConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser1
+		user.Mail = "john*)(uid=*"
+		user.EmployeeNumber = ""
+		sr := um.getUsersSearchRequest(
+			"(&(objectClass=inetOrgPerson)(|(mail=" + ldap.EscapeFilter(user.Mail) + ")))")
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(getUsersEmptySearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, um.validateUniqueness(user))
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(uniqueConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := um.validateUniqueness(testUser1)
+		assert.Equal(t, http.StatusForbidden, cErr.Status)
+	})
+}
@@ -0,0 +1,56 @@
+package ldap
+
+import (
+	"fmt"
+
+	"github.com/atselvan/go-utils/utils/slice"
+)
+
+const (
+	telephoneNumberAttr  = "telephoneNumber"
+	titleAttr            = "title"
+	organizationAttr     = "o"
+	localityAttr         = "l"
+	departmentNumberAttr = "departmentNumber"
+
+	userContactAttributesParam = "userContactAttributes"
+	invalidContactAttrMsg      = "Invalid user contact attribute '%s'. Valid attributes are %v"
+)
+
+// userContactAttributes are the optional attributes of a user which can be enabled with the UserContactAttributes
+// set in the client Config.
+var userContactAttributes = []string{
+	telephoneNumberAttr,
+	titleAttr,
+	organizationAttr,
+	localityAttr,
+	departmentNumberAttr,
+}
+
+// validateContactAttributes checks if the UserContactAttributes set in the client Config are valid.
+func (cnf Config) validateContactAttributes() *Error {
+	for _, attr := range cnf.UserContactAttributes {
+		if !slice.EntryExists(userContactAttributes, attr) {
+			return invalidParameterError(userContactAttributesParam,
+				fmt.Sprintf(invalidContactAttrMsg, attr, userContactAttributes))
+		}
+	}
+	return nil
+}
+
+// contactAttributes returns the valid contact attributes enabled in the client Config.
+func (cnf Config) contactAttributes() []string {
+	var attrs []string
+	for _, attr := range userContactAttributes {
+		if slice.EntryExists(cnf.UserContactAttributes, attr) {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// attributes returns the attributes of the users which are read and written, the user attributes followed by the
+// contact attributes enabled in the client Config.
+func (um *usersManager) attributes() []string {
+	return append(append([]string{}, userAttributes...), um.Client.getConfig().contactAttributes()...)
+}
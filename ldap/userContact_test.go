@@ -0,0 +1,89 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testContactConfig = func() Config {
+	config := testConfig
+	config.UserContactAttributes = []string{telephoneNumberAttr, titleAttr, departmentNumberAttr}
+	return config
+}()
+
+func TestConfig_validateContactAttributes(t *testing.T) {
+	assert.Nil(t, testContactConfig.validateContactAttributes())
+
+	config := testConfig
+	config.UserContactAttributes = []string{"mobile"}
+	cErr := config.validateContactAttributes()
+	assert.Equal(t, FieldErrCodeInvalid, cErr.Validation.Field(userContactAttributesParam).Code)
+}
+
+func TestUsersManager_contactAttributes(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testContactConfig, WithLDAPClient(ldapMock), UnitTesting())
+		entry := getUserLDAPEntry(testUser1)
+		entry.Attributes = append(entry.Attributes,
+			ldap.NewEntryAttribute(telephoneNumberAttr, []string{"+31 20 123 4567"}),
+			ldap.NewEntryAttribute(departmentNumberAttr, []string{"IT-42"}))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, mock.AnythingOfType("*ldap.SearchRequest")).
+			Run(func(args mock.Arguments) {
+				attributes := args.Get(0).(*ldap.SearchRequest).Attributes
+				assert.Subset(t, attributes, testContactConfig.UserContactAttributes)
+				assert.NotContains(t, attributes, localityAttr)
+			}).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{entry}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		user, cErr := client.Users.Get(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, "+31 20 123 4567", user.TelephoneNumber)
+		assert.Equal(t, "IT-42", user.Department)
+		assert.Empty(t, user.Title)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		client := NewClient(testContactConfig, UnitTesting())
+		user := testUser1
+		user.Title = "Engineer"
+		user.Locality = "Amsterdam"
+
+		ar := (&usersManager{Client: client}).getAddRequest(user)
+		var attributes []string
+		for _, attr := range ar.Attributes {
+			attributes = append(attributes, attr.Type)
+		}
+		assert.Contains(t, ar.Attributes, ldap.Attribute{Type: titleAttr, Vals: []string{"Engineer"}})
+		assert.NotContains(t, attributes, localityAttr)
+		assert.NotContains(t, attributes, telephoneNumberAttr)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testContactConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		entry := getUserLDAPEntry(testUser1)
+		entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(titleAttr, []string{"Engineer"}))
+		user := testUser1
+		user.TelephoneNumber = "+31 20 123 4567"
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(telephoneNumberAttr, []string{user.TelephoneNumber})
+		mr.Replace(titleAttr, []string{})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{entry}}, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserProvisioner).Update(user, ""))
+	})
+}
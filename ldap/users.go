@@ -2,12 +2,15 @@ package ldap
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/atselvan/go-utils/utils/errors"
 	"github.com/atselvan/go-utils/utils/slice"
+	"github.com/atselvan/ldap-go-lib/dn"
 	"github.com/go-ldap/ldap/v3"
 )
 
@@ -26,6 +29,9 @@ const (
 	invalidStatusErrMsg    = "Invalid status '%s'. Valid status's are %v"
 	invalidUserTypeErrMsg  = "Invalid type '%s'. Valid types are %v"
 	invalidFilterKeyErrMsg = "Invalid filter key '%s'. Valid filter keys are %v"
+
+	oldPasswordParam = "oldPassword"
+	newPasswordParam = "newPassword"
 )
 
 var (
@@ -61,21 +67,98 @@ var (
 type (
 	// UsersManager describes an interface the needs to be implemented for performing operations on
 	// all user accounts in LDAP.
+	// The further operations on the users are described by the optional interfaces UsersReader,
+	// UserPasswordsManager, UserLifecycleManager, UserProvisioner, UserProfileManager, UserHierarchyManager and
+	// UsersExporter, which are implemented by the UsersManager of the client, e.g.
+	//
+	//	client.Users.(ldap.UserPasswordsManager).ChangePassword(uid, oldPassword, newPassword)
 	UsersManager interface {
-		GetAll() ([]User, *errors.Error)
-		Get(uid string) (*User, *errors.Error)
-		Filter(key, value string) ([]User, *errors.Error)
-		FilterByStatus(status string) ([]User, *errors.Error)
-		FilterByType(userType string) ([]User, *errors.Error)
-		Create(user User) *errors.Error
-		Delete(uid string) *errors.Error
-		Authenticate() *errors.Error
-		SetNewPassword(uid, newPassword string) (string, *errors.Error)
+		GetAll() ([]User, *Error)
+		Get(uid string) (*User, *Error)
+		Filter(key, value string) ([]User, *Error)
+		FilterByStatus(status string) ([]User, *Error)
+		FilterByType(userType string) ([]User, *Error)
+		Create(user User) *Error
+		Delete(uid string) *Error
+		Authenticate() *Error
+		SetNewPassword(uid, newPassword string) (string, *Error)
+	}
+
+	// UsersReader describes the read operations on the users with request options, e.g. WithAttributes or
+	// WithTimeout, the paged search of the users and the reads of their attributes and history.
+	UsersReader interface {
+		GetAllWithOptions(opts ...RequestOption) ([]User, *Error)
+		GetWithOptions(uid string, opts ...RequestOption) (*User, *Error)
+		FilterWithOptions(key, value string, opts ...RequestOption) ([]User, *Error)
+		FilterByStatusWithOptions(status string, opts ...RequestOption) ([]User, *Error)
+		FilterByTypeWithOptions(userType string, opts ...RequestOption) ([]User, *Error)
+		Search(searchFilter string, opts ...RequestOption) (*UsersResult, *Error)
+		GetAttributes(uid string, attributes []string) (Attributes, *Error)
+		GetAllAttributes(attributes []string) (map[string]Attributes, *Error)
+		History(uid string) ([]ChangeRecord, *Error)
+	}
+
+	// UserPasswordsManager describes the operations on the passwords of the users.
+	UserPasswordsManager interface {
+		ChangePassword(uid, oldPassword, newPassword string) *Error
+		GetPasswordStatus(uid string) (*PasswordStatus, *Error)
+		ExpiringPasswords(within time.Duration, handler func(status PasswordStatus) *Error) *Error
+	}
+
+	// UserLifecycleManager describes the operations which offboard, archive and remove the users.
+	UserLifecycleManager interface {
+		HardDelete(uid string) *Error
+		Deprovision(uid string, opts DeprovisionOptions) (*DeprovisionResult, *Error)
+		Archive(uid string) *Error
+		Unarchive(uid string) *Error
+		PurgeDeleted(olderThan time.Duration, dryRun bool) (*PurgeReport, *Error)
+		Inactive(since time.Time) ([]InactiveUser, *Error)
+	}
+
+	// UserProvisioner describes the operations which create and update the users in bulk, idempotently or from
+	// templates.
+	UserProvisioner interface {
+		ImportCSV(r io.Reader, opts CSVImportOptions) (*CSVImportResult, *Error)
+		CreateBulk(users []User) ([]UserChange, *Error)
+		Ensure(user User) (*UserChange, *Error)
+		Update(user User, revision string) *Error
+		Reconcile(desired []User, opts ReconcileOptions) (*ReconcileResult, *Error)
+		CreateFromTemplate(templateName string, user User) *Error
+		CreateInGroups(user User, groups []GroupMembership) *Error
+		GenerateIDs(user User) (User, *Error)
+	}
+
+	// UserProfileManager describes the operations on the photos, the certificates and the mail aliases of the
+	// users.
+	UserProfileManager interface {
+		GetPhoto(uid string) ([]byte, *Error)
+		SetPhoto(uid string, photo []byte) *Error
+		GetCertificates(uid string) ([][]byte, *Error)
+		SetCertificates(uid string, certificates [][]byte) *Error
+		GetAliases(uid string) ([]string, *Error)
+		AddMailAlias(uid, alias string) *Error
+		RemoveMailAlias(uid, alias string) *Error
+	}
+
+	// UserHierarchyManager describes the operations on the managers and the reports of the users.
+	UserHierarchyManager interface {
+		SetManager(uid, manager string) *Error
+		GetDirectReports(uid string, opts ...RequestOption) ([]User, *Error)
+		GetManagementChain(uid string) ([]User, *Error)
+		OrgChart(uid string, opts ...RequestOption) (*OrgChart, *Error)
+	}
+
+	// UsersExporter describes the exports of the users.
+	UsersExporter interface {
+		ExportCSV(w io.Writer, columns ...string) *Error
+		ExportJSON(w io.Writer, fields ...string) *Error
 	}
 
 	// usersManager implements the UsersManager interface.
 	usersManager struct {
 		Client *Client
+		// baseDN overrides the UserBaseDN set in the client Config.
+		baseDN string
 	}
 
 	// User represents the attributes of a user in LDAP
@@ -89,6 +172,25 @@ type (
 		Mail           string `json:"mail" form:"mail" required:"true"`
 		UserPassword   string `json:"userPassword,omitempty" form:"userPassword" required:"true"`
 		Status         string `json:"status" form:"status" required:"true"`
+		// Photo is the JPEG profile photo of the user. The photo is not retrieved by Get, GetAll and Filter,
+		// use GetPhoto to retrieve it.
+		Photo []byte `json:"photo,omitempty" form:"photo"`
+		// Certificates are the DER encoded X.509 certificates of the user. The certificates are not retrieved by Get,
+		// GetAll and Filter, use GetCertificates to retrieve them.
+		Certificates [][]byte `json:"certificates,omitempty" form:"certificates"`
+		// Revision identifies the revision of the user entry retrieved from LDAP, see Update.
+		Revision string `json:"revision,omitempty" form:"revision"`
+		// Manager is the dn of the manager of the user. When a user is created the manager can also be set to the uid
+		// of a user within the UserBaseDN, use SetManager to change the manager of an existing user.
+		Manager string `json:"manager,omitempty" form:"manager"`
+		// TelephoneNumber, Title, Organization (o), Locality (l) and Department (departmentNumber) are the optional
+		// contact attributes of the user. They are only retrieved, created and updated if they are listed in the
+		// UserContactAttributes set in the client Config.
+		TelephoneNumber string `json:"telephoneNumber,omitempty" form:"telephoneNumber"`
+		Title           string `json:"title,omitempty" form:"title"`
+		Organization    string `json:"organization,omitempty" form:"organization"`
+		Locality        string `json:"locality,omitempty" form:"locality"`
+		Department      string `json:"department,omitempty" form:"department"`
 	}
 )
 
@@ -97,9 +199,32 @@ type (
 //   - if a validation fails
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) GetAll() ([]User, *errors.Error) {
-	sr := um.getUsersSearchRequest(userSearchFilter)
-	result, err := um.Client.doLDAPSearch(sr)
+func (um *usersManager) GetAll() ([]User, *Error) {
+	return um.GetAllWithOptions()
+}
+
+// GetAllWithOptions retrieves all the user entries from LDAP like GetAll.
+// params:
+//
+//	opts = options applied to the search request, e.g. WithAttributes or WithTimeout
+//
+// The user entries retrieved without request options are cached if WithCache is set on the client.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetAllWithOptions(opts ...RequestOption) ([]User, *Error) {
+	if cache := um.cache(opts); cache != nil {
+		return cache.users(func() ([]User, *Error) { return um.getAll() })
+	}
+	return um.getAll(opts...)
+}
+
+// getAll retrieves all the user entries from LDAP with the options applied to the search request, bypassing the
+// cache.
+func (um *usersManager) getAll(opts ...RequestOption) ([]User, *Error) {
+	sr := um.getUsersSearchRequest(um.Client.getConfig().usersFilter())
+	result, err := um.Client.doLDAPSearch(sr, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -113,20 +238,49 @@ func (um *usersManager) GetAll() ([]User, *errors.Error) {
 //
 // The method returns an error:
 //   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Get(uid string) (*User, *Error) {
+	return um.GetWithOptions(uid)
+}
+
+// GetWithOptions retrieves a single user's entry from LDAP like Get.
+// params:
+//
+//	uid = user identifier
+//	opts = options applied to the search request, e.g. WithAttributes("mail") or WithTimeout(2*time.Second)
+//
+// The user entries retrieved without request options are cached if WithCache is set on the client.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) Get(uid string) (*User, *errors.Error) {
+func (um *usersManager) GetWithOptions(uid string, opts ...RequestOption) (*User, *Error) {
+	if cache := um.cache(opts); cache != nil {
+		return cache.user(uid, func() (*User, *Error) { return um.get(uid) })
+	}
+	return um.get(uid, opts...)
+}
+
+// get validates the uid and retrieves a single user's entry from LDAP with the options applied to the search
+// request, bypassing the cache.
+func (um *usersManager) get(uid string, opts ...RequestOption) (*User, *Error) {
 	if cErr := um.validateUid(uid); cErr != nil {
 		return nil, cErr
 	}
 	sr := um.getUserSearchRequest(um.getDN(uid))
-	result, cErr := um.Client.doLDAPSearch(sr)
+	result, cErr := um.Client.doLDAPSearch(sr, opts...)
 	if cErr != nil {
 		if cErr.Status == http.StatusNotFound {
-			return nil, errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid))
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
 		}
 		return nil, cErr
 	}
+	if len(result.Entries) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+	}
 	return &(um.parseSearchResult(result))[0], nil
 }
 
@@ -141,13 +295,28 @@ func (um *usersManager) Get(uid string) (*User, *errors.Error) {
 //   - if a validation fails
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) Filter(key, value string) ([]User, *errors.Error) {
+func (um *usersManager) Filter(key, value string) ([]User, *Error) {
+	return um.FilterWithOptions(key, value)
+}
+
+// FilterWithOptions retrieves a list of user entries from LDAP like Filter.
+// params:
+//
+//	key 	= The key of the filter
+//	value 	=  The value of the filter
+//	opts 	= options applied to the search request, e.g. WithBaseDN or WithTimeout
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) FilterWithOptions(key, value string, opts ...RequestOption) ([]User, *Error) {
 	if cErr := um.validateFilter(key, value); cErr != nil {
 		return nil, cErr
 	}
-	userSearchFilter := fmt.Sprintf(WildcardUserSearchFilter, key, value)
-	sr := um.getUsersSearchRequest(userSearchFilter)
-	result, err := um.Client.doLDAPSearch(sr)
+	sr := um.getUsersSearchRequest(fmt.Sprintf(userFilterTemplate, key, value,
+		um.Client.getConfig().userObjectFilter()))
+	result, err := um.Client.doLDAPSearch(sr, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -163,11 +332,25 @@ func (um *usersManager) Filter(key, value string) ([]User, *errors.Error) {
 //   - if a validation fails
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) FilterByStatus(status string) ([]User, *errors.Error) {
+func (um *usersManager) FilterByStatus(status string) ([]User, *Error) {
+	return um.FilterByStatusWithOptions(status)
+}
+
+// FilterByStatusWithOptions retrieves a list of user entries from LDAP like FilterByStatus.
+// params:
+//
+//	status = the status of a user record
+//	opts = options applied to the search request, see FilterWithOptions
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) FilterByStatusWithOptions(status string, opts ...RequestOption) ([]User, *Error) {
 	if cErr := um.validateStatus(status); cErr != nil {
 		return nil, cErr
 	}
-	return um.Filter(statusAttr, status)
+	return um.FilterWithOptions(statusAttr, status, opts...)
 }
 
 // FilterByType retrieves all the user entries from LDAP and then filters the list based on the type of the user.
@@ -179,34 +362,93 @@ func (um *usersManager) FilterByStatus(status string) ([]User, *errors.Error) {
 //   - if a validation fails
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) FilterByType(userType string) ([]User, *errors.Error) {
+func (um *usersManager) FilterByType(userType string) ([]User, *Error) {
+	return um.FilterByTypeWithOptions(userType)
+}
+
+// FilterByTypeWithOptions retrieves the user entries of a type from LDAP like FilterByType.
+// params:
+//
+//	userType = the type of the user record
+//	opts = options applied to the search request, see FilterWithOptions
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) FilterByTypeWithOptions(userType string, opts ...RequestOption) ([]User, *Error) {
 	switch userType {
 	case UserTypePersonal:
-		return um.getPersonalAccounts()
+		return um.getPersonalAccounts(opts...)
 	case UserTypeBuilder:
-		return um.getBuilderAccounts()
+		return um.getBuilderAccounts(opts...)
 	case UserTypeNPA:
-		return um.getNPAAccounts()
+		return um.getNPAAccounts(opts...)
 	default:
-		return nil, errors.BadRequestError(fmt.Sprintf(invalidUserTypeErrMsg, userType, validUserTypes))
+		return nil, invalidParameterError("userType", fmt.Sprintf(invalidUserTypeErrMsg, userType, validUserTypes))
+	}
+}
+
+// Search retrieves the user entries from LDAP which match the searchFilter together with the response controls and
+// the referrals returned by the LDAP server.
+// params:
+//
+//	searchFilter = the LDAP search filter, e.g. "(&(objectClass=inetOrgPerson)(status=Active))"
+//	opts = options applied to the search request, e.g. WithControls to request server side sorting
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Search(searchFilter string, opts ...RequestOption) (*UsersResult, *Error) {
+	if strings.TrimSpace(searchFilter) == "" {
+		return nil, missingParametersError([]string{searchFilterParam})
+	}
+	result, cErr := um.Client.doLDAPSearch(um.getUsersSearchRequest(searchFilter), opts...)
+	if cErr != nil {
+		return nil, cErr
 	}
+	return &UsersResult{Users: um.parseSearchResult(result), ResultControls: newResultControls(result)}, nil
 }
 
 // Create a new user entry in LDAP.
+// The uid and the altUid are generated by the IDGenerators set on the client if they are not set, see GenerateIDs.
 // The method returns an error:
+//   - if an IDGenerator fails
 //   - if a validation fails
+//   - if the mail or the employee number is already used by another user and the uniqueness check is enabled
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) Create(user User) *errors.Error {
+func (um *usersManager) Create(user User) *Error {
+	return um.create(user, nil)
+}
+
+// create validates and creates a new user entry in LDAP with the objectClasses, or with the default object classes
+// if no objectClasses are set, and the additional attributes.
+func (um *usersManager) create(user User, objectClasses []string, attributes ...ldap.Attribute) *Error {
+	user, cErr := um.GenerateIDs(user)
+	if cErr != nil {
+		return cErr
+	}
 	if cErr := um.validateUser(user); cErr != nil {
 		return cErr
 	}
+	if cErr := um.validateUidPolicy(user.Uid); cErr != nil {
+		return cErr
+	}
+	if cErr := um.validateUniqueness(user); cErr != nil {
+		return cErr
+	}
 
 	ar := um.getAddRequest(user)
+	if len(objectClasses) > 0 {
+		ar.Attributes[0].Vals = objectClasses
+	}
+	ar.Attributes = append(ar.Attributes, attributes...)
 
 	if cErr := um.Client.doLDAPAdd(ar); cErr != nil {
 		if cErr.Status == http.StatusBadRequest {
-			return errors.ConflictError(fmt.Sprintf(userAlreadyExistsMsg, user.Uid))
+			return WrapError(errors.ConflictError(fmt.Sprintf(userAlreadyExistsMsg, user.Uid)))
 		} else {
 			return cErr
 		}
@@ -216,6 +458,7 @@ func (um *usersManager) Create(user User) *errors.Error {
 		return cErr
 	}
 
+	um.emit(EventUserCreated, user.Uid)
 	return nil
 }
 
@@ -224,22 +467,42 @@ func (um *usersManager) Create(user User) *errors.Error {
 //
 //	uid = user identifier
 //
+// If SoftDelete is set in the client Config the user entry is not removed, instead the status of the user is set to
+// Deleted, the password is scrambled and the entry is moved to the ArchiveBaseDN, see HardDelete to remove the entry.
 // The method returns an error:
 //   - if a validation fails
+//   - if SoftDelete is set and the ArchiveBaseDN is not set in the client Config
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) Delete(uid string) *errors.Error {
+func (um *usersManager) Delete(uid string) *Error {
+	if um.Client.getConfig().SoftDelete {
+		return um.softDelete(uid)
+	}
+	return um.HardDelete(uid)
+}
+
+// HardDelete removes an existing user entry from LDAP, also if SoftDelete is set in the client Config.
+// param:
+//
+//	uid = user identifier
+//
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) HardDelete(uid string) *Error {
 	if cErr := um.validateUid(uid); cErr != nil {
 		return cErr
 	}
 	dr := um.getDeleteRequest(uid)
 	if cErr := um.Client.doLDAPDelete(dr); cErr != nil {
 		if cErr.Status == http.StatusNotFound {
-			return errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid))
+			return WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
 		} else {
 			return cErr
 		}
 	}
+	um.emit(EventUserDeleted, uid)
 	return nil
 }
 
@@ -249,8 +512,13 @@ func (um *usersManager) Delete(uid string) *errors.Error {
 //   - if a validation fails
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) Authenticate() *errors.Error {
-	return um.Client.connect()
+func (um *usersManager) Authenticate() *Error {
+	conn, cErr := um.Client.connect()
+	if cErr != nil {
+		return cErr
+	}
+	conn.Close()
+	return nil
 }
 
 // SetNewPassword sets a new password for an existing user entry in LDAP.
@@ -265,39 +533,243 @@ func (um *usersManager) Authenticate() *errors.Error {
 //   - if a validation fails
 //   - if there is a connection/network issue while opening a connection with LDAP
 //   - if the query to LDAP fails
-func (um *usersManager) SetNewPassword(uid, newPassword string) (string, *errors.Error) {
+func (um *usersManager) SetNewPassword(uid, newPassword string) (string, *Error) {
 	if newPassword == "" {
 		result, cErr := um.modifyPassword(uid, "", "")
 		if cErr != nil {
 			return "", cErr
 		}
+		um.emit(EventUserPasswordChanged, uid)
 		return result.GeneratedPassword, nil
 	} else {
 		_, cErr := um.modifyPassword(uid, "", newPassword)
 		if cErr != nil {
 			return "", cErr
 		}
+		um.emit(EventUserPasswordChanged, uid)
 		return newPassword, nil
 	}
 }
 
+// ChangePassword changes the password of an existing user entry in LDAP on behalf of the user.
+// param:
+//
+//	uid 		= user identifier
+//	oldPassword = the current password of the user
+//	newPassword = a new password to be set for the user
+//
+// Unlike SetNewPassword the operation binds as the user with the old password and passes the old password in the
+// password modify request, so the old password is verified and the password policy of the user is enforced by the
+// LDAP server.
+// The method returns an error:
+//   - if a validation fails
+//   - if the old password is invalid
+//   - if the new password is rejected by the password policy
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) ChangePassword(uid, oldPassword, newPassword string) *Error {
+	var missingParams []string
+	if strings.TrimSpace(uid) == "" {
+		missingParams = append(missingParams, userIdAttr)
+	}
+	if oldPassword == "" {
+		missingParams = append(missingParams, oldPasswordParam)
+	}
+	if newPassword == "" {
+		missingParams = append(missingParams, newPasswordParam)
+	}
+	if len(missingParams) > 0 {
+		return missingParametersError(missingParams)
+	}
+	userClient := um.Client.boundAs(um.getDN(uid), oldPassword)
+	pmr := um.getPasswordModifyRequest(uid, oldPassword, newPassword)
+	if _, cErr := userClient.doLDAPPasswordModify(pmr); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return cErr
+	}
+	um.emit(EventUserPasswordChanged, uid)
+	return nil
+}
+
+// GetPhoto retrieves the JPEG profile photo of an existing user entry from LDAP.
+// param:
+//
+//	uid = user identifier
+//
+// The method returns nil if no photo is set for the user.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetPhoto(uid string) ([]byte, *Error) {
+	values, cErr := um.getBinaryAttribute(uid, jpegPhotoAttr)
+	if cErr != nil || len(values) == 0 {
+		return nil, cErr
+	}
+	return values[0], nil
+}
+
+// SetPhoto sets the JPEG profile photo of an existing user entry in LDAP.
+// param:
+//
+//	uid 	= user identifier
+//	photo 	= the JPEG encoded photo
+//
+// If photo is empty then the photo of the user is removed.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) SetPhoto(uid string, photo []byte) *Error {
+	var values [][]byte
+	if len(photo) > 0 {
+		values = [][]byte{photo}
+	}
+	if cErr := um.setBinaryAttribute(uid, jpegPhotoAttr, values); cErr != nil {
+		return cErr
+	}
+	um.emit(EventUserPhotoChanged, uid)
+	return nil
+}
+
+// GetCertificates retrieves the DER encoded X.509 certificates of an existing user entry from LDAP.
+// param:
+//
+//	uid = user identifier
+//
+// The method returns nil if no certificates are set for the user.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetCertificates(uid string) ([][]byte, *Error) {
+	return um.getBinaryAttribute(uid, userCertificateAttr)
+}
+
+// SetCertificates replaces the DER encoded X.509 certificates of an existing user entry in LDAP.
+// param:
+//
+//	uid 			= user identifier
+//	certificates 	= the DER encoded certificates
+//
+// If certificates is empty then all the certificates of the user are removed.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) SetCertificates(uid string, certificates [][]byte) *Error {
+	if cErr := um.setBinaryAttribute(uid, userCertificateAttr, certificates); cErr != nil {
+		return cErr
+	}
+	um.emit(EventUserCertificatesChanged, uid)
+	return nil
+}
+
+// GetAttributes retrieves the selected attributes of a single user's entry from LDAP.
+// params:
+//
+//	uid 		= user identifier
+//	attributes 	= the names of the attributes to retrieve, which can include attributes of custom schema extensions
+//
+// Only the attributes which are set on the user entry are part of the result.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetAttributes(uid string, attributes []string) (Attributes, *Error) {
+	if cErr := um.validateUid(uid); cErr != nil {
+		return nil, cErr
+	}
+	if cErr := um.validateAttributes(attributes); cErr != nil {
+		return nil, cErr
+	}
+	sr := um.getUserSearchRequest(um.getDN(uid))
+	sr.Attributes = attributes
+	result, cErr := um.Client.doLDAPSearch(sr)
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return nil, cErr
+	}
+	if len(result.Entries) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+	}
+	return newAttributes(result.Entries[0]), nil
+}
+
+// GetAllAttributes retrieves the selected attributes of all the user entries from LDAP.
+// params:
+//
+//	attributes = the names of the attributes to retrieve, which can include attributes of custom schema extensions
+//
+// The result is keyed by the uid of the user entries.
+// The method returns an error:
+//   - if a validation fails
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) GetAllAttributes(attributes []string) (map[string]Attributes, *Error) {
+	if cErr := um.validateAttributes(attributes); cErr != nil {
+		return nil, cErr
+	}
+	sr := um.getUsersSearchRequest(um.Client.getConfig().usersFilter())
+	sr.Attributes = attributes
+	if !slice.EntryExists(attributes, userIdAttr) {
+		sr.Attributes = append([]string{userIdAttr}, attributes...)
+	}
+	result, cErr := um.Client.doLDAPSearch(sr)
+	if cErr != nil {
+		return nil, cErr
+	}
+	users := make(map[string]Attributes, len(result.Entries))
+	for _, entry := range result.Entries {
+		users[entry.GetAttributeValue(userIdAttr)] = newAttributes(entry)
+	}
+	return users, nil
+}
+
+// userBaseDN returns the base dn of the user entries managed by the usersManager.
+func (um *usersManager) userBaseDN() string {
+	if um.baseDN != "" {
+		return um.baseDN
+	}
+	return um.Client.getConfig().UserBaseDN
+}
+
+// emit emits an event of the event type for the user entry.
+func (um *usersManager) emit(eventType EventType, uid string) {
+	um.emitChanges(eventType, uid, nil)
+}
+
+// emitChanges emits an event of the event type for the user entry with the changes of its attributes.
+func (um *usersManager) emitChanges(eventType EventType, uid string, changes ChangeSet) {
+	um.Client.emit(Event{Type: eventType, Dn: um.getDN(uid), Uid: uid, Changes: changes})
+}
+
 // getDN returns the formatted LDAP user domain name.
 func (um *usersManager) getDN(uid string) string {
-	return fmt.Sprintf("%s=%s,%s", userIdAttr, uid, um.Client.Config.UserBaseDN)
+	return dn.Join(dn.RDN(userIdAttr, uid), um.userBaseDN())
 }
 
 // getUsersSearchRequest returns a ldap search request to get a list of users.
 // The list of users retrieved depends on the userSearchFilter.
 func (um *usersManager) getUsersSearchRequest(userSearchFilter string) *ldap.SearchRequest {
 	return &ldap.SearchRequest{
-		BaseDN:       um.Client.Config.UserBaseDN,
+		BaseDN:       um.userBaseDN(),
 		Scope:        ldap.ScopeWholeSubtree,
 		DerefAliases: ldap.NeverDerefAliases,
 		SizeLimit:    0,
 		TimeLimit:    0,
 		TypesOnly:    false,
 		Filter:       userSearchFilter,
-		Attributes:   userAttributes,
+		Attributes:   um.searchAttributes(),
 		Controls:     nil,
 	}
 }
@@ -311,12 +783,17 @@ func (um *usersManager) getUserSearchRequest(DN string) *ldap.SearchRequest {
 		SizeLimit:    0,
 		TimeLimit:    0,
 		TypesOnly:    false,
-		Filter:       userSearchFilter,
-		Attributes:   userAttributes,
+		Filter:       um.Client.getConfig().usersFilter(),
+		Attributes:   um.searchAttributes(),
 		Controls:     nil,
 	}
 }
 
+// searchAttributes returns the attributes retrieved by the user search requests.
+func (um *usersManager) searchAttributes() []string {
+	return append(um.attributes(), managerAttr, um.Client.revisionAttr())
+}
+
 // getAddRequest returns a ldap add request to add a new user entry.
 func (um *usersManager) getAddRequest(user User) *ldap.AddRequest {
 	ar := ldap.NewAddRequest(um.getDN(user.Uid), nil)
@@ -330,9 +807,38 @@ func (um *usersManager) getAddRequest(user User) *ldap.AddRequest {
 	ar.Attribute(mailAttr, []string{user.Mail})
 	ar.Attribute(userPasswordAttr, []string{user.UserPassword})
 	ar.Attribute(statusAttr, []string{user.Status})
+	if len(user.Photo) > 0 {
+		ar.Attribute(jpegPhotoAttr, []string{string(user.Photo)})
+	}
+	if len(user.Certificates) > 0 {
+		ar.Attribute(userCertificateAttr, binaryToStrings(user.Certificates))
+	}
+	if user.Manager != "" {
+		ar.Attribute(managerAttr, []string{um.managerDn(user.Manager)})
+	}
+	for _, attr := range um.Client.getConfig().contactAttributes() {
+		if value := userAttributeValue(user, attr); value != "" {
+			ar.Attribute(attr, []string{value})
+		}
+	}
 	return ar
 }
 
+// getBinaryAttributeSearchRequest returns a ldap search request to get a binary attribute of a single user entry.
+func (um *usersManager) getBinaryAttributeSearchRequest(uid, attr string) *ldap.SearchRequest {
+	sr := um.getUserSearchRequest(um.getDN(uid))
+	sr.Attributes = []string{attr}
+	return sr
+}
+
+// getBinaryAttributeModifyRequest returns a ldap modify request to replace the values of a binary attribute of
+// a user entry. If there are no values then the attribute is removed.
+func (um *usersManager) getBinaryAttributeModifyRequest(uid, attr string, values [][]byte) *ldap.ModifyRequest {
+	mr := ldap.NewModifyRequest(um.getDN(uid), nil)
+	mr.Replace(attr, binaryToStrings(values))
+	return mr
+}
+
 // getPasswordModifyRequest returns a ldap password modify request.
 func (um *usersManager) getPasswordModifyRequest(uid, oldPassword, newPassword string) *ldap.PasswordModifyRequest {
 	return ldap.NewPasswordModifyRequest(
@@ -361,6 +867,13 @@ func (um *usersManager) parseSearchResult(result *ldap.SearchResult) []User {
 			Mail:           e.GetAttributeValue(mailAttr),
 			UserPassword:   e.GetAttributeValue(userPasswordAttr),
 			Status:         e.GetAttributeValue(statusAttr),
+			Photo:          rawAttributeValue(e, jpegPhotoAttr),
+			Certificates:   rawAttributeValues(e, userCertificateAttr),
+			Revision:       e.GetAttributeValue(um.Client.revisionAttr()),
+			Manager:        e.GetAttributeValue(managerAttr),
+		}
+		for _, attr := range userContactAttributes {
+			setUserAttributeValue(&user, attr, e.GetAttributeValue(attr))
 		}
 		users = append(users, user)
 	}
@@ -372,13 +885,13 @@ func (um *usersManager) parseSearchResult(result *ldap.SearchResult) []User {
 
 // getPersonalAccounts retrieves all the users from LDAP and then filters for the personal accounts based on the
 // PersonalUserTypeRegex regular expression.
-func (um *usersManager) getPersonalAccounts() ([]User, *errors.Error) {
+func (um *usersManager) getPersonalAccounts(opts ...RequestOption) ([]User, *Error) {
 	var result []User
 	cRegex, err := regexp.Compile(PersonalUserTypeRegex)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return nil, WrapError(errors.InternalServerError(err.Error()))
 	}
-	users, cErr := um.GetAll()
+	users, cErr := um.getAll(opts...)
 	if cErr != nil {
 		return nil, cErr
 	}
@@ -392,19 +905,19 @@ func (um *usersManager) getPersonalAccounts() ([]User, *errors.Error) {
 
 // getBuilderAccounts retrieves all the builder accounts from LDAP using the Filter method and the
 // BuilderAccountTypeFilter.
-func (um *usersManager) getBuilderAccounts() ([]User, *errors.Error) {
-	return um.Filter(userIdAttr, BuilderAccountTypeFilter)
+func (um *usersManager) getBuilderAccounts(opts ...RequestOption) ([]User, *Error) {
+	return um.FilterWithOptions(userIdAttr, BuilderAccountTypeFilter, opts...)
 }
 
 // getNPAAccounts retrieves all the users from LDAP. The personal accounts and the builder accounts are filtered out
 // of the list and the remainder of the accounts are returned.
-func (um *usersManager) getNPAAccounts() ([]User, *errors.Error) {
+func (um *usersManager) getNPAAccounts(opts ...RequestOption) ([]User, *Error) {
 	var result []User
 	cRegex, err := regexp.Compile(PersonalUserTypeRegex)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return nil, WrapError(errors.InternalServerError(err.Error()))
 	}
-	users, cErr := um.GetAll()
+	users, cErr := um.getAll(opts...)
 	if cErr != nil {
 		return nil, cErr
 	}
@@ -417,12 +930,12 @@ func (um *usersManager) getNPAAccounts() ([]User, *errors.Error) {
 }
 
 // modifyPassword processes the ldap password modify request.
-func (um *usersManager) modifyPassword(uid, oldPassword, newPassword string) (*ldap.PasswordModifyResult, *errors.Error) {
+func (um *usersManager) modifyPassword(uid, oldPassword, newPassword string) (*ldap.PasswordModifyResult, *Error) {
 	pmr := um.getPasswordModifyRequest(uid, oldPassword, newPassword)
 	result, cErr := um.Client.doLDAPPasswordModify(pmr)
 	if cErr != nil {
 		if cErr.Status == http.StatusNotFound {
-			return nil, errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid))
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
 		} else {
 			return nil, cErr
 		}
@@ -430,16 +943,48 @@ func (um *usersManager) modifyPassword(uid, oldPassword, newPassword string) (*l
 	return result, nil
 }
 
+// getBinaryAttribute retrieves the raw values of a binary attribute of a user entry.
+func (um *usersManager) getBinaryAttribute(uid, attr string) ([][]byte, *Error) {
+	if cErr := um.validateUid(uid); cErr != nil {
+		return nil, cErr
+	}
+	result, cErr := um.Client.doLDAPSearch(um.getBinaryAttributeSearchRequest(uid, attr))
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return nil, cErr
+	}
+	if len(result.Entries) == 0 {
+		return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+	}
+	return rawAttributeValues(result.Entries[0], attr), nil
+}
+
+// setBinaryAttribute replaces the values of a binary attribute of a user entry.
+func (um *usersManager) setBinaryAttribute(uid, attr string, values [][]byte) *Error {
+	if cErr := um.validateUid(uid); cErr != nil {
+		return cErr
+	}
+	if cErr := um.Client.doLDAPModify(um.getBinaryAttributeModifyRequest(uid, attr, values)); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, uid)))
+		}
+		return cErr
+	}
+	return nil
+}
+
 // validateUid checks if the uid is set.
-func (um *usersManager) validateUid(uid string) *errors.Error {
+func (um *usersManager) validateUid(uid string) *Error {
 	if strings.TrimSpace(uid) == "" {
-		return errors.BadRequestErrorf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter], []string{userIdAttr})
+		return missingParametersError([]string{userIdAttr})
 	}
 	return nil
 }
 
 // validateUser checks if all the required attributes of a User are set for creating a new user.
-func (um *usersManager) validateUser(user User) *errors.Error {
+func (um *usersManager) validateUser(user User) *Error {
 
 	var missingParams []string
 
@@ -469,16 +1014,24 @@ func (um *usersManager) validateUser(user User) *errors.Error {
 	}
 
 	if len(missingParams) > 0 {
-		return errors.BadRequestErrorf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter], missingParams)
+		return missingParametersError(missingParams)
 	}
 	if cErr := um.validateStatus(user.Status); cErr != nil {
 		return cErr
 	}
+	return um.validateUserSyntax(user)
+}
+
+// validateAttributes checks if at least one attribute is selected.
+func (um *usersManager) validateAttributes(attributes []string) *Error {
+	if len(attributes) == 0 {
+		return missingParametersError([]string{"attributes"})
+	}
 	return nil
 }
 
 // validateFilter checks if the filter key and value is set.
-func (um *usersManager) validateFilter(key, value string) *errors.Error {
+func (um *usersManager) validateFilter(key, value string) *Error {
 	var missingParams []string
 	if strings.TrimSpace(key) == "" {
 		missingParams = append(missingParams, "key")
@@ -487,18 +1040,18 @@ func (um *usersManager) validateFilter(key, value string) *errors.Error {
 		missingParams = append(missingParams, "value")
 	}
 	if len(missingParams) > 0 {
-		return errors.BadRequestErrorf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter], missingParams)
+		return missingParametersError(missingParams)
 	}
-	if !slice.EntryExists(userAttributes, key) {
-		return errors.BadRequestError(fmt.Sprintf(invalidFilterKeyErrMsg, key, userAttributes))
+	if attrs := um.attributes(); !slice.EntryExists(attrs, key) {
+		return invalidParameterError("key", fmt.Sprintf(invalidFilterKeyErrMsg, key, attrs))
 	}
 	return nil
 }
 
 // validateStatus checks if the status attribute value is valid.
-func (um *usersManager) validateStatus(status string) *errors.Error {
+func (um *usersManager) validateStatus(status string) *Error {
 	if !slice.EntryExists(validStatusList, status) {
-		return errors.BadRequestError(fmt.Sprintf(invalidStatusErrMsg, status, validStatusList))
+		return invalidParameterError(statusAttr, fmt.Sprintf(invalidStatusErrMsg, status, validStatusList))
 	}
 	return nil
 }
@@ -0,0 +1,203 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// mandatoryUserAttributes are the attributes besides the uid and the password which every user entry must have.
+var mandatoryUserAttributes = []string{
+	alternateUserIdAttr,
+	CommonNameAttr,
+	familyNameAttr,
+	displayNameAttr,
+	mailAttr,
+	statusAttr,
+}
+
+// Update updates the attributes of an existing user entry in LDAP which differ from the user.
+// params:
+//
+//	user = the updated user entry, the user must contain all the mandatory attributes
+//	revision = the Revision of the user entry the update is based on, empty to update the entry unconditionally
+//
+// If the revision is set, the user entry is only updated if it was not modified since the revision was retrieved,
+// which prevents lost updates from concurrent changes. The revision is checked immediately before the update.
+// The password is never updated and an empty value removes an optional attribute. The ChangeSet of the update is
+// passed to the handlers of the EventUserUpdated event.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if the user entry was modified since the revision
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) Update(user User, revision string) *Error {
+	existing, cErr := um.get(user.Uid)
+	if cErr != nil {
+		return cErr
+	}
+	if cErr := checkRevision(um.getDN(existing.Uid), revision, existing.Revision); cErr != nil {
+		return cErr
+	}
+	changed := changedUserAttributes(*existing, user, um.attributes())
+	if len(changed) == 0 {
+		return nil
+	}
+	_, cErr = um.update(*existing, user, changed)
+	return cErr
+}
+
+// update replaces the attributes attrs of the existing user entry with the values of user and returns the changes.
+// The attributes are validated the same way as on Create, an empty value removes an optional attribute.
+// The method returns an error:
+//   - if a validation fails
+//   - if the user is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (um *usersManager) update(existing, user User, attrs []string) (ChangeSet, *Error) {
+	updated := mergeUserAttributes(existing, user, attrs)
+	if cErr := um.validateUpdate(updated); cErr != nil {
+		return nil, cErr
+	}
+
+	if cErr := um.Client.doLDAPModify(um.getUpdateRequest(existing.Uid, updated, attrs)); cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, WrapError(errors.NotFoundError(fmt.Sprintf(userNotFoundMsg, existing.Uid)))
+		}
+		return nil, cErr
+	}
+	changes := userChangeSet(existing, updated, attrs)
+	um.emitChanges(EventUserUpdated, existing.Uid, changes)
+	return changes, nil
+}
+
+// validateUpdate checks if the user entry is still valid after an update.
+func (um *usersManager) validateUpdate(user User) *Error {
+	var missingParams []string
+	for _, attr := range mandatoryUserAttributes {
+		if strings.TrimSpace(userAttributeValue(user, attr)) == "" {
+			missingParams = append(missingParams, attr)
+		}
+	}
+	if len(missingParams) > 0 {
+		return missingParametersError(missingParams)
+	}
+	if cErr := um.validateStatus(user.Status); cErr != nil {
+		return cErr
+	}
+	if cErr := um.validateUserSyntax(user); cErr != nil {
+		return cErr
+	}
+	return um.validateUniqueness(user)
+}
+
+// getUpdateRequest returns a ldap modify request which replaces the attributes attrs of the user entry with the
+// values of user. An empty value removes the attribute.
+func (um *usersManager) getUpdateRequest(uid string, user User, attrs []string) *ldap.ModifyRequest {
+	mr := ldap.NewModifyRequest(um.getDN(uid), nil)
+	for _, attr := range attrs {
+		if value := userAttributeValue(user, attr); value != "" {
+			mr.Replace(attr, []string{value})
+		} else {
+			mr.Replace(attr, []string{})
+		}
+	}
+	return mr
+}
+
+// mergeUserAttributes returns the existing user with the values of the attributes attrs taken from user.
+func mergeUserAttributes(existing, user User, attrs []string) User {
+	merged := existing
+	for _, attr := range attrs {
+		setUserAttributeValue(&merged, attr, userAttributeValue(user, attr))
+	}
+	return merged
+}
+
+// changedUserAttributes returns the attributes of attrs of which the value of user differs from the existing user.
+func changedUserAttributes(existing, user User, attrs []string) []string {
+	var changed []string
+	for _, attr := range attrs {
+		if attr == userIdAttr || attr == userPasswordAttr {
+			continue
+		}
+		if userAttributeValue(existing, attr) != userAttributeValue(user, attr) {
+			changed = append(changed, attr)
+		}
+	}
+	return changed
+}
+
+// userAttributeValue returns the value of the attribute of a user.
+func userAttributeValue(user User, attr string) string {
+	switch attr {
+	case userIdAttr:
+		return user.Uid
+	case alternateUserIdAttr:
+		return user.AltUid
+	case CommonNameAttr:
+		return user.Cn
+	case familyNameAttr:
+		return user.Sn
+	case displayNameAttr:
+		return user.DisplayName
+	case employeeNumberAttr:
+		return user.EmployeeNumber
+	case mailAttr:
+		return user.Mail
+	case userPasswordAttr:
+		return user.UserPassword
+	case statusAttr:
+		return user.Status
+	case telephoneNumberAttr:
+		return user.TelephoneNumber
+	case titleAttr:
+		return user.Title
+	case organizationAttr:
+		return user.Organization
+	case localityAttr:
+		return user.Locality
+	case departmentNumberAttr:
+		return user.Department
+	default:
+		return ""
+	}
+}
+
+// setUserAttributeValue sets the value of the attribute of a user.
+func setUserAttributeValue(user *User, attr, value string) {
+	switch attr {
+	case userIdAttr:
+		user.Uid = value
+	case alternateUserIdAttr:
+		user.AltUid = value
+	case CommonNameAttr:
+		user.Cn = value
+	case familyNameAttr:
+		user.Sn = value
+	case displayNameAttr:
+		user.DisplayName = value
+	case employeeNumberAttr:
+		user.EmployeeNumber = value
+	case mailAttr:
+		user.Mail = value
+	case userPasswordAttr:
+		user.UserPassword = value
+	case statusAttr:
+		user.Status = value
+	case telephoneNumberAttr:
+		user.TelephoneNumber = value
+	case titleAttr:
+		user.Title = value
+	case organizationAttr:
+		user.Organization = value
+	case localityAttr:
+		user.Locality = value
+	case departmentNumberAttr:
+		user.Department = value
+	}
+}
@@ -0,0 +1,173 @@
+package ldap
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersManager_update(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser1
+		user.Mail = "john.doe@example.com"
+		user.EmployeeNumber = ""
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(mailAttr, []string{user.Mail})
+		mr.Replace(employeeNumberAttr, []string{})
+
+		var events []Event
+		client.On(EventUserUpdated, func(event Event) { events = append(events, event) })
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		changes, cErr := um.update(testUser1, user, []string{mailAttr, employeeNumberAttr})
+		assert.Nil(t, cErr)
+		expected := ChangeSet{
+			{Attribute: mailAttr, Old: []string{testUser1.Mail}, New: []string{user.Mail}},
+			{Attribute: employeeNumberAttr, Old: []string{testUser1.EmployeeNumber}, New: []string{}},
+		}
+		assert.Equal(t, expected, changes)
+		assert.Len(t, events, 1)
+		assert.Equal(t, expected, events[0].Changes)
+	})
+
+	t.Run("missing mandatory attribute", func(t *testing.T) {
+		um := usersManager{Client: NewClient(testConfig)}
+		user := testUser1
+		user.Mail = ""
+
+		_, cErr := um.update(testUser1, user, []string{mailAttr})
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, FieldErrCodeMissing, cErr.Validation.Field(mailAttr).Code)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		um := usersManager{Client: NewClient(testConfig)}
+		user := testUser1
+		user.Status = "invalid"
+
+		_, cErr := um.update(testUser1, user, []string{statusAttr})
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		user := testUser1
+		user.Cn = "Johnny"
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(CommonNameAttr, []string{user.Cn})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		_, cErr := um.update(testUser1, user, []string{CommonNameAttr})
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+}
+
+func TestUsersManager_Update(t *testing.T) {
+	entry := getUserLDAPEntry(testUser1)
+	entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(modifyTimestampAttr, []string{testRevision}))
+	searchResult := &ldap.SearchResult{Entries: []*ldap.Entry{entry}}
+	user := testUser1
+	user.Mail = "john.doe@example.com"
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(mailAttr, []string{user.Mail})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).Return(searchResult, nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserProvisioner).Update(user, testRevision))
+	})
+
+	t.Run("modified since the revision", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).Return(searchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProvisioner).Update(user, "20261016110000Z")
+		assert.Equal(t, http.StatusConflict, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(revisionConflictMsg, um.getDN(testUser1.Uid), "20261016110000Z", testRevision),
+			cErr.Message)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).Return(searchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		assert.Nil(t, client.Users.(UserProvisioner).Update(testUser1, ""))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUserSearchRequest(um.getDN(testUser1.Uid))).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProvisioner).Update(user, testRevision)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+}
+
+func TestChangedUserAttributes(t *testing.T) {
+	user := testUser1
+	user.Mail = "john.doe@example.com"
+	user.UserPassword = "newPassword"
+	user.Uid = "c00001"
+
+	assert.Equal(t, []string{mailAttr},
+		changedUserAttributes(testUser1, user, []string{userIdAttr, mailAttr, CommonNameAttr, userPasswordAttr}))
+	assert.Nil(t, changedUserAttributes(testUser1, testUser1, userAttributes))
+}
+
+func TestMergeUserAttributes(t *testing.T) {
+	user := User{Uid: testUser1.Uid, Mail: "john.doe@example.com", Cn: "Johnny"}
+	merged := mergeUserAttributes(testUser1, user, []string{mailAttr})
+
+	assert.Equal(t, user.Mail, merged.Mail)
+	assert.Equal(t, testUser1.Cn, merged.Cn)
+	assert.Equal(t, testUser1.DisplayName, merged.DisplayName)
+}
+
+func TestUserAttributeValue(t *testing.T) {
+	for _, attr := range userCSVColumns {
+		var user User
+		setUserAttributeValue(&user, attr, "value")
+		assert.Equal(t, "value", userAttributeValue(user, attr), attr)
+	}
+	assert.Equal(t, "", userAttributeValue(testUser1, "unknown"))
+}
@@ -113,6 +113,25 @@ func TestUsersManager_GetAll(t *testing.T) {
 		assert.Equal(t, testUser4.Uid, users[3].Uid)
 	})
 
+	t.Run("success: user search filter", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.UserSearchFilter = "(objectClass=posixAccount)"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+
+		um := usersManager{Client: client}
+		sr := um.getUsersSearchRequest("(objectClass=posixAccount)")
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).
+			Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.GetAll()
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 4)
+	})
+
 	t.Run("success: empty list", func(t *testing.T) {
 		ldapMock := mocks.NewClient(t)
 		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
@@ -282,6 +301,24 @@ func TestUsersManager_Filter(t *testing.T) {
 		assert.Equal(t, testUser3.Uid, users[0].Uid)
 	})
 
+	t.Run("success: user search filter", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		config := testConfig
+		config.UserSearchFilter = "(objectClass=posixAccount)"
+		client := NewClient(config, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		sr := um.getUsersSearchRequest("(&(uid=" + BuilderAccountTypeFilter + ")(objectClass=posixAccount))")
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).
+			Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(getBuilderAccountFilteredSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		users, cErr := client.Users.Filter(userIdAttr, BuilderAccountTypeFilter)
+		assert.Nil(t, cErr)
+		assert.Len(t, users, 1)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		ldapMock := mocks.NewClient(t)
 		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
@@ -522,6 +559,62 @@ func TestUsersManager_FilterByType(t *testing.T) {
 	})
 }
 
+func TestUsersManager_Search(t *testing.T) {
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		result, cErr := client.Users.(UsersReader).Search("")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(
+			errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{searchFilterParam},
+		), cErr.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		um := usersManager{Client: client}
+		paging := ldap.NewControlPaging(2)
+		sr := um.getUsersSearchRequest(userSearchFilter)
+		sr.Controls = []ldap.Control{paging}
+		pagingResult := ldap.NewControlPaging(0)
+		pagingResult.SetCookie([]byte("cookie"))
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(&ldap.SearchResult{
+			Entries:  getUsersSearchResult.Entries[:2],
+			Controls: []ldap.Control{pagingResult},
+		}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UsersReader).Search(userSearchFilter, WithControls(paging))
+		assert.Nil(t, cErr)
+		assert.Len(t, result.Users, 2)
+		assert.Equal(t, testUser1.Uid, result.Users[0].Uid)
+		assert.Equal(t, []byte("cookie"), result.PagingCookie())
+		assert.Nil(t, result.PasswordPolicy())
+		assert.Empty(t, result.Referrals)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getUsersSearchRequest(userSearchFilter)).Return(nil, ldapNetworkErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UsersReader).Search(userSearchFilter)
+		assert.Nil(t, result)
+		assert.NotNil(t, cErr)
+	})
+}
+
 func TestUsersManager_Create(t *testing.T) {
 	t.Run("validate user", func(t *testing.T) {
 		client := NewClient(testConfig)
@@ -699,6 +792,7 @@ func TestUsersManager_Authenticate(t *testing.T) {
 
 		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).
 			Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
 
 		cErr := client.Users.Authenticate()
 		assert.Nil(t, cErr)
@@ -854,3 +948,323 @@ func getUserLDAPEntry(user User) *ldap.Entry {
 		Attributes: attributes,
 	}
 }
+
+func TestUsersManager_ChangePassword(t *testing.T) {
+	t.Run("validation error", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.(UserPasswordsManager).ChangePassword(testUser1.Uid, "", "")
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+		assert.Equal(t, fmt.Sprintf(
+			errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{oldPasswordParam, newPasswordParam},
+		), cErr.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		pmr := um.getPasswordModifyRequest(testUser1.Uid, "oldPassword", "newPassword")
+
+		ldapMock.On(methodNameBind, um.getDN(testUser1.Uid), "oldPassword").Return(nil)
+		ldapMock.On("PasswordModify", pmr).Return(&ldap.PasswordModifyResult{}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserPasswordsManager).ChangePassword(testUser1.Uid, "oldPassword", "newPassword")
+		assert.Nil(t, cErr)
+		assert.Equal(t, testConfig.BindUser, client.Config.BindUser)
+	})
+
+	t.Run("invalid old password", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, um.getDN(testUser1.Uid), "wrongPassword").Return(ldapInvalidCredentialsErr)
+
+		cErr := client.Users.(UserPasswordsManager).ChangePassword(testUser1.Uid, "wrongPassword", "newPassword")
+		assert.Equal(t, http.StatusUnauthorized, cErr.Status)
+	})
+
+	t.Run("denied by the policy", func(t *testing.T) {
+		client := NewClient(testConfig, UnitTesting(), WithPolicy(selfServicePolicy))
+
+		cErr := client.Users.(UserPasswordsManager).ChangePassword(testUser1.Uid, "oldPassword", "newPassword")
+		assert.Equal(t, ErrCodePolicyDenied, cErr.Code)
+	})
+}
+
+func TestUsersManager_GetPhoto(t *testing.T) {
+	photo := []byte{0xff, 0xd8, 0xff, 0xe0, 0x00}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		entry := ldap.NewEntry(um.getDN(testUser1.Uid), nil)
+		entry.Attributes = []*ldap.EntryAttribute{{Name: jpegPhotoAttr, ByteValues: [][]byte{photo}}}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getBinaryAttributeSearchRequest(testUser1.Uid, jpegPhotoAttr)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{entry}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProfileManager).GetPhoto(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, photo, result)
+	})
+
+	t.Run("no photo", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getBinaryAttributeSearchRequest(testUser1.Uid, jpegPhotoAttr)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(um.getDN(testUser1.Uid), nil)}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProfileManager).GetPhoto(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Nil(t, result)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getBinaryAttributeSearchRequest(testUser1.Uid, jpegPhotoAttr)).
+			Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProfileManager).GetPhoto(testUser1.Uid)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+
+	t.Run("validate uid", func(t *testing.T) {
+		client := NewClient(testConfig)
+		result, cErr := client.Users.(UserProfileManager).GetPhoto("")
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestUsersManager_SetPhoto(t *testing.T) {
+	photo := []byte{0xff, 0xd8, 0xff, 0xe0, 0x00}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(jpegPhotoAttr, []string{string(photo)})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProfileManager).SetPhoto(testUser1.Uid, photo)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("remove photo", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(jpegPhotoAttr, []string{})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProfileManager).SetPhoto(testUser1.Uid, nil)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, um.getBinaryAttributeModifyRequest(testUser1.Uid, jpegPhotoAttr,
+			[][]byte{photo})).Return(ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProfileManager).SetPhoto(testUser1.Uid, photo)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+}
+
+func TestUsersManager_Certificates(t *testing.T) {
+	certificates := [][]byte{{0x30, 0x82, 0x01}, {0x30, 0x82, 0x02}}
+
+	t.Run("get", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		entry := ldap.NewEntry(um.getDN(testUser1.Uid), nil)
+		entry.Attributes = []*ldap.EntryAttribute{{Name: userCertificateAttr, ByteValues: certificates}}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, um.getBinaryAttributeSearchRequest(testUser1.Uid, userCertificateAttr)).
+			Return(&ldap.SearchResult{Entries: []*ldap.Entry{entry}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UserProfileManager).GetCertificates(testUser1.Uid)
+		assert.Nil(t, cErr)
+		assert.Equal(t, certificates, result)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		mr := ldap.NewModifyRequest(um.getDN(testUser1.Uid), nil)
+		mr.Replace(userCertificateAttr, []string{string(certificates[0]), string(certificates[1])})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameModify, mr).Return(nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		cErr := client.Users.(UserProfileManager).SetCertificates(testUser1.Uid, certificates)
+		assert.Nil(t, cErr)
+	})
+
+	t.Run("validate uid", func(t *testing.T) {
+		client := NewClient(testConfig)
+		cErr := client.Users.(UserProfileManager).SetCertificates(" ", certificates)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+	})
+}
+
+func TestUsersManager_binaryAttributes(t *testing.T) {
+	client := NewClient(testConfig)
+	um := usersManager{Client: client}
+	user := testUser1
+	user.Photo = []byte{0xff, 0xd8}
+	user.Certificates = [][]byte{{0x30, 0x82}}
+
+	t.Run("add request", func(t *testing.T) {
+		ar := um.getAddRequest(user)
+		assert.Contains(t, ar.Attributes, ldap.Attribute{Type: jpegPhotoAttr, Vals: []string{string(user.Photo)}})
+		assert.Contains(t, ar.Attributes,
+			ldap.Attribute{Type: userCertificateAttr, Vals: []string{string(user.Certificates[0])}})
+		assert.Len(t, um.getAddRequest(testUser1).Attributes, len(ar.Attributes)-2)
+	})
+
+	t.Run("parse search result", func(t *testing.T) {
+		entry := getUserLDAPEntry(testUser1)
+		entry.Attributes = append(entry.Attributes,
+			&ldap.EntryAttribute{Name: jpegPhotoAttr, ByteValues: [][]byte{user.Photo}},
+			&ldap.EntryAttribute{Name: userCertificateAttr, ByteValues: user.Certificates},
+		)
+		users := um.parseSearchResult(&ldap.SearchResult{Entries: []*ldap.Entry{entry}})
+		assert.Equal(t, user.Photo, users[0].Photo)
+		assert.Equal(t, user.Certificates, users[0].Certificates)
+
+		users = um.parseSearchResult(&ldap.SearchResult{Entries: []*ldap.Entry{getUserLDAPEntry(testUser1)}})
+		assert.Nil(t, users[0].Photo)
+		assert.Nil(t, users[0].Certificates)
+	})
+}
+
+func TestUsersManager_GetAttributes(t *testing.T) {
+	attributes := []string{mailAttr, "costCenter"}
+
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		sr := um.getUserSearchRequest(um.getDN(testUser1.Uid))
+		sr.Attributes = attributes
+		entry := ldap.NewEntry(um.getDN(testUser1.Uid), map[string][]string{
+			mailAttr:     {testUser1.Mail},
+			"costCenter": {"CC100"},
+		})
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(&ldap.SearchResult{Entries: []*ldap.Entry{entry}}, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UsersReader).GetAttributes(testUser1.Uid, attributes)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testUser1.Mail, result.Get(mailAttr))
+		assert.Equal(t, "CC100", result.Get("costCenter"))
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		result, cErr := client.Users.(UsersReader).GetAttributes("", attributes)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+
+		result, cErr = client.Users.(UsersReader).GetAttributes(testUser1.Uid, nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(
+			errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter],
+			[]string{"attributes"},
+		), cErr.Message)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		sr := um.getUserSearchRequest(um.getDN(testUser1.Uid))
+		sr.Attributes = attributes
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(nil, ldapNoSuchObjectErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UsersReader).GetAttributes(testUser1.Uid, attributes)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeNotFound, cErr.Code)
+		assert.Equal(t, fmt.Sprintf(userNotFoundMsg, testUser1.Uid), cErr.Message)
+	})
+}
+
+func TestUsersManager_GetAllAttributes(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		sr := um.getUsersSearchRequest(userSearchFilter)
+		sr.Attributes = []string{userIdAttr, mailAttr}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(&getUsersSearchResult, nil)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UsersReader).GetAllAttributes([]string{mailAttr})
+		assert.Nil(t, cErr)
+		assert.Len(t, result, 4)
+		assert.Equal(t, testUser2.Mail, result[testUser2.Uid].Get(mailAttr))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ldapMock := mocks.NewClient(t)
+		client := NewClient(testConfig, WithLDAPClient(ldapMock), UnitTesting())
+		um := usersManager{Client: client}
+		sr := um.getUsersSearchRequest(userSearchFilter)
+		sr.Attributes = []string{userIdAttr}
+
+		ldapMock.On(methodNameBind, client.Config.BindUser, client.Config.BindPassword).Return(nil)
+		ldapMock.On(methodNameSearch, sr).Return(nil, ldapInsufficientRightsErr)
+		ldapMock.On(methodNameClose).Return(nil)
+
+		result, cErr := client.Users.(UsersReader).GetAllAttributes([]string{userIdAttr})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrCodeInsufficientAccess, cErr.Code)
+	})
+}
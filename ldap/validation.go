@@ -0,0 +1,87 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/go-utils/utils/errors"
+)
+
+const (
+	// FieldErrCodeMissing is the code of a FieldError of a mandatory field which is not set.
+	FieldErrCodeMissing = "MISSING"
+	// FieldErrCodeInvalid is the code of a FieldError of a field which has an invalid value.
+	FieldErrCodeInvalid = "INVALID"
+
+	missingFieldErrMsg           = "%s is mandatory"
+	invalidFieldsErrMsgSeparator = "; "
+)
+
+type (
+	// ValidationError lists the fields which failed the validation of the input of a method. The ValidationError is
+	// set as the Validation of the bad request or conflict error returned by the method, e.g. to render an error for
+	// every field of a form.
+	ValidationError struct {
+		Fields []FieldError `json:"fields"`
+	}
+
+	// FieldError describes why a single field failed the validation.
+	FieldError struct {
+		// Field is the name of the field or the attribute, e.g. "uid" or "mail".
+		Field string `json:"field"`
+		// Code is the machine-readable reason, e.g. FieldErrCodeMissing or FieldErrCodeInvalid.
+		Code string `json:"code"`
+		// Message is the human-readable reason.
+		Message string `json:"message"`
+	}
+)
+
+// Error joins the messages of the fields, so the ValidationError can be retrieved from an Error with errors.As.
+func (v *ValidationError) Error() string {
+	messages := make([]string, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		messages = append(messages, field.Message)
+	}
+	return strings.Join(messages, invalidFieldsErrMsgSeparator)
+}
+
+// Field returns the FieldError of the field or nil if the field passed the validation.
+func (v *ValidationError) Field(field string) *FieldError {
+	for i := range v.Fields {
+		if v.Fields[i].Field == field {
+			return &v.Fields[i]
+		}
+	}
+	return nil
+}
+
+// missingParametersError returns a bad request error for the missing mandatory parameters with a ValidationError
+// listing every missing parameter.
+func missingParametersError(params []string) *Error {
+	validationErr := &ValidationError{}
+	for _, param := range params {
+		validationErr.Fields = append(validationErr.Fields, FieldError{
+			Field:   param,
+			Code:    FieldErrCodeMissing,
+			Message: fmt.Sprintf(missingFieldErrMsg, param),
+		})
+	}
+	cErr := WrapError(errors.BadRequestErrorf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter], params))
+	cErr.Validation = validationErr
+	return cErr
+}
+
+// invalidParameterError returns a bad request error with the message for a parameter with an invalid value together
+// with a ValidationError for the parameter.
+func invalidParameterError(param, message string) *Error {
+	return invalidFieldsError([]FieldError{{Field: param, Code: FieldErrCodeInvalid, Message: message}})
+}
+
+// invalidFieldsError returns a bad request error for the invalid fields together with a ValidationError listing the
+// fields. The message of the error joins the messages of the fields.
+func invalidFieldsError(fields []FieldError) *Error {
+	validationErr := &ValidationError{Fields: fields}
+	cErr := WrapError(errors.BadRequestError(validationErr.Error()))
+	cErr.Validation = validationErr
+	return cErr
+}
@@ -0,0 +1,52 @@
+package ldap
+
+import (
+	err "errors"
+	"net/http"
+	"testing"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Run("missing fields", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		cErr := client.Users.Create(User{Uid: testUser1.Uid, Cn: testUser1.Cn})
+		assert.Equal(t, http.StatusBadRequest, cErr.Status)
+
+		validationErr := cErr.Validation
+		assert.Len(t, validationErr.Fields, 6)
+		assert.Nil(t, validationErr.Field(userIdAttr))
+		assert.Equal(t, &FieldError{
+			Field:   mailAttr,
+			Code:    FieldErrCodeMissing,
+			Message: "mail is mandatory",
+		}, validationErr.Field(mailAttr))
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		users, cErr := client.Users.FilterByStatus("unknown")
+		assert.Nil(t, users)
+		assert.Equal(t, &ValidationError{
+			Fields: []FieldError{{Field: statusAttr, Code: FieldErrCodeInvalid, Message: cErr.Message}},
+		}, cErr.Validation)
+	})
+
+	t.Run("errors.As", func(t *testing.T) {
+		client := NewClient(testConfig)
+
+		var validationErr *ValidationError
+		assert.True(t, err.As(client.Users.Create(User{Uid: testUser1.Uid}), &validationErr))
+		assert.Equal(t, FieldErrCodeMissing, validationErr.Field(mailAttr).Code)
+	})
+
+	t.Run("not a validation error", func(t *testing.T) {
+		var validationErr *ValidationError
+		assert.Nil(t, WrapError(errors.InternalServerError("error")).Validation)
+		assert.False(t, err.As(WrapError(errors.InternalServerError("error")), &validationErr))
+	})
+}
@@ -0,0 +1,173 @@
+package ldap
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/logger"
+)
+
+const (
+	// WebhookSignatureHeader is the header which contains the HMAC-SHA256 signature of the webhook request body,
+	// formatted as "sha256=<hex encoded signature>".
+	WebhookSignatureHeader = "X-Ldap-Signature"
+	// WebhookEventHeader is the header which contains the type of the event posted to the webhook.
+	WebhookEventHeader = "X-Ldap-Event"
+
+	webhookSignaturePrefix = "sha256="
+
+	defaultWebhookMaxRetries   = 3
+	defaultWebhookRetryBackoff = time.Second
+	defaultWebhookTimeout      = 10 * time.Second
+
+	webhookDeliveryFailedMsg   = "Delivery of the event '%s' of '%s' to the webhook '%s' failed: %v"
+	webhookUnexpectedStatusMsg = "unexpected status code %d"
+)
+
+type (
+	// WebhookConfig is the configuration of the webhooks to which the events are posted.
+	WebhookConfig struct {
+		// URLs of the webhooks, every event is posted to every URL.
+		URLs []string
+		// Secret used to sign the request body with HMAC-SHA256, the requests are not signed if the Secret is empty.
+		Secret string
+		// EventTypes to post to the webhooks, all the events are posted if no EventTypes are set.
+		EventTypes []EventType
+		// MaxRetries is the number of retries of a failed delivery, defaults to 3.
+		MaxRetries int
+		// RetryBackoff is the delay before the first retry which doubles with every retry, defaults to 1 second.
+		RetryBackoff time.Duration
+		// Timeout of a single delivery, defaults to 10 seconds. It is ignored if an HTTPClient is set.
+		Timeout time.Duration
+		// HTTPClient overrides the http.Client which is used to post the events.
+		HTTPClient *http.Client
+	}
+
+	// webhookDispatcher posts the events to the webhooks.
+	webhookDispatcher struct {
+		config     WebhookConfig
+		httpClient *http.Client
+		wg         sync.WaitGroup
+	}
+)
+
+// WithWebhooks posts the events emitted after successful write operations as JSON to the webhooks.
+// The events are posted asynchronously, so a slow or unavailable webhook does not delay the write operations.
+// A delivery is considered failed if the webhook can not be reached or responds with a status code other than 2xx.
+// Failed deliveries are retried and logged once all the retries failed.
+func WithWebhooks(config WebhookConfig) ClientOption {
+	return func(c *Client) {
+		if len(config.URLs) == 0 {
+			return
+		}
+		dispatcher := newWebhookDispatcher(config)
+		types := config.EventTypes
+		if len(types) == 0 {
+			types = eventTypes
+		}
+		for _, eventType := range types {
+			c.On(eventType, dispatcher.dispatch)
+		}
+		c.onClose(dispatcher.drain)
+	}
+}
+
+// newWebhookDispatcher returns a webhookDispatcher with the defaults applied to the config.
+func newWebhookDispatcher(config WebhookConfig) *webhookDispatcher {
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = defaultWebhookMaxRetries
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultWebhookRetryBackoff
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultWebhookTimeout
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+	return &webhookDispatcher{config: config, httpClient: httpClient}
+}
+
+// dispatch posts the event to all the webhooks in the background.
+func (wd *webhookDispatcher) dispatch(event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf(webhookDeliveryFailedMsg, event.Type, event.Dn, "", err)
+		return
+	}
+	for _, url := range wd.config.URLs {
+		wd.wg.Add(1)
+		go func(url string) {
+			defer wd.wg.Done()
+			wd.deliver(url, event, body)
+		}(url)
+	}
+}
+
+// drain blocks until all the deliveries in progress are finished or the ctx is done.
+func (wd *webhookDispatcher) drain(ctx context.Context) *Error {
+	return waitContext(ctx, &wd.wg)
+}
+
+// deliver posts the event to the webhook and retries failed deliveries.
+func (wd *webhookDispatcher) deliver(url string, event Event, body []byte) {
+	var err error
+	backoff := wd.config.RetryBackoff
+	for attempt := 0; attempt <= wd.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = wd.post(url, event, body); err == nil {
+			return
+		}
+	}
+	logger.Errorf(webhookDeliveryFailedMsg, event.Type, event.Dn, url, err)
+}
+
+// post posts the signed event to the webhook.
+func (wd *webhookDispatcher) post(url string, event Event, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(event.Type))
+	if wd.config.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, webhookSignaturePrefix+SignWebhookPayload(wd.config.Secret, body))
+	}
+	resp, err := wd.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf(webhookUnexpectedStatusMsg, resp.StatusCode)
+	}
+	return nil
+}
+
+// wait blocks until all the deliveries in progress are finished.
+func (wd *webhookDispatcher) wait() {
+	wd.wg.Wait()
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 signature of the payload.
+// Receivers of the webhooks can use it to verify the signature in the WebhookSignatureHeader.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package ldap
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWebhooks(t *testing.T) {
+	t.Run("signed event", func(t *testing.T) {
+		received := make(chan *http.Request, 1)
+		bodies := make(chan []byte, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received <- r
+			bodies <- body
+		}))
+		defer server.Close()
+
+		client := NewClient(testConfig, WithWebhooks(WebhookConfig{
+			URLs:       []string{server.URL},
+			Secret:     "secret",
+			EventTypes: []EventType{EventUserCreated},
+		}))
+		client.emit(Event{Type: EventUserDeleted, Uid: testUser2.Uid})
+		client.emit(Event{Type: EventUserCreated, Uid: testUser1.Uid})
+
+		select {
+		case r := <-received:
+			body := <-bodies
+			assert.Equal(t, string(EventUserCreated), r.Header.Get(WebhookEventHeader))
+			assert.Equal(t, webhookSignaturePrefix+SignWebhookPayload("secret", body),
+				r.Header.Get(WebhookSignatureHeader))
+			var event Event
+			assert.Nil(t, json.Unmarshal(body, &event))
+			assert.Equal(t, testUser1.Uid, event.Uid)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the event was not posted to the webhook")
+		}
+	})
+
+	t.Run("no urls", func(t *testing.T) {
+		client := NewClient(testConfig, WithWebhooks(WebhookConfig{}))
+		assert.Empty(t, client.events.get(EventUserCreated))
+	})
+}
+
+func TestWebhookDispatcher_dispatch(t *testing.T) {
+	t.Run("retry failed delivery", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		dispatcher := newWebhookDispatcher(WebhookConfig{URLs: []string{server.URL}, RetryBackoff: time.Millisecond})
+		dispatcher.dispatch(Event{Type: EventGroupCreated})
+		dispatcher.wait()
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("give up after max retries", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		dispatcher := newWebhookDispatcher(WebhookConfig{
+			URLs:         []string{server.URL},
+			MaxRetries:   1,
+			RetryBackoff: time.Millisecond,
+		})
+		assert.EqualError(t, dispatcher.post(server.URL, Event{}, nil), "unexpected status code 500")
+		dispatcher.dispatch(Event{Type: EventGroupDeleted})
+		dispatcher.wait()
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	})
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	assert.Equal(t, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		SignWebhookPayload("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
@@ -0,0 +1,106 @@
+// Package ldaptest provides builders for the LDAP entries and search results returned by an LDAP server, so services
+// which mock the go-ldap Client can unit test the ldap package against realistic search results.
+package ldaptest
+
+import (
+	"strings"
+
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/atselvan/ldap-go-lib/ldap"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// The attribute names of the entries as expected by the ldap package.
+const (
+	userIdAttr          = "uid"
+	alternateUserIdAttr = "altUid"
+	familyNameAttr      = "sn"
+	displayNameAttr     = "displayName"
+	employeeNumberAttr  = "employeeNumber"
+	mailAttr            = "mail"
+	statusAttr          = "status"
+	uniqueMemberAttr    = "uniqueMember"
+)
+
+// NewUserEntry returns the user entry of user within the UserBaseDN of config as returned by a user search.
+func NewUserEntry(config ldap.Config, user ldap.User) *goldap.Entry {
+	return goldap.NewEntry(UserDN(config, user.Uid), map[string][]string{
+		userIdAttr:          {user.Uid},
+		alternateUserIdAttr: {user.AltUid},
+		ldap.CommonNameAttr: {user.Cn},
+		familyNameAttr:      {user.Sn},
+		displayNameAttr:     {user.DisplayName},
+		employeeNumberAttr:  {user.EmployeeNumber},
+		mailAttr:            {user.Mail},
+		statusAttr:          {user.Status},
+	})
+}
+
+// NewGroupEntry returns the group entry cn within the GroupBaseDN of config as returned by a group search.
+// params:
+//
+//	config: the client Config
+//	cn: common name of the group
+//	ouPath: name or path of the organizational unit of the group, e.g. "parent/child"
+//	uniqueMembers: the distinguished names of the members of the group, see UserDN
+func NewGroupEntry(config ldap.Config, cn, ouPath string, uniqueMembers []string) *goldap.Entry {
+	return goldap.NewEntry(GroupDN(config, cn, ouPath), map[string][]string{
+		ldap.CommonNameAttr: {cn},
+		uniqueMemberAttr:    uniqueMembers,
+	})
+}
+
+// NewOrganizationalUnitEntry returns the organizational unit entry of ouPath within the GroupBaseDN of config as
+// returned by an organizational unit search.
+func NewOrganizationalUnitEntry(config ldap.Config, ouPath string) *goldap.Entry {
+	var ou string
+	if ouNames := splitOuPath(ouPath); len(ouNames) > 0 {
+		ou = ouNames[len(ouNames)-1]
+	}
+	return goldap.NewEntry(GroupDN(config, "", ouPath), map[string][]string{
+		ldap.OrganizationalUnitAttr: {ou},
+	})
+}
+
+// NewSearchResult returns a search result with the entries.
+func NewSearchResult(entries ...*goldap.Entry) *goldap.SearchResult {
+	return &goldap.SearchResult{
+		Entries: append([]*goldap.Entry{}, entries...),
+	}
+}
+
+// UserDN returns the distinguished name of the user with the user id uid within the UserBaseDN of config.
+func UserDN(config ldap.Config, uid string) string {
+	return dn.Join(dn.RDN(userIdAttr, uid), config.UserBaseDN)
+}
+
+// UserDNs returns the distinguished names of the users with the user ids uids within the UserBaseDN of config.
+func UserDNs(config ldap.Config, uids ...string) []string {
+	dns := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		dns = append(dns, UserDN(config, uid))
+	}
+	return dns
+}
+
+// GroupDN returns the distinguished name of the group cn within the organizational unit path ouPath and the
+// GroupBaseDN of config. If cn is empty the distinguished name of the organizational unit is returned.
+func GroupDN(config ldap.Config, cn, ouPath string) string {
+	var rdns []string
+	if cn != "" {
+		rdns = append(rdns, dn.RDN(ldap.CommonNameAttr, cn))
+	}
+	ouNames := splitOuPath(ouPath)
+	for i := len(ouNames) - 1; i >= 0; i-- {
+		rdns = append(rdns, dn.RDN(ldap.OrganizationalUnitAttr, ouNames[i]))
+	}
+	return dn.Join(append(rdns, config.GroupBaseDN)...)
+}
+
+// splitOuPath returns the names of the organizational units of an organizational unit path.
+func splitOuPath(ouPath string) []string {
+	if ouPath == "" {
+		return nil
+	}
+	return strings.Split(strings.Trim(ouPath, ldap.OuPathSeparator), ldap.OuPathSeparator)
+}
@@ -0,0 +1,88 @@
+package ldaptest
+
+import (
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/ldap"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testConfig = ldap.Config{
+		Protocol:     "ldaps",
+		Hostname:     "ldap.company.com",
+		Port:         "636",
+		BaseDN:       "company",
+		UserBaseDN:   "ou=users,o=company",
+		GroupBaseDN:  "ou=projects,o=company",
+		BindUser:     "cn=root,o=company",
+		BindPassword: "somePassword",
+	}
+
+	testUser = ldap.User{
+		Uid:            "C00001",
+		AltUid:         "john.doe",
+		Cn:             "John",
+		Sn:             "Doe",
+		DisplayName:    "John Doe",
+		EmployeeNumber: "E100001",
+		Mail:           "john.doe@company.com",
+		Status:         ldap.UserStatusActive,
+	}
+)
+
+func TestNewUserEntry(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := ldap.NewClient(testConfig, ldap.WithLDAPClient(ldapMock), ldap.UnitTesting())
+
+	ldapMock.On("Bind", testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On("Search", mock.AnythingOfType("*ldap.SearchRequest")).
+		Return(NewSearchResult(NewUserEntry(testConfig, testUser)), nil)
+	ldapMock.On("Close").Return(nil)
+
+	user, cErr := client.Users.Get(testUser.Uid)
+	assert.Nil(t, cErr)
+	assert.Equal(t, testUser, *user)
+}
+
+func TestNewGroupEntry(t *testing.T) {
+	ldapMock := mocks.NewClient(t)
+	client := ldap.NewClient(testConfig, ldap.WithLDAPClient(ldapMock), ldap.UnitTesting())
+	members := UserDNs(testConfig, "C00001", "C00002")
+
+	ldapMock.On("Bind", testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On("Search", mock.AnythingOfType("*ldap.SearchRequest")).
+		Return(NewSearchResult(NewGroupEntry(testConfig, "group1", "parent/child", members)), nil)
+	ldapMock.On("Close").Return(nil)
+
+	groups, cErr := client.Groups.GetFilter("(cn=group1)")
+	assert.Nil(t, cErr)
+	assert.Equal(t, []ldap.Group{
+		{
+			Dn:      "cn=group1,ou=child,ou=parent,ou=projects,o=company",
+			Ou:      "child",
+			OuPath:  []string{"parent", "child"},
+			Cn:      "group1",
+			Members: []string{"uid=C00001,ou=users,o=company", "uid=C00002,ou=users,o=company"},
+		},
+	}, groups)
+}
+
+func TestNewOrganizationalUnitEntry(t *testing.T) {
+	entry := NewOrganizationalUnitEntry(testConfig, "parent/child")
+	assert.Equal(t, "ou=child,ou=parent,ou=projects,o=company", entry.DN)
+	assert.Equal(t, "child", entry.GetAttributeValue(ldap.OrganizationalUnitAttr))
+}
+
+func TestNewSearchResult(t *testing.T) {
+	assert.Empty(t, NewSearchResult().Entries)
+	assert.Len(t, NewSearchResult(NewUserEntry(testConfig, testUser)).Entries, 1)
+}
+
+func TestGroupDN(t *testing.T) {
+	assert.Equal(t, "cn=group1,ou=project1,ou=projects,o=company", GroupDN(testConfig, "group1", "project1"))
+	assert.Equal(t, "ou=project1,ou=projects,o=company", GroupDN(testConfig, "", "project1"))
+	assert.Equal(t, testConfig.GroupBaseDN, GroupDN(testConfig, "", ""))
+}
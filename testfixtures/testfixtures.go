@@ -0,0 +1,84 @@
+// Package testfixtures provides builders for the values returned by the ldap managers, so services depending on the
+// ldap package can unit test against the manager mocks in mocks/ldapmocks without building the values by hand.
+package testfixtures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atselvan/ldap-go-lib/ldap"
+	"github.com/atselvan/ldap-go-lib/ldaptest"
+)
+
+const (
+	// UserBaseDN is the base dn of the user entries built by the fixtures.
+	UserBaseDN = "ou=users,o=company"
+	// GroupBaseDN is the base dn of the group entries built by the fixtures.
+	GroupBaseDN = "ou=projects,o=company"
+
+	mailDomain = "company.com"
+)
+
+// config is the client Config of which the base dns are used to build the distinguished names.
+var config = ldap.Config{UserBaseDN: UserBaseDN, GroupBaseDN: GroupBaseDN}
+
+// NewUser returns an active user with the user id uid. The remaining attributes are derived from the uid.
+func NewUser(uid string) ldap.User {
+	return ldap.User{
+		Uid:            uid,
+		AltUid:         strings.ToLower(uid),
+		Cn:             uid,
+		Sn:             uid,
+		DisplayName:    uid,
+		EmployeeNumber: "E" + uid,
+		Mail:           fmt.Sprintf("%s@%s", strings.ToLower(uid), mailDomain),
+		Status:         ldap.UserStatusActive,
+	}
+}
+
+// NewGroupEntry returns a group as returned by the GroupsManager.
+// params:
+//
+//	cn: common name of the group
+//	ouPath: name or path of the organizational unit of the group, e.g. "parent/child"
+//	memberIds: user ids of the members of the group
+func NewGroupEntry(cn, ouPath string, memberIds ...string) ldap.Group {
+	group := Group(cn, ouPath)
+	for _, memberId := range memberIds {
+		group.Members = append(group.Members, UserDN(memberId))
+	}
+	return group
+}
+
+// Group returns a group without members as returned by the GroupsManager.
+func Group(cn, ouPath string) ldap.Group {
+	ouNames := splitOuPath(ouPath)
+	var ou string
+	if len(ouNames) > 0 {
+		ou = ouNames[len(ouNames)-1]
+	}
+	return ldap.Group{
+		Dn:     GroupDN(cn, ouPath),
+		Ou:     ou,
+		OuPath: ouNames,
+		Cn:     cn,
+	}
+}
+
+// UserDN returns the distinguished name of the user with the user id uid.
+func UserDN(uid string) string {
+	return ldaptest.UserDN(config, uid)
+}
+
+// GroupDN returns the distinguished name of the group cn within the organizational unit path ouPath.
+func GroupDN(cn, ouPath string) string {
+	return ldaptest.GroupDN(config, cn, ouPath)
+}
+
+// splitOuPath returns the names of the organizational units of an organizational unit path.
+func splitOuPath(ouPath string) []string {
+	if ouPath == "" {
+		return nil
+	}
+	return strings.Split(strings.Trim(ouPath, ldap.OuPathSeparator), ldap.OuPathSeparator)
+}
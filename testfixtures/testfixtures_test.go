@@ -0,0 +1,74 @@
+package testfixtures
+
+import (
+	"testing"
+
+	"github.com/atselvan/ldap-go-lib/ldap"
+	"github.com/atselvan/ldap-go-lib/mocks/ldapmocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ ldap.UsersManager               = (*ldapmocks.UsersManager)(nil)
+	_ ldap.GroupsManager              = (*ldapmocks.GroupsManager)(nil)
+	_ ldap.OrganizationalUnitsManager = (*ldapmocks.OrganizationalUnitsManager)(nil)
+)
+
+func TestNewUser(t *testing.T) {
+	user := NewUser("C00001")
+	assert.Equal(t, "C00001", user.Uid)
+	assert.Equal(t, "c00001", user.AltUid)
+	assert.Equal(t, "c00001@company.com", user.Mail)
+	assert.Equal(t, ldap.UserStatusActive, user.Status)
+}
+
+func TestNewGroupEntry(t *testing.T) {
+	t.Run("nested organizational unit", func(t *testing.T) {
+		group := NewGroupEntry("group1", "parent/child", "C00001", "C00002")
+		assert.Equal(t, "cn=group1,ou=child,ou=parent,ou=projects,o=company", group.Dn)
+		assert.Equal(t, "child", group.Ou)
+		assert.Equal(t, []string{"parent", "child"}, group.OuPath)
+		assert.Equal(t, "group1", group.Cn)
+		assert.Equal(t, []string{
+			"uid=C00001,ou=users,o=company",
+			"uid=C00002,ou=users,o=company",
+		}, group.Members)
+	})
+
+	t.Run("without members", func(t *testing.T) {
+		group := NewGroupEntry("group1", "project1")
+		assert.Equal(t, "cn=group1,ou=project1,ou=projects,o=company", group.Dn)
+		assert.Equal(t, "project1", group.Ou)
+		assert.Nil(t, group.Members)
+	})
+}
+
+func TestGroupDN(t *testing.T) {
+	assert.Equal(t, "cn=group\\,1,ou=project1,ou=projects,o=company", GroupDN("group,1", "project1"))
+	assert.Equal(t, "ou=project1,ou=projects,o=company", GroupDN("", "project1"))
+	assert.Equal(t, GroupBaseDN, GroupDN("", ""))
+}
+
+func TestUserDN(t *testing.T) {
+	assert.Equal(t, "uid=C00001,ou=users,o=company", UserDN("C00001"))
+}
+
+func TestManagerMocks(t *testing.T) {
+	usersMock := ldapmocks.NewUsersManager(t)
+	groupsMock := ldapmocks.NewGroupsManager(t)
+	client := ldap.NewClient(ldap.Config{}, ldap.WithUsersManager(usersMock), ldap.WithGroupsManager(groupsMock),
+		ldap.UnitTesting())
+
+	testUser := NewUser("C00001")
+	usersMock.EXPECT().Get("C00001").Return(&testUser, nil)
+	groupsMock.EXPECT().Get("group1", "project1").
+		Return([]ldap.Group{NewGroupEntry("group1", "project1", "C00001")}, nil)
+
+	user, cErr := client.Users.Get("C00001")
+	assert.Nil(t, cErr)
+	assert.Equal(t, "C00001", user.Uid)
+
+	groups, cErr := client.Groups.Get("group1", "project1")
+	assert.Nil(t, cErr)
+	assert.Equal(t, []string{UserDN(user.Uid)}, groups[0].Members)
+}
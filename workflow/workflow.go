@@ -0,0 +1,274 @@
+// Package workflow provides a minimal approval flow for group membership changes. A membership change is stored as a
+// pending request entry in a dedicated organizational unit of the directory and is only applied to the group once an
+// approver approves the request, so no separate database is needed.
+package workflow
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/dn"
+	"github.com/atselvan/ldap-go-lib/ldap"
+)
+
+const (
+	commonNameAttr  = "cn"
+	descriptionAttr = "description"
+	objectClassAttr = "objectClass"
+
+	// requestSearchFilter matches the pending request entries.
+	requestSearchFilter = "(objectClass=applicationProcess)"
+	requestIdLength     = 8
+
+	requestNotFoundMsg      = "Membership request with id = '%s' was not found"
+	invalidRequestEntryMsg  = "The membership request entry '%s' is invalid: %v"
+	generateRequestIdErrMsg = "Generating a membership request id failed: %v"
+	invalidActionMsg        = "The membership request with id = '%s' has the invalid action '%s'"
+)
+
+// requestObjectClasses are the object classes of the pending request entries. applicationProcess is part of the core
+// schema and allows a cn and a description, so no schema extension is needed.
+var requestObjectClasses = []string{"applicationProcess", "top"}
+
+// Action is the membership change requested by a Request.
+type Action string
+
+const (
+	ActionAddMembers    Action = "addMembers"
+	ActionRemoveMembers Action = "removeMembers"
+)
+
+type (
+	// Request is a pending membership change of a group.
+	Request struct {
+		Id        string    `json:"id"`
+		Action    Action    `json:"action"`
+		Cn        string    `json:"cn"`
+		Ou        string    `json:"ou"`
+		MemberIds []string  `json:"memberIds"`
+		Requester string    `json:"requester"`
+		Reason    string    `json:"reason,omitempty"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	// Groups manages the membership requests of the groups of a client.
+	Groups struct {
+		client     *ldap.Client
+		requestsDN string
+	}
+)
+
+// NewGroups returns a Groups which stores the membership requests as entries within requestsDN, e.g.
+// "ou=requests,o=company". The container must exist and the bind user must be allowed to add and delete entries
+// within it.
+func NewGroups(client *ldap.Client, requestsDN string) *Groups {
+	return &Groups{client: client, requestsDN: requestsDN}
+}
+
+// RequestAddMembers creates a pending request to add members to an existing group.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	memberIds: the uids of the members to add
+//	requester: the uid of the user who requests the change
+//	reason: the justification of the request, shown to the approver
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (g *Groups) RequestAddMembers(cn, ou string, memberIds []string, requester, reason string) (*Request,
+	*ldap.Error) {
+	return g.createRequest(ActionAddMembers, cn, ou, memberIds, requester, reason)
+}
+
+// RequestRemoveMembers creates a pending request to remove members from an existing group.
+// Params:
+//
+//	cn: name of the group
+//	ou: organizational unit under which the group exists
+//	memberIds: the uids of the members to remove
+//	requester: the uid of the user who requests the change
+//	reason: the justification of the request, shown to the approver
+//
+// The method returns an error:
+//   - if any validation fails
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (g *Groups) RequestRemoveMembers(cn, ou string, memberIds []string, requester, reason string) (*Request,
+	*ldap.Error) {
+	return g.createRequest(ActionRemoveMembers, cn, ou, memberIds, requester, reason)
+}
+
+// GetRequest retrieves a pending request.
+// The method returns an error:
+//   - if the request is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (g *Groups) GetRequest(id string) (*Request, *ldap.Error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, missingParametersError([]string{"id"})
+	}
+	entry, cErr := g.client.Entries.Get(g.getDN(id), commonNameAttr, descriptionAttr)
+	if cErr != nil {
+		if cErr.Status == http.StatusNotFound {
+			return nil, ldap.WrapError(errors.NotFoundError(fmt.Sprintf(requestNotFoundMsg, id)))
+		}
+		return nil, cErr
+	}
+	return parseRequestEntry(entry.DN, entry.GetAttributeValue(descriptionAttr))
+}
+
+// PendingRequests retrieves all the pending requests ordered by the time they were created.
+// The method returns an error:
+//   - if a request entry is invalid
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (g *Groups) PendingRequests() ([]Request, *ldap.Error) {
+	entries, cErr := g.client.Entries.Search(g.requestsDN, requestSearchFilter, commonNameAttr, descriptionAttr)
+	if cErr != nil {
+		return nil, cErr
+	}
+	requests := []Request{}
+	for _, entry := range entries {
+		request, cErr := parseRequestEntry(entry.DN, entry.GetAttributeValue(descriptionAttr))
+		if cErr != nil {
+			return nil, cErr
+		}
+		requests = append(requests, *request)
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		if requests[i].CreatedAt.Equal(requests[j].CreatedAt) {
+			return requests[i].Id < requests[j].Id
+		}
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// ApproveRequest applies the membership change of a pending request to the group and removes the request.
+// The request is kept if the change cannot be applied, so the approval can be retried.
+// The method returns an error:
+//   - if the request is not found
+//   - if the group is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (g *Groups) ApproveRequest(id string) (*Request, *ldap.Error) {
+	request, cErr := g.GetRequest(id)
+	if cErr != nil {
+		return nil, cErr
+	}
+	switch request.Action {
+	case ActionAddMembers:
+		cErr = g.client.Groups.AddMembers(request.Cn, request.Ou, request.MemberIds)
+	case ActionRemoveMembers:
+		cErr = g.client.Groups.RemoveMembers(request.Cn, request.Ou, request.MemberIds)
+	default:
+		cErr = ldap.WrapError(errors.InternalServerErrorf(invalidActionMsg, request.Id, request.Action))
+	}
+	if cErr != nil {
+		return nil, cErr
+	}
+	if cErr := g.client.Entries.Delete(g.getDN(request.Id)); cErr != nil {
+		return nil, cErr
+	}
+	return request, nil
+}
+
+// RejectRequest removes a pending request without applying the membership change.
+// The method returns an error:
+//   - if the request is not found
+//   - if there is a connection/network issue while opening a connection with LDAP
+//   - if the query to LDAP fails
+func (g *Groups) RejectRequest(id string) *ldap.Error {
+	request, cErr := g.GetRequest(id)
+	if cErr != nil {
+		return cErr
+	}
+	return g.client.Entries.Delete(g.getDN(request.Id))
+}
+
+// createRequest validates the membership change and stores it as a pending request entry.
+func (g *Groups) createRequest(action Action, cn, ou string, memberIds []string, requester,
+	reason string) (*Request, *ldap.Error) {
+	var missingParams []string
+	if strings.TrimSpace(cn) == "" {
+		missingParams = append(missingParams, "cn")
+	}
+	if strings.TrimSpace(ou) == "" {
+		missingParams = append(missingParams, "ou")
+	}
+	if len(memberIds) == 0 {
+		missingParams = append(missingParams, "memberIds")
+	}
+	if strings.TrimSpace(requester) == "" {
+		missingParams = append(missingParams, "requester")
+	}
+	if len(missingParams) > 0 {
+		return nil, missingParametersError(missingParams)
+	}
+	if _, cErr := g.client.Groups.Get(cn, ou); cErr != nil {
+		return nil, cErr
+	}
+	id, cErr := newRequestId()
+	if cErr != nil {
+		return nil, cErr
+	}
+	request := &Request{
+		Id:        id,
+		Action:    action,
+		Cn:        cn,
+		Ou:        ou,
+		MemberIds: memberIds,
+		Requester: requester,
+		Reason:    reason,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	data, _ := json.Marshal(request)
+	if cErr := g.client.Entries.Add(g.getDN(id), map[string][]string{
+		objectClassAttr: requestObjectClasses,
+		commonNameAttr:  {id},
+		descriptionAttr: {string(data)},
+	}); cErr != nil {
+		return nil, cErr
+	}
+	return request, nil
+}
+
+// missingParametersError returns a bad request error for the missing mandatory parameters.
+func missingParametersError(params []string) *ldap.Error {
+	return ldap.WrapError(errors.BadRequestErrorf(errors.ErrMsg[errors.ErrCodeMissingMandatoryParameter], params))
+}
+
+// getDN returns the distinguished name of the request entry.
+func (g *Groups) getDN(id string) string {
+	return dn.Join(dn.RDN(commonNameAttr, id), g.requestsDN)
+}
+
+// parseRequestEntry parses the request stored in the description of a request entry.
+func parseRequestEntry(entryDN, description string) (*Request, *ldap.Error) {
+	request := &Request{}
+	if err := json.Unmarshal([]byte(description), request); err != nil {
+		return nil, ldap.WrapError(errors.InternalServerErrorf(invalidRequestEntryMsg, entryDN, err))
+	}
+	return request, nil
+}
+
+// newRequestId returns a random request id.
+func newRequestId() (string, *ldap.Error) {
+	id := make([]byte, requestIdLength)
+	if _, err := rand.Read(id); err != nil {
+		return "", ldap.WrapError(errors.InternalServerErrorf(generateRequestIdErrMsg, err))
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,202 @@
+package workflow
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/atselvan/go-utils/utils/errors"
+	"github.com/atselvan/ldap-go-lib/ldap"
+	"github.com/atselvan/ldap-go-lib/ldaptest"
+	"github.com/atselvan/ldap-go-lib/mocks"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testRequestsDN = "ou=requests,o=company"
+
+var (
+	testConfig = ldap.Config{
+		Protocol:     "ldaps",
+		Hostname:     "ldap.company.com",
+		Port:         "636",
+		BaseDN:       "company",
+		UserBaseDN:   "ou=users,o=company",
+		GroupBaseDN:  "ou=projects,o=company",
+		BindUser:     "cn=root,o=company",
+		BindPassword: "somePassword",
+	}
+
+	testRequest = Request{
+		Id:        "0123456789abcdef",
+		Action:    ActionAddMembers,
+		Cn:        "group1",
+		Ou:        "ou1",
+		MemberIds: []string{"C00002"},
+		Requester: "C00001",
+		Reason:    "New team member",
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+// setup returns the go-ldap Client mock and the Groups using it.
+func setup(t *testing.T) (*mocks.Client, *Groups) {
+	ldapMock := mocks.NewClient(t)
+	client := ldap.NewClient(testConfig, ldap.WithLDAPClient(ldapMock), ldap.UnitTesting(),
+		ldap.WithoutOrganizationalUnitValidation())
+	ldapMock.On("Bind", testConfig.BindUser, testConfig.BindPassword).Return(nil)
+	ldapMock.On("Close").Return(nil)
+	return ldapMock, NewGroups(client, testRequestsDN)
+}
+
+// searchBaseDN matches a search request with the base dn.
+func searchBaseDN(baseDN string) interface{} {
+	return mock.MatchedBy(func(sr *goldap.SearchRequest) bool {
+		return sr.BaseDN == baseDN
+	})
+}
+
+// getRequestEntry returns the entry of the request.
+func getRequestEntry(request Request) *goldap.Entry {
+	data, _ := json.Marshal(request)
+	return goldap.NewEntry("cn="+request.Id+","+testRequestsDN, map[string][]string{
+		commonNameAttr:  {request.Id},
+		descriptionAttr: {string(data)},
+	})
+}
+
+func TestGroups_RequestAddMembers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock, groups := setup(t)
+		var ar *goldap.AddRequest
+
+		ldapMock.On("Search", searchBaseDN(ldaptest.GroupDN(testConfig, "group1", "ou1"))).
+			Return(ldaptest.NewSearchResult(ldaptest.NewGroupEntry(testConfig, "group1", "ou1",
+				ldaptest.UserDNs(testConfig, "C00001"))), nil)
+		ldapMock.On("Add", mock.AnythingOfType("*ldap.AddRequest")).
+			Run(func(args mock.Arguments) { ar = args.Get(0).(*goldap.AddRequest) }).Return(nil)
+
+		request, cErr := groups.RequestAddMembers("group1", "ou1", []string{"C00002"}, "C00001", "New team member")
+		assert.Nil(t, cErr)
+		assert.Len(t, request.Id, 16)
+		assert.Equal(t, ActionAddMembers, request.Action)
+		assert.Equal(t, "cn="+request.Id+","+testRequestsDN, ar.DN)
+		assert.Equal(t, "objectClass", ar.Attributes[2].Type)
+		assert.Equal(t, requestObjectClasses, ar.Attributes[2].Vals)
+
+		stored := &Request{}
+		assert.Nil(t, json.Unmarshal([]byte(ar.Attributes[1].Vals[0]), stored))
+		assert.Equal(t, request, stored)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		ldapMock, groups := setup(t)
+
+		ldapMock.On("Search", mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(nil, goldap.NewError(goldap.LDAPResultNoSuchObject, assert.AnError))
+
+		request, cErr := groups.RequestAddMembers("group1", "ou1", []string{"C00002"}, "C00001", "")
+		assert.Nil(t, request)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		groups := NewGroups(ldap.NewClient(testConfig), testRequestsDN)
+
+		request, cErr := groups.RequestRemoveMembers("group1", "", nil, "", "")
+		assert.Nil(t, request)
+		assert.Equal(t, errors.ErrCodeBadRequest, cErr.Code)
+		assert.Contains(t, cErr.Message, "[ou memberIds requester]")
+	})
+}
+
+func TestGroups_PendingRequests(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock, groups := setup(t)
+		older := testRequest
+		older.Id = "fedcba9876543210"
+		older.CreatedAt = testRequest.CreatedAt.Add(-time.Hour)
+
+		ldapMock.On("Search", mock.MatchedBy(func(sr *goldap.SearchRequest) bool {
+			return sr.BaseDN == testRequestsDN && sr.Filter == requestSearchFilter
+		})).Return(ldaptest.NewSearchResult(getRequestEntry(testRequest), getRequestEntry(older)), nil)
+
+		requests, cErr := groups.PendingRequests()
+		assert.Nil(t, cErr)
+		assert.Equal(t, []Request{older, testRequest}, requests)
+	})
+
+	t.Run("invalid request entry", func(t *testing.T) {
+		ldapMock, groups := setup(t)
+
+		ldapMock.On("Search", searchBaseDN(testRequestsDN)).Return(ldaptest.NewSearchResult(
+			goldap.NewEntry("cn=invalid,"+testRequestsDN, map[string][]string{descriptionAttr: {"invalid"}})), nil)
+
+		requests, cErr := groups.PendingRequests()
+		assert.Nil(t, requests)
+		assert.Equal(t, http.StatusInternalServerError, cErr.Status)
+	})
+}
+
+func TestGroups_ApproveRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ldapMock, groups := setup(t)
+		requestDN := "cn=" + testRequest.Id + "," + testRequestsDN
+
+		ldapMock.On("Search", searchBaseDN(requestDN)).
+			Return(ldaptest.NewSearchResult(getRequestEntry(testRequest)), nil)
+		ldapMock.On("Search", searchBaseDN(ldaptest.GroupDN(testConfig, "group1", "ou1"))).
+			Return(ldaptest.NewSearchResult(ldaptest.NewGroupEntry(testConfig, "group1", "ou1",
+				ldaptest.UserDNs(testConfig, "C00001"))), nil)
+		ldapMock.On("Modify", mock.MatchedBy(func(mr *goldap.ModifyRequest) bool {
+			return mr.DN == ldaptest.GroupDN(testConfig, "group1", "ou1") &&
+				mr.Changes[0].Modification.Vals[0] == ldaptest.UserDN(testConfig, "C00002")
+		})).Return(nil)
+		ldapMock.On("Del", goldap.NewDelRequest(requestDN, nil)).Return(nil)
+
+		request, cErr := groups.ApproveRequest(testRequest.Id)
+		assert.Nil(t, cErr)
+		assert.Equal(t, testRequest, *request)
+	})
+
+	t.Run("apply error keeps the request", func(t *testing.T) {
+		ldapMock, groups := setup(t)
+		request := testRequest
+		request.Action = ActionRemoveMembers
+		requestDN := "cn=" + testRequest.Id + "," + testRequestsDN
+
+		ldapMock.On("Search", searchBaseDN(requestDN)).
+			Return(ldaptest.NewSearchResult(getRequestEntry(request)), nil)
+		ldapMock.On("Search", searchBaseDN(ldaptest.GroupDN(testConfig, "group1", "ou1"))).
+			Return(nil, goldap.NewError(goldap.LDAPResultNoSuchObject, assert.AnError))
+
+		result, cErr := groups.ApproveRequest(testRequest.Id)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+	})
+
+	t.Run("request not found", func(t *testing.T) {
+		ldapMock, groups := setup(t)
+
+		ldapMock.On("Search", mock.AnythingOfType("*ldap.SearchRequest")).
+			Return(nil, goldap.NewError(goldap.LDAPResultNoSuchObject, assert.AnError))
+
+		request, cErr := groups.ApproveRequest(testRequest.Id)
+		assert.Nil(t, request)
+		assert.Equal(t, http.StatusNotFound, cErr.Status)
+		assert.Contains(t, cErr.Message, testRequest.Id)
+	})
+}
+
+func TestGroups_RejectRequest(t *testing.T) {
+	ldapMock, groups := setup(t)
+	requestDN := "cn=" + testRequest.Id + "," + testRequestsDN
+
+	ldapMock.On("Search", searchBaseDN(requestDN)).
+		Return(ldaptest.NewSearchResult(getRequestEntry(testRequest)), nil)
+	ldapMock.On("Del", goldap.NewDelRequest(requestDN, nil)).Return(nil)
+
+	assert.Nil(t, groups.RejectRequest(testRequest.Id))
+}